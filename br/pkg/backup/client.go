@@ -479,7 +479,11 @@ func skipUnsupportedDDLJob(job *model.Job) bool {
 		model.ActionModifySchemaDefaultPlacement,
 		model.ActionAlterTablePlacement,
 		model.ActionAlterTableAttributes,
-		model.ActionAlterTablePartitionAttributes:
+		model.ActionAlterTablePartitionAttributes,
+		// Resource groups are cluster level settings and are not part of the backup.
+		model.ActionCreateResourceGroup,
+		model.ActionAlterResourceGroup,
+		model.ActionDropResourceGroup:
 		return true
 	default:
 		return false
@@ -233,6 +233,11 @@ func (row RowArrayMarshaler) MarshalLogArray(encoder zapcore.ArrayEncoder) error
 		case types.KindMaxValue:
 			str = "+inf"
 		default:
+			if common.IsMissingValue(&datum) {
+				// the column is missing in the row of the JSON data file and its default value will be used
+				str = "DEFAULT"
+				break
+			}
 			str, err = datum.ToString()
 			if err != nil {
 				return err
@@ -370,7 +375,7 @@ func (kvcodec *tableKVEncoder) Encode(
 	for i, col := range cols {
 		var theDatum *types.Datum = nil
 		j := columnPermutation[i]
-		if j >= 0 && j < len(row) {
+		if j >= 0 && j < len(row) && !common.IsMissingValue(&row[j]) {
 			theDatum = &row[j]
 		}
 		value, err = kvcodec.getActualDatum(rowID, i, theDatum)
@@ -398,7 +403,7 @@ func (kvcodec *tableKVEncoder) Encode(
 	if common.TableHasAutoRowID(meta) {
 		rowValue := rowID
 		j := columnPermutation[len(cols)]
-		if j >= 0 && j < len(row) {
+		if j >= 0 && j < len(row) && !common.IsMissingValue(&row[j]) {
 			value, err = table.CastValue(kvcodec.se, row[j], ExtraHandleColumnInfo, false, false)
 			rowValue = value.GetInt64()
 		} else {
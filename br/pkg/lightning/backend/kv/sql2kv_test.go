@@ -57,6 +57,15 @@ func TestMarshal(t *testing.T) {
 		map[string]interface{}{"kind": "max", "val": "+inf"},
 	})
 
+	missing := types.Datum{}
+	common.SetMissingValue(&missing)
+	err = encoder.AddArray("missing-test", lkv.RowArrayMarshaler{types.NewStringDatum("1"), missing})
+	require.NoError(t, err)
+	require.Equal(t, encoder.Fields["missing-test"], []interface{}{
+		map[string]interface{}{"kind": "string", "val": "1"},
+		map[string]interface{}{"kind": "interface", "val": "DEFAULT"},
+	})
+
 	invalid := types.Datum{}
 	invalid.SetInterface(1)
 	err = encoder.AddArray("bad-test", lkv.RowArrayMarshaler{minNotNull, invalid})
@@ -430,6 +439,36 @@ func TestEncodeMissingAutoValue(t *testing.T) {
 	}
 }
 
+func TestEncodeMissingValue(t *testing.T) {
+	logger := log.Logger{Logger: zap.NewNop()}
+	tblInfo := mockTableInfo(t, "create table t (id int primary key, a varchar(10) not null default 'x', b int);")
+	tbl, err := tables.TableFromMeta(lkv.NewPanickingAllocators(0), tblInfo)
+	require.NoError(t, err)
+
+	encoder, err := lkv.NewTableKVEncoder(tbl, &lkv.SessionOptions{
+		SQLMode: mysql.ModeStrictAllTables,
+		SysVars: map[string]string{
+			"tidb_row_format_version": "2",
+		},
+	}, nil, logger)
+	require.NoError(t, err)
+
+	// the missing values use the default values, the same as the columns which are not in the data file.
+	pairsExpect, err := encoder.Encode(logger, []types.Datum{types.NewIntDatum(1)}, 1, []int{0, -1, -1}, "1.json", 1234)
+	require.NoError(t, err)
+	var missingDatum types.Datum
+	common.SetMissingValue(&missingDatum)
+	pairs, err := encoder.Encode(logger, []types.Datum{types.NewIntDatum(1), missingDatum, missingDatum}, 1, []int{0, 1, 2}, "1.json", 1234)
+	require.NoError(t, err)
+	require.Equal(t, pairsExpect, pairs)
+
+	// NULL is not the missing value, it can't be written into the NOT NULL column.
+	var nullDatum types.Datum
+	nullDatum.SetNull()
+	_, err = encoder.Encode(logger, []types.Datum{types.NewIntDatum(1), nullDatum, missingDatum}, 1, []int{0, 1, 2}, "1.json", 1234)
+	require.Error(t, err)
+}
+
 func mockTableInfo(t *testing.T, createSQL string) *model.TableInfo {
 	parser := parser.New()
 	node, err := parser.ParseOneStmt(createSQL, "", "")
@@ -352,6 +352,10 @@ func (enc *tidbEncoder) appendSQLBytes(sb *strings.Builder, value []byte) {
 // appendSQL appends the SQL representation of the Datum into the string builder.
 // Note that we cannot use Datum.ToString since it doesn't perform SQL escaping.
 func (enc *tidbEncoder) appendSQL(sb *strings.Builder, datum *types.Datum, _ *table.Column) error {
+	if common.IsMissingValue(datum) {
+		sb.WriteString("DEFAULT")
+		return nil
+	}
 	switch datum.Kind() {
 	case types.KindNull:
 		sb.WriteString("NULL")
@@ -521,7 +525,16 @@ func EncodeRowForRecord(ctx context.Context, encTable table.Table, sqlMode mysql
 	if err != nil {
 		// if encode can't succeed, fallback to record the raw input strings
 		// ignore the error since it can only happen if the datum type is unknown, this can't happen here.
-		datumStr, _ := types.DatumsToString(row, true)
+		// the missing values of the JSON data files are recorded as DEFAULT, since they can't be converted to strings.
+		rawRow := make([]types.Datum, len(row))
+		for i := range row {
+			if common.IsMissingValue(&row[i]) {
+				rawRow[i].SetBytes([]byte("DEFAULT"))
+			} else {
+				rawRow[i] = row[i]
+			}
+		}
+		datumStr, _ := types.DatumsToString(rawRow, true)
 		return datumStr
 	}
 	return resRow.(tidbRow).insertStmt
@@ -619,6 +619,16 @@ func TestEncodeRowForRecord(t *testing.T) {
 	}, []int{0, -1, -1, -1, -1, -1, -1, -1, 1, 2, -1, -1, -1, -1})
 	require.Equal(t, row, "(5,'test test',x'000000abcdef')")
 
+	// the missing value is written as the default value of the column.
+	var missingDatum types.Datum
+	common.SetMissingValue(&missingDatum)
+	row = tidb.EncodeRowForRecord(context.Background(), s.tbl, mysql.ModeStrictTransTables, []types.Datum{
+		types.NewIntDatum(5),
+		missingDatum,
+		types.NewBinaryLiteralDatum(types.NewBinaryLiteralFromUint(0xabcdef, 6)),
+	}, []int{0, -1, -1, -1, -1, -1, -1, -1, 1, 2, -1, -1, -1, -1})
+	require.Equal(t, row, "(5,DEFAULT,x'000000abcdef')")
+
 	// the following row will result in column count mismatch error, there for encode
 	// result will fallback to a "," separated string list.
 	row = tidb.EncodeRowForRecord(context.Background(), s.tbl, mysql.ModeStrictTransTables, []types.Datum{
@@ -627,4 +637,12 @@ func TestEncodeRowForRecord(t *testing.T) {
 		types.NewBinaryLiteralDatum(types.NewBinaryLiteralFromUint(0xabcdef, 6)),
 	}, []int{0, -1, -1, -1, -1, -1, -1, -1, 1, 2, 3, -1, -1, -1})
 	require.Equal(t, row, "(5, \"test test\", \x00\x00\x00\xab\xcd\xef)")
+
+	// the missing value in the fallback string list is recorded as DEFAULT.
+	row = tidb.EncodeRowForRecord(context.Background(), s.tbl, mysql.ModeStrictTransTables, []types.Datum{
+		types.NewIntDatum(5),
+		missingDatum,
+		types.NewBinaryLiteralDatum(types.NewBinaryLiteralFromUint(0xabcdef, 6)),
+	}, []int{0, -1, -1, -1, -1, -1, -1, -1, 1, 2, 3, -1, -1, -1})
+	require.Equal(t, row, "(5, DEFAULT, \x00\x00\x00\xab\xcd\xef)")
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/utils"
 	tmysql "github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/types"
 	"go.uber.org/zap"
 )
 
@@ -408,3 +409,21 @@ func StringSliceEqual(a, b []string) bool {
 	}
 	return true
 }
+
+// missingValue is the value of a column which doesn't appear in a row of the data file.
+type missingValue struct{}
+
+// SetMissingValue marks the datum as missing in the row of the data file. The default value of the column
+// is used for it, just like the columns which are not in the header of the CSV file.
+func SetMissingValue(d *types.Datum) {
+	d.SetInterface(missingValue{})
+}
+
+// IsMissingValue returns whether the datum is marked by SetMissingValue.
+func IsMissingValue(d *types.Datum) bool {
+	if d.Kind() != types.KindInterface {
+		return false
+	}
+	_, ok := d.GetInterface().(missingValue)
+	return ok
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	tidbcfg "github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/util"
@@ -466,7 +467,7 @@ type MydumperRuntime struct {
 	StrictFormat     bool             `toml:"strict-format" json:"strict-format"`
 	DefaultFileRules bool             `toml:"default-file-rules" json:"default-file-rules"`
 	IgnoreColumns    AllIgnoreColumns `toml:"ignore-data-columns" json:"ignore-data-columns"`
-	// DataCharacterSet is the character set of the source file. Only CSV files are supported now. The following options are supported.
+	// DataCharacterSet is the character set of the source file. Only CSV and JSON files are supported now. The following options are supported.
 	//   - utf8mb4
 	//   - GB18030
 	//   - GBK: an extension of the GB2312 character set and is also known as Code Page 936.
@@ -476,6 +477,9 @@ type MydumperRuntime struct {
 	// DataInvalidCharReplace is the replacement characters for non-compatible characters, which shouldn't duplicate with the separators or line breaks.
 	// Changing the default value will result in increased parsing time. Non-compatible characters do not cause an increase in error.
 	DataInvalidCharReplace string `toml:"data-invalid-char-replace" json:"data-invalid-char-replace"`
+	// Encryption is used to decrypt the source files encrypted by Dumpling, all the source files should be encrypted
+	// when it's set.
+	Encryption storage.EncryptionConfig `toml:"encryption" json:"encryption"`
 }
 
 type AllIgnoreColumns []*IgnoreColumns
@@ -889,6 +893,9 @@ func (cfg *Config) Adjust(ctx context.Context) error {
 			zap.String("source-character-set", charset.String()),
 			zap.ByteString("invalid-char-replacement", []byte(cfg.Mydumper.DataInvalidCharReplace)))
 	}
+	if err := cfg.Mydumper.Encryption.Validate(); err != nil {
+		return common.ErrInvalidConfig.Wrap(err).GenWithStack("invalid `mydumper.encryption`")
+	}
 
 	mustHaveInternalConnections, err := cfg.AdjustCommon()
 	if err != nil {
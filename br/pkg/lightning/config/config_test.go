@@ -866,6 +866,56 @@ func TestDataCharacterSet(t *testing.T) {
 	}
 }
 
+func TestMydumperEncryption(t *testing.T) {
+	testCases := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: `
+				[mydumper.encryption]
+				method = 'aes256-gcm'
+				master-key = '/path/to/keyring'
+			`,
+			err: "",
+		},
+		{
+			input: `
+				[mydumper.encryption]
+				method = 'aes128-ctr'
+			`,
+			err: "invalid `mydumper.encryption`",
+		},
+		{
+			input: `
+				[mydumper.encryption]
+				method = 'sm4'
+				key = '0123456789abcdef0123456789abcdef'
+			`,
+			err: "invalid `mydumper.encryption`",
+		},
+	}
+
+	for _, tc := range testCases {
+		comment := fmt.Sprintf("input = %s", tc.input)
+		cfg := config.NewConfig()
+		cfg.Mydumper.SourceDir = "file://."
+		cfg.TiDB.Port = 4000
+		cfg.TiDB.PdAddr = "test.invalid:2379"
+		cfg.TikvImporter.Backend = config.BackendLocal
+		cfg.TikvImporter.SortedKVDir = "."
+		cfg.TiDB.DistSQLScanConcurrency = 1
+		err := cfg.LoadFromTOML([]byte(tc.input))
+		require.NoError(t, err)
+		err = cfg.Adjust(context.Background())
+		if tc.err != "" {
+			require.ErrorContains(t, err, tc.err, comment)
+		} else {
+			require.NoError(t, err, comment)
+		}
+	}
+}
+
 func TestCheckpointKeepStrategy(t *testing.T) {
 	tomlCases := map[interface{}]config.CheckpointKeepStrategy{
 		true:     config.CheckpointRename,
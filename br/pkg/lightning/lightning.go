@@ -466,6 +466,10 @@ func (l *Lightning) run(taskCtx context.Context, taskCfg *config.Config, o *opti
 			return common.NormalizeError(err)
 		}
 	}
+	s, err = storage.WithEncryption(ctx, s, &taskCfg.Mydumper.Encryption)
+	if err != nil {
+		return common.NormalizeError(err)
+	}
 
 	// return expectedErr means at least meet one file
 	expectedErr := errors.New("Stop Iter")
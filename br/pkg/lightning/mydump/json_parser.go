@@ -0,0 +1,301 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/metric"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"go.uber.org/zap"
+)
+
+// minJSONRowSize is the size of the shortest row "{}" of the newline-delimited JSON files.
+const minJSONRowSize = 2
+
+// JSONParser is the parser of the newline-delimited JSON (NDJSON) data files. Each line of the file is a JSON
+// object whose keys are mapped to the columns case-insensitively. The columns whose keys are missing use their
+// default values, like the columns which are not in the header of the CSV file. The values of the JSON columns
+// are kept as they are in the file, and the other values are read like the SQL literals.
+type JSONParser struct {
+	blockParser
+
+	// columnIndexes maps the lower-case column names to their indexes in the row.
+	columnIndexes map[string]int
+	// jsonColumns contains the lower-case names of the columns with JSON type.
+	jsonColumns map[string]struct{}
+	// isJSONColumn indicates whether the column of the same index in the row has JSON type.
+	isJSONColumn []bool
+	// unknownKeys records the keys which don't match any column, each of them is only warned once.
+	unknownKeys map[string]struct{}
+	// charsetConvertor converts the lines from the data-character-set to utf8mb4 before they're decoded.
+	charsetConvertor *CharsetConvertor
+}
+
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// NewJSONParser creates a JSON parser. If the columns are not set, they're the keys of the first JSON object.
+// Do not do the conversion if the charsetConvertor is nil.
+func NewJSONParser(
+	ctx context.Context,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+	charsetConvertor *CharsetConvertor,
+) *JSONParser {
+	metrics, _ := metric.FromContext(ctx)
+	return &JSONParser{
+		blockParser:      makeBlockParser(reader, blockBufSize, ioWorkers, metrics, log.FromContext(ctx)),
+		unknownKeys:      make(map[string]struct{}),
+		charsetConvertor: charsetConvertor,
+	}
+}
+
+// SetTableColumns sets the columns of the target table as the columns of the rows. The keys of the JSON objects
+// may differ row by row, so all the columns which can be written are used, and the JSON columns are recorded.
+func (parser *JSONParser) SetTableColumns(columns []*model.ColumnInfo) {
+	names := make([]string, 0, len(columns))
+	parser.jsonColumns = make(map[string]struct{})
+	for _, col := range columns {
+		if col.Hidden || col.IsGenerated() {
+			continue
+		}
+		names = append(names, col.Name.O)
+		if col.GetType() == mysql.TypeJSON {
+			parser.jsonColumns[col.Name.L] = struct{}{}
+		}
+	}
+	parser.SetColumns(names)
+}
+
+// SetColumns implements the Parser interface.
+func (parser *JSONParser) SetColumns(columns []string) {
+	parser.columns = make([]string, 0, len(columns))
+	parser.columnIndexes = make(map[string]int, len(columns))
+	parser.isJSONColumn = make([]bool, 0, len(columns))
+	for i, col := range columns {
+		col = strings.ToLower(col)
+		_, isJSON := parser.jsonColumns[col]
+		parser.columns = append(parser.columns, col)
+		parser.columnIndexes[col] = i
+		parser.isJSONColumn = append(parser.isJSONColumn, isJSON)
+	}
+}
+
+// readLine reads the next line of the file without the trailing newline.
+func (parser *JSONParser) readLine() ([]byte, error) {
+	scanned := 0
+	for {
+		if index := bytes.IndexByte(parser.buf[scanned:], '\n'); index >= 0 {
+			index += scanned
+			line := parser.buf[:index]
+			parser.buf = parser.buf[index+1:]
+			parser.pos += int64(index + 1)
+			return line, nil
+		}
+		scanned = len(parser.buf)
+		if parser.isLastChunk {
+			if len(parser.buf) == 0 {
+				return nil, io.EOF
+			}
+			line := parser.buf
+			parser.buf = nil
+			parser.pos += int64(len(line))
+			return line, nil
+		}
+		if err := parser.readBlock(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ReadUntilTerminator seeks the file until the newline is found, and returns
+// the file offset beyond the newline. It's used in dividing a JSON file.
+func (parser *JSONParser) ReadUntilTerminator() (int64, error) {
+	if _, err := parser.readLine(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return parser.pos, nil
+}
+
+// ReadRow reads a JSON object from the file. The empty lines are skipped.
+func (parser *JSONParser) ReadRow() error {
+	var (
+		line   []byte
+		offset int64
+	)
+	for len(line) == 0 {
+		var err error
+		offset = parser.pos
+		if line, err = parser.readLine(); err != nil {
+			return errors.Trace(err)
+		}
+		line = bytes.TrimSpace(line)
+	}
+	rowLength := len(line)
+
+	// Convert the line from another charset to utf8mb4 before it's decoded. The JSON tokens are all ASCII, but
+	// the multi-byte characters of e.g. GBK may contain the byte of '\', so the conversion must be done first.
+	if parser.charsetConvertor != nil {
+		text, err := parser.charsetConvertor.Decode(string(line))
+		if err != nil {
+			return errors.Errorf("invalid %s characters at offset %d: %s",
+				parser.charsetConvertor.sourceCharacterSet, offset, err.Error())
+		}
+		line = []byte(text)
+	}
+
+	fields, err := decodeJSONObject(line)
+	if err != nil {
+		return errors.Errorf("syntax error: invalid JSON object at offset %d: %s", offset, err.Error())
+	}
+	if parser.columnIndexes == nil {
+		keys := make([]string, 0, len(fields))
+		for _, field := range fields {
+			keys = append(keys, field.key)
+		}
+		parser.SetColumns(keys)
+	}
+
+	row := &parser.lastRow
+	row.RowID++
+	row.Length = rowLength
+	row.Row = parser.acquireDatumSlice()
+	if cap(row.Row) >= len(parser.columns) {
+		row.Row = row.Row[:len(parser.columns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.columns))
+	}
+	for i := range row.Row {
+		row.Row[i] = types.Datum{}
+		common.SetMissingValue(&row.Row[i])
+	}
+	for _, field := range fields {
+		key := strings.ToLower(field.key)
+		index, ok := parser.columnIndexes[key]
+		if !ok {
+			if _, warned := parser.unknownKeys[key]; !warned {
+				parser.unknownKeys[key] = struct{}{}
+				parser.Logger.Warn("the key of the JSON object doesn't match any column, ignored",
+					zap.String("key", field.key), zap.Int64("offset", offset))
+			}
+			continue
+		}
+		if parser.isJSONColumn[index] {
+			err = setRawJSONValue(&row.Row[index], field.value)
+		} else {
+			err = setJSONValue(&row.Row[index], field.value)
+		}
+		if err != nil {
+			return errors.Errorf("syntax error: invalid value of key '%s' at offset %d: %s", field.key, offset, err.Error())
+		}
+	}
+	return nil
+}
+
+// decodeJSONObject decodes the JSON object into the fields in the order of their keys.
+func decodeJSONObject(data []byte) ([]jsonField, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("the line is not a JSON object")
+	}
+	var fields []jsonField
+	for decoder.More() {
+		tok, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		field := jsonField{key: tok.(string)}
+		if err = decoder.Decode(&field.value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	// consumes the closing brace.
+	if _, err = decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err = decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+	return fields, nil
+}
+
+// setRawJSONValue sets the datum of a JSON column to the JSON value, only the null is read as the SQL NULL.
+func setRawJSONValue(datum *types.Datum, value json.RawMessage) error {
+	if value[0] == 'n' {
+		datum.SetNull()
+		return nil
+	}
+	j, err := types.ParseBinaryJSONFromString(string(value))
+	if err != nil {
+		return err
+	}
+	datum.SetMysqlJSON(j)
+	return nil
+}
+
+// setJSONValue sets the datum to the JSON value. The values are converted like the SQL literals, and the
+// objects and arrays are kept as their JSON text.
+func setJSONValue(datum *types.Datum, value json.RawMessage) error {
+	switch value[0] {
+	case 'n':
+		datum.SetNull()
+	case 't':
+		datum.SetInt64(1)
+	case 'f':
+		datum.SetInt64(0)
+	case '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return err
+		}
+		datum.SetString(s, "utf8mb4_bin")
+	case '{', '[':
+		datum.SetString(string(value), "utf8mb4_bin")
+	default:
+		c := string(value)
+		if strings.HasPrefix(c, "-") {
+			if i, err := strconv.ParseInt(c, 10, 64); err == nil {
+				datum.SetInt64(i)
+				return nil
+			}
+		} else if u, err := strconv.ParseUint(c, 10, 64); err == nil {
+			datum.SetUint64(u)
+			return nil
+		}
+		// the decimals, floats and the integers out of range are read as strings.
+		datum.SetString(c, "utf8mb4_bin")
+	}
+	return nil
+}
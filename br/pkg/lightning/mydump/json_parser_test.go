@@ -0,0 +1,232 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newMissingDatum() types.Datum {
+	var d types.Datum
+	common.SetMissingValue(&d)
+	return d
+}
+
+func newJSONDatum(t *testing.T, s string) types.Datum {
+	j, err := types.ParseBinaryJSONFromString(s)
+	require.NoError(t, err)
+	return types.NewJSONDatum(j)
+}
+
+func TestJSONParser(t *testing.T) {
+	lines := []string{
+		`{"ID": 1, "name": "a\nb", "info": {"k": [1, 2]}, "price": 1.50, "ok": true}` + "\n",
+		"\n",
+		`{"id": -2, "info": [], "price": 18446744073709551616, "ok": false, "extra": "x"}` + "\r\n",
+		`{"name": null, "id": 3}`,
+	}
+	missingDatum := newMissingDatum()
+	// the small block size makes the lines cross the blocks.
+	for _, blockBufSize := range []int64{3, config.ReadBlockSize} {
+		parser := mydump.NewJSONParser(context.Background(), mydump.NewStringReader(strings.Join(lines, "")), blockBufSize, ioWorkers, nil)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []string{"id", "name", "info", "price", "ok"}, parser.Columns())
+		require.Equal(t, mydump.Row{
+			RowID: 1,
+			Row: []types.Datum{
+				types.NewUintDatum(1),
+				types.NewStringDatum("a\nb"),
+				types.NewStringDatum(`{"k": [1, 2]}`),
+				types.NewStringDatum("1.50"),
+				types.NewIntDatum(1),
+			},
+			Length: len(lines[0]) - 1,
+		}, parser.LastRow())
+		assertPosEqual(t, parser, int64(len(lines[0])), 1)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, mydump.Row{
+			RowID: 2,
+			Row: []types.Datum{
+				types.NewIntDatum(-2),
+				missingDatum,
+				types.NewStringDatum(`[]`),
+				types.NewStringDatum("18446744073709551616"),
+				types.NewIntDatum(0),
+			},
+			Length: len(lines[2]) - 2,
+		}, parser.LastRow())
+		assertPosEqual(t, parser, int64(len(lines[0])+len(lines[1])+len(lines[2])), 2)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, mydump.Row{
+			RowID:  3,
+			Row:    []types.Datum{types.NewUintDatum(3), nullDatum, missingDatum, missingDatum, missingDatum},
+			Length: len(lines[3]),
+		}, parser.LastRow())
+		assertPosEqual(t, parser, int64(len(strings.Join(lines, ""))), 3)
+
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestJSONParserSetColumns(t *testing.T) {
+	input := `{"b": "x", "A": 1, "d": 2}` + "\n" + `{"c": {"e": null}}` + "\n"
+	parser := mydump.NewJSONParser(context.Background(), mydump.NewStringReader(input), config.ReadBlockSize, ioWorkers, nil)
+	parser.SetColumns([]string{"a", "B", "c"})
+	require.Equal(t, []string{"a", "b", "c"}, parser.Columns())
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewUintDatum(1), types.NewStringDatum("x"), newMissingDatum()}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{newMissingDatum(), newMissingDatum(), types.NewStringDatum(`{"e": null}`)}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestJSONParserTableColumns(t *testing.T) {
+	newCol := func(name string, tp byte) *model.ColumnInfo {
+		col := &model.ColumnInfo{Name: model.NewCIStr(name)}
+		col.SetType(tp)
+		return col
+	}
+	id := newCol("id", mysql.TypeLong)
+	j := newCol("J", mysql.TypeJSON)
+	name := newCol("name", mysql.TypeVarchar)
+	name.AddFlag(mysql.NotNullFlag)
+	hidden := newCol("hidden", mysql.TypeLong)
+	hidden.Hidden = true
+	generated := newCol("generated", mysql.TypeJSON)
+	generated.GeneratedExprString = "`j`"
+
+	input := `{"id": 1, "j": "abc"}` + "\n" +
+		`{"id": 2, "J": true, "name": false}` + "\n" +
+		`{"j": {"a": [1, false]}, "id": null}` + "\n" +
+		`{"j": null, "generated": 1}` + "\n"
+	parser := mydump.NewJSONParser(context.Background(), mydump.NewStringReader(input), config.ReadBlockSize, ioWorkers, nil)
+	parser.SetTableColumns([]*model.ColumnInfo{id, j, hidden, name, generated})
+	require.Equal(t, []string{"id", "j", "name"}, parser.Columns())
+
+	// the JSON strings and booleans are kept as JSON values for the JSON columns,
+	// and the missing keys are marked to use the default values.
+	missingDatum := newMissingDatum()
+	expected := [][]types.Datum{
+		{types.NewUintDatum(1), newJSONDatum(t, `"abc"`), missingDatum},
+		{types.NewUintDatum(2), newJSONDatum(t, `true`), types.NewIntDatum(0)},
+		{nullDatum, newJSONDatum(t, `{"a": [1, false]}`), missingDatum},
+		{missingDatum, nullDatum, missingDatum},
+	}
+	for _, row := range expected {
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, row, parser.LastRow().Row)
+	}
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+
+	// the JSON columns are still known after the columns are reordered.
+	parser = mydump.NewJSONParser(context.Background(), mydump.NewStringReader(`{"j": false, "name": "x"}`), config.ReadBlockSize, ioWorkers, nil)
+	parser.SetTableColumns([]*model.ColumnInfo{id, j, name})
+	parser.SetColumns([]string{"name", "j"})
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewStringDatum("x"), newJSONDatum(t, `false`)}, parser.LastRow().Row)
+}
+
+func TestJSONParserCharset(t *testing.T) {
+	charsetConvertor, err := mydump.NewCharsetConvertor("gbk", "\ufffd")
+	require.NoError(t, err)
+	input, err := charsetConvertor.Encode(`{"id": 1, "name": "中文"}` + "\n")
+	require.NoError(t, err)
+	parser := mydump.NewJSONParser(context.Background(), mydump.NewStringReader(input), config.ReadBlockSize, ioWorkers, charsetConvertor)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"id", "name"}, parser.Columns())
+	require.Equal(t, []types.Datum{types.NewUintDatum(1), types.NewStringDatum("中文")}, parser.LastRow().Row)
+	require.Equal(t, len(input)-1, parser.LastRow().Length)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestJSONParserSyntaxError(t *testing.T) {
+	for _, input := range []string{
+		`[1, 2]`,
+		`"a"`,
+		`{"a": 1`,
+		`{"a": 1,}`,
+		`{"a": 1} {"a": 2}`,
+		`{"a": 1}, `,
+		`{a: 1}`,
+	} {
+		parser := mydump.NewJSONParser(context.Background(), mydump.NewStringReader(input), config.ReadBlockSize, ioWorkers, nil)
+		err := parser.ReadRow()
+		require.Error(t, err, input)
+		require.Regexp(t, "syntax error: invalid JSON object at offset 0", err.Error(), input)
+	}
+}
+
+func TestSplitLargeJSONFile(t *testing.T) {
+	meta := &mydump.MDTableMeta{DB: "json", Name: "large_json_file"}
+	cfg := &config.Config{
+		Mydumper: config.MydumperRuntime{
+			ReadBlockSize: config.ReadBlockSize,
+			CSV: config.CSVConfig{
+				Separator: ",",
+				Header:    true,
+			},
+			MaxRegionSize: 10,
+		},
+	}
+	dir := t.TempDir()
+	// the regions end at the newlines, and the last line has no newline.
+	content := "{\"a\": 1}\n{\"a\": 11}\n{\"a\": 111}\n{\"a\": 1111}\n{}"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "json.large_json_file.json"), []byte(content), 0o644))
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ioWorker := worker.NewPool(context.Background(), 4, "io")
+
+	fileInfo := mydump.FileInfo{FileMeta: mydump.SourceFileMeta{
+		Path:     "json.large_json_file.json",
+		Type:     mydump.SourceTypeJSON,
+		FileSize: int64(len(content)),
+	}}
+	regions, sizes, err := mydump.MakeSourceFileRegion(context.Background(), meta, fileInfo, 1, cfg, ioWorker, store)
+	require.NoError(t, err)
+	offsets := [][]int64{{0, 19}, {19, 30}, {30, 42}, {42, 44}}
+	require.Len(t, regions, len(offsets))
+	require.Len(t, sizes, len(offsets))
+	prevRowIDMax := int64(0)
+	for i, region := range regions {
+		require.Equal(t, offsets[i][0], region.Chunk.Offset)
+		require.Equal(t, offsets[i][1], region.Chunk.EndOffset)
+		require.Equal(t, prevRowIDMax, region.Chunk.PrevRowIDMax)
+		// the row ID range must cover all the rows of the region.
+		require.GreaterOrEqual(t, region.Chunk.RowIDMax-prevRowIDMax, int64(strings.Count(content[offsets[i][0]:offsets[i][1]], "{")))
+		require.Nil(t, region.Chunk.Columns)
+		prevRowIDMax = region.Chunk.RowIDMax
+	}
+}
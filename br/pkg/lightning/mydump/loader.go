@@ -187,6 +187,10 @@ func NewMyDumpLoader(ctx context.Context, cfg *config.Config, opts ...MDLoaderSe
 	if err != nil {
 		return nil, common.NormalizeError(err)
 	}
+	s, err = storage.WithEncryption(ctx, s, &cfg.Mydumper.Encryption)
+	if err != nil {
+		return nil, common.NormalizeError(err)
+	}
 
 	return NewMyDumpLoaderWithStore(ctx, cfg, s, opts...)
 }
@@ -418,7 +422,7 @@ func (s *mdLoaderSetup) listFiles(ctx context.Context, store storage.ExternalSto
 			s.tableSchemas = append(s.tableSchemas, info)
 		case SourceTypeViewSchema:
 			s.viewSchemas = append(s.viewSchemas, info)
-		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet:
+		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet, SourceTypeJSON:
 			s.tableDatas = append(s.tableDatas, info)
 		}
 
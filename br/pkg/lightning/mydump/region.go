@@ -276,7 +276,12 @@ func MakeSourceFileRegion(
 	dataFileSize := fi.FileMeta.FileSize
 	divisor := int64(columns)
 	isCsvFile := fi.FileMeta.Type == SourceTypeCSV
-	if !isCsvFile {
+	isJSONFile := fi.FileMeta.Type == SourceTypeJSON
+	switch {
+	case isJSONFile:
+		// the keys of the JSON objects may be omitted, so a row is as short as "{}".
+		divisor = minJSONRowSize
+	case !isCsvFile:
 		divisor += 2
 	}
 	// If a csv file is overlarge, we need to split it into multiple regions.
@@ -285,7 +290,8 @@ func MakeSourceFileRegion(
 	// like dumpling might be slight exceed the threshold when it is equal `max-region-size`, so we can
 	// avoid split a lot of small chunks.
 	// If a csv file is compressed, we can't split it now because we can't get the exact size of a row.
-	if isCsvFile && cfg.Mydumper.StrictFormat && fi.FileMeta.Compression == CompressionNone &&
+	// The newline-delimited JSON files are always split since the JSON strings can't contain raw newlines.
+	if (isJSONFile || isCsvFile && cfg.Mydumper.StrictFormat) && fi.FileMeta.Compression == CompressionNone &&
 		dataFileSize > int64(cfg.Mydumper.MaxRegionSize+cfg.Mydumper.MaxRegionSize/largeCSVLowerThresholdRation) {
 		_, regions, subFileSizes, err := SplitLargeFile(ctx, meta, cfg, fi, divisor, 0, ioWorkers, store)
 		return regions, subFileSizes, err
@@ -344,8 +350,8 @@ func makeParquetFileRegion(
 	return rowIDMax, region, nil
 }
 
-// SplitLargeFile splits a large csv file into multiple regions, the size of
-// each regions is specified by `config.MaxRegionSize`.
+// SplitLargeFile splits a large csv or newline-delimited JSON file into multiple
+// regions, the size of each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
 // strict.
 // e.g.
@@ -365,7 +371,8 @@ func SplitLargeFile(
 	dataFileSizes = make([]float64, 0, dataFile.FileMeta.FileSize/maxRegionSize+1)
 	startOffset, endOffset := int64(0), maxRegionSize
 	var columns []string
-	if cfg.Mydumper.CSV.Header {
+	isJSONFile := dataFile.FileMeta.Type == SourceTypeJSON
+	if cfg.Mydumper.CSV.Header && !isJSONFile {
 		r, err := store.Open(ctx, dataFile.FileMeta.Path)
 		if err != nil {
 			return 0, nil, nil, err
@@ -397,14 +404,25 @@ func SplitLargeFile(
 			if err != nil {
 				return 0, nil, nil, err
 			}
-			// Create a utf8mb4 convertor to encode and decode data with the charset of CSV files.
-			charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
-			if err != nil {
-				return 0, nil, nil, err
+			var parser interface {
+				Parser
+				ReadUntilTerminator() (int64, error)
 			}
-			parser, err := NewCSVParser(ctx, &cfg.Mydumper.CSV, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, false, charsetConvertor)
-			if err != nil {
-				return 0, nil, nil, err
+			terminator := "\n"
+			if isJSONFile {
+				// only the newlines are searched, which are the same bytes in all the supported charsets.
+				parser = NewJSONParser(ctx, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, nil)
+			} else {
+				// Create a utf8mb4 convertor to encode and decode data with the charset of CSV files.
+				charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+				if err != nil {
+					return 0, nil, nil, err
+				}
+				parser, err = NewCSVParser(ctx, &cfg.Mydumper.CSV, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, false, charsetConvertor)
+				if err != nil {
+					return 0, nil, nil, err
+				}
+				terminator = cfg.Mydumper.CSV.Terminator
 			}
 			if err = parser.SetPos(endOffset, prevRowIDMax); err != nil {
 				return 0, nil, nil, err
@@ -416,7 +434,7 @@ func SplitLargeFile(
 				}
 				log.FromContext(ctx).Warn("file contains no terminator at end",
 					zap.String("path", dataFile.FileMeta.Path),
-					zap.String("terminator", cfg.Mydumper.CSV.Terminator))
+					zap.String("terminator", terminator))
 				pos = dataFile.FileMeta.FileSize
 			}
 			endOffset = pos
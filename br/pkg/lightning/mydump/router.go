@@ -32,6 +32,8 @@ const (
 	SourceTypeParquet
 	// SourceTypeViewSchema means this source file is a schema file for the view.
 	SourceTypeViewSchema
+	// SourceTypeJSON means this source file is a newline-delimited JSON data file.
+	SourceTypeJSON
 )
 
 const (
@@ -47,6 +49,8 @@ const (
 	TypeCSV = "csv"
 	// TypeParquet is the source type value for parquet data file.
 	TypeParquet = "parquet"
+	// TypeJSON is the source type value for newline-delimited JSON data file.
+	TypeJSON = "json"
 	// TypeIgnore is the source type value for a ignored data file.
 	TypeIgnore = "ignore"
 )
@@ -83,6 +87,8 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeJSON, "jsonl", "ndjson":
+		return SourceTypeJSON, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -104,6 +110,8 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeJSON:
+		return TypeJSON
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -143,8 +151,8 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema\.sql(?:\.(\w*?))?$`, Schema: "$1", Table: "$2", Type: TableSchema, Compression: "$3", Unescape: true},
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql(?:\.(\w*?))?$`, Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}[.{compress}]'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)(?:\.(\w+))?$`, Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|json}[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|json|jsonl|ndjson)(?:\.(\w+))?$`, Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
 // FileRouter provides some operations to apply a rule to route file path to target schema/table
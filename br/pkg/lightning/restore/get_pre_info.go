@@ -478,6 +478,13 @@ func (p *PreRestoreInfoGetterImpl) ReadFirstNRowsByFileMeta(ctx context.Context,
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSON:
+		charsetConvertor, err := mydump.NewCharsetConvertor(p.cfg.Mydumper.DataCharacterSet, p.cfg.Mydumper.DataInvalidCharReplace)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		// the columns are the keys of the first JSON object.
+		parser = mydump.NewJSONParser(ctx, reader, blockBufSize, p.ioWorkers, charsetConvertor)
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -636,6 +643,14 @@ func (p *PreRestoreInfoGetterImpl) sampleDataFromTable(
 		if err != nil {
 			return 0.0, false, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSON:
+		charsetConvertor, err := mydump.NewCharsetConvertor(p.cfg.Mydumper.DataCharacterSet, p.cfg.Mydumper.DataInvalidCharReplace)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
+		jsonParser := mydump.NewJSONParser(ctx, reader, blockBufSize, p.ioWorkers, charsetConvertor)
+		jsonParser.SetTableColumns(tableInfo.Columns)
+		parser = jsonParser
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
@@ -115,6 +115,8 @@ func NewMockImportSource(dbSrcDataMap map[string]*MockDBSourceData) (*MockImport
 					fileInfo.FileMeta.Type = mydump.SourceTypeSQL
 				case strings.HasSuffix(tblDataFile.FileName, ".parquet"):
 					fileInfo.FileMeta.Type = mydump.SourceTypeParquet
+				case strings.HasSuffix(tblDataFile.FileName, ".json"):
+					fileInfo.FileMeta.Type = mydump.SourceTypeJSON
 				default:
 					return nil, errors.Errorf("unsupported file type: %s", tblDataFile.FileName)
 				}
@@ -2166,6 +2166,14 @@ func newChunkRestore(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSON:
+		charsetConvertor, err := mydump.NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		jsonParser := mydump.NewJSONParser(ctx, reader, blockBufSize, ioWorkers, charsetConvertor)
+		jsonParser.SetTableColumns(tableInfo.Core.Columns)
+		parser = jsonParser
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String()))
 	}
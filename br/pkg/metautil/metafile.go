@@ -37,6 +37,8 @@ const (
 	MetaFile = "backupmeta"
 	// MetaJSONFile represents backup meta json file name
 	MetaJSONFile = "backupmeta.json"
+	// DataKeyFile represents the file name of the data key wrapped by the master key
+	DataKeyFile = "backup.datakey"
 	// MaxBatchSize represents the internal channel buffer size of MetaWriter and MetaReader.
 	MaxBatchSize = 1024
 
@@ -397,7 +397,10 @@ func (s *AzureBlobStorage) Create(_ context.Context, name string) (ExternalFileW
 		accessTier: s.accessTier,
 	}
 
-	uploaderWriter := newBufferedWriter(uploader, azblob.BlockBlobMaxUploadBlobBytes, NoCompression)
+	uploaderWriter, err := newBufferedWriter(uploader, azblob.BlockBlobMaxUploadBlobBytes, NoCompression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return uploaderWriter, nil
 }
 
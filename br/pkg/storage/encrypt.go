@@ -0,0 +1,666 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+// EncryptionMethod is the cipher of the client-side encryption.
+type EncryptionMethod uint8
+
+const (
+	// EncryptionPlaintext means the files are not encrypted.
+	EncryptionPlaintext EncryptionMethod = iota
+	// EncryptionAES128CTR encrypts the files by AES-128 in CTR mode.
+	EncryptionAES128CTR
+	// EncryptionAES192CTR encrypts the files by AES-192 in CTR mode.
+	EncryptionAES192CTR
+	// EncryptionAES256CTR encrypts the files by AES-256 in CTR mode.
+	EncryptionAES256CTR
+	// EncryptionAES128GCM encrypts and authenticates the files by AES-128 in GCM mode.
+	EncryptionAES128GCM
+	// EncryptionAES192GCM encrypts and authenticates the files by AES-192 in GCM mode.
+	EncryptionAES192GCM
+	// EncryptionAES256GCM encrypts and authenticates the files by AES-256 in GCM mode.
+	EncryptionAES256GCM
+)
+
+var encryptionMethodNames = []string{
+	EncryptionPlaintext: "plaintext",
+	EncryptionAES128CTR: "aes128-ctr",
+	EncryptionAES192CTR: "aes192-ctr",
+	EncryptionAES256CTR: "aes256-ctr",
+	EncryptionAES128GCM: "aes128-gcm",
+	EncryptionAES192GCM: "aes192-gcm",
+	EncryptionAES256GCM: "aes256-gcm",
+}
+
+// ParseEncryptionMethod parses the encryption method case-insensitively, the empty string means plaintext.
+func ParseEncryptionMethod(s string) (EncryptionMethod, error) {
+	if len(s) == 0 {
+		return EncryptionPlaintext, nil
+	}
+	for m, name := range encryptionMethodNames {
+		if strings.EqualFold(s, name) {
+			return EncryptionMethod(m), nil
+		}
+	}
+	return EncryptionPlaintext, errors.Annotatef(berrors.ErrStorageInvalidConfig,
+		"invalid encryption method '%s', should be one of %s", s, strings.Join(encryptionMethodNames, "|"))
+}
+
+// String implements fmt.Stringer.
+func (m EncryptionMethod) String() string {
+	if int(m) < len(encryptionMethodNames) {
+		return encryptionMethodNames[m]
+	}
+	return "unknown"
+}
+
+// KeyLen returns the length of the data key in bytes.
+func (m EncryptionMethod) KeyLen() int {
+	switch m {
+	case EncryptionAES128CTR, EncryptionAES128GCM:
+		return 16
+	case EncryptionAES192CTR, EncryptionAES192GCM:
+		return 24
+	case EncryptionAES256CTR, EncryptionAES256GCM:
+		return 32
+	default:
+		return 0
+	}
+}
+
+func (m EncryptionMethod) isGCM() bool {
+	return m == EncryptionAES128GCM || m == EncryptionAES192GCM || m == EncryptionAES256GCM
+}
+
+// EncryptionConfig is the configuration of the client-side encryption. Exactly one of Key, KeyFile and MasterKey
+// should be set unless Method is plaintext.
+type EncryptionConfig struct {
+	// Method is the encryption method, see ParseEncryptionMethod.
+	Method string `toml:"method" json:"method"`
+	// Key is the hexadecimal data key.
+	Key string `toml:"key" json:"-"`
+	// KeyFile is the path of the file whose content is the hexadecimal data key.
+	KeyFile string `toml:"key-file" json:"key-file"`
+	// MasterKey is the URI of the master key for the envelope encryption, see NewMasterKey. A random data key is
+	// generated to encrypt the files, and it's stored in the files after wrapped by the master key.
+	MasterKey string `toml:"master-key" json:"master-key"`
+}
+
+// Validate checks the encryption configuration without loading the keys.
+func (cfg *EncryptionConfig) Validate() error {
+	method, err := ParseEncryptionMethod(cfg.Method)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if method == EncryptionPlaintext {
+		return nil
+	}
+	keys := 0
+	for _, s := range []string{cfg.Key, cfg.KeyFile, cfg.MasterKey} {
+		if len(s) > 0 {
+			keys++
+		}
+	}
+	if keys != 1 {
+		return errors.Annotate(berrors.ErrStorageInvalidConfig,
+			"exactly one of the encryption key, key file and master key should be provided")
+	}
+	return nil
+}
+
+// LoadEncryptionKey returns the data key given by the hexadecimal string or the file containing it.
+func LoadEncryptionKey(key, keyFile string) ([]byte, error) {
+	if len(key) == 0 {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to read the encryption key file")
+		}
+		key = string(bytes.TrimSuffix(content, []byte("\n")))
+	}
+	dataKey, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "the encryption key should be a hexadecimal string")
+	}
+	return dataKey, nil
+}
+
+const (
+	encryptionMagic   = "TENC"
+	encryptionVersion = 1
+	// encryptionSegmentSize is the plaintext size of a GCM segment. The segments are sealed separately so that the
+	// reader can seek to any segment, and the last segment is sealed with a different additional data to detect the
+	// truncation of the file.
+	encryptionSegmentSize = 64 * 1024
+	gcmNonceLen           = 12
+	gcmTagLen             = 16
+)
+
+// encryptionHeader is written at the beginning of the encrypted files:
+//
+//	magic "TENC" | version (1 byte) | method (1 byte) | IV length (1 byte) | IV | wrapped key length (2 bytes) | wrapped key
+//
+// The whole header is a part of the additional data of every GCM segment, so it can't be modified undetected.
+type encryptionHeader struct {
+	method EncryptionMethod
+	iv     []byte
+	// wrappedKey is the data key wrapped by the master key, it's empty if the data key is given directly.
+	wrappedKey []byte
+}
+
+func (h *encryptionHeader) marshal() []byte {
+	buf := make([]byte, 0, len(encryptionMagic)+5+len(h.iv)+len(h.wrappedKey))
+	buf = append(buf, encryptionMagic...)
+	buf = append(buf, encryptionVersion, byte(h.method), byte(len(h.iv)))
+	buf = append(buf, h.iv...)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(h.wrappedKey)))
+	return append(buf, h.wrappedKey...)
+}
+
+// readEncryptionHeader reads the header from r, and returns the header and its length.
+func readEncryptionHeader(r io.Reader) (*encryptionHeader, int64, error) {
+	fixed := make([]byte, len(encryptionMagic)+3)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, 0, errors.Annotate(berrors.ErrStorageUnknown, "the file is not encrypted or corrupted")
+	}
+	if string(fixed[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, 0, errors.Annotate(berrors.ErrStorageUnknown, "the file is not encrypted or corrupted")
+	}
+	if version := fixed[len(encryptionMagic)]; version != encryptionVersion {
+		return nil, 0, errors.Annotatef(berrors.ErrStorageUnknown, "unsupported encryption version %d", version)
+	}
+	h := &encryptionHeader{method: EncryptionMethod(fixed[len(encryptionMagic)+1])}
+	if h.method.KeyLen() == 0 {
+		return nil, 0, errors.Annotatef(berrors.ErrStorageUnknown, "unsupported encryption method %d", h.method)
+	}
+	h.iv = make([]byte, fixed[len(encryptionMagic)+2])
+	if _, err := io.ReadFull(r, h.iv); err != nil {
+		return nil, 0, errors.Annotate(berrors.ErrStorageUnknown, "the encryption header is corrupted")
+	}
+	keyLen := make([]byte, 2)
+	if _, err := io.ReadFull(r, keyLen); err != nil {
+		return nil, 0, errors.Annotate(berrors.ErrStorageUnknown, "the encryption header is corrupted")
+	}
+	h.wrappedKey = make([]byte, binary.BigEndian.Uint16(keyLen))
+	if _, err := io.ReadFull(r, h.wrappedKey); err != nil {
+		return nil, 0, errors.Annotate(berrors.ErrStorageUnknown, "the encryption header is corrupted")
+	}
+	return h, int64(len(fixed) + len(h.iv) + len(keyLen) + len(h.wrappedKey)), nil
+}
+
+type withEncryption struct {
+	ExternalStorage
+	method EncryptionMethod
+	// dataKey encrypts the written files.
+	dataKey []byte
+	// wrappedKey is the dataKey wrapped by the master key, it's nil if the data key is given directly.
+	wrappedKey []byte
+	masterKey  MasterKey
+
+	mu sync.Mutex
+	// unwrappedKeys caches the data keys unwrapped by the master key.
+	unwrappedKeys map[string][]byte
+}
+
+// WithEncryption returns an ExternalStorage which encrypts the created files and decrypts the opened files on
+// the client side. Only the files encrypted by the configured method can be read, the method recorded in the header
+// isn't authenticated in CTR mode, so it can't be trusted to choose the way to decrypt the file. The sizes reported by WalkDir are the sizes of the encrypted
+// files, which are a little larger than the plaintext.
+func WithEncryption(ctx context.Context, inner ExternalStorage, cfg *EncryptionConfig) (ExternalStorage, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	method, _ := ParseEncryptionMethod(cfg.Method)
+	if method == EncryptionPlaintext {
+		return inner, nil
+	}
+	w := &withEncryption{
+		ExternalStorage: inner,
+		method:          method,
+		unwrappedKeys:   make(map[string][]byte),
+	}
+	if len(cfg.MasterKey) == 0 {
+		dataKey, err := LoadEncryptionKey(cfg.Key, cfg.KeyFile)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(dataKey) != method.KeyLen() {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig,
+				"the length of the encryption key should be %d bytes for %s", method.KeyLen(), method)
+		}
+		w.dataKey = dataKey
+		return w, nil
+	}
+
+	masterKey, err := NewMasterKey(ctx, cfg.MasterKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w.masterKey = masterKey
+	w.dataKey = make([]byte, method.KeyLen())
+	if _, err := rand.Read(w.dataKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if w.wrappedKey, err = WrapDataKey(ctx, masterKey, w.dataKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(w.wrappedKey) > math.MaxUint16 {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "the wrapped data key is too long")
+	}
+	w.unwrappedKeys[string(w.wrappedKey)] = w.dataKey
+	return w, nil
+}
+
+func (w *withEncryption) Create(ctx context.Context, name string) (ExternalFileWriter, error) {
+	writer, err := w.ExternalStorage.Create(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encryptWriter, err := w.newEncryptWriter(ctx, writer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return encryptWriter, nil
+}
+
+func (w *withEncryption) Open(ctx context.Context, path string) (ExternalFileReader, error) {
+	fileReader, err := w.ExternalStorage.Open(ctx, path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	decryptReader, err := w.newDecryptReader(ctx, fileReader)
+	if err != nil {
+		_ = fileReader.Close()
+		return nil, errors.Annotatef(err, "fail to decrypt file %s", path)
+	}
+	return decryptReader, nil
+}
+
+func (w *withEncryption) WriteFile(ctx context.Context, name string, data []byte) error {
+	bf := NewBufferWriter()
+	encryptWriter, err := w.newEncryptWriter(ctx, bf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = encryptWriter.Write(ctx, data); err != nil {
+		return errors.Trace(err)
+	}
+	if err = encryptWriter.Close(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	return w.ExternalStorage.WriteFile(ctx, name, bf.Bytes())
+}
+
+func (w *withEncryption) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	data, err := w.ExternalStorage.ReadFile(ctx, name)
+	if err != nil {
+		return data, errors.Trace(err)
+	}
+	decryptReader, err := w.newDecryptReader(ctx, &memFileReader{br: bytes.NewReader(data)})
+	if err != nil {
+		return nil, errors.Annotatef(err, "fail to decrypt file %s", name)
+	}
+	return io.ReadAll(decryptReader)
+}
+
+// dataKeyOf returns the data key which encrypts the file of the header.
+func (w *withEncryption) dataKeyOf(ctx context.Context, h *encryptionHeader) ([]byte, error) {
+	var dataKey []byte
+	if len(h.wrappedKey) == 0 {
+		if w.masterKey != nil {
+			return nil, errors.Annotate(berrors.ErrStorageInvalidConfig,
+				"the file is encrypted by a data key directly, but only the master key is provided")
+		}
+		dataKey = w.dataKey
+	} else {
+		if w.masterKey == nil {
+			return nil, errors.Annotate(berrors.ErrStorageInvalidConfig,
+				"the data key of the file is wrapped by a master key, but the master key is not provided")
+		}
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		var ok bool
+		if dataKey, ok = w.unwrappedKeys[string(h.wrappedKey)]; !ok {
+			var err error
+			if dataKey, err = UnwrapDataKey(ctx, w.masterKey, h.wrappedKey); err != nil {
+				return nil, errors.Trace(err)
+			}
+			w.unwrappedKeys[string(h.wrappedKey)] = dataKey
+		}
+	}
+	if len(dataKey) != h.method.KeyLen() {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig,
+			"the length of the encryption key doesn't match the method %s of the file", h.method)
+	}
+	return dataKey, nil
+}
+
+// segmentNonce returns the nonce of the i-th GCM segment, which is the base nonce XOR the segment index.
+func segmentNonce(base []byte, i uint64) []byte {
+	nonce := make([]byte, len(base))
+	copy(nonce, base)
+	for j := 0; j < 8; j++ {
+		nonce[len(nonce)-1-j] ^= byte(i >> (8 * j))
+	}
+	return nonce
+}
+
+// segmentAdditionalData returns the additional data of a GCM segment, which is the header of the file followed by
+// whether the segment is the last one.
+func segmentAdditionalData(header []byte, last bool) []byte {
+	ad := make([]byte, 0, len(header)+1)
+	ad = append(ad, header...)
+	if last {
+		return append(ad, 1)
+	}
+	return append(ad, 0)
+}
+
+// newCTRStream returns the CTR keystream starting from the offset of the plaintext.
+func newCTRStream(block cipher.Block, iv []byte, offset int64) cipher.Stream {
+	counter := make([]byte, len(iv))
+	copy(counter, iv)
+	// add offset / block size to the big-endian counter
+	carry := uint64(offset / aes.BlockSize)
+	for i := len(counter) - 1; i >= 0 && carry > 0; i-- {
+		sum := uint64(counter[i]) + carry&0xff
+		counter[i] = byte(sum)
+		carry = carry>>8 + sum>>8
+	}
+	stream := cipher.NewCTR(block, counter)
+	if skip := offset % aes.BlockSize; skip > 0 {
+		buf := make([]byte, skip)
+		stream.XORKeyStream(buf, buf)
+	}
+	return stream
+}
+
+type encryptWriter struct {
+	writer ExternalFileWriter
+	// stream is the keystream of CTR.
+	stream cipher.Stream
+	// aead, header, nonce, segment and buf are used by GCM.
+	aead    cipher.AEAD
+	header  []byte
+	nonce   []byte
+	segment uint64
+	buf     []byte
+}
+
+func (w *withEncryption) newEncryptWriter(ctx context.Context, writer ExternalFileWriter) (*encryptWriter, error) {
+	block, err := aes.NewCipher(w.dataKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	h := &encryptionHeader{method: w.method, wrappedKey: w.wrappedKey}
+	ew := &encryptWriter{writer: writer}
+	if w.method.isGCM() {
+		if ew.aead, err = cipher.NewGCM(block); err != nil {
+			return nil, errors.Trace(err)
+		}
+		h.iv = make([]byte, gcmNonceLen)
+		ew.nonce = h.iv
+		ew.buf = make([]byte, 0, encryptionSegmentSize)
+	} else {
+		h.iv = make([]byte, aes.BlockSize)
+	}
+	if _, err = rand.Read(h.iv); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !w.method.isGCM() {
+		ew.stream = newCTRStream(block, h.iv, 0)
+	}
+	header := h.marshal()
+	if ew.aead != nil {
+		ew.header = header
+	}
+	if _, err = writer.Write(ctx, header); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ew, nil
+}
+
+// Write implements ExternalFileWriter.
+func (w *encryptWriter) Write(ctx context.Context, p []byte) (int, error) {
+	if w.stream != nil {
+		ciphertext := make([]byte, len(p))
+		w.stream.XORKeyStream(ciphertext, p)
+		if _, err := w.writer.Write(ctx, ciphertext); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return len(p), nil
+	}
+	n := len(p)
+	for len(p) > 0 {
+		// a full segment is sealed only when there is more data, so the last segment is never empty unless the
+		// file is empty
+		if len(w.buf) == encryptionSegmentSize {
+			if err := w.sealSegment(ctx, false); err != nil {
+				return 0, errors.Trace(err)
+			}
+		}
+		m := encryptionSegmentSize - len(w.buf)
+		if m > len(p) {
+			m = len(p)
+		}
+		w.buf = append(w.buf, p[:m]...)
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func (w *encryptWriter) sealSegment(ctx context.Context, last bool) error {
+	ciphertext := w.aead.Seal(nil, segmentNonce(w.nonce, w.segment), w.buf, segmentAdditionalData(w.header, last))
+	w.segment++
+	w.buf = w.buf[:0]
+	_, err := w.writer.Write(ctx, ciphertext)
+	return errors.Trace(err)
+}
+
+// Close implements ExternalFileWriter.
+func (w *encryptWriter) Close(ctx context.Context) error {
+	if w.aead != nil {
+		if err := w.sealSegment(ctx, true); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return w.writer.Close(ctx)
+}
+
+type decryptReader struct {
+	reader    ExternalFileReader
+	headerLen int64
+	// pos is the offset in the plaintext.
+	pos int64
+	// size is the size of the plaintext, -1 means unknown.
+	size  int64
+	block cipher.Block
+	iv    []byte
+	// header is the marshaled header authenticated by the GCM segments.
+	header []byte
+
+	// stream is the CTR keystream at pos, nil means it should be recreated after Seek.
+	stream cipher.Stream
+
+	aead cipher.AEAD
+	// cipherPos is the offset of the underlying reader.
+	cipherPos int64
+	// segment is the plaintext of the segmentIdx-th GCM segment.
+	segment    []byte
+	segmentIdx int64
+}
+
+func (w *withEncryption) newDecryptReader(ctx context.Context, reader ExternalFileReader) (*decryptReader, error) {
+	h, headerLen, err := readEncryptionHeader(reader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dataKey, err := w.dataKeyOf(ctx, h)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the method isn't authenticated in CTR mode, a GCM file can be downgraded to CTR by modifying the header
+	if h.method != w.method {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig,
+			"the file is encrypted by %s, but the encryption method is %s", h.method, w.method)
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &decryptReader{
+		reader:     reader,
+		headerLen:  headerLen,
+		size:       -1,
+		block:      block,
+		iv:         h.iv,
+		header:     h.marshal(),
+		segmentIdx: -1,
+	}
+	if !h.method.isGCM() {
+		if len(h.iv) != aes.BlockSize {
+			return nil, errors.Annotate(berrors.ErrStorageUnknown, "the encryption header is corrupted")
+		}
+		r.stream = newCTRStream(block, h.iv, 0)
+		return r, nil
+	}
+
+	if r.aead, err = cipher.NewGCM(block); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(h.iv) != r.aead.NonceSize() {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "the encryption header is corrupted")
+	}
+	// the size is required to recognize the last segment
+	end, err := reader.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bodyLen := end - headerLen
+	segments := (bodyLen + encryptionSegmentSize + gcmTagLen - 1) / (encryptionSegmentSize + gcmTagLen)
+	if segments == 0 || bodyLen-(segments-1)*(encryptionSegmentSize+gcmTagLen) < gcmTagLen {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "the encrypted file is truncated")
+	}
+	r.size = bodyLen - segments*gcmTagLen
+	if r.cipherPos, err = reader.Seek(headerLen, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+// Read implements io.Reader.
+func (r *decryptReader) Read(p []byte) (int, error) {
+	if r.aead == nil {
+		if r.stream == nil {
+			// the position is changed by Seek, move the underlying reader and the keystream to it
+			if _, err := r.reader.Seek(r.headerLen+r.pos, io.SeekStart); err != nil {
+				return 0, errors.Trace(err)
+			}
+			r.stream = newCTRStream(r.block, r.iv, r.pos)
+		}
+		n, err := r.reader.Read(p)
+		r.stream.XORKeyStream(p[:n], p[:n])
+		r.pos += int64(n)
+		return n, err
+	}
+
+	if r.pos >= r.size {
+		// the empty file still has a sealed segment, verify it to detect the truncation
+		if r.size == 0 && r.segmentIdx < 0 {
+			if err := r.loadSegment(0); err != nil {
+				return 0, errors.Trace(err)
+			}
+		}
+		return 0, io.EOF
+	}
+	idx := r.pos / encryptionSegmentSize
+	if idx != r.segmentIdx {
+		if err := r.loadSegment(idx); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	n := copy(p, r.segment[r.pos-idx*encryptionSegmentSize:])
+	r.pos += int64(n)
+	return n, nil
+}
+
+func (r *decryptReader) loadSegment(idx int64) error {
+	start := r.headerLen + idx*(encryptionSegmentSize+gcmTagLen)
+	if r.cipherPos != start {
+		if _, err := r.reader.Seek(start, io.SeekStart); err != nil {
+			return errors.Trace(err)
+		}
+		r.cipherPos = start
+	}
+	plainLen := r.size - idx*encryptionSegmentSize
+	if plainLen > encryptionSegmentSize {
+		plainLen = encryptionSegmentSize
+	}
+	ciphertext := make([]byte, plainLen+gcmTagLen)
+	n, err := io.ReadFull(r.reader, ciphertext)
+	r.cipherPos += int64(n)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	last := (idx+1)*encryptionSegmentSize >= r.size
+	r.segment, err = r.aead.Open(r.segment[:0], segmentNonce(r.iv, uint64(idx)), ciphertext, segmentAdditionalData(r.header, last))
+	if err != nil {
+		r.segmentIdx = -1
+		return errors.Annotate(berrors.ErrStorageUnknown, "fail to authenticate the encrypted file, the key is wrong or the file is corrupted")
+	}
+	r.segmentIdx = idx
+	return nil
+}
+
+// Seek implements io.Seeker, the offset is in the plaintext.
+func (r *decryptReader) Seek(offset int64, whence int) (int64, error) {
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = r.pos + offset
+	case io.SeekEnd:
+		if r.size < 0 {
+			end, err := r.reader.Seek(0, io.SeekEnd)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			r.size = end - r.headerLen
+			r.stream = nil
+		}
+		target = r.size + offset
+	default:
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid whence '%d'", whence)
+	}
+	if target < 0 {
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid offset to seek '%d'", target)
+	}
+	if target != r.pos {
+		r.pos = target
+		r.stream = nil
+	}
+	return target, nil
+}
+
+// Close implements io.Closer.
+func (r *decryptReader) Close() error {
+	return r.reader.Close()
+}
@@ -0,0 +1,306 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEncryptionConfig(t *testing.T) {
+	for s, expected := range map[string]EncryptionMethod{
+		"":           EncryptionPlaintext,
+		"plaintext":  EncryptionPlaintext,
+		"AES128-CTR": EncryptionAES128CTR,
+		"aes256-ctr": EncryptionAES256CTR,
+		"aes192-gcm": EncryptionAES192GCM,
+		"AES256-GCM": EncryptionAES256GCM,
+	} {
+		method, err := ParseEncryptionMethod(s)
+		require.NoError(t, err)
+		require.Equal(t, expected, method)
+	}
+	_, err := ParseEncryptionMethod("sm4-ctr")
+	require.ErrorContains(t, err, "invalid encryption method 'sm4-ctr'")
+	require.Equal(t, 24, EncryptionAES192CTR.KeyLen())
+	require.Equal(t, "aes256-gcm", EncryptionAES256GCM.String())
+
+	cfg := &EncryptionConfig{Method: "plaintext"}
+	require.NoError(t, cfg.Validate())
+	cfg.Method = "aes128-gcm"
+	require.ErrorContains(t, cfg.Validate(), "exactly one of")
+	cfg.Key = "0123456789abcdef0123456789abcdef"
+	require.NoError(t, cfg.Validate())
+	cfg.MasterKey = "local:///keyring"
+	require.ErrorContains(t, cfg.Validate(), "exactly one of")
+
+	cfg = &EncryptionConfig{Method: "aes256-ctr", Key: "0123456789abcdef0123456789abcdef"}
+	_, err = WithEncryption(context.Background(), NewMemStorage(), cfg)
+	require.ErrorContains(t, err, "the length of the encryption key should be 32 bytes for aes256-ctr")
+}
+
+func TestCTRStreamAtOffset(t *testing.T) {
+	block, err := aes.NewCipher(make([]byte, 16))
+	require.NoError(t, err)
+	// the counter overflows in the low bytes
+	iv := bytes.Repeat([]byte{0xff}, aes.BlockSize)
+	iv[0] = 0
+	keystream := make([]byte, 1000)
+	cipher.NewCTR(block, iv).XORKeyStream(keystream, keystream)
+	for _, offset := range []int64{0, 1, 15, 16, 17, 255, 256, 999} {
+		buf := make([]byte, 1000-offset)
+		newCTRStream(block, iv, offset).XORKeyStream(buf, buf)
+		require.Equal(t, keystream[offset:], buf, offset)
+	}
+}
+
+func testEncryptionReadWrite(t *testing.T, s ExternalStorage, inner *MemStorage) {
+	ctx := context.Background()
+	for _, size := range []int{0, 1, 100, encryptionSegmentSize, 2*encryptionSegmentSize + 7} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		require.NoError(t, s.WriteFile(ctx, "/write", data))
+		raw, err := inner.ReadFile(ctx, "/write")
+		require.NoError(t, err)
+		require.Equal(t, encryptionMagic, string(raw[:len(encryptionMagic)]))
+		if size >= 100 {
+			require.NotContains(t, string(raw), string(data))
+		}
+		content, err := s.ReadFile(ctx, "/write")
+		require.NoError(t, err)
+		require.Equal(t, data, content)
+
+		// write in small pieces
+		w, err := s.Create(ctx, "/create")
+		require.NoError(t, err)
+		for i := 0; i < size; i += 1000 {
+			end := i + 1000
+			if end > size {
+				end = size
+			}
+			_, err = w.Write(ctx, data[i:end])
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close(ctx))
+
+		r, err := s.Open(ctx, "/create")
+		require.NoError(t, err)
+		content, err = io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, content)
+
+		// seek and read
+		for _, offset := range []int{size / 3, size - 1, 0, size / 2, encryptionSegmentSize + 3} {
+			if offset < 0 || offset > size {
+				continue
+			}
+			pos, err := r.Seek(int64(offset), io.SeekStart)
+			require.NoError(t, err)
+			require.Equal(t, int64(offset), pos)
+			buf := make([]byte, 20)
+			n, err := io.ReadFull(r, buf)
+			if offset+20 <= size {
+				require.NoError(t, err)
+			}
+			require.Equal(t, data[offset:offset+n], buf[:n])
+		}
+		pos, err := r.Seek(-1, io.SeekEnd)
+		if size == 0 {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, int64(size-1), pos)
+			content, err = io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, data[size-1:], content)
+		}
+		require.NoError(t, r.Close())
+		require.NoError(t, s.DeleteFile(ctx, "/create"))
+	}
+}
+
+func TestEncryptionReadWrite(t *testing.T) {
+	ctx := context.Background()
+	for _, method := range []string{"aes128-ctr", "aes256-ctr", "aes192-gcm", "aes256-gcm"} {
+		m, err := ParseEncryptionMethod(method)
+		require.NoError(t, err)
+		key := make([]byte, m.KeyLen())
+		_, err = rand.Read(key)
+		require.NoError(t, err)
+		keyFile := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0o600))
+
+		inner := NewMemStorage()
+		s, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: method, KeyFile: keyFile})
+		require.NoError(t, err)
+		testEncryptionReadWrite(t, s, inner)
+
+		// the files can't be read by the storage of the other methods with the same key
+		other, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes256-gcm", Key: hex.EncodeToString(key)})
+		if m.KeyLen() == 32 && m != EncryptionAES256GCM {
+			require.NoError(t, err)
+			_, err = other.ReadFile(ctx, "/write")
+			require.ErrorContains(t, err, "the file is encrypted by "+method+", but the encryption method is aes256-gcm")
+		}
+	}
+}
+
+func TestEncryptionAuthentication(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemStorage()
+	s, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-gcm", Key: "0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+	data := bytes.Repeat([]byte("0123456789"), encryptionSegmentSize/5)
+	require.NoError(t, s.WriteFile(ctx, "/f", data))
+	raw, err := inner.ReadFile(ctx, "/f")
+	require.NoError(t, err)
+
+	// the wrong key
+	wrong, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-gcm", Key: "0123456789abcdef0123456789abcdee"})
+	require.NoError(t, err)
+	_, err = wrong.ReadFile(ctx, "/f")
+	require.ErrorContains(t, err, "fail to authenticate")
+
+	// the modified file
+	modified := append([]byte{}, raw...)
+	modified[len(modified)-100] ^= 1
+	require.NoError(t, inner.WriteFile(ctx, "/f", modified))
+	_, err = s.ReadFile(ctx, "/f")
+	require.ErrorContains(t, err, "fail to authenticate")
+
+	// the file truncated at the segment boundary
+	headerLen := len(raw) - 2*(encryptionSegmentSize+gcmTagLen)
+	require.NoError(t, inner.WriteFile(ctx, "/f", raw[:headerLen+encryptionSegmentSize+gcmTagLen]))
+	_, err = s.ReadFile(ctx, "/f")
+	require.ErrorContains(t, err, "fail to authenticate")
+	require.NoError(t, inner.WriteFile(ctx, "/f", raw[:headerLen+gcmTagLen-1]))
+	_, err = s.ReadFile(ctx, "/f")
+	require.ErrorContains(t, err, "truncated")
+
+	// the header downgraded to CTR: the keystream of GCM is CTR starting at nonce || 00000002, so the first segment
+	// would be decrypted without authentication if the method in the header was trusted
+	iv := append(append([]byte{}, raw[len(encryptionMagic)+3:len(encryptionMagic)+3+gcmNonceLen]...), 0, 0, 0, 2)
+	downgraded := (&encryptionHeader{method: EncryptionAES128CTR, iv: iv}).marshal()
+	downgraded = append(downgraded, raw[headerLen:headerLen+encryptionSegmentSize]...)
+	require.NoError(t, inner.WriteFile(ctx, "/f", downgraded))
+	_, err = s.ReadFile(ctx, "/f")
+	require.ErrorContains(t, err, "the file is encrypted by aes128-ctr, but the encryption method is aes128-gcm")
+	// the crafted file is a valid CTR file, only the method check rejects it
+	ctr, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-ctr", Key: "0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+	content, err := ctr.ReadFile(ctx, "/f")
+	require.NoError(t, err)
+	require.Equal(t, data[:encryptionSegmentSize], content)
+
+	// the modified header is detected even if the nonce and the key are unchanged
+	h, _, err := readEncryptionHeader(bytes.NewReader(raw))
+	require.NoError(t, err)
+	block, err := aes.NewCipher([]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef})
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	_, err = aead.Open(nil, segmentNonce(h.iv, 0), raw[headerLen:headerLen+encryptionSegmentSize+gcmTagLen],
+		segmentAdditionalData(h.marshal(), false))
+	require.NoError(t, err)
+	h.wrappedKey = []byte("k")
+	_, err = aead.Open(nil, segmentNonce(h.iv, 0), raw[headerLen:headerLen+encryptionSegmentSize+gcmTagLen],
+		segmentAdditionalData(h.marshal(), false))
+	require.Error(t, err)
+
+	// the plaintext file
+	require.NoError(t, inner.WriteFile(ctx, "/f", data))
+	_, err = s.ReadFile(ctx, "/f")
+	require.ErrorContains(t, err, "not encrypted")
+}
+
+type mockKMS struct {
+	key byte
+}
+
+func (m *mockKMS) Encrypt(_ context.Context, plaintext []byte) (string, []byte, error) {
+	ciphertext := make([]byte, len(plaintext))
+	for i := range plaintext {
+		ciphertext[i] = plaintext[i] ^ m.key
+	}
+	return "mock", ciphertext, nil
+}
+
+func (m *mockKMS) Decrypt(ctx context.Context, _ string, ciphertext []byte) ([]byte, error) {
+	_, plaintext, err := m.Encrypt(ctx, ciphertext)
+	return plaintext, err
+}
+
+func TestEnvelopeEncryption(t *testing.T) {
+	ctx := context.Background()
+	keyring := filepath.Join(t.TempDir(), "keyring")
+	require.NoError(t, os.WriteFile(keyring, []byte(`# keys for test
+k1 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+
+k2 fedcba9876543210fedcba9876543210
+`), 0o600))
+
+	inner := NewMemStorage()
+	s, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes256-gcm", MasterKey: keyring})
+	require.NoError(t, err)
+	testEncryptionReadWrite(t, s, inner)
+	require.NoError(t, s.WriteFile(ctx, "/k1", []byte("encrypted by k1")))
+
+	// the master key is rotated, the files encrypted by the old key can still be read
+	s, err = WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes256-gcm", MasterKey: "local://" + filepath.ToSlash(keyring) + "?key-id=k2"})
+	require.NoError(t, err)
+	require.NoError(t, s.WriteFile(ctx, "/k2", []byte("encrypted by k2")))
+	for name, expected := range map[string]string{"/k1": "encrypted by k1", "/k2": "encrypted by k2"} {
+		content, err := s.ReadFile(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(content))
+	}
+	raw, err := inner.ReadFile(ctx, "/k2")
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "k2")
+
+	_, err = WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-ctr", MasterKey: "local://" + filepath.ToSlash(keyring) + "?key-id=k3"})
+	require.ErrorContains(t, err, "key 'k3' is not found")
+
+	// the data key is required to read the files encrypted by the data key directly, and vice versa
+	keyStorage, err := WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-ctr", Key: "0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+	_, err = keyStorage.ReadFile(ctx, "/k2")
+	require.ErrorContains(t, err, "the master key is not provided")
+	require.NoError(t, keyStorage.WriteFile(ctx, "/key", []byte("encrypted by data key")))
+	_, err = s.ReadFile(ctx, "/key")
+	require.ErrorContains(t, err, "only the master key is provided")
+
+	// the KMS provider
+	_, err = WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-ctr", MasterKey: "mock-kms://key"})
+	require.ErrorContains(t, err, "unknown KMS provider 'mock-kms'")
+	RegisterKMSProvider("mock-kms", func(_ context.Context, u *url.URL) (MasterKey, error) {
+		require.Equal(t, "key", u.Host)
+		return &mockKMS{key: 0x5a}, nil
+	})
+	s, err = WithEncryption(ctx, inner, &EncryptionConfig{Method: "aes128-ctr", MasterKey: "mock-kms://key"})
+	require.NoError(t, err)
+	testEncryptionReadWrite(t, s, inner)
+
+	mk, err := NewMasterKey(ctx, "mock-kms://key")
+	require.NoError(t, err)
+	wrapped, err := WrapDataKey(ctx, mk, []byte("data key"))
+	require.NoError(t, err)
+	dataKey, err := UnwrapDataKey(ctx, mk, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, "data key", string(dataKey))
+	_, err = UnwrapDataKey(ctx, mk, wrapped[:1])
+	require.ErrorContains(t, err, "corrupted")
+}
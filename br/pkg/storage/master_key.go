@@ -0,0 +1,181 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+const localKeyringScheme = "local"
+
+// MasterKey encrypts and decrypts the data keys of the envelope encryption. The data keys are the random keys
+// which encrypt the files, they're stored beside the encrypted data after wrapped by the master key.
+type MasterKey interface {
+	// Encrypt encrypts the data key, and returns the ID of the key used which is passed to Decrypt later.
+	Encrypt(ctx context.Context, plaintext []byte) (keyID string, ciphertext []byte, err error)
+	// Decrypt decrypts the data key encrypted by the key of keyID.
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// KMSProvider creates the MasterKey managed by a key management service from the URI.
+type KMSProvider func(ctx context.Context, u *url.URL) (MasterKey, error)
+
+var kmsProviders = struct {
+	sync.RWMutex
+	m map[string]KMSProvider
+}{m: make(map[string]KMSProvider)}
+
+// RegisterKMSProvider registers the KMS provider of the URI scheme, it's usually called in the init function
+// of the package which implements the provider.
+func RegisterKMSProvider(scheme string, provider KMSProvider) {
+	kmsProviders.Lock()
+	defer kmsProviders.Unlock()
+	kmsProviders.m[strings.ToLower(scheme)] = provider
+}
+
+// NewMasterKey creates the MasterKey from the URI. The URI without scheme or with the "local" scheme is the path
+// of a local keyring file, e.g. "local:///path/to/keyring?key-id=k1". The other schemes are looked up in the
+// registered KMS providers.
+func NewMasterKey(ctx context.Context, uri string) (MasterKey, error) {
+	if !strings.Contains(uri, "://") {
+		return newLocalKeyring(uri, "")
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid master key URI '%s': %v", uri, err)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme == localKeyringScheme {
+		return newLocalKeyring(u.Host+u.Path, u.Query().Get("key-id"))
+	}
+	kmsProviders.RLock()
+	provider, ok := kmsProviders.m[scheme]
+	kmsProviders.RUnlock()
+	if !ok {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "unknown KMS provider '%s'", u.Scheme)
+	}
+	mk, err := provider(ctx, u)
+	return mk, errors.Trace(err)
+}
+
+// localKeyring is a MasterKey of the keys in a local file. Each line of the file is a key ID and the hexadecimal
+// AES key separated by whitespace, the empty lines and the lines starting with '#' are ignored. All the keys can
+// decrypt the data keys, so the old keys can be kept in the file after the master key is rotated.
+type localKeyring struct {
+	keys map[string]cipher.AEAD
+	// current is the ID of the key which encrypts the data keys, it's the first key of the file by default.
+	current string
+}
+
+func newLocalKeyring(path, keyID string) (*localKeyring, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read the keyring file")
+	}
+	kr := &localKeyring{keys: make(map[string]cipher.AEAD)}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid line %d in the keyring file", lineNo)
+		}
+		key, err := hex.DecodeString(fields[1])
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid key '%s' in the keyring file", fields[0])
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid key '%s' in the keyring file: %v", fields[0], err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		kr.keys[fields[0]] = aead
+		if len(kr.current) == 0 {
+			kr.current = fields[0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(keyID) > 0 {
+		kr.current = keyID
+	}
+	if _, ok := kr.keys[kr.current]; !ok {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "key '%s' is not found in the keyring file", kr.current)
+	}
+	return kr, nil
+}
+
+// Encrypt implements MasterKey.
+func (kr *localKeyring) Encrypt(_ context.Context, plaintext []byte) (keyID string, ciphertext []byte, err error) {
+	aead := kr.keys[kr.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return kr.current, aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt implements MasterKey.
+func (kr *localKeyring) Decrypt(_ context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	aead, ok := kr.keys[keyID]
+	if !ok {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "key '%s' is not found in the keyring file", keyID)
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "the wrapped data key is corrupted")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageUnknown, "fail to decrypt the data key by key '%s'", keyID)
+	}
+	return plaintext, nil
+}
+
+// WrapDataKey encrypts the data key by the master key. The result contains the ID of the master key, so it can be
+// unwrapped by UnwrapDataKey alone.
+func WrapDataKey(ctx context.Context, mk MasterKey, dataKey []byte) ([]byte, error) {
+	keyID, ciphertext, err := mk.Encrypt(ctx, dataKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(keyID) > math.MaxUint16 {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "the master key ID is too long")
+	}
+	wrapped := make([]byte, 2, 2+len(keyID)+len(ciphertext))
+	binary.BigEndian.PutUint16(wrapped, uint16(len(keyID)))
+	wrapped = append(wrapped, keyID...)
+	return append(wrapped, ciphertext...), nil
+}
+
+// UnwrapDataKey decrypts the data key wrapped by WrapDataKey.
+func UnwrapDataKey(ctx context.Context, mk MasterKey, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 2 || len(wrapped) < 2+int(binary.BigEndian.Uint16(wrapped)) {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "the wrapped data key is corrupted")
+	}
+	idLen := int(binary.BigEndian.Uint16(wrapped))
+	dataKey, err := mk.Decrypt(ctx, string(wrapped[2:2+idLen]), wrapped[2+idLen:])
+	return dataKey, errors.Trace(err)
+}
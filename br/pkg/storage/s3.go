@@ -900,7 +900,10 @@ func (rs *S3Storage) Create(ctx context.Context, name string) (ExternalFileWrite
 	if err != nil {
 		return nil, err
 	}
-	uploaderWriter := newBufferedWriter(uploader, hardcodedS3ChunkSize, NoCompression)
+	uploaderWriter, err := newBufferedWriter(uploader, hardcodedS3ChunkSize, NoCompression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return uploaderWriter, nil
 }
 
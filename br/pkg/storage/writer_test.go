@@ -5,6 +5,7 @@ package storage
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -102,8 +103,8 @@ func TestCompressReaderWriter(t *testing.T) {
 		ctx := context.Background()
 		storage, err := Create(ctx, backend, true)
 		require.NoError(t, err)
-		storage = WithCompression(storage, Gzip)
-		fileName := strings.ReplaceAll(test.name, " ", "-") + ".txt.gz"
+		storage = WithCompression(storage, test.compressType)
+		fileName := fmt.Sprintf("%s-%d.txt", strings.ReplaceAll(test.name, " ", "-"), test.compressType)
 		writer, err := storage.Create(ctx, fileName)
 		require.NoError(t, err)
 		for _, str := range test.content {
@@ -135,7 +136,7 @@ func TestCompressReaderWriter(t *testing.T) {
 
 		require.Nil(t, file.Close())
 	}
-	compressTypeArr := []CompressType{Gzip}
+	compressTypeArr := []CompressType{Gzip, Zstd}
 	tests := []testcase{
 		{
 			name: "long text medium chunks",
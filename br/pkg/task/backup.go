@@ -336,6 +336,9 @@ func RunBackup(c context.Context, g glue.Glue, cmdName string, cfg *BackupConfig
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = cfg.prepareCipherKey(ctx, client.GetStorage(), true); err != nil {
+		return errors.Trace(err)
+	}
 	client.SetGCTTL(cfg.GCTTL)
 
 	backupTS, err := client.GetTS(ctx, cfg.TimeAgo, cfg.BackupTS)
@@ -156,6 +156,9 @@ func RunBackupRaw(c context.Context, g glue.Glue, cmdName string, cfg *RawKvConf
 	if err = client.SetStorage(ctx, u, &opts); err != nil {
 		return errors.Trace(err)
 	}
+	if err = cfg.prepareCipherKey(ctx, client.GetStorage(), true); err != nil {
+		return errors.Trace(err)
+	}
 
 	backupRange := rtree.Range{StartKey: cfg.StartKey, EndKey: cfg.EndKey}
 
@@ -5,6 +5,7 @@ package task
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"encoding/hex"
 	"fmt"
@@ -87,9 +88,10 @@ const (
 	defaultGRPCKeepaliveTimeout = 3 * time.Second
 	defaultCloudAPIConcurrency  = 8
 
-	flagCipherType    = "crypter.method"
-	flagCipherKey     = "crypter.key"
-	flagCipherKeyFile = "crypter.key-file"
+	flagCipherType      = "crypter.method"
+	flagCipherKey       = "crypter.key"
+	flagCipherKeyFile   = "crypter.key-file"
+	flagCipherMasterKey = "crypter.master-key"
 
 	unlimited           = 0
 	crypterAES128KeyLen = 16
@@ -231,6 +233,8 @@ type Config struct {
 	GRPCKeepaliveTimeout time.Duration `json:"grpc-keepalive-timeout" toml:"grpc-keepalive-timeout"`
 
 	CipherInfo backuppb.CipherInfo `json:"-" toml:"-"`
+	// CipherMasterKey is the URI of the master key which wraps the data key of CipherInfo.
+	CipherMasterKey string `json:"-" toml:"-"`
 
 	// whether there's explicit filter
 	ExplicitFilter bool `json:"-" toml:"-"`
@@ -288,6 +292,10 @@ func DefineCommonFlags(flags *pflag.FlagSet) {
 		"aes-crypter key, used to encrypt/decrypt the data "+
 			"by the hexadecimal string, eg: \"0123456789abcdef0123456789abcdef\"")
 	flags.String(flagCipherKeyFile, "", "FilePath, its content is used as the cipher-key")
+	flags.String(flagCipherMasterKey, "",
+		"the master key to wrap the random data key which encrypts/decrypts the data, "+
+			"be the path of a local keyring file or the URI of a registered KMS provider, "+
+			"the wrapped data key is stored in the backup storage")
 
 	storage.DefineFlags(flags)
 }
@@ -302,6 +310,7 @@ func HiddenFlagsForStream(flags *pflag.FlagSet) {
 	_ = flags.MarkHidden(flagCipherType)
 	_ = flags.MarkHidden(flagCipherKey)
 	_ = flags.MarkHidden(flagCipherKeyFile)
+	_ = flags.MarkHidden(flagCipherMasterKey)
 	_ = flags.MarkHidden(flagSwitchModeInterval)
 
 	storage.HiddenFlagsForStream(flags)
@@ -435,6 +444,19 @@ func (cfg *Config) parseCipherInfo(flags *pflag.FlagSet) error {
 		return errors.Trace(err)
 	}
 
+	cfg.CipherMasterKey, err = flags.GetString(flagCipherMasterKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(cfg.CipherMasterKey) > 0 {
+		if len(key) > 0 || len(keyFilePath) > 0 {
+			return errors.Annotate(berrors.ErrInvalidArgument,
+				"--crypter.master-key can't be used with --crypter.key or --crypter.key-file")
+		}
+		// the data key is generated or loaded by prepareCipherKey after the storage is created
+		return nil
+	}
+
 	cfg.CipherInfo.CipherKey, err = getCipherKeyContent(key, keyFilePath)
 	if err != nil {
 		return errors.Trace(err)
@@ -447,6 +469,56 @@ func (cfg *Config) parseCipherInfo(flags *pflag.FlagSet) error {
 	return nil
 }
 
+// prepareCipherKey fills the data key of CipherInfo when the master key is specified. When generate is true, a
+// random data key is generated and saved into the storage after wrapped by the master key, otherwise the wrapped
+// data key is read from the storage and unwrapped.
+func (cfg *Config) prepareCipherKey(ctx context.Context, s storage.ExternalStorage, generate bool) error {
+	if len(cfg.CipherMasterKey) == 0 || cfg.CipherInfo.CipherType == encryptionpb.EncryptionMethod_PLAINTEXT {
+		return nil
+	}
+	masterKey, err := storage.NewMasterKey(ctx, cfg.CipherMasterKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if generate {
+		var keyLen int
+		switch cfg.CipherInfo.CipherType {
+		case encryptionpb.EncryptionMethod_AES128_CTR:
+			keyLen = crypterAES128KeyLen
+		case encryptionpb.EncryptionMethod_AES192_CTR:
+			keyLen = crypterAES192KeyLen
+		default:
+			keyLen = crypterAES256KeyLen
+		}
+		dataKey := make([]byte, keyLen)
+		if _, err = rand.Read(dataKey); err != nil {
+			return errors.Trace(err)
+		}
+		var wrapped []byte
+		if wrapped, err = storage.WrapDataKey(ctx, masterKey, dataKey); err != nil {
+			return errors.Trace(err)
+		}
+		if err = s.WriteFile(ctx, metautil.DataKeyFile, wrapped); err != nil {
+			return errors.Annotate(err, "save the data key failed")
+		}
+		cfg.CipherInfo.CipherKey = dataKey
+		return nil
+	}
+
+	wrapped, err := s.ReadFile(ctx, metautil.DataKeyFile)
+	if err != nil {
+		return errors.Annotate(err, "load the data key failed")
+	}
+	cfg.CipherInfo.CipherKey, err = storage.UnwrapDataKey(ctx, masterKey, wrapped)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !checkCipherKeyMatch(&cfg.CipherInfo) {
+		return errors.Annotate(berrors.ErrInvalidArgument, "crypter method and the length of the data key not match")
+	}
+	return nil
+}
+
 func (cfg *Config) normalizePDURLs() error {
 	for i := range cfg.PD {
 		var err error
@@ -652,6 +724,9 @@ func ReadBackupMeta(
 	if err != nil {
 		return nil, nil, nil, errors.Trace(err)
 	}
+	if err = cfg.prepareCipherKey(ctx, s, false); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
 	metaData, err := s.ReadFile(ctx, fileName)
 	if err != nil {
 		if gcsObjectNotFound(err) {
@@ -3,12 +3,17 @@
 package task
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	backup "github.com/pingcap/kvproto/pkg/brpb"
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/config"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
@@ -157,3 +162,39 @@ func TestCheckCipherKey(t *testing.T) {
 		}
 	}
 }
+
+func TestPrepareCipherKey(t *testing.T) {
+	ctx := context.Background()
+	keyring := filepath.Join(t.TempDir(), "keyring")
+	require.NoError(t, os.WriteFile(keyring, []byte("k1 0123456789abcdef0123456789abcdef\n"), 0o600))
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	backupCfg := &Config{
+		CipherInfo:      backup.CipherInfo{CipherType: encryptionpb.EncryptionMethod_AES192_CTR},
+		CipherMasterKey: keyring,
+	}
+	require.NoError(t, backupCfg.prepareCipherKey(ctx, s, true))
+	require.Len(t, backupCfg.CipherInfo.CipherKey, 24)
+	wrapped, err := s.ReadFile(ctx, metautil.DataKeyFile)
+	require.NoError(t, err)
+	require.NotContains(t, string(wrapped), string(backupCfg.CipherInfo.CipherKey))
+
+	restoreCfg := &Config{
+		CipherInfo:      backup.CipherInfo{CipherType: encryptionpb.EncryptionMethod_AES192_CTR},
+		CipherMasterKey: keyring,
+	}
+	require.NoError(t, restoreCfg.prepareCipherKey(ctx, s, false))
+	require.Equal(t, backupCfg.CipherInfo.CipherKey, restoreCfg.CipherInfo.CipherKey)
+
+	restoreCfg.CipherInfo.CipherType = encryptionpb.EncryptionMethod_AES256_CTR
+	require.ErrorContains(t, restoreCfg.prepareCipherKey(ctx, s, false), "not match")
+	empty, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	require.Error(t, restoreCfg.prepareCipherKey(ctx, empty, false))
+
+	// nothing is done without the master key
+	plainCfg := &Config{CipherInfo: backup.CipherInfo{CipherType: encryptionpb.EncryptionMethod_AES128_CTR}}
+	require.NoError(t, plainCfg.prepareCipherKey(ctx, empty, true))
+	require.Empty(t, plainCfg.CipherInfo.CipherKey)
+}
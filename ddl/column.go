@@ -67,41 +67,43 @@ func InitAndAddColumnToTable(tblInfo *model.TableInfo, colInfo *model.ColumnInfo
 }
 
 func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ColumnInfo, *model.ColumnInfo,
-	*ast.ColumnPosition, bool /* ifNotExists */, error) {
+	*ast.ColumnPosition, []*model.ConstraintInfo, bool /* ifNotExists */, error) {
 	schemaID := job.SchemaID
 	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, schemaID)
 	if err != nil {
-		return nil, nil, nil, nil, false, errors.Trace(err)
+		return nil, nil, nil, nil, nil, false, errors.Trace(err)
 	}
 	col := &model.ColumnInfo{}
 	pos := &ast.ColumnPosition{}
 	offset := 0
 	ifNotExists := false
-	err = job.DecodeArgs(col, pos, &offset, &ifNotExists)
+	var constraints []*model.ConstraintInfo
+	err = job.DecodeArgs(col, pos, &offset, &ifNotExists, &constraints)
 	if err != nil {
 		job.State = model.JobStateCancelled
-		return nil, nil, nil, nil, false, errors.Trace(err)
+		return nil, nil, nil, nil, nil, false, errors.Trace(err)
 	}
 
 	columnInfo := model.FindColumnInfo(tblInfo.Columns, col.Name.L)
 	if columnInfo != nil {
-		if columnInfo.State == model.StatePublic {
+		// The column check constraints become public after the column, see onAddColumn.
+		if columnInfo.State == model.StatePublic && !isAddingColumnCheckConstraints(tblInfo, constraints) {
 			// We already have a column with the same column name.
 			job.State = model.JobStateCancelled
-			return nil, nil, nil, nil, ifNotExists, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
+			return nil, nil, nil, nil, nil, ifNotExists, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
 		}
 	}
 
 	err = CheckAfterPositionExists(tblInfo, pos)
 	if err != nil {
 		job.State = model.JobStateCancelled
-		return nil, nil, nil, nil, false, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
+		return nil, nil, nil, nil, nil, false, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
 	}
 
-	return tblInfo, columnInfo, col, pos, false, nil
+	return tblInfo, columnInfo, col, pos, constraints, false, nil
 }
 
-func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func (w *worker) onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumn(d, t, job)
@@ -118,7 +120,7 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 	})
 
-	tblInfo, columnInfo, colFromArgs, pos, ifNotExists, err := checkAddColumn(t, job)
+	tblInfo, columnInfo, colFromArgs, pos, constraints, ifNotExists, err := checkAddColumn(t, job)
 	if err != nil {
 		if ifNotExists && infoschema.ErrColumnExists.Equal(err) {
 			job.Warning = toTError(err)
@@ -134,6 +136,12 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
 		}
+		for _, constraintInfo := range constraints {
+			if tblInfo.FindConstraintInfoByName(constraintInfo.Name.L) != nil {
+				job.State = model.JobStateCancelled
+				return ver, dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constraintInfo.Name.O)
+			}
+		}
 	}
 
 	originalState := columnInfo.State
@@ -174,14 +182,28 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 		tblInfo.MoveColumnInfo(columnInfo.Offset, offset)
 		columnInfo.State = model.StatePublic
+		// The check constraints of the column become write only along with the column, so the values
+		// written to the public column are always checked. The existing rows are verified later.
+		for _, constraintInfo := range constraints {
+			constraintInfo.ID = allocateConstraintID(tblInfo)
+			constraintInfo.State = model.StateWriteOnly
+			tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+		}
 		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
+		if len(constraints) > 0 {
+			job.SchemaState = model.StatePublic
+			return ver, nil
+		}
 
 		// Finish this job.
 		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
 		asyncNotifyEvent(d, &ddlutil.Event{Tp: model.ActionAddColumn, TableInfo: tblInfo, ColumnInfos: []*model.ColumnInfo{columnInfo}})
+	case model.StatePublic:
+		// The column is public, and its check constraints are being added.
+		return w.onAddColumnCheckConstraints(d, t, job, tblInfo, columnInfo, constraints)
 	default:
 		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("column", columnInfo.State)
 	}
@@ -251,6 +273,8 @@ func onDropColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error)
 		// public -> write only
 		colInfo.State = model.StateWriteOnly
 		setIndicesState(idxInfos, model.StateWriteOnly)
+		// The check constraints referring to other columns as well are rejected by checkDropColumn.
+		removeColumnCheckConstraints(tblInfo, colInfo.Name)
 		tblInfo.MoveColumnInfo(colInfo.Offset, len(tblInfo.Columns)-1)
 		err = checkDropColumnForStatePublic(colInfo)
 		if err != nil {
@@ -851,6 +875,7 @@ func adjustTableInfoAfterModifyColumnWithData(tblInfo *model.TableInfo, pos *ast
 		return errors.Trace(infoschema.ErrColumnNotExists.GenWithStackByArgs(oldCol.Name, tblInfo.Name))
 	}
 	internalColName := changingCol.Name
+	updateTTLInfoWhenModifyColumn(tblInfo, oldCol.Name, newName)
 	changingCol = replaceOldColumn(tblInfo, oldCol, changingCol, newName)
 	if len(changingIdxs) > 0 {
 		updateNewIdxColsNameOffset(changingIdxs, internalColName, changingCol)
@@ -1473,6 +1498,7 @@ func adjustTableInfoAfterModifyColumn(
 	tblInfo.MoveColumnInfo(oldCol.Offset, destOffset)
 	updateNewIdxColsNameOffset(tblInfo.Indices, oldCol.Name, newCol)
 	updateFKInfoWhenModifyColumn(tblInfo, oldCol.Name, newCol.Name)
+	updateTTLInfoWhenModifyColumn(tblInfo, oldCol.Name, newCol.Name)
 	return nil
 }
 
@@ -0,0 +1,600 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	ddlutil "github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+func (w *worker) onAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	schemaID := job.SchemaID
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfoInJob := &model.ConstraintInfo{}
+	if err = job.DecodeArgs(constraintInfoInJob); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintInfoInJob.Name.L)
+	if constraintInfo == nil {
+		// It's the first time to run the job, check the constraint again since the table may be changed.
+		for _, colName := range constraintInfoInJob.ConstraintCols {
+			col := model.FindColumnInfo(tblInfo.Columns, colName.L)
+			if col == nil || col.State != model.StatePublic {
+				job.State = model.JobStateCancelled
+				return ver, dbterror.ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constraintInfoInJob.Name.O, colName.O)
+			}
+		}
+		constraintInfo = constraintInfoInJob
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		constraintInfo.State = model.StateNone
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	} else if constraintInfo.State == model.StatePublic {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StateNone:
+		// none -> write only
+		// The constraint is enforced on the new written rows since now.
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		job.SchemaState = model.StateWriteReorganization
+		constraintInfo.State = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteReorganization:
+		// write reorganization -> public
+		// All the servers check the new written rows now, so it's safe to verify the existing rows.
+		if constraintInfo.Enforced {
+			err = w.verifyRemainRecordsForCheckConstraint(job.SchemaName, tblInfo, constraintInfo)
+			if err != nil {
+				if table.ErrCheckConstraintViolated.Equal(err) {
+					return rollbackAddCheckConstraint(d, t, job, tblInfo, constraintInfo, err)
+				}
+				return ver, errors.Trace(err)
+			}
+		}
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackAddCheckConstraint removes the check constraint which is being added, occurredErr is returned to the user.
+func rollbackAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo, occurredErr error) (ver int64, err error) {
+	removeConstraintInfo(tblInfo, constraintInfo.Name)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	return ver, errors.Trace(occurredErr)
+}
+
+// onAddColumnCheckConstraints makes the check constraints of the column being added public after verifying the
+// existing rows, the column is rolled back if any of them is violated, so that ADD COLUMN ... CHECK is atomic.
+func (w *worker) onAddColumnCheckConstraints(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	colInfo *model.ColumnInfo, constraints []*model.ConstraintInfo) (ver int64, err error) {
+	constraintInfos := make([]*model.ConstraintInfo, 0, len(constraints))
+	for _, constraintInfoInJob := range constraints {
+		constraintInfos = append(constraintInfos, tblInfo.FindConstraintInfoByName(constraintInfoInJob.Name.L))
+	}
+	switch constraintInfos[0].State {
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		for _, constraintInfo := range constraintInfos {
+			constraintInfo.State = model.StateWriteReorganization
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	case model.StateWriteReorganization:
+		// write reorganization -> public
+		for _, constraintInfo := range constraintInfos {
+			if !constraintInfo.Enforced {
+				continue
+			}
+			err = w.verifyRemainRecordsForCheckConstraint(job.SchemaName, tblInfo, constraintInfo)
+			if err != nil {
+				if table.ErrCheckConstraintViolated.Equal(err) {
+					return rollbackAddColumnWithCheckConstraints(d, t, job, tblInfo, colInfo, constraints, err)
+				}
+				return ver, errors.Trace(err)
+			}
+		}
+		for _, constraintInfo := range constraintInfos {
+			constraintInfo.State = model.StatePublic
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		asyncNotifyEvent(d, &ddlutil.Event{Tp: model.ActionAddColumn, TableInfo: tblInfo, ColumnInfos: []*model.ColumnInfo{colInfo}})
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfos[0].State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackAddColumnWithCheckConstraints removes the check constraints of the public column being added, and drops
+// the column like onDropColumn does. occurredErr is returned to the user.
+func rollbackAddColumnWithCheckConstraints(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	colInfo *model.ColumnInfo, constraints []*model.ConstraintInfo, occurredErr error) (ver int64, err error) {
+	for _, constraintInfo := range constraints {
+		removeConstraintInfo(tblInfo, constraintInfo.Name)
+	}
+	// public -> write only
+	colInfo.State = model.StateWriteOnly
+	tblInfo.MoveColumnInfo(colInfo.Offset, len(tblInfo.Columns)-1)
+	job.SchemaState = model.StateWriteOnly
+	// The rolling back job is handled by onDropColumn.
+	job.Args = []interface{}{colInfo.Name, false}
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.State = model.JobStateRollingback
+	return ver, errors.Trace(occurredErr)
+}
+
+// isAddingColumnCheckConstraints returns whether the check constraints of the column being added
+// are already in the table, which means the column is public and the constraints are not.
+func isAddingColumnCheckConstraints(tblInfo *model.TableInfo, constraints []*model.ConstraintInfo) bool {
+	if len(constraints) == 0 {
+		return false
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraints[0].Name.L)
+	return constraintInfo != nil && constraintInfo.State != model.StatePublic
+}
+
+func onDropCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, constraintInfo, err := checkDropCheckConstraint(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StatePublic:
+		// public -> write only
+		// The constraint is still checked until all the servers know it's being dropped.
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> none
+		removeConstraintInfo(tblInfo, constraintInfo.Name)
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+func checkDropCheckConstraint(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ConstraintInfo, error) {
+	schemaID := job.SchemaID
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var constrName model.CIStr
+	if err = job.DecodeArgs(&constrName); err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	return tblInfo, constraintInfo, nil
+}
+
+func (w *worker) onAlterCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	schemaID := job.SchemaID
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		constrName model.CIStr
+		enforced   bool
+	)
+	if err = job.DecodeArgs(&constrName, &enforced); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	if !enforced {
+		// The existing rows needn't be verified if the constraint isn't enforced any more.
+		constraintInfo.Enforced = false
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	switch constraintInfo.State {
+	case model.StatePublic:
+		if constraintInfo.Enforced {
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		// Enforce the constraint on the new written rows first, and then verify the existing rows.
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.Enforced = true
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	case model.StateWriteOnly:
+		err = w.verifyRemainRecordsForCheckConstraint(job.SchemaName, tblInfo, constraintInfo)
+		if err != nil {
+			if table.ErrCheckConstraintViolated.Equal(err) {
+				return rollbackAlterCheckConstraint(d, t, job, tblInfo, constraintInfo, err)
+			}
+			return ver, errors.Trace(err)
+		}
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackAlterCheckConstraint makes the check constraint not enforced again, occurredErr is returned to the user.
+func rollbackAlterCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo, occurredErr error) (ver int64, err error) {
+	constraintInfo.Enforced = false
+	constraintInfo.State = model.StatePublic
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StatePublic, ver, tblInfo)
+	return ver, errors.Trace(occurredErr)
+}
+
+// verifyRemainRecordsForCheckConstraint checks whether the existing rows of the table satisfy the check constraint.
+func (w *worker) verifyRemainRecordsForCheckConstraint(schemaName string, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo) error {
+	sctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(sctx)
+
+	// The expression is restored from the AST, only the '%' in it should be escaped.
+	sql := "select 1 from %n.%n where not (" + strings.ReplaceAll(constraintInfo.ExprString, "%", "%%") + ") limit 1"
+	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(w.ctx, nil, sql, schemaName, tblInfo.Name.L)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) != 0 {
+		return table.ErrCheckConstraintViolated.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+	return nil
+}
+
+func allocateConstraintID(tblInfo *model.TableInfo) int64 {
+	tblInfo.MaxConstraintID++
+	return tblInfo.MaxConstraintID
+}
+
+func removeConstraintInfo(tblInfo *model.TableInfo, constrName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constr := range tblInfo.Constraints {
+		if constr.Name.L != constrName.L {
+			constraints = append(constraints, constr)
+		}
+	}
+	tblInfo.Constraints = constraints
+}
+
+// genCheckConstraintName generates the name for an unnamed check constraint like MySQL does,
+// that is `<table name>_chk_<n>`.
+func genCheckConstraintName(tblName model.CIStr, usedNames map[string]struct{}) model.CIStr {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_chk_%d", tblName.O, i)
+		if _, ok := usedNames[strings.ToLower(name)]; !ok {
+			usedNames[strings.ToLower(name)] = struct{}{}
+			return model.NewCIStr(name)
+		}
+	}
+}
+
+// buildCheckConstraints builds the check constraints of a new created table.
+func buildCheckConstraints(ctx sessionctx.Context, tbInfo *model.TableInfo, constraints []*ast.Constraint) error {
+	usedNames := make(map[string]struct{}, len(constraints))
+	for _, constr := range constraints {
+		if constr.Name != "" {
+			usedNames[strings.ToLower(constr.Name)] = struct{}{}
+		}
+	}
+	for _, constr := range constraints {
+		name := model.NewCIStr(constr.Name)
+		if name.L == "" {
+			name = genCheckConstraintName(tbInfo.Name, usedNames)
+		}
+		constraintInfo, err := buildCheckConstraintInfo(ctx, tbInfo, name, constr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		constraintInfo.ID = allocateConstraintID(tbInfo)
+		constraintInfo.State = model.StatePublic
+		tbInfo.Constraints = append(tbInfo.Constraints, constraintInfo)
+	}
+	return nil
+}
+
+// buildCheckConstraintInfo validates the check constraint on the public columns of the table and builds its meta.
+func buildCheckConstraintInfo(ctx sessionctx.Context, tblInfo *model.TableInfo, name model.CIStr, constr *ast.Constraint) (*model.ConstraintInfo, error) {
+	if err := checkTooLongConstraint(name); err != nil {
+		return nil, errors.Trace(err)
+	}
+	checker := &checkConstraintChecker{
+		name:    name,
+		tblInfo: tblInfo,
+	}
+	if constr.InColumn {
+		checker.inColumn = model.NewCIStr(constr.InColumnName)
+	}
+	constr.Expr.Accept(checker)
+	if checker.err != nil {
+		return nil, checker.err
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation
+	if err := constr.Expr.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	constraintInfo := &model.ConstraintInfo{
+		Name:           name,
+		Table:          tblInfo.Name,
+		ConstraintCols: checker.cols,
+		Enforced:       constr.Enforced,
+		InColumn:       constr.InColumn,
+		ExprString:     sb.String(),
+	}
+
+	expr, err := table.BuildConstraintExpr(ctx, constraintInfo.ExprString, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !mysql.HasIsBooleanFlag(expr.GetType().GetFlag()) {
+		return nil, dbterror.ErrNonBooleanExprForCheckConstraint.GenWithStackByArgs(name.O)
+	}
+	return constraintInfo, nil
+}
+
+func checkTooLongConstraint(name model.CIStr) error {
+	if len(name.L) > mysql.MaxConstraintIdentifierLen {
+		return dbterror.ErrTooLongIdent.GenWithStackByArgs(name.O)
+	}
+	return nil
+}
+
+// checkConstraintChecker checks the expression of a check constraint and collects the columns it depends on.
+type checkConstraintChecker struct {
+	name     model.CIStr
+	tblInfo  *model.TableInfo
+	inColumn model.CIStr // inColumn is the column of a column check constraint.
+	cols     []model.CIStr
+	err      error
+}
+
+func (c *checkConstraintChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
+	if c.err != nil {
+		return inNode, true
+	}
+	switch node := inNode.(type) {
+	case *ast.ColumnNameExpr:
+		c.err = c.checkColumn(node.Name)
+	case *ast.VariableExpr:
+		c.err = dbterror.ErrCheckConstraintVariables.GenWithStackByArgs(c.name.O)
+	case *ast.FuncCallExpr:
+		_, isFunctionBlocked := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]
+		if isFunctionBlocked || !expression.IsFunctionSupported(node.FnName.L) {
+			c.err = dbterror.ErrCheckConstraintNamedFunctionIsNotAllowed.GenWithStackByArgs(c.name.O, node.FnName.L)
+		}
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.ValuesExpr, *ast.DefaultExpr,
+		*ast.AggregateFuncExpr, *ast.WindowFuncExpr, *ast.ParamMarkerExpr:
+		c.err = dbterror.ErrCheckConstraintFunctionIsNotAllowed.GenWithStackByArgs(c.name.O)
+	}
+	return inNode, c.err != nil
+}
+
+func (c *checkConstraintChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	return inNode, true
+}
+
+func (c *checkConstraintChecker) checkColumn(colName *ast.ColumnName) error {
+	if (colName.Schema.L != "" && colName.Table.L == "") || (colName.Table.L != "" && colName.Table.L != c.tblInfo.Name.L) {
+		return dbterror.ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(c.name.O, colName.String())
+	}
+	col := model.FindColumnInfo(c.tblInfo.Columns, colName.Name.L)
+	if col == nil || col.Hidden || col.State != model.StatePublic {
+		return dbterror.ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(c.name.O, colName.Name.O)
+	}
+	if c.inColumn.L != "" && c.inColumn.L != col.Name.L {
+		return dbterror.ErrColumnCheckConstraintReferencesOtherColumn.GenWithStackByArgs(c.name.O)
+	}
+	if mysql.HasAutoIncrementFlag(col.GetFlag()) {
+		return dbterror.ErrCheckConstraintRefersAutoIncrementColumn.GenWithStackByArgs(c.name.O)
+	}
+	// The qualifiers are removed so that the restored expression only refers to the column names.
+	colName.Schema, colName.Table = model.CIStr{}, model.CIStr{}
+	for _, dep := range c.cols {
+		if dep.L == col.Name.L {
+			return nil
+		}
+	}
+	c.cols = append(c.cols, col.Name)
+	return nil
+}
+
+// findDependentCheckConstraints returns the check constraints which refer to the column.
+func findDependentCheckConstraints(tblInfo *model.TableInfo, colName model.CIStr) []*model.ConstraintInfo {
+	var constraints []*model.ConstraintInfo
+	for _, constr := range tblInfo.Constraints {
+		for _, dep := range constr.ConstraintCols {
+			if dep.L == colName.L {
+				constraints = append(constraints, constr)
+				break
+			}
+		}
+	}
+	return constraints
+}
+
+// checkDropColumnForCheckConstraint checks whether the column can be dropped. As MySQL does, a check constraint
+// referring to only the dropped column is dropped along with it, while the one referring to other columns as well
+// prevents the column from being dropped.
+func checkDropColumnForCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr) error {
+	for _, constr := range findDependentCheckConstraints(tblInfo, colName) {
+		if len(constr.ConstraintCols) > 1 {
+			return dbterror.ErrDependentByCheckConstraint.GenWithStackByArgs(constr.Name.O, colName.O)
+		}
+	}
+	return nil
+}
+
+// removeColumnCheckConstraints removes the check constraints referring to only the dropped column.
+func removeColumnCheckConstraints(tblInfo *model.TableInfo, colName model.CIStr) {
+	for _, constr := range findDependentCheckConstraints(tblInfo, colName) {
+		if len(constr.ConstraintCols) == 1 {
+			removeConstraintInfo(tblInfo, constr.Name)
+		}
+	}
+}
+
+// checkColumnRenameForCheckConstraint checks whether the column can be renamed,
+// a column referred by check constraints can't be renamed as MySQL does.
+func checkColumnRenameForCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr) error {
+	if constraints := findDependentCheckConstraints(tblInfo, colName); len(constraints) > 0 {
+		return dbterror.ErrDependentByCheckConstraint.GenWithStackByArgs(constraints[0].Name.O, colName.O)
+	}
+	return nil
+}
+
+// buildColumnCheckConstraints builds the check constraints of the column being added to the table,
+// they are added in the same job as the column.
+func buildColumnCheckConstraints(ctx sessionctx.Context, tblInfo *model.TableInfo, col *table.Column, colDef *ast.ColumnDef) ([]*model.ConstraintInfo, error) {
+	var constraints []*ast.Constraint
+	for _, option := range colDef.Options {
+		if option.Tp == ast.ColumnOptionCheck {
+			constraints = append(constraints, newColumnCheckConstraint(colDef.Name.Name, option))
+		}
+	}
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+
+	usedNames := make(map[string]struct{}, len(tblInfo.Constraints)+len(constraints))
+	for _, constrInfo := range tblInfo.Constraints {
+		usedNames[constrInfo.Name.L] = struct{}{}
+	}
+	for _, constr := range constraints {
+		if constr.Name == "" {
+			continue
+		}
+		nameLower := strings.ToLower(constr.Name)
+		if _, ok := usedNames[nameLower]; ok {
+			return nil, dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		usedNames[nameLower] = struct{}{}
+	}
+	// The constraints are built on the table with the new column as if the column was public.
+	tblInfo = tblInfo.Clone()
+	colInfo := col.ToInfo().Clone()
+	colInfo.State = model.StatePublic
+	colInfo.Offset = len(tblInfo.Columns)
+	tblInfo.Columns = append(tblInfo.Columns, colInfo)
+	constraintInfos := make([]*model.ConstraintInfo, 0, len(constraints))
+	for _, constr := range constraints {
+		name := model.NewCIStr(constr.Name)
+		if name.L == "" {
+			name = genCheckConstraintName(tblInfo.Name, usedNames)
+		}
+		constraintInfo, err := buildCheckConstraintInfo(ctx, tblInfo, name, constr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		constraintInfos = append(constraintInfos, constraintInfo)
+	}
+	return constraintInfos, nil
+}
+
+// newColumnCheckConstraint converts the check option of a column to a column check constraint.
+func newColumnCheckConstraint(colName model.CIStr, option *ast.ColumnOption) *ast.Constraint {
+	return &ast.Constraint{
+		Tp:           ast.ConstraintCheck,
+		Name:         option.ConstraintName,
+		Expr:         option.Expr,
+		Enforced:     option.Enforced,
+		InColumn:     true,
+		InColumnName: colName.O,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestCheckConstraintDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int check (a > 0), b int, constraint c_ab check (a < b))")
+
+	tk.MustExec("insert into t values (1, 2), (null, 1)")
+	tk.MustGetErrCode("insert into t values (0, 2)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t values (2, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("replace into t values (3, 3)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update t set b = 0 where a = 1", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("<nil> 1", "1 2"))
+
+	tk.MustExec("insert ignore into t values (0, 2), (5, 6)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 't_chk_1' is violated."))
+	tk.MustExec("update ignore t set b = 0")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("<nil> 0", "1 2", "5 6"))
+
+	tk.MustExec("alter table t alter check c_ab not enforced")
+	tk.MustExec("insert into t values (3, 1)")
+	tk.MustGetErrCode("alter table t alter check c_ab enforced", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into t values (4, 1)")
+	tk.MustExec("delete from t where a in (3, 4)")
+	tk.MustExec("alter table t alter check c_ab enforced")
+	tk.MustGetErrCode("insert into t values (3, 1)", errno.ErrCheckConstraintViolated)
+}
+
+func TestCheckConstraintDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (a int check (b > 0), b int)", errno.ErrColumnCheckConstraintReferencesOtherColumn)
+	tk.MustGetErrCode("create table t (a int, check (c > 0))", errno.ErrCheckConstraintRefersUnknownColumn)
+	tk.MustGetErrCode("create table t (a int auto_increment primary key, check (a > 0))", errno.ErrCheckConstraintRefersAutoIncrementColumn)
+	tk.MustGetErrCode("create table t (a int, check (a + 1))", errno.ErrNonBooleanExprForCheckConstraint)
+	tk.MustGetErrCode("create table t (a int, check (a > @x))", errno.ErrCheckConstraintVariables)
+	tk.MustGetErrCode("create table t (a int, check (a > rand()))", errno.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t (a int, constraint c check (a > 0), constraint c check (a < 10))", errno.ErrCheckConstraintDupName)
+
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (-1, 2)")
+	tk.MustGetErrCode("alter table t add constraint c_a check (a > 0)", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select count(*) from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows("0"))
+	tk.MustExec("alter table t add constraint c_a check (a > 0) not enforced")
+	tk.MustExec("alter table t add check (a < b + 10)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  CONSTRAINT `c_a` CHECK ((`a` > 0)) /*!80016 NOT ENFORCED */,\n" +
+		"  CONSTRAINT `t_chk_1` CHECK ((`a` < `b` + 10))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select constraint_name, check_clause from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").
+		Check(testkit.Rows("c_a (`a` > 0)", "t_chk_1 (`a` < `b` + 10)"))
+
+	tk.MustGetErrCode("alter table t drop column b", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table t rename column b to c", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table t change column b c int", errno.ErrDependentByCheckConstraint)
+	tk.MustExec("alter table t drop check t_chk_1")
+	tk.MustGetErrCode("alter table t drop check t_chk_1", errno.ErrCheckConstraintNotFound)
+	tk.MustExec("alter table t drop column a")
+	tk.MustQuery("select count(*) from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows("0"))
+
+	tk.MustExec("alter table t add column c int check (c > 0)")
+	tk.MustGetErrCode("insert into t values (1, 0)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into t values (1, 1)")
+}
+
+func TestCheckConstraintAddDropColumn(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1)")
+
+	// The column is rolled back when the existing rows violate its check constraint.
+	tk.MustGetErrCode("alter table t add column b int default 0 check (b > 0)", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("1"))
+	tk.MustGetErrCode("alter table t add column b int constraint c_a check (b > 0), add check (a > 0)", errno.ErrUnsupportedDDLOperation)
+
+	tk.MustExec("alter table t add column b int default 1 constraint c_b check (b > 0)")
+	tk.MustQuery("select constraint_name, check_clause from information_schema.check_constraints where constraint_schema = 'test'").
+		Check(testkit.Rows("c_b (`b` > 0)"))
+	tk.MustGetErrCode("insert into t values (2, 0)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("alter table t add column c int constraint c_b check (c > 0)", errno.ErrCheckConstraintDupName)
+
+	// The check constraint of an empty table is not violated by the default value.
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("alter table t1 add column b int default 0 check (b > 0)")
+	tk.MustGetErrCode("insert into t1 (a) values (1)", errno.ErrCheckConstraintViolated)
+
+	// The check constraint referring to only the dropped column is dropped along with it,
+	// while the one referring to other columns as well prevents the column from being dropped.
+	tk.MustExec("alter table t add column c int")
+	tk.MustExec("alter table t add constraint c_c check (c > 0)")
+	tk.MustExec("alter table t add constraint c_ac check (a < c)")
+	tk.MustGetErrCode("alter table t drop column a", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table t drop column c", errno.ErrDependentByCheckConstraint)
+	tk.MustQuery("select count(*) from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows("3"))
+	tk.MustExec("alter table t drop check c_ac")
+	tk.MustExec("alter table t drop column c")
+	tk.MustExec("alter table t drop column b")
+	tk.MustQuery("select count(*) from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows("0"))
+	tk.MustExec("insert into t values (-1)")
+}
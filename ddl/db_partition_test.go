@@ -397,7 +397,9 @@ func TestCreateTableWithHashPartition(t *testing.T) {
                                        PARTITION p0 VALUES LESS THAN (100),
                                        PARTITION p1 VALUES LESS THAN (200),
                                        PARTITION p2 VALUES LESS THAN MAXVALUE)`)
-	tk.MustGetErrCode("select * from t_sub partition (p0)", errno.ErrPartitionClauseOnNonpartitioned)
+	tk.MustQuery("select * from t_sub partition (p0)").Check(testkit.Rows())
+	tk.MustQuery("select * from t_sub partition (p0sp1)").Check(testkit.Rows())
+	tk.MustGetErrCode(`CREATE TABLE t_sub2 (a int) PARTITION BY HASH(a) SUBPARTITION BY HASH(a) SUBPARTITIONS 2 PARTITIONS 2`, errno.ErrSubpartition)
 
 	// Fix create partition table using extract() function as partition key.
 	tk.MustExec("create table t2 (a date, b datetime) partition by hash (EXTRACT(YEAR_MONTH FROM a)) partitions 7")
@@ -1546,8 +1548,160 @@ func TestCreateTableWithKeyPartition(t *testing.T) {
 	)
 	partition by key(s1) partitions 10;`)
 
+	tk.MustQuery("show create table tm1").Check(testkit.Rows("tm1 CREATE TABLE `tm1` (\n" +
+		"  `s1` char(32) NOT NULL,\n" +
+		"  PRIMARY KEY (`s1`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY KEY (`s1`) PARTITIONS 10"))
+
+	// KEY() uses the primary key, or a unique key whose columns are all NOT NULL.
 	tk.MustExec(`drop table if exists tm2`)
-	tk.MustExec(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`)
+	tk.MustGetErrCode(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`, errno.ErrFieldNotFoundPart)
+	tk.MustExec(`create table tm2 (a char(5) not null, unique key(a)) partition by key() partitions 5;`)
+	tk.MustQuery("select partition_expression from information_schema.partitions where table_name = 'tm2' and partition_name = 'p0'").Check(testkit.Rows("a"))
+
+	tk.MustGetErrCode(`create table tm3 (a int, b blob) partition by key(b) partitions 3`, errno.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustGetErrCode(`create table tm3 (a int, b int) partition by key(c) partitions 3`, errno.ErrFieldNotFoundPart)
+	tk.MustGetErrCode(`create table tm3 (a int primary key, b int) partition by key(b) partitions 3`, errno.ErrUniqueKeyNeedAllFieldsInPf)
+
+	// LINEAR KEY isn't supported.
+	tk.MustGetErrCode(`create table tm3 (a int, b int) partition by linear key(a) partitions 3`, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(`create table tm3 (a int, b int) partition by range (a) subpartition by linear key(b) subpartitions 2 (
+		partition p0 values less than (10))`, errno.ErrUnsupportedDDLOperation)
+}
+
+func TestKeyPartitionDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	explain := func(sql string) string {
+		return fmt.Sprint(tk.MustQuery("explain format = 'brief' " + sql).Rows())
+	}
+	tk.MustExec(`create table t (a varchar(32) collate utf8mb4_general_ci, b int, key(b)) partition by key(a) partitions 4`)
+	tk.MustExec(`insert into t values ('a', 1), ('A', 2), ('b', 3), ('c', 4), ('d', 5), (null, 6)`)
+
+	// The rows that are equal under the collation are in the same partition.
+	total := 0
+	for i := 0; i < 4; i++ {
+		res := tk.MustQuery(fmt.Sprintf("select a from t partition (p%d) where a = 'a'", i)).Rows()
+		require.True(t, len(res) == 0 || len(res) == 2)
+		total += len(res)
+	}
+	require.Equal(t, 2, total)
+
+	tk.MustQuery("select b from t where a = 'A' order by b").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select b from t where a in ('b', 'c') order by b").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select b from t where a is null").Check(testkit.Rows("6"))
+	tk.MustQuery("select b from t where a > 'b' order by b").Check(testkit.Rows("4", "5"))
+
+	// A point condition on the key column accesses one partition only.
+	require.Equal(t, 1, strings.Count(explain("select * from t where a = 'b'"), "partition:p"))
+	require.Equal(t, 4, strings.Count(explain("select * from t where a > 'b'"), "partition:p"))
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustQuery("select b from t where a = 'A' order by b").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select b from t where a in ('b', 'c') order by b").Check(testkit.Rows("3", "4"))
+
+	tk.MustExec("update t set a = 'e' where b = 5")
+	tk.MustQuery("select b from t where a = 'e'").Check(testkit.Rows("5"))
+	tk.MustExec("delete from t where a = 'a'")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("4"))
+
+	for i := 0; i < 4; i++ {
+		tk.MustExec(fmt.Sprintf("alter table t truncate partition p%d", i))
+	}
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+	tk.MustGetErrCode("alter table t add partition partitions 2", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t drop partition p0", errno.ErrOnlyOnRangeListPartition)
+
+	// Multiple key columns.
+	tk.MustExec(`create table t2 (a int, b date, c int) partition by key(a, b) partitions 3`)
+	tk.MustExec(`insert into t2 values (1, '2022-01-01', 1), (1, '2022-01-02', 2), (2, '2022-01-01', 3)`)
+	tk.MustQuery("select c from t2 where a = 1 and b = '2022-01-02'").Check(testkit.Rows("2"))
+	tk.MustQuery("select c from t2 where a = 1 order by c").Check(testkit.Rows("1", "2"))
+}
+
+func TestSubPartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	explain := func(sql string) string {
+		return fmt.Sprint(tk.MustQuery("explain format = 'brief' " + sql).Rows())
+	}
+	tk.MustExec(`create table t (a int, b varchar(10)) partition by range (a) subpartition by hash (a) subpartitions 2 (
+		partition p0 values less than (10),
+		partition p1 values less than (20))`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`a`)\n" +
+		"SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20))"))
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (11, 'c'), (12, 'd')")
+	tk.MustQuery("select a from t partition (p0) order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select a from t partition (p0sp0)").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t partition (p1sp1)").Check(testkit.Rows("11"))
+	tk.MustGetErrCode("insert into t values (20, 'e')", errno.ErrNoPartitionForGivenValue)
+	tk.MustGetErrCode("insert into t partition (p0sp0) values (1, 'e')", errno.ErrRowDoesNotMatchGivenPartitionSet)
+
+	// Both the partition and the subpartition are pruned.
+	require.Equal(t, 1, strings.Count(explain("select * from t where a = 12"), "partition:p"))
+	require.Contains(t, explain("select * from t where a = 12"), "partition:p1sp0")
+	require.Equal(t, 2, strings.Count(explain("select * from t where a < 10"), "partition:p"))
+	tk.MustQuery("select b from t where a = 12").Check(testkit.Rows("d"))
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustQuery("select b from t where a = 12").Check(testkit.Rows("d"))
+	tk.MustQuery("select a from t where a < 10 order by a").Check(testkit.Rows("1", "2"))
+
+	tk.MustExec(`alter table t add partition (partition p2 values less than (30) (subpartition s0, subpartition s1))`)
+	tk.MustGetErrCode(`alter table t add partition (partition p3 values less than (40) (subpartition s2))`, errno.ErrPartitionWrongNoSubpart)
+	tk.MustExec("insert into t values (21, 'f')")
+	tk.MustQuery("select a from t partition (s1)").Check(testkit.Rows("21"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`a`)\n" +
+		"SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10)\n" +
+		" (SUBPARTITION `p0sp0`,\n" +
+		"  SUBPARTITION `p0sp1`),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20)\n" +
+		" (SUBPARTITION `p1sp0`,\n" +
+		"  SUBPARTITION `p1sp1`),\n" +
+		" PARTITION `p2` VALUES LESS THAN (30)\n" +
+		" (SUBPARTITION `s0`,\n" +
+		"  SUBPARTITION `s1`))"))
+
+	tk.MustExec("alter table t truncate partition p0")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("11", "12", "21"))
+	tk.MustExec("alter table t truncate partition p1sp0")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("11", "21"))
+	tk.MustGetErrCode("alter table t drop partition p1sp1", errno.ErrDropPartitionNonExistent)
+	tk.MustExec("alter table t drop partition p1")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("21"))
+	tk.MustQuery("select partition_name, subpartition_name, partition_ordinal_position, subpartition_ordinal_position, subpartition_method, subpartition_expression " +
+		"from information_schema.partitions where table_name = 't' and table_schema = 'test'").Sort().Check(testkit.Rows(
+		"p0 p0sp0 1 1 HASH `a`",
+		"p0 p0sp1 1 2 HASH `a`",
+		"p2 s0 2 1 HASH `a`",
+		"p2 s1 2 2 HASH `a`"))
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values less than (5))", errno.ErrUnsupportedDDLOperation)
+
+	// LIST partitions subpartitioned by KEY.
+	tk.MustExec(`create table tl (a int, b varchar(10)) partition by list (a) subpartition by key (b) subpartitions 3 (
+		partition p0 values in (1, 2),
+		partition p1 values in (3, 4))`)
+	tk.MustExec("insert into tl values (1, 'x'), (2, 'y'), (3, 'x'), (4, 'z')")
+	tk.MustQuery("select a from tl where b = 'x' order by a").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select a from tl partition (p1) order by a").Check(testkit.Rows("3", "4"))
+	tk.MustExec("alter table tl drop partition p0")
+	tk.MustQuery("select a from tl order by a").Check(testkit.Rows("3", "4"))
 }
 
 func TestAlterTableAddPartition(t *testing.T) {
@@ -3344,7 +3498,7 @@ func TestPartitionErrorCode(t *testing.T) {
 	tk.MustGetDBError("alter table t_part coalesce partition 4;", dbterror.ErrCoalesceOnlyOnHashPartition)
 
 	tk.MustGetErrCode(`alter table t_part reorganize partition p0, p1 into (
-			partition p0 values less than (1980));`, errno.ErrUnsupportedDDLOperation)
+			partition p0 values less than (15));`, errno.ErrReorgOutsideRange)
 
 	tk.MustGetErrCode("alter table t_part check partition p0, p1;", errno.ErrUnsupportedDDLOperation)
 	tk.MustGetErrCode("alter table t_part optimize partition p0,p1;", errno.ErrUnsupportedDDLOperation)
@@ -4663,3 +4817,132 @@ func TestAlterModifyColumnOnPartitionedTable(t *testing.T) {
 		"46 46",
 		"57 57"))
 }
+
+func TestReorganizePartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database ReorgPartition")
+	tk.MustExec("use ReorgPartition")
+	tk.MustExec(`create table t (a int unsigned PRIMARY KEY, b varchar(255), c int, key (b), key (c,b))
+		partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30),
+		partition pMax values less than (MAXVALUE))`)
+	tk.MustExec(`insert into t values (1,"1",1), (12,"12",21), (23,"23",32), (34,"34",43), (45,"45",54), (56,"56",65)`)
+
+	// Split a partition.
+	tk.MustExec(`alter table t reorganize partition pMax into (partition p3 values less than (40), partition pMax values less than (MAXVALUE))`)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select * from t partition (p3)`).Check(testkit.Rows("34 34 43"))
+	tk.MustQuery(`select * from t partition (pMax)`).Sort().Check(testkit.Rows("45 45 54", "56 56 65"))
+
+	// Merge partitions.
+	tk.MustExec(`alter table t reorganize partition p0, p1 into (partition p01 values less than (20))`)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`show create table t`).Check(testkit.Rows(
+		"t CREATE TABLE `t` (\n" +
+			"  `a` int(10) unsigned NOT NULL,\n" +
+			"  `b` varchar(255) DEFAULT NULL,\n" +
+			"  `c` int(11) DEFAULT NULL,\n" +
+			"  PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */,\n" +
+			"  KEY `b` (`b`),\n" +
+			"  KEY `c` (`c`,`b`)\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+			"PARTITION BY RANGE (`a`)\n" +
+			"(PARTITION `p01` VALUES LESS THAN (20),\n" +
+			" PARTITION `p2` VALUES LESS THAN (30),\n" +
+			" PARTITION `p3` VALUES LESS THAN (40),\n" +
+			" PARTITION `pMax` VALUES LESS THAN (MAXVALUE))"))
+	tk.MustQuery(`select * from t partition (p01)`).Sort().Check(testkit.Rows("1 1 1", "12 12 21"))
+	tk.MustQuery(`select a from t use index (c) where c > 20 and c < 40`).Sort().Check(testkit.Rows("12", "23"))
+
+	// Change the ranges without changing the total range, the partition names can be reused.
+	tk.MustExec(`alter table t reorganize partition p01, p2 into (partition p0 values less than (5), partition p2 values less than (30))`)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select * from t partition (p0)`).Check(testkit.Rows("1 1 1"))
+	tk.MustQuery(`select * from t partition (p2)`).Sort().Check(testkit.Rows("12 12 21", "23 23 32"))
+
+	tk.MustGetErrCode(`alter table t reorganize partition p0, p3 into (partition p03 values less than (40))`, errno.ErrConsecutiveReorgPartitions)
+	tk.MustGetErrCode(`alter table t reorganize partition p2 into (partition p2 values less than (25))`, errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode(`alter table t reorganize partition p2 into (partition p2 values less than (35))`, errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode(`alter table t reorganize partition pNotExist into (partition p4 values less than (50))`, errno.ErrDropPartitionNonExistent)
+	tk.MustGetErrCode(`alter table t reorganize partition`, errno.ErrReorgNoParam)
+
+	// The range of the last partition can not be reduced.
+	tk.MustGetErrCode(`alter table t reorganize partition pMax into (partition p4 values less than (50))`, errno.ErrReorgOutsideRange)
+	tk.MustExec(`alter table t reorganize partition pMax into (partition p4 values less than (50), partition p5 values less than (100), partition pMax values less than (MAXVALUE))`)
+	tk.MustExec(`insert into t values (60, "60", 60)`)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select a from t partition (p5)`).Sort().Check(testkit.Rows("56", "60"))
+	tk.MustQuery(`select count(*) from t`).Check(testkit.Rows("7"))
+
+	// List partitions.
+	tk.MustExec(`create table tl (a int, b int, key (b)) partition by list (a) (
+		partition p0 values in (1, 2, 3),
+		partition p1 values in (4, 5, 6),
+		partition p2 values in (7, 8, 9))`)
+	tk.MustExec(`insert into tl values (1,1), (2,2), (5,5), (6,6), (8,8)`)
+	tk.MustExec(`alter table tl reorganize partition p0, p2 into (partition p02 values in (1, 2, 3, 7, 8, 9, 10))`)
+	tk.MustExec(`admin check table tl`)
+	tk.MustQuery(`select a from tl partition (p02)`).Sort().Check(testkit.Rows("1", "2", "8"))
+	tk.MustExec(`insert into tl values (10, 10)`)
+	tk.MustGetErrCode(`alter table tl reorganize partition p1 into (partition p1 values in (4, 5))`, errno.ErrReorgOutsideRange)
+
+	tk.MustExec(`create table th (a int) partition by hash (a) partitions 4`)
+	tk.MustGetErrCode(`alter table th reorganize partition p0 into (partition p0)`, errno.ErrUnsupportedDDLOperation)
+	tk.MustExec(`create table tn (a int)`)
+	tk.MustGetErrCode(`alter table tn reorganize partition p0 into (partition p0 values less than (10))`, errno.ErrPartitionMgmtOnNonpartitioned)
+}
+
+func TestReorganizePartitionWithConcurrentDML(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int, b int, unique key (a), key (b))
+		partition by range (a) (
+		partition p0 values less than (100),
+		partition p1 values less than (200))`)
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i*10, i*10))
+	}
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	states := make(map[model.SchemaState]struct{})
+	var checkErr error
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionReorganizePartition || checkErr != nil {
+			return
+		}
+		if _, ok := states[job.SchemaState]; ok {
+			return
+		}
+		states[job.SchemaState] = struct{}{}
+		n := 1000 + len(states)
+		for _, sql := range []string{
+			fmt.Sprintf("insert into t values (%d, %d)", n%200, n),
+			fmt.Sprintf("update t set b = %d where a = %d", n, len(states)*10),
+			fmt.Sprintf("update t set a = %d where a = %d", 100+len(states)*10+1, len(states)*10+100),
+			fmt.Sprintf("delete from t where a = %d", len(states)*10+50),
+		} {
+			if _, checkErr = tk1.Exec(sql); checkErr != nil {
+				return
+			}
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustExec(`alter table t reorganize partition p0, p1 into (
+		partition p0 values less than (50),
+		partition p1 values less than (150),
+		partition p2 values less than (200))`)
+	require.NoError(t, checkErr)
+	require.Greater(t, len(states), 4)
+	tk.MustExec("admin check table t")
+	// Each state inserts one row and deletes one row.
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("20"))
+	tk.MustQuery("select count(*) from t partition (p0, p1, p2)").Check(tk.MustQuery("select count(*) from t").Rows())
+}
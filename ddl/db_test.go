@@ -357,9 +357,10 @@ func TestDropCheck(t *testing.T) {
 	tk.MustExec("drop table if exists drop_check")
 	tk.MustExec("create table drop_check (pk int primary key)")
 	defer tk.MustExec("drop table if exists drop_check")
+	tk.MustGetErrCode("alter table drop_check drop check crcn", errno.ErrCheckConstraintNotFound)
+	tk.MustExec("alter table drop_check add constraint crcn check (pk > 0)")
 	tk.MustExec("alter table drop_check drop check crcn")
-	require.Equal(t, uint16(1), tk.Session().GetSessionVars().StmtCtx.WarningCount())
-	tk.MustQuery("show warnings").Check(testkit.RowsWithSep("|", "Warning|8231|DROP CHECK is not supported"))
+	tk.MustExec("insert into drop_check values (-1)")
 }
 
 func TestAlterOrderBy(t *testing.T) {
@@ -513,22 +514,21 @@ func TestAddConstraintCheck(t *testing.T) {
 	tk.MustExec("create table add_constraint_check (pk int primary key, a int)")
 	defer tk.MustExec("drop table if exists add_constraint_check")
 	tk.MustExec("alter table add_constraint_check add constraint crn check (a > 1)")
-	require.Equal(t, uint16(1), tk.Session().GetSessionVars().StmtCtx.WarningCount())
-	tk.MustQuery("show warnings").Check(testkit.RowsWithSep("|", "Warning|8231|ADD CONSTRAINT CHECK is not supported"))
+	tk.MustGetErrCode("insert into add_constraint_check values (1, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into add_constraint_check values (1, 2)")
 }
 
-func TestCreateTableIgnoreCheckConstraint(t *testing.T) {
+func TestCreateTableWithCheckConstraint(t *testing.T) {
 	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
 
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")
 	tk.MustExec("drop table if exists table_constraint_check")
 	tk.MustExec("CREATE TABLE admin_user (enable bool, CHECK (enable IN (0, 1)));")
-	require.Equal(t, uint16(1), tk.Session().GetSessionVars().StmtCtx.WarningCount())
-	tk.MustQuery("show warnings").Check(testkit.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
 	tk.MustQuery("show create table admin_user").Check(testkit.RowsWithSep("|", ""+
 		"admin_user CREATE TABLE `admin_user` (\n"+
-		"  `enable` tinyint(1) DEFAULT NULL\n"+
+		"  `enable` tinyint(1) DEFAULT NULL,\n"+
+		"  CONSTRAINT `admin_user_chk_1` CHECK ((`enable` in (0,1)))\n"+
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
 }
 
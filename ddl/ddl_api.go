@@ -67,6 +67,7 @@ import (
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -1136,7 +1137,8 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.GenWithStackByArgs())
 			case ast.ColumnOptionCheck:
-				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+				// The column check constraint is built with the table ones.
+				constraints = append(constraints, newColumnCheckConstraint(colDef.Name.Name, v))
 			}
 		}
 	}
@@ -1687,10 +1689,20 @@ func setEmptyConstraintName(namesMap map[string]bool, constr *ast.Constraint) {
 func checkConstraintNames(constraints []*ast.Constraint) error {
 	constrNames := map[string]bool{}
 	fkNames := map[string]bool{}
+	checkNames := map[string]bool{}
 
 	// Check not empty constraint name whether is duplicated.
 	for _, constr := range constraints {
-		if constr.Tp == ast.ConstraintForeignKey {
+		if constr.Tp == ast.ConstraintCheck {
+			if constr.Name == "" {
+				continue
+			}
+			nameLower := strings.ToLower(constr.Name)
+			if checkNames[nameLower] {
+				return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+			}
+			checkNames[nameLower] = true
+		} else if constr.Tp == ast.ConstraintForeignKey {
 			err := checkDuplicateConstraint(fkNames, constr.Name, true)
 			if err != nil {
 				return errors.Trace(err)
@@ -1705,7 +1717,7 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 
 	// Set empty constraint names.
 	for _, constr := range constraints {
-		if constr.Tp != ast.ConstraintForeignKey {
+		if constr.Tp != ast.ConstraintForeignKey && constr.Tp != ast.ConstraintCheck {
 			setEmptyConstraintName(constrNames, constr)
 		}
 	}
@@ -1804,6 +1816,7 @@ func BuildTableInfo(
 		tblColumns = append(tblColumns, table.ToColumn(v.ToInfo()))
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
+	var checkConstraints []*ast.Constraint
 	for _, constr := range constraints {
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
@@ -1872,7 +1885,7 @@ func BuildTableInfo(
 			continue
 		}
 		if constr.Tp == ast.ConstraintCheck {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+			checkConstraints = append(checkConstraints, constr)
 			continue
 		}
 
@@ -1918,6 +1931,9 @@ func BuildTableInfo(
 		tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 	}
 
+	if err = buildCheckConstraints(ctx, tbInfo, checkConstraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 	err = addIndexForForeignKey(ctx, tbInfo)
 	return tbInfo, err
 }
@@ -2074,6 +2090,11 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			}
 		}
 	}
+	if tbInfo.TTLInfo != nil {
+		if err := checkTTLInfoValid(ctx, tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	return nil
 }
@@ -2093,15 +2114,32 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 		return err
 	}
 
+	pi := tbInfo.Partition
+	if pi.Sub != nil {
+		// The values are defined on the partition level, check them on the partitions instead of the subpartitions.
+		parentTbInfo := *tbInfo
+		parentTbInfo.Partition = pi.ParentPartitionInfo()
+		tbInfo = &parentTbInfo
+	}
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		err = checkPartitionByRange(ctx, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		err = checkPartitionByHash(ctx, tbInfo)
 	case model.PartitionTypeList:
 		err = checkPartitionByList(ctx, tbInfo)
 	}
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if pi.Sub != nil {
+		// The range values may be simplified by the check, keep the subpartitions in sync with their partition.
+		num := pi.SubPartitionNum()
+		for i := range pi.Definitions {
+			pi.Definitions[i].LessThan = tbInfo.Partition.Definitions[i/num].LessThan
+		}
+	}
+	return nil
 }
 
 // checkTableInfoValid uses to check table info valid. This is used to validate table info.
@@ -2949,6 +2987,8 @@ func SetDirectPlacementOpt(placementSettings *model.PlacementSettings, placement
 
 // handleTableOptions updates tableInfo according to table options.
 func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) error {
+	var ttlOptionsHandled bool
+
 	for _, op := range options {
 		switch op.Tp {
 		case ast.TableOptionAutoIncrement:
@@ -2985,6 +3025,23 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: model.NewCIStr(op.StrValue),
 			}
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable:
+			if ttlOptionsHandled {
+				continue
+			}
+
+			ttlInfo, ttlEnable, err := getTTLInfoInOptions(options)
+			if err != nil {
+				return err
+			}
+			// It's impossible that `ttlInfo` and `ttlEnable` are all nil, because we have met this option.
+			// After excluding the situation `ttlInfo == nil && ttlEnable != nil`, we could say `ttlInfo != nil`
+			if ttlInfo == nil && ttlEnable != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ENABLE"))
+			}
+
+			tbInfo.TTLInfo = ttlInfo
+			ttlOptionsHandled = true
 		}
 	}
 	shardingBits := shardingBits(tbInfo)
@@ -3184,7 +3241,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizePartition:
-			err = errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+			err = d.ReorganizePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizeFirstPartition:
 			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("MERGE FIRST PARTITION")
 		case ast.AlterTableReorganizeLastPartition:
@@ -3249,7 +3306,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			case ast.ConstraintFulltext:
 				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
 			case ast.ConstraintCheck:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD CONSTRAINT CHECK"))
+				err = d.CreateCheckConstraint(sctx, ident, constr)
 			default:
 				// Nothing to do now.
 			}
@@ -3273,6 +3330,8 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			err = errors.New("alter table partition is unsupported")
 		case ast.AlterTableOption:
 			var placementPolicyRef *model.PolicyRefInfo
+			var ttlInfo *model.TTLInfo
+			var ttlEnable *bool
 			for i, opt := range spec.Options {
 				switch opt.Tp {
 				case ast.TableOptionShardRowID:
@@ -3311,6 +3370,12 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 					placementPolicyRef = &model.PolicyRefInfo{
 						Name: model.NewCIStr(opt.StrValue),
 					}
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable:
+					// getTTLInfoInOptions will aggregate all TTL options, so they should be handled only once.
+					if ttlInfo != nil || ttlEnable != nil {
+						continue
+					}
+					ttlInfo, ttlEnable, err = getTTLInfoInOptions(spec.Options)
 				case ast.TableOptionEngine:
 				default:
 					err = dbterror.ErrUnsupportedAlterTableOption
@@ -3324,6 +3389,11 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			if placementPolicyRef != nil {
 				err = d.AlterTablePlacement(sctx, ident, placementPolicyRef)
 			}
+			if err == nil && (ttlInfo != nil || ttlEnable != nil) {
+				err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable)
+			}
+		case ast.AlterTableRemoveTTL:
+			err = d.AlterTableRemoveTTL(sctx, ident)
 		case ast.AlterTableSetTiFlashReplica:
 			err = d.AlterTableSetTiFlashReplica(sctx, ident, spec.TiFlashReplica)
 		case ast.AlterTableOrderByColumns:
@@ -3331,9 +3401,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 		case ast.AlterTableIndexInvisible:
 			err = d.AlterIndexVisibility(sctx, ident, spec.IndexName, spec.Visibility)
 		case ast.AlterTableAlterCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ALTER CHECK"))
+			err = d.AlterCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name), spec.Constraint.Enforced)
 		case ast.AlterTableDropCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("DROP CHECK"))
+			err = d.DropCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name))
 		case ast.AlterTableWithValidation:
 			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedAlterTableWithValidation)
 		case ast.AlterTableWithoutValidation:
@@ -3639,7 +3709,19 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 	if err != nil {
 		return errors.Trace(err)
 	}
+	constraints, err := buildColumnCheckConstraints(ctx, t.Meta(), col, specNewColumn)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(constraints) > 0 && ctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+		return dbterror.ErrRunMultiSchemaChanges.GenWithStackByArgs(model.ActionAddCheckConstraint.String())
+	}
 
+	args := []interface{}{col, spec.Position, 0, spec.IfNotExists}
+	if len(constraints) > 0 {
+		// The column check constraints are added in the same job, so the column never becomes public without them.
+		args = append(args, constraints)
+	}
 	job := &model.Job{
 		SchemaID:   schema.ID,
 		TableID:    t.Meta().ID,
@@ -3647,7 +3729,7 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		TableName:  t.Meta().Name.L,
 		Type:       model.ActionAddColumn,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{col, spec.Position, 0, spec.IfNotExists},
+		Args:       args,
 	}
 
 	err = d.DoDDLJob(ctx, job)
@@ -3768,6 +3850,195 @@ func (d *ddl) CoalescePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	return errors.Trace(err)
 }
 
+// ReorganizePartitions reorganizes the given range or list partitions into the new partition definitions.
+// The data of the old partitions is copied into the new partitions in the reorganization state of the job.
+func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange, model.PartitionTypeList:
+	default:
+		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+	}
+	if pi.Sub != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REORGANIZE PARTITION of a subpartitioned table")
+	}
+	if spec.OnAllPartitions {
+		return errors.Trace(dbterror.ErrReorgNoParam)
+	}
+	if hasGlobalIndex(meta) {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REORGANIZE PARTITION of a table with global index")
+	}
+	if meta.TiFlashReplica != nil && meta.TiFlashReplica.Count > 0 {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REORGANIZE PARTITION of a table with TiFlash replica")
+	}
+
+	partNames := make([]string, 0, len(spec.PartitionNames))
+	for _, name := range spec.PartitionNames {
+		partNames = append(partNames, name.L)
+	}
+	firstIdx, lastIdx, err := checkReorganizePartitionNames(pi, partNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if pi.Type == model.PartitionTypeRange && lastIdx-firstIdx+1 != len(partNames) {
+		return errors.Trace(dbterror.ErrConsecutiveReorgPartitions)
+	}
+
+	partInfo, err := BuildAddedPartitionInfo(ctx, meta, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Check the partition layout after the reorganization.
+	droppingDefs := make([]model.PartitionDefinition, 0, len(partNames))
+	for _, def := range pi.Definitions {
+		if slices.Contains(partNames, def.Name.L) {
+			droppingDefs = append(droppingDefs, def)
+		}
+	}
+	clonedMeta := meta.Clone()
+	tmp := *partInfo
+	tmp.Definitions = model.ReplacePartitionDefinitions(pi.Definitions, droppingDefs, partInfo.Definitions)
+	clonedMeta.Partition = &tmp
+	if err = checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkReorganizePartitionValues(ctx, meta, droppingDefs, partInfo, lastIdx == len(pi.Definitions)-1); err != nil {
+		return errors.Trace(err)
+	}
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		TableName:  meta.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+		Args:     []interface{}{partNames, partInfo},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	if err == nil {
+		d.preSplitAndScatter(ctx, meta, partInfo)
+	}
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// checkReorganizePartitionNames checks the reorganized partitions exist and returns the offsets of the first
+// and the last one in the partition definitions.
+func checkReorganizePartitionNames(pi *model.PartitionInfo, partNames []string) (firstIdx, lastIdx int, err error) {
+	firstIdx, lastIdx = len(pi.Definitions), -1
+	for i, name := range partNames {
+		if slices.Contains(partNames[:i], name) {
+			return 0, 0, errors.Trace(dbterror.ErrSameNamePartition.GenWithStackByArgs(name))
+		}
+		idx := slices.IndexFunc(pi.Definitions, func(def model.PartitionDefinition) bool { return def.Name.L == name })
+		if idx < 0 {
+			return 0, 0, errors.Trace(dbterror.ErrDropPartitionNonExistent.GenWithStackByArgs("REORGANIZE"))
+		}
+		firstIdx = mathutil.Min(firstIdx, idx)
+		lastIdx = mathutil.Max(lastIdx, idx)
+	}
+	return firstIdx, lastIdx, nil
+}
+
+// checkReorganizePartitionValues checks the new partitions cover the same values as the reorganized ones.
+// For range partitions, only the range of the last partition can be extended.
+func checkReorganizePartitionValues(ctx sessionctx.Context, tblInfo *model.TableInfo, droppingDefs []model.PartitionDefinition, partInfo *model.PartitionInfo, isLast bool) error {
+	switch tblInfo.Partition.Type {
+	case model.PartitionTypeRange:
+		oldLast := &droppingDefs[len(droppingDefs)-1]
+		newLast := &partInfo.Definitions[len(partInfo.Definitions)-1]
+		shrunk, err := isRangeBoundGreater(ctx, tblInfo, oldLast, newLast)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		extended := false
+		if !isLast {
+			extended, err = isRangeBoundGreater(ctx, tblInfo, newLast, oldLast)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if shrunk || extended {
+			return errors.Trace(dbterror.ErrReorgOutsideRange)
+		}
+	case model.PartitionTypeList:
+		oldTblInfo := tblInfo.Clone()
+		oldTblInfo.Partition.Definitions = droppingDefs
+		oldValues, err := formatListPartitionValue(ctx, oldTblInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newTblInfo := tblInfo.Clone()
+		newTblInfo.Partition.Definitions = partInfo.Definitions
+		newValues, err := formatListPartitionValue(ctx, newTblInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, v := range oldValues {
+			if !slices.Contains(newValues, v) {
+				return errors.Trace(dbterror.ErrReorgOutsideRange)
+			}
+		}
+	}
+	return nil
+}
+
+// isRangeBoundGreater returns whether the VALUES LESS THAN bound of partition `a` is greater than the one of `b`.
+func isRangeBoundGreater(ctx sessionctx.Context, tblInfo *model.TableInfo, a, b *model.PartitionDefinition) (bool, error) {
+	pi := tblInfo.Partition
+	if len(pi.Columns) > 0 {
+		return checkTwoRangeColumns(ctx, a, b, pi, tblInfo)
+	}
+	aMax, bMax := strings.EqualFold(a.LessThan[0], partitionMaxValue), strings.EqualFold(b.LessThan[0], partitionMaxValue)
+	if aMax || bMax {
+		return aMax && !bMax, nil
+	}
+	isUnsigned := isPartExprUnsigned(tblInfo)
+	aValue, _, err := getRangeValue(ctx, a.LessThan[0], isUnsigned)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	bValue, _, err := getRangeValue(ctx, b.LessThan[0], isUnsigned)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if isUnsigned {
+		return aValue.(uint64) > bValue.(uint64), nil
+	}
+	return aValue.(int64) > bValue.(int64), nil
+}
+
 func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
@@ -3794,11 +4065,13 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		// so we filter them out through a hash
 		pidMap := make(map[int64]bool)
 		for _, name := range spec.PartitionNames {
-			pid, err := tables.FindPartitionByName(meta, name.L)
+			partIDs, err := tables.FindPartitionIDsByName(meta, name.L)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			pidMap[pid] = true
+			for _, pid := range partIDs {
+				pidMap[pid] = true
+			}
 		}
 		// linter makezero does not handle changing pids to zero length,
 		// so create a new var and then assign to pids...
@@ -4097,7 +4370,10 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 
 	partName := spec.PartitionNames[0].L
 
-	// NOTE: if pt is subPartitioned, it should be checked
+	// The rows of the exchanged table can't be validated against a KEY partition or a subpartition.
+	if ptMeta.Partition.Sub != nil || ptMeta.Partition.Type == model.PartitionTypeKey {
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("EXCHANGE PARTITION of KEY partitioned or subpartitioned tables"))
+	}
 
 	defID, err := tables.FindPartitionByName(ptMeta, partName)
 	if err != nil {
@@ -4473,6 +4749,9 @@ func GetModifiableColumnJob(
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if err = checkColumnRenameForCheckConstraint(t.Meta(), originalColName); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -4585,6 +4864,10 @@ func GetModifiableColumnJob(
 		return nil, err
 	}
 
+	if err = checkModifyColumnWithTTLConfig(t.Meta(), col.ColumnInfo, newCol.ColumnInfo); err != nil {
+		return nil, err
+	}
+
 	// As same with MySQL, we don't support modifying the stored status for generated columns.
 	if err = checkModifyGeneratedColumn(sctx, t, col, newCol, specNewColumn, spec.Position); err != nil {
 		return nil, errors.Trace(err)
@@ -4844,6 +5127,9 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 			}
 		}
 	}
+	if err = checkColumnRenameForCheckConstraint(tbl.Meta(), oldColName); err != nil {
+		return errors.Trace(err)
+	}
 
 	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
 
@@ -4988,6 +5274,76 @@ func (d *ddl) AlterTableComment(ctx sessionctx.Context, ident ast.Ident, spec *a
 	return errors.Trace(err)
 }
 
+// AlterTableTTLInfoOrEnable submits ddl job to change table info for TTL. ttlInfo or ttlInfoEnable may be nil.
+func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlInfoEnable *bool) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+
+	tb, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	tblInfo := tb.Meta().Clone()
+	if ttlInfo != nil {
+		tblInfo.TTLInfo = ttlInfo
+		if err = checkTTLInfoValid(ctx, tblInfo); err != nil {
+			return err
+		}
+	} else if tblInfo.TTLInfo == nil {
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ENABLE"))
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionAlterTTLInfo,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{ttlInfo, ttlInfoEnable},
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// AlterTableRemoveTTL submits ddl job to remove the TTL config of the table.
+func (d *ddl) AlterTableRemoveTTL(ctx sessionctx.Context, ident ast.Ident) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+
+	tb, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	tblInfo := tb.Meta()
+	if tblInfo.TTLInfo == nil {
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionAlterTTLRemove,
+		BinlogInfo: &model.HistoryInfo{},
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 // AlterTableAutoIDCache updates the table comment information.
 func (d *ddl) AlterTableAutoIDCache(ctx sessionctx.Context, ident ast.Ident, newCache int64) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
@@ -6021,6 +6377,13 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		indexName = GetName4AnonymousIndex(t, colName, model.NewCIStr(""))
 	}
 
+	if indexOption != nil && indexOption.Tp == model.IndexTypeHypo {
+		if err = checkTooLongIndex(indexName); err != nil {
+			return errors.Trace(err)
+		}
+		return createHypoIndex(ctx, t.Meta(), indexName, unique, indexPartSpecifications, indexOption, ifNotExists)
+	}
+
 	if indexInfo := t.Meta().FindIndexByName(indexName.L); indexInfo != nil {
 		if indexInfo.State != model.StatePublic {
 			// NOTE: explicit error message. See issue #18363.
@@ -6274,8 +6637,108 @@ func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.
 	return errors.Trace(err)
 }
 
+// CreateCheckConstraint adds a check constraint to the table, the existing rows are verified
+// before the constraint becomes public.
+func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constr *ast.Constraint) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+	name := model.NewCIStr(constr.Name)
+	if name.L == "" {
+		usedNames := make(map[string]struct{}, len(tblInfo.Constraints))
+		for _, constrInfo := range tblInfo.Constraints {
+			usedNames[constrInfo.Name.L] = struct{}{}
+		}
+		name = genCheckConstraintName(tblInfo.Name, usedNames)
+	} else if tblInfo.FindConstraintInfoByName(name.L) != nil {
+		return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(name.O)
+	}
+	constraintInfo, err := buildCheckConstraintInfo(ctx, tblInfo, name, constr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionAddCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constraintInfo},
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropCheckConstraint drops a check constraint of the table.
+func (d *ddl) DropCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:    schema.ID,
+		TableID:     t.Meta().ID,
+		SchemaName:  schema.Name.L,
+		SchemaState: model.StatePublic,
+		TableName:   t.Meta().Name.L,
+		Type:        model.ActionDropCheckConstraint,
+		BinlogInfo:  &model.HistoryInfo{},
+		Args:        []interface{}{constrName},
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// AlterCheckConstraint changes whether a check constraint of the table is enforced.
+func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, enforced bool) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	constraintInfo := t.Meta().FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		return dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	if constraintInfo.Enforced == enforced {
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		TableName:  t.Meta().Name.L,
+		Type:       model.ActionAlterCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName, enforced},
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) DropIndex(ctx sessionctx.Context, stmt *ast.DropIndexStmt) error {
 	ti := ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name}
+	if stmt.IsHypo {
+		_, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return dropHypoIndex(ctx, t.Meta(), model.NewCIStr(stmt.IndexName), stmt.IfExists)
+	}
 	err := d.dropIndex(ctx, ti, model.NewCIStr(stmt.IndexName), stmt.IfExists)
 	if (infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableNotExists.Equal(err)) && stmt.IfExists {
 		err = nil
@@ -6373,12 +6836,18 @@ func CheckIsDropPrimaryKey(indexName model.CIStr, indexInfo *model.IndexInfo, t
 }
 
 func isDroppableColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
+	if tblInfo.TTLInfo != nil && tblInfo.TTLInfo.ColumnName.L == colName.L {
+		return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
+	}
 	if ok, dep, isHidden := hasDependentByGeneratedColumn(tblInfo, colName); ok {
 		if isHidden {
 			return dbterror.ErrDependentByFunctionalIndex.GenWithStackByArgs(dep)
 		}
 		return dbterror.ErrDependentByGeneratedColumn.GenWithStackByArgs(dep)
 	}
+	if err := checkDropColumnForCheckConstraint(tblInfo, colName); err != nil {
+		return errors.Trace(err)
+	}
 
 	if len(tblInfo.Columns) == 1 {
 		return dbterror.ErrCantRemoveAllFields.GenWithStack("can't drop only column %s in table %s",
@@ -6456,6 +6925,13 @@ func BuildAddedPartitionInfo(ctx sessionctx.Context, meta *model.TableInfo, spec
 	if err != nil {
 		return nil, err
 	}
+	if meta.Partition.Sub != nil {
+		part.Sub = meta.Partition.Sub.Clone()
+		defs, err = buildSubPartitionDefinitions(part, defs, spec.PartDefinitions)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	part.Definitions = defs
 	return part, nil
@@ -7269,6 +7745,108 @@ func (d *ddl) AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacem
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) (err error) {
+	if !variable.EnableResourceControl.Load() {
+		return dbterror.ErrResourceGroupSupportDisabled
+	}
+	groupName := stmt.ResourceGroupName
+	// Check group existence.
+	_, ok := d.GetInfoSchemaWithInterceptor(ctx).ResourceGroupByName(groupName)
+	if ok {
+		err = infoschema.ErrResourceGroupExists.GenWithStackByArgs(groupName)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	groupInfo, err := buildResourceGroup(groupName, stmt.ResourceGroupOptionList)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkResourceGroupValidation(groupInfo.ResourceGroupSettings); err != nil {
+		return err
+	}
+
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	groupInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaName: groupName.L,
+		Type:       model.ActionCreateResourceGroup,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{groupInfo},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) (err error) {
+	if !variable.EnableResourceControl.Load() {
+		return dbterror.ErrResourceGroupSupportDisabled
+	}
+	groupName := stmt.ResourceGroupName
+	// Check group existence.
+	group, ok := d.GetInfoSchemaWithInterceptor(ctx).ResourceGroupByName(groupName)
+	if !ok {
+		err = infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(groupName)
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	newGroupInfo, err := buildResourceGroup(group.Name, stmt.ResourceGroupOptionList)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkResourceGroupValidation(newGroupInfo.ResourceGroupSettings); err != nil {
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   group.ID,
+		SchemaName: group.Name.L,
+		Type:       model.ActionAlterResourceGroup,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{newGroupInfo},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) (err error) {
+	groupName := stmt.ResourceGroupName
+	// Check group existence.
+	group, ok := d.GetInfoSchemaWithInterceptor(ctx).ResourceGroupByName(groupName)
+	if !ok {
+		err = infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(groupName)
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   group.ID,
+		SchemaName: group.Name.L,
+		Type:       model.ActionDropResourceGroup,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{groupName},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) AlterTableCache(sctx sessionctx.Context, ti ast.Ident) (err error) {
 	schema, t, err := d.getSchemaAndTableByIdent(sctx, ti)
 	if err != nil {
@@ -0,0 +1,95 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// createHypoIndex creates a hypothetical index for the current session. The index is never built,
+// it is only seen by the optimizer when explaining statements.
+// The hypothetical indexes have negative IDs, so they never share the IDs and the statistics with the real indexes,
+// including the ones added later.
+func createHypoIndex(ctx sessionctx.Context, tblInfo *model.TableInfo, indexName model.CIStr, unique bool,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
+	sessVars := ctx.GetSessionVars()
+	var err error
+	if tblInfo.FindIndexByName(indexName.L) != nil {
+		err = dbterror.ErrDupKeyName.GenWithStack("index already exist %s", indexName)
+	}
+	minIndexID := int64(0)
+	for _, idx := range sessVars.HypoIndexes[tblInfo.ID] {
+		if idx.Name.L == indexName.L {
+			err = dbterror.ErrDupKeyName.GenWithStack("hypothetical index already exist %s", indexName)
+		}
+		if idx.ID < minIndexID {
+			minIndexID = idx.ID
+		}
+	}
+	if err != nil {
+		if ifNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	for _, spec := range indexPartSpecifications {
+		if spec.Expr != nil {
+			return dbterror.ErrUnsupportedIndexType.GenWithStack("hypothetical expression index is not supported")
+		}
+	}
+
+	idxInfo, err := BuildIndexInfo(ctx, tblInfo.Columns, indexName, false, unique, false, indexPartSpecifications, indexOption, model.StatePublic)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	idxInfo.ID = minIndexID - 1
+	idxInfo.Table = tblInfo.Name
+	if sessVars.HypoIndexes == nil {
+		sessVars.HypoIndexes = make(map[int64][]*model.IndexInfo)
+	}
+	sessVars.HypoIndexes[tblInfo.ID] = append(sessVars.HypoIndexes[tblInfo.ID], idxInfo)
+	return nil
+}
+
+// dropHypoIndex drops a hypothetical index of the current session.
+func dropHypoIndex(ctx sessionctx.Context, tblInfo *model.TableInfo, indexName model.CIStr, ifExists bool) error {
+	sessVars := ctx.GetSessionVars()
+	hypoIndexes := sessVars.HypoIndexes[tblInfo.ID]
+	for i, idx := range hypoIndexes {
+		if idx.Name.L != indexName.L {
+			continue
+		}
+		remained := make([]*model.IndexInfo, 0, len(hypoIndexes)-1)
+		remained = append(remained, hypoIndexes[:i]...)
+		remained = append(remained, hypoIndexes[i+1:]...)
+		if len(remained) == 0 {
+			delete(sessVars.HypoIndexes, tblInfo.ID)
+		} else {
+			sessVars.HypoIndexes[tblInfo.ID] = remained
+		}
+		return nil
+	}
+	err := dbterror.ErrCantDropFieldOrKey.GenWithStack("hypothetical index %s doesn't exist", indexName)
+	if ifExists {
+		sessVars.StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
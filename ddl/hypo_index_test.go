@@ -0,0 +1,116 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHypoIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	values := make([]string, 0, 256)
+	for i := 1; i <= 256; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, %d)", i, i, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ", "))
+	tk.MustExec("analyze table t")
+
+	tk.MustExec("create index idx_a on t(a) type hypo")
+	tk.MustGetErrCode("create index idx_a on t(b) type hypo", errno.ErrDupKeyName)
+	tk.MustExec("create index if not exists idx_a on t(b) type hypo")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1061 hypothetical index already exist idx_a"))
+	tk.MustGetErrCode("create index idx_d on t(d) type hypo", errno.ErrKeyColumnDoesNotExits)
+	tk.MustGetErrCode("create index idx_e on t((a + 1)) type hypo", errno.ErrUnsupportedIndexType)
+
+	// The hypothetical index is not created in the table.
+	tk.MustQuery("show index from t").Check(testkit.Rows())
+	require.True(t, tk.MustUseIndex("select * from t where a = 1", "idx_a"))
+	// It is never used for execution.
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1 1"))
+	require.False(t, tk.HasPlan4ExplainFor(tk.MustQuery("explain analyze select * from t where a = 1"), "IndexLookUp"))
+	tk.MustExec("prepare stmt from 'select * from t where a = ?'")
+	tk.MustExec("set @a = 1")
+	require.True(t, tk.MustUseIndex4ExplainFor(tk.MustQuery("explain execute stmt using @a"), "idx_a"))
+	tk.MustQuery("execute stmt using @a").Check(testkit.Rows("1 1 1"))
+	require.False(t, tk.HasPlan4ExplainFor(tk.MustQuery("explain analyze execute stmt using @a"), "IndexLookUp"))
+
+	// The hypothetical index is only seen by the session which creates it.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	require.False(t, tk2.MustUseIndex("select * from t where a = 1", "idx_a"))
+
+	tk.MustExec("create unique index idx_bc on t(b, c) type hypo")
+	require.True(t, tk.MustUseIndex("select * from t where b = 1 and c = 1", "idx_bc"))
+	tk.MustExec("alter table t drop column c")
+	require.False(t, tk.MustUseIndex("select * from t where b = 1", "idx_bc"))
+
+	tk.MustExec("drop hypo index idx_a on t")
+	require.False(t, tk.MustUseIndex("select * from t where a = 1", "idx_a"))
+	tk.MustGetErrCode("drop hypo index idx_a on t", errno.ErrCantDropFieldOrKey)
+	tk.MustExec("drop hypo index if exists idx_a on t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1091 hypothetical index idx_a doesn't exist"))
+}
+
+func TestHypoIndexWithRealIndexes(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int, key idx_r(a))")
+	values := make([]string, 0, 256)
+	for i := 1; i <= 256; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, %d)", i, i, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ", "))
+	tk.MustExec("analyze table t")
+
+	// The name of the hypothetical index can't be the same as a real index.
+	tk.MustGetErrCode("create index idx_r on t(b) type hypo", errno.ErrDupKeyName)
+	tk.MustExec("create index if not exists idx_r on t(b) type hypo")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1061 index already exist idx_r"))
+
+	// The hypothetical indexes have their own ID space, so they don't share the statistics with the real indexes
+	// added later.
+	tk.MustExec("create index idx_b on t(b) type hypo")
+	tk.MustExec("create index idx_c on t(c) type hypo")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	hypoIndexes := tk.Session().GetSessionVars().HypoIndexes[tbl.Meta().ID]
+	require.Len(t, hypoIndexes, 2)
+	require.Equal(t, int64(-1), hypoIndexes[0].ID)
+	require.Equal(t, int64(-2), hypoIndexes[1].ID)
+	tk.MustExec("alter table t add index idx_real_c(c)")
+	tk.MustExec("analyze table t")
+	tbl, err = dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	for _, idx := range tbl.Meta().Indices {
+		require.Greater(t, idx.ID, int64(0))
+	}
+
+	// The columns are matched by name after the columns of the table are changed.
+	tk.MustExec("alter table t add column z int first")
+	require.True(t, tk.MustUseIndex("select * from t where b = 1", "idx_b(b)"))
+	tk.MustExec("alter table t modify column b int after c")
+	require.True(t, tk.MustUseIndex("select * from t where b = 1", "idx_b(b)"))
+}
@@ -70,11 +70,18 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 	maxIndexLength := config.GetGlobalConfig().MaxIndexLength
 	// The sum of length of all index columns.
 	sumLength := 0
+	mvIndex := false
 	for _, ip := range indexPartSpecifications {
 		col = model.FindColumnInfo(columns, ip.Column.Name.L)
 		if col == nil {
 			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
 		}
+		if col.FieldType.IsArray() {
+			if mvIndex {
+				return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("more than one multi-valued key part per index is not supported")
+			}
+			mvIndex = true
+		}
 
 		if err := checkIndexColumn(ctx, col, ip.Length); err != nil {
 			return nil, err
@@ -276,6 +283,11 @@ func BuildIndexInfo(
 		Unique:  isUnique,
 		Global:  isGlobal,
 	}
+	for _, idxCol := range idxColumns {
+		if model.FindColumnInfo(allTableColumns, idxCol.Name.L).FieldType.IsArray() {
+			idxInfo.MVIndex = true
+		}
+	}
 
 	if indexOption != nil {
 		idxInfo.Comment = indexOption.Comment
@@ -1341,7 +1353,18 @@ func (w *baseIndexWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBac
 				if err1 != nil {
 					return false, errors.Trace(err1)
 				}
-				w.idxRecords = append(w.idxRecords, idxRecord)
+				if !index.Meta().MVIndex {
+					w.idxRecords = append(w.idxRecords, idxRecord)
+					continue
+				}
+				// A multi-valued index has a record for every element of the array.
+				valsList, err1 := tables.GetIndexedValues(w.sessCtx.GetSessionVars().StmtCtx, w.table.Meta(), index.Meta(), idxRecord.vals)
+				if err1 != nil {
+					return false, errors.Trace(err1)
+				}
+				for _, vals := range valsList {
+					w.idxRecords = append(w.idxRecords, &indexRecord{handle: handle, key: recordKey, vals: vals, rsData: idxRecord.rsData})
+				}
 			}
 			// If there are generated column, rowDecoder will use column value that not in idxInfo.Columns to calculate
 			// the generated value, so we need to clear up the reusing map.
@@ -1800,15 +1823,14 @@ func (w *worker) updateReorgInfoForPartitions(t table.PartitionedTable, reorg *r
 		return true, nil
 	}
 
-	var pid int64
-	for i, pi := range partitionIDs {
-		if pi == reorg.PhysicalTableID {
-			if i == len(partitionIDs)-1 {
-				return true, nil
-			}
-		}
-		pid = partitionIDs[i+1]
+	idx := slices.Index(partitionIDs, reorg.PhysicalTableID)
+	if idx < 0 {
+		return false, errors.Errorf("cannot find the partition %d in the reorganized partitions", reorg.PhysicalTableID)
+	}
+	if idx == len(partitionIDs)-1 {
+		return true, nil
 	}
+	pid := partitionIDs[idx+1]
 
 	currentVer, err := getValidCurrentVersion(reorg.d.store)
 	if err != nil {
@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
@@ -44,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
@@ -58,6 +60,7 @@ import (
 	"github.com/pingcap/tidb/util/slice"
 	"github.com/pingcap/tidb/util/sqlexec"
 	"github.com/pingcap/tidb/util/stringutil"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
@@ -300,7 +303,7 @@ func rollbackAddingPartitionInfo(tblInfo *model.TableInfo) ([]int64, []string, [
 // checkAddPartitionValue values less than value must be strictly increasing for each partition.
 func checkAddPartitionValue(meta *model.TableInfo, part *model.PartitionInfo) error {
 	if meta.Partition.Type == model.PartitionTypeRange && len(meta.Partition.Columns) == 0 {
-		newDefs, oldDefs := part.Definitions, meta.Partition.Definitions
+		newDefs, oldDefs := part.ParentDefinitions(part.Definitions), meta.Partition.ParentDefinitions(meta.Partition.Definitions)
 		rangeValue := oldDefs[len(oldDefs)-1].LessThan[0]
 		if strings.EqualFold(rangeValue, "MAXVALUE") {
 			return errors.Trace(dbterror.ErrPartitionMaxvalue)
@@ -420,18 +423,14 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	var enable bool
 	switch s.Tp {
 	case model.PartitionTypeRange:
-		if s.Sub == nil {
-			enable = true
-		}
-	case model.PartitionTypeHash:
-		// Partition by hash is enabled by default.
+		enable = true
+	case model.PartitionTypeHash, model.PartitionTypeKey:
+		// Partition by hash and key is enabled by default.
 		// Note that linear hash is simply ignored, and creates non-linear hash.
-		if s.Linear {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack("LINEAR HASH is not supported, using non-linear HASH instead"))
-		}
-		if s.Sub == nil {
-			enable = true
+		if err := checkLinearPartition(ctx, &s.PartitionMethod); err != nil {
+			return errors.Trace(err)
 		}
+		enable = true
 	case model.PartitionTypeList:
 		// Partition by list is enabled only when tidb_enable_list_partition is 'ON'.
 		enable = ctx.GetSessionVars().EnableListTablePartition
@@ -441,6 +440,14 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack(fmt.Sprintf("Unsupported partition type %v, treat as normal table", s.Tp)))
 		return nil
 	}
+	if s.Sub != nil {
+		if s.Tp != model.PartitionTypeRange && s.Tp != model.PartitionTypeList {
+			return errors.Trace(dbterror.ErrSubpartition)
+		}
+		if s.Interval != nil {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("INTERVAL partitioning with subpartitions")
+		}
+	}
 
 	pi := &model.PartitionInfo{
 		Type:   s.Tp,
@@ -458,6 +465,12 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 			return err
 		}
 		pi.Expr = buf.String()
+	} else if s.Tp == model.PartitionTypeKey {
+		columns, err := buildKeyPartitionColumns(tbInfo, s.ColumnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pi.Columns = columns
 	} else if s.ColumnNames != nil {
 		pi.Columns = make([]model.CIStr, 0, len(s.ColumnNames))
 		for _, cn := range s.ColumnNames {
@@ -480,6 +493,12 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 
 	tbInfo.Partition.Definitions = defs
 
+	if s.Sub != nil {
+		if err = buildSubPartitionInfo(ctx, s, tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if s.Interval != nil {
 		// Syntactic sugar for INTERVAL partitioning
 		// Generate the resulting CREATE TABLE as the query string
@@ -498,11 +517,179 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	return nil
 }
 
+// checkLinearPartition appends a warning for LINEAR HASH, which is created as non-linear HASH.
+// LINEAR KEY isn't supported and is rejected.
+func checkLinearPartition(ctx sessionctx.Context, method *ast.PartitionMethod) error {
+	if !method.Linear {
+		return nil
+	}
+	if method.Tp == model.PartitionTypeKey {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("LINEAR KEY partitioning")
+	}
+	ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack(
+		fmt.Sprintf("LINEAR %[1]s is not supported, using non-linear %[1]s instead", method.Tp)))
+	return nil
+}
+
+// buildKeyPartitionColumns returns the columns of `PARTITION BY KEY (columnNames)`. An empty column list means the
+// columns of the primary key, or the columns of a unique key whose columns are all NOT NULL if there is no primary key.
+// See https://dev.mysql.com/doc/refman/8.0/en/partitioning-key.html
+func buildKeyPartitionColumns(tbInfo *model.TableInfo, columnNames []*ast.ColumnName) ([]model.CIStr, error) {
+	var columns []model.CIStr
+	if len(columnNames) == 0 {
+		columns = getDefaultKeyPartitionColumns(tbInfo)
+		if len(columns) == 0 {
+			return nil, errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+	} else {
+		columns = make([]model.CIStr, 0, len(columnNames))
+		for _, cn := range columnNames {
+			columns = append(columns, cn.Name)
+		}
+	}
+	for _, col := range columns {
+		colInfo := tbInfo.FindPublicColumnByName(col.L)
+		if colInfo == nil {
+			return nil, errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+		// KEY partitioning takes any column type except the TEXT, BLOB, JSON and GEOMETRY types.
+		switch colInfo.FieldType.GetType() {
+		case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeJSON, mysql.TypeGeometry:
+			return nil, dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(col.O)
+		}
+	}
+	return columns, nil
+}
+
+func getDefaultKeyPartitionColumns(tbInfo *model.TableInfo) []model.CIStr {
+	if tbInfo.PKIsHandle {
+		if pkCol := tbInfo.GetPkColInfo(); pkCol != nil {
+			return []model.CIStr{pkCol.Name}
+		}
+	}
+	getIndexColumns := func(idx *model.IndexInfo) []model.CIStr {
+		columns := make([]model.CIStr, 0, len(idx.Columns))
+		for _, idxCol := range idx.Columns {
+			columns = append(columns, idxCol.Name)
+		}
+		return columns
+	}
+	if pk := tbInfo.GetPrimaryKey(); pk != nil {
+		return getIndexColumns(pk)
+	}
+	for _, idx := range tbInfo.Indices {
+		if !idx.Unique || idx.HasPrefixIndex() {
+			continue
+		}
+		allNotNull := true
+		for _, idxCol := range idx.Columns {
+			if !mysql.HasNotNullFlag(tbInfo.Columns[idxCol.Offset].GetFlag()) {
+				allNotNull = false
+				break
+			}
+		}
+		if allNotNull {
+			return getIndexColumns(idx)
+		}
+	}
+	return nil
+}
+
+// buildSubPartitionInfo builds the HASH or KEY subpartitions of a RANGE or LIST partitioned table. It replaces the
+// partition definitions with the subpartition definitions, see model.SubPartitionInfo for the layout.
+func buildSubPartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tbInfo *model.TableInfo) error {
+	sub := s.Sub
+	si := &model.SubPartitionInfo{
+		Type: sub.Tp,
+		Num:  sub.Num,
+	}
+	if si.Num == 0 {
+		si.Num = 1
+	}
+	switch sub.Tp {
+	case model.PartitionTypeHash:
+		if err := checkPartitionFuncValid(ctx, tbInfo, sub.Expr); err != nil {
+			return errors.Trace(err)
+		}
+		if err := checkPartitionFuncType(ctx, sub.Expr, tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+		buf := new(bytes.Buffer)
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreBracketAroundBinaryOperation, buf)
+		if err := sub.Expr.Restore(restoreCtx); err != nil {
+			return err
+		}
+		si.Expr = buf.String()
+	case model.PartitionTypeKey:
+		columns, err := buildKeyPartitionColumns(tbInfo, sub.ColumnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		si.Columns = columns
+	default:
+		return errors.Trace(dbterror.ErrSubpartition)
+	}
+	if err := checkLinearPartition(ctx, sub); err != nil {
+		return errors.Trace(err)
+	}
+
+	pi := tbInfo.Partition
+	pi.Sub = si
+	defs, err := buildSubPartitionDefinitions(pi, pi.Definitions, s.Definitions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pi.Definitions = defs
+	return nil
+}
+
+// buildSubPartitionDefinitions expands the partitions in `parents` into their subpartitions. `defs` are the AST
+// definitions of the partitions, the subpartitions that are not defined there are named like `p0sp0`.
+// The comment and placement policy of a partition are inherited by its subpartitions.
+func buildSubPartitionDefinitions(pi *model.PartitionInfo, parents []model.PartitionDefinition, defs []*ast.PartitionDefinition) ([]model.PartitionDefinition, error) {
+	num := pi.SubPartitionNum()
+	if err := checkAddPartitionTooManyPartitions(uint64(len(parents) * num)); err != nil {
+		return nil, err
+	}
+	for _, def := range defs {
+		if len(def.Sub) > 0 && len(def.Sub) != num {
+			return nil, errors.Trace(dbterror.ErrPartitionWrongNoSubpart)
+		}
+	}
+	subDefs := make([]model.PartitionDefinition, 0, len(parents)*num)
+	for i := range parents {
+		for j := 0; j < num; j++ {
+			def := parents[i].Clone()
+			def.ID = 0
+			def.ParentName = parents[i].Name
+			def.Name = model.NewCIStr(fmt.Sprintf("%ssp%d", parents[i].Name.O, j))
+			if i < len(defs) && j < len(defs[i].Sub) {
+				spd := defs[i].Sub[j]
+				if err := checkTooLongTable(spd.Name); err != nil {
+					return nil, err
+				}
+				def.Name = spd.Name
+				for _, opt := range spd.Options {
+					if opt.Tp == ast.TableOptionComment {
+						def.Comment = opt.StrValue
+					}
+				}
+				if err := setPartitionPlacementFromOptions(&def, spd.Options); err != nil {
+					return nil, err
+				}
+			}
+			subDefs = append(subDefs, def)
+		}
+	}
+	return subDefs, nil
+}
+
 // getPartitionIntervalFromTable checks if a partitioned table matches a generated INTERVAL partitioned scheme
 // will return nil if error occurs, i.e. not an INTERVAL partitioned table
 func getPartitionIntervalFromTable(ctx sessionctx.Context, tbInfo *model.TableInfo) *ast.PartitionInterval {
 	if tbInfo.Partition == nil ||
-		tbInfo.Partition.Type != model.PartitionTypeRange {
+		tbInfo.Partition.Type != model.PartitionTypeRange ||
+		tbInfo.Partition.Sub != nil {
 		return nil
 	}
 	if len(tbInfo.Partition.Columns) > 1 {
@@ -1024,7 +1211,7 @@ func buildPartitionDefinitionsInfo(ctx sessionctx.Context, defs []*ast.Partition
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		partitions, err = buildRangePartitionDefinitions(ctx, defs, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		partitions, err = buildHashPartitionDefinitions(ctx, defs, tbInfo)
 	case model.PartitionTypeList:
 		partitions, err = buildListPartitionDefinitions(ctx, defs, tbInfo)
@@ -1254,11 +1441,11 @@ func checkPartitionValuesIsInt(ctx sessionctx.Context, defName interface{}, expr
 func checkPartitionNameUnique(pi *model.PartitionInfo) error {
 	newPars := pi.Definitions
 	partNames := make(map[string]struct{}, len(newPars))
-	for _, newPar := range newPars {
-		if _, ok := partNames[newPar.Name.L]; ok {
-			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar.Name)
+	for _, newPar := range partitionAndSubPartitionNames(pi, newPars) {
+		if _, ok := partNames[newPar.L]; ok {
+			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar)
 		}
-		partNames[newPar.Name.L] = struct{}{}
+		partNames[newPar.L] = struct{}{}
 	}
 	return nil
 }
@@ -1267,20 +1454,35 @@ func checkAddPartitionNameUnique(tbInfo *model.TableInfo, pi *model.PartitionInf
 	partNames := make(map[string]struct{})
 	if tbInfo.Partition != nil {
 		oldPars := tbInfo.Partition.Definitions
-		for _, oldPar := range oldPars {
-			partNames[oldPar.Name.L] = struct{}{}
+		for _, oldPar := range partitionAndSubPartitionNames(tbInfo.Partition, oldPars) {
+			partNames[oldPar.L] = struct{}{}
 		}
 	}
 	newPars := pi.Definitions
-	for _, newPar := range newPars {
-		if _, ok := partNames[newPar.Name.L]; ok {
-			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar.Name)
+	for _, newPar := range partitionAndSubPartitionNames(pi, newPars) {
+		if _, ok := partNames[newPar.L]; ok {
+			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar)
 		}
-		partNames[newPar.Name.L] = struct{}{}
+		partNames[newPar.L] = struct{}{}
 	}
 	return nil
 }
 
+// partitionAndSubPartitionNames returns the names of the definitions. The partition names are returned together
+// with the subpartition names if the table is subpartitioned, since they share the same namespace.
+func partitionAndSubPartitionNames(pi *model.PartitionInfo, defs []model.PartitionDefinition) []model.CIStr {
+	names := make([]model.CIStr, 0, len(defs))
+	for _, def := range defs {
+		names = append(names, def.Name)
+	}
+	if pi.Sub != nil {
+		for _, def := range pi.ParentDefinitions(defs) {
+			names = append(names, def.Name)
+		}
+	}
+	return names
+}
+
 func checkAndOverridePartitionID(newTableInfo, oldTableInfo *model.TableInfo) error {
 	// If any old partitionInfo has lost, that means the partition ID lost too, so did the data, repair failed.
 	if newTableInfo.Partition == nil {
@@ -1293,11 +1495,14 @@ func checkAndOverridePartitionID(newTableInfo, oldTableInfo *model.TableInfo) er
 		return dbterror.ErrRepairTableFail.GenWithStackByArgs("Partition type should be the same")
 	}
 	// Check whether partitionType is hash partition.
-	if newTableInfo.Partition.Type == model.PartitionTypeHash {
+	if newTableInfo.Partition.Type == model.PartitionTypeHash || newTableInfo.Partition.Type == model.PartitionTypeKey {
 		if newTableInfo.Partition.Num != oldTableInfo.Partition.Num {
 			return dbterror.ErrRepairTableFail.GenWithStackByArgs("Hash partition num should be the same")
 		}
 	}
+	if newTableInfo.Partition.SubPartitionNum() != oldTableInfo.Partition.SubPartitionNum() {
+		return dbterror.ErrRepairTableFail.GenWithStackByArgs("Subpartition num should be the same")
+	}
 	for i, newOne := range newTableInfo.Partition.Definitions {
 		found := false
 		for _, oldOne := range oldTableInfo.Partition.Definitions {
@@ -1534,7 +1739,8 @@ func CheckDropTablePartition(meta *model.TableInfo, partLowerNames []string) err
 
 	// To be error compatible with MySQL, we need to do this first!
 	// see https://github.com/pingcap/tidb/issues/31681#issuecomment-1015536214
-	oldDefs := pi.Definitions
+	// The subpartitions can only be dropped together with their parent partition.
+	oldDefs := pi.ParentDefinitions(pi.Definitions)
 	if len(oldDefs) <= len(partLowerNames) {
 		return errors.Trace(dbterror.ErrDropLastPartition)
 	}
@@ -1559,6 +1765,22 @@ func CheckDropTablePartition(meta *model.TableInfo, partLowerNames []string) err
 	return nil
 }
 
+// physicalPartitionNames returns the names of the physical partitions of `partLowerNames`,
+// the name of a subpartitioned parent partition is replaced by the names of its subpartitions.
+func physicalPartitionNames(pi *model.PartitionInfo, partLowerNames []string) []string {
+	if pi.Sub == nil {
+		return partLowerNames
+	}
+	names := make([]string, 0, len(partLowerNames)*pi.SubPartitionNum())
+	for _, name := range partLowerNames {
+		start, end := pi.SubPartitionRange(pi.Definitions, name)
+		for _, def := range pi.Definitions[start:end] {
+			names = append(names, def.Name.L)
+		}
+	}
+	return names
+}
+
 // updateDroppingPartitionInfo move dropping partitions to DroppingDefinitions, and return partitionIDs
 func updateDroppingPartitionInfo(tblInfo *model.TableInfo, partLowerNames []string) []int64 {
 	oldDefs := tblInfo.Partition.Definitions
@@ -1690,8 +1912,9 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
 		}
-		physicalTableIDs = updateDroppingPartitionInfo(tblInfo, partNames)
-		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, partNames)
+		physicalNames := physicalPartitionNames(tblInfo.Partition, partNames)
+		physicalTableIDs = updateDroppingPartitionInfo(tblInfo, physicalNames)
+		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, physicalNames)
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
@@ -1767,6 +1990,497 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 	return ver, errors.Trace(err)
 }
 
+// onReorganizePartition reorganizes the partitions of a range or list partitioned table.
+// The job goes through the following states:
+//
+//	none -> delete only -> write only -> write reorganization -> delete reorganization -> none
+//
+// The new partitions are added to AddingDefinitions and the reorganized partitions are moved to
+// DroppingDefinitions at the beginning. Since delete only, the writes to the reorganized partitions
+// are also applied to the new partitions, see tables.partitionedTable. In the write reorganization state,
+// the rows of the reorganized partitions are copied into the new partitions and the indexes of the new
+// partitions are backfilled. Then the new partitions replace the reorganized ones in the delete
+// reorganization state, and the reorganized partitions are dropped when the job is done.
+func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	// Handle the rolling back job
+	if job.IsRollingback() {
+		return w.rollbackReorganizePartition(d, t, job)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+
+	originalState := job.SchemaState
+	switch job.SchemaState {
+	case model.StateNone:
+		var (
+			partNames []string
+			partInfo  = &model.PartitionInfo{}
+		)
+		if err = job.DecodeArgs(&partNames, &partInfo); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if len(partNames) == 0 || len(partInfo.Definitions) == 0 {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(dbterror.ErrReorgNoParam)
+		}
+		droppingDefs := make([]model.PartitionDefinition, 0, len(partNames))
+		for _, def := range pi.Definitions {
+			if slice.AnyOf(partNames, func(i int) bool { return partNames[i] == def.Name.L }) {
+				droppingDefs = append(droppingDefs, def)
+			}
+		}
+		if len(droppingDefs) != len(partNames) {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(dbterror.ErrDropPartitionNonExistent.GenWithStackByArgs("REORGANIZE"))
+		}
+		err = checkAddPartitionTooManyPartitions(uint64(len(pi.Definitions) - len(droppingDefs) + len(partInfo.Definitions)))
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+
+		pi.DroppingDefinitions = droppingDefs
+		updateAddingPartitionInfo(partInfo, tblInfo)
+		for _, def := range pi.AddingDefinitions {
+			if _, err = checkPlacementPolicyRefValidAndCanNonValidJob(t, job, def.PlacementPolicyRef); err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(err)
+			}
+		}
+		bundles, err := alterTablePartitionBundles(t, tblInfo, pi.AddingDefinitions)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), bundles); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+		ids := getIDs([]*model.TableInfo{tblInfo})
+		ids = append(ids, getPartitionIDsFromDefinitions(pi.AddingDefinitions)...)
+		if err = alterTableLabelRule(job.SchemaName, tblInfo, ids); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, err
+		}
+
+		// none -> delete only
+		pi.DDLAction = model.ActionReorganizePartition
+		pi.DDLState = model.StateDeleteOnly
+		job.SchemaState = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		pi.DDLState = model.StateWriteOnly
+		job.SchemaState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		pi.DDLState = model.StateWriteReorganization
+		job.SchemaState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteReorganization:
+		tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		elements := buildReorgPartitionElements(tblInfo)
+		rh := newReorgHandler(t, w.sess, w.concurrentDDL)
+		reorgInfo, err := getReorgInfoFromPartitions(d.jobContext(job), d, rh, job, tbl, getPartitionIDsFromDefinitions(pi.DroppingDefinitions), elements)
+		if err != nil || reorgInfo.first {
+			// If we run reorg firstly, we should update the job snapshot version
+			// and then run the reorg next time.
+			return ver, errors.Trace(err)
+		}
+		err = w.runReorgJob(rh, reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+			defer tidbutil.Recover(metrics.LabelDDL, "onReorganizePartition",
+				func() {
+					reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("reorganize partition of table `%v` panic", tblInfo.Name)
+				}, false)
+			return w.reorgPartitionDataAndIndex(tbl, reorgInfo)
+		})
+		if err != nil {
+			if dbterror.ErrWaitReorgTimeout.Equal(err) {
+				// If timeout, we should return, check for the owner and re-wait job done.
+				return ver, nil
+			}
+			if kv.IsTxnRetryableError(err) {
+				return ver, errors.Trace(err)
+			}
+			if err1 := rh.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, RemoveDDLReorgHandle failed, can't convert job to rollback",
+					zap.String("job", job.String()), zap.Error(err1))
+			}
+			logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+			job.State = model.JobStateRollingback
+			return ver, errors.Trace(err)
+		}
+
+		// write reorganization -> delete reorganization, the new partitions are public now.
+		pi.Definitions = model.ReplacePartitionDefinitions(pi.Definitions, pi.DroppingDefinitions, pi.AddingDefinitions)
+		pi.DDLState = model.StateDeleteReorganization
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateDeleteReorganization:
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		droppingNames := make([]string, 0, len(pi.DroppingDefinitions))
+		for _, def := range pi.DroppingDefinitions {
+			// The label rules are bound to the partition names, keep the ones reused by the new partitions.
+			if !slice.AnyOf(pi.Definitions, func(i int) bool { return pi.Definitions[i].Name.L == def.Name.L }) {
+				droppingNames = append(droppingNames, def.Name.L)
+			}
+		}
+		if err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, droppingNames); err != nil {
+			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
+		}
+		if err = alterTableLabelRule(job.SchemaName, tblInfo, getIDs([]*model.TableInfo{tblInfo})); err != nil {
+			return ver, err
+		}
+
+		partInfo := &model.PartitionInfo{Definitions: pi.AddingDefinitions}
+		pi.AddingDefinitions = nil
+		pi.DroppingDefinitions = nil
+		pi.DDLState = model.StateNone
+		pi.DDLAction = model.ActionNone
+		// used by ApplyDiff in updateSchemaVersion
+		job.CtxVars = []interface{}{physicalTableIDs}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateNone
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: model.ActionReorganizePartition, TableInfo: tblInfo, PartInfo: partInfo})
+		// A background job will be created to delete the data of the reorganized partitions.
+		job.Args = []interface{}{physicalTableIDs}
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("partition", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackReorganizePartition removes the new partitions, their data are deleted by the delete range.
+func (w *worker) rollbackReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return ver, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	physicalTableIDs, pNames, rollbackBundles := rollbackAddingPartitionInfo(tblInfo)
+	if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), rollbackBundles); err != nil {
+		return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+	}
+	reusedNames := make(map[string]struct{}, len(pi.DroppingDefinitions))
+	for _, def := range pi.DroppingDefinitions {
+		reusedNames[def.Name.L] = struct{}{}
+	}
+	droppingNames := make([]string, 0, len(pNames))
+	for _, name := range pNames {
+		if _, ok := reusedNames[name]; !ok {
+			droppingNames = append(droppingNames, name)
+		}
+	}
+	if err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, droppingNames); err != nil {
+		return ver, errors.Wrapf(err, "failed to notify PD the label rules")
+	}
+	pi.DroppingDefinitions = nil
+	pi.DDLState = model.StateNone
+	pi.DDLAction = model.ActionNone
+	if err = alterTableLabelRule(job.SchemaName, tblInfo, getIDs([]*model.TableInfo{tblInfo})); err != nil {
+		return ver, err
+	}
+
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	job.Args = []interface{}{physicalTableIDs}
+	return ver, nil
+}
+
+// getReorganizedTableInfo returns a copy of the table info with the partition layout after REORGANIZE PARTITION.
+func getReorganizedTableInfo(tblInfo *model.TableInfo) *model.TableInfo {
+	nt := tblInfo.Clone()
+	pi := nt.Partition
+	if pi.DDLState != model.StateDeleteReorganization {
+		pi.Definitions = model.ReplacePartitionDefinitions(pi.Definitions, pi.DroppingDefinitions, pi.AddingDefinitions)
+	}
+	pi.AddingDefinitions = nil
+	pi.DroppingDefinitions = nil
+	pi.DDLState = model.StateNone
+	pi.DDLAction = model.ActionNone
+	return nt
+}
+
+// buildReorgPartitionElements builds the reorg elements of REORGANIZE PARTITION. The column element stands for
+// copying the rows, its ID is not used. The index elements stand for backfilling the indexes of the new partitions.
+func buildReorgPartitionElements(tblInfo *model.TableInfo) []*meta.Element {
+	indexes := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.State == model.StatePublic {
+			indexes = append(indexes, idxInfo)
+		}
+	}
+	return BuildElements(tblInfo.Columns[0], indexes)
+}
+
+// reorgPartitionDataAndIndex copies the rows of the reorganized partitions into the new partitions,
+// and then backfills the indexes of the new partitions one by one.
+func (w *worker) reorgPartitionDataAndIndex(t table.Table, reorgInfo *reorgInfo) error {
+	tbl, ok := t.(table.PartitionedTable)
+	if !ok {
+		return dbterror.ErrCancelledDDLJob.GenWithStack("table %d is not a partitioned table", t.Meta().ID)
+	}
+	pi := t.Meta().GetPartitionInfo()
+	if bytes.Equal(reorgInfo.currElement.TypeKey, meta.ColumnElementKey) {
+		droppingIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		logutil.BgLogger().Info("[ddl] start to copy the rows of the reorganized partitions", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+		for done := false; !done; {
+			p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+			if p == nil {
+				return dbterror.ErrCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+			}
+			if err := w.writePhysicalTableRecord(w.sessPool, p, typeReorgPartitionWorker, reorgInfo); err != nil {
+				return errors.Trace(err)
+			}
+			var err error
+			done, err = w.updateReorgInfoForPartitions(tbl, reorgInfo, droppingIDs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	reorgTbl, err := getTable(reorgInfo.d.store, reorgInfo.Job.SchemaID, getReorganizedTableInfo(t.Meta()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newTbl := reorgTbl.(table.PartitionedTable)
+	addingIDs := getPartitionIDsFromDefinitions(pi.AddingDefinitions)
+	startElementOffset := 0
+	resumed := false
+	if bytes.Equal(reorgInfo.currElement.TypeKey, meta.IndexElementKey) {
+		for i, element := range reorgInfo.elements[1:] {
+			if reorgInfo.currElement.ID == element.ID {
+				startElementOffset, resumed = i, true
+				break
+			}
+		}
+	}
+	for i := startElementOffset; i < len(reorgInfo.elements[1:]); i++ {
+		// The job has been exited during processing this element, continue with the handle range saved before.
+		if !resumed || i != startElementOffset {
+			if err = w.resetReorgInfoForPartition(newTbl, reorgInfo, reorgInfo.elements[i+1], addingIDs[0]); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for done := false; !done; {
+			p := newTbl.GetPartition(reorgInfo.PhysicalTableID)
+			if p == nil {
+				return dbterror.ErrCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+			}
+			if err = w.addPhysicalTableIndex(p, reorgInfo); err != nil {
+				return errors.Trace(err)
+			}
+			done, err = w.updateReorgInfoForPartitions(newTbl, reorgInfo, addingIDs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// resetReorgInfoForPartition sets the reorg element and the handle range of the partition `pid` to reorgInfo,
+// and saves them for recovering.
+func (w *worker) resetReorgInfoForPartition(t table.PartitionedTable, reorgInfo *reorgInfo, element *meta.Element, pid int64) error {
+	currentVer, err := getValidCurrentVersion(reorgInfo.d.store)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	start, end, err := getTableRange(reorgInfo.d.jobContext(reorgInfo.Job), reorgInfo.d, t.GetPartition(pid), currentVer.Ver, reorgInfo.Job.Priority)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Update the element in the reorgCtx to keep the atomic access for daemon-worker.
+	w.getReorgCtx(reorgInfo.Job).setCurrentElement(element)
+	reorgInfo.currElement = element
+	reorgInfo.StartKey, reorgInfo.EndKey, reorgInfo.PhysicalTableID = start, end, pid
+	// Write the reorg info to store so the whole reorganize process can recover from panic.
+	err = reorgInfo.UpdateReorgMeta(reorgInfo.StartKey, w.sessPool)
+	logutil.BgLogger().Info("[ddl] reorganize partition update reorgInfo",
+		zap.Int64("jobID", reorgInfo.Job.ID),
+		zap.ByteString("elementType", element.TypeKey),
+		zap.Int64("elementID", element.ID),
+		zap.Int64("partitionTableID", pid),
+		zap.String("startHandle", tryDecodeToHandleString(start)),
+		zap.String("endHandle", tryDecodeToHandleString(end)),
+		zap.Error(err))
+	return errors.Trace(err)
+}
+
+type reorgPartitionRecord struct {
+	// oldKey is used to lock the row in the reorganized partition.
+	oldKey kv.Key
+	// newKey is the key of the row in the new partition.
+	newKey kv.Key
+	vals   []byte
+}
+
+// reorgPartitionWorker copies the rows of a reorganized partition into the new partitions.
+type reorgPartitionWorker struct {
+	*backfillWorker
+	metricCounter prometheus.Counter
+	// reorgedTbl is the table with the partition layout after the reorganization.
+	reorgedTbl table.PartitionedTable
+
+	// The following attributes are used to reduce memory allocation.
+	rowRecords []*reorgPartitionRecord
+	jobContext *JobContext
+}
+
+func newReorgPartitionWorker(sessCtx sessionctx.Context, id int, t table.PhysicalTable, reorgInfo *reorgInfo, jc *JobContext) (*reorgPartitionWorker, error) {
+	reorgedTbl, err := getTable(reorgInfo.d.store, reorgInfo.Job.SchemaID, getReorganizedTableInfo(t.Meta()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &reorgPartitionWorker{
+		backfillWorker: newBackfillWorker(sessCtx, id, t, reorgInfo, typeReorgPartitionWorker),
+		metricCounter:  metrics.BackfillTotalCounter.WithLabelValues(metrics.GenerateReorgLabel("reorg_partition_rate", reorgInfo.SchemaName, t.Meta().Name.String())),
+		reorgedTbl:     reorgedTbl.(table.PartitionedTable),
+		jobContext:     jc,
+	}, nil
+}
+
+func (w *reorgPartitionWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+// BackfillDataInTxn copies the rows in the handle range into the new partitions in a transaction.
+func (w *reorgPartitionWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceType(context.Background(), w.jobContext.ddlJobSourceType())
+	errInTxn = kv.RunInNewTxn(ctx, w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, w.priority)
+		if tagger := w.reorgInfo.d.getResourceGroupTaggerForTopSQL(w.reorgInfo.Job); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+
+		rowRecords, nextKey, taskDone, err := w.fetchRowColVals(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		newKeys := make([]kv.Key, 0, len(rowRecords))
+		for _, r := range rowRecords {
+			newKeys = append(newKeys, r.newKey)
+		}
+		found, err := txn.BatchGet(ctx, newKeys)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, r := range rowRecords {
+			taskCtx.scanCount++
+			// The row is already written to the new partition by the concurrent DML, skip it.
+			if _, ok := found[string(r.newKey)]; ok {
+				continue
+			}
+			// Lock the row to make sure it's not updated or deleted by the concurrent DML before committing.
+			if err = txn.LockKeys(context.Background(), new(kv.LockCtx), r.oldKey); err != nil {
+				return errors.Trace(err)
+			}
+			if err = txn.Set(r.newKey, r.vals); err != nil {
+				return errors.Trace(err)
+			}
+			taskCtx.addedCount++
+		}
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "ReorgPartitionBackfillDataInTxn", 3000)
+
+	return
+}
+
+// getNextKey gets next handle of entry that we are going to process.
+func (*reorgPartitionWorker) getNextKey(taskRange reorgBackfillTask,
+	taskDone bool, lastAccessedHandle kv.Key) (nextHandle kv.Key) {
+	if !taskDone {
+		// The task is not done. So we need to pick the last processed entry's handle and add one.
+		return lastAccessedHandle.Next()
+	}
+
+	return taskRange.endKey.Next()
+}
+
+func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBackfillTask) ([]*reorgPartitionRecord, kv.Key, bool, error) {
+	w.rowRecords = w.rowRecords[:0]
+	startTime := time.Now()
+
+	// taskDone means that the added handle is out of taskRange.endHandle.
+	taskDone := false
+	var lastAccessedHandle kv.Key
+	tblInfo := w.reorgedTbl.Meta()
+	cols := w.reorgedTbl.Cols()
+	err := iterateSnapshotKeys(w.reorgInfo.d.jobContext(w.reorgInfo.Job), w.sessCtx.GetStore(), w.priority, w.table.RecordPrefix(), txn.StartTS(), taskRange.startKey, taskRange.endKey,
+		func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			if taskRange.endInclude {
+				taskDone = recordKey.Cmp(taskRange.endKey) > 0
+			} else {
+				taskDone = recordKey.Cmp(taskRange.endKey) >= 0
+			}
+
+			if taskDone || len(w.rowRecords) >= w.batchCnt {
+				return false, nil
+			}
+
+			row, _, err := tables.DecodeRawRowData(w.sessCtx, tblInfo, handle, cols, rawRow)
+			if err != nil {
+				return false, errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("row", err))
+			}
+			if !tblInfo.PKIsHandle && !tblInfo.IsCommonHandle {
+				row = append(row, types.NewIntDatum(handle.IntValue()))
+			}
+			p, err := w.reorgedTbl.GetPartitionByRow(w.sessCtx, row)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			w.rowRecords = append(w.rowRecords, &reorgPartitionRecord{
+				oldKey: recordKey,
+				newKey: tablecodec.EncodeRecordKey(p.RecordPrefix(), handle),
+				vals:   rawRow,
+			})
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.rowRecords) == 0 {
+		taskDone = true
+	}
+
+	logutil.BgLogger().Debug("[ddl] txn fetches handle info", zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	return w.rowRecords, w.getNextKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
+}
+
 // onTruncateTablePartition truncates old partition meta.
 func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
 	var ver int64
@@ -2338,11 +3052,21 @@ func checkAddPartitionOnTemporaryMode(tbInfo *model.TableInfo) error {
 }
 
 func checkPartitionColumnsUnique(tbInfo *model.TableInfo) error {
-	if len(tbInfo.Partition.Columns) <= 1 {
+	if err := checkColumnsUnique(tbInfo.Partition.Columns); err != nil {
+		return err
+	}
+	if tbInfo.Partition.Sub != nil {
+		return checkColumnsUnique(tbInfo.Partition.Sub.Columns)
+	}
+	return nil
+}
+
+func checkColumnsUnique(columns []model.CIStr) error {
+	if len(columns) <= 1 {
 		return nil
 	}
 	var columnsMap = make(map[string]struct{})
-	for _, col := range tbInfo.Partition.Columns {
+	for _, col := range columns {
 		if _, ok := columnsMap[col.L]; ok {
 			return dbterror.ErrSameNamePartitionField.GenWithStackByArgs(col.L)
 		}
@@ -2398,10 +3122,25 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 		partCols = columnInfoSlice(partColumns)
 	} else if len(s.Partition.ColumnNames) > 0 {
 		partCols = columnNameSlice(s.Partition.ColumnNames)
+	} else if len(tblInfo.Partition.Columns) > 0 {
+		// KEY partitioning without columns takes the primary key or unique key columns.
+		partColumns, err := findPartitionColumnsByNames(tblInfo.Partition.Columns, tblInfo)
+		if err != nil {
+			return err
+		}
+		partCols = columnInfoSlice(partColumns)
 	} else {
 		// TODO: Check keys constraints for list, key partition type and so on.
 		return nil
 	}
+	if tblInfo.Partition.Sub != nil {
+		// The subpartitioning columns are part of the partitioning key as well.
+		subCols, err := getSubPartitionColumns(tblInfo.Partition.Sub, tblInfo)
+		if err != nil {
+			return err
+		}
+		partCols = multiStringSlice{partCols, columnInfoSlice(subCols)}
+	}
 
 	// Checks that the partitioning key is included in the constraint.
 	// Every unique key on the table must use every column in the table's partitioning expression.
@@ -2443,15 +3182,18 @@ func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model
 			return false, err
 		}
 	} else {
-		partCols = make([]*model.ColumnInfo, 0, len(pi.Columns))
-		for _, col := range pi.Columns {
-			colInfo := tblInfo.FindPublicColumnByName(col.L)
-			if colInfo == nil {
-				return false, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
-			}
-			partCols = append(partCols, colInfo)
+		partCols, err = findPartitionColumnsByNames(pi.Columns, tblInfo)
+		if err != nil {
+			return false, err
 		}
 	}
+	if pi.Sub != nil {
+		subCols, err := getSubPartitionColumns(pi.Sub, tblInfo)
+		if err != nil {
+			return false, err
+		}
+		partCols = append(partCols, subCols...)
+	}
 
 	// In MySQL, every unique key on the table must use every column in the table's partitioning expression.(This
 	// also includes the table's primary key.)
@@ -2512,6 +3254,26 @@ func extractPartitionColumns(partExpr string, tblInfo *model.TableInfo) ([]*mode
 	return extractor.extractedColumns, nil
 }
 
+func findPartitionColumnsByNames(names []model.CIStr, tblInfo *model.TableInfo) ([]*model.ColumnInfo, error) {
+	partCols := make([]*model.ColumnInfo, 0, len(names))
+	for _, col := range names {
+		colInfo := tblInfo.FindPublicColumnByName(col.L)
+		if colInfo == nil {
+			return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
+		}
+		partCols = append(partCols, colInfo)
+	}
+	return partCols, nil
+}
+
+// getSubPartitionColumns returns the columns used by the HASH expression or the KEY of the subpartitions.
+func getSubPartitionColumns(si *model.SubPartitionInfo, tblInfo *model.TableInfo) ([]*model.ColumnInfo, error) {
+	if si.Expr != "" {
+		return extractPartitionColumns(si.Expr, tblInfo)
+	}
+	return findPartitionColumnsByNames(si.Columns, tblInfo)
+}
+
 // stringSlice is defined for checkUniqueKeyIncludePartKey.
 // if Go supports covariance, the code shouldn't be so complex.
 type stringSlice interface {
@@ -2547,6 +3309,27 @@ func (cis columnInfoSlice) At(i int) string {
 	return cis[i].Name.L
 }
 
+// multiStringSlice implements the stringSlice interface by concatenating the slices.
+type multiStringSlice []stringSlice
+
+func (mss multiStringSlice) Len() int {
+	l := 0
+	for _, ss := range mss {
+		l += ss.Len()
+	}
+	return l
+}
+
+func (mss multiStringSlice) At(i int) string {
+	for _, ss := range mss {
+		if i < ss.Len() {
+			return ss.At(i)
+		}
+		i -= ss.Len()
+	}
+	return ""
+}
+
 // columnNameSlice implements the stringSlice interface.
 type columnNameSlice []*ast.ColumnName
 
@@ -2811,7 +3594,9 @@ func hexIfNonPrint(s string) string {
 // as well as needed for generating the ADD PARTITION query for INTERVAL partitioning of ALTER TABLE t LAST PARTITION
 // and generating the CREATE TABLE query from CREATE TABLE ... INTERVAL
 func AppendPartitionDefs(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
-	for i, def := range partitionInfo.Definitions {
+	num := partitionInfo.SubPartitionNum()
+	showSubPartitions := partitionInfo.Sub != nil && !hasDefaultSubPartitionDefinitions(partitionInfo)
+	for i, def := range partitionInfo.ParentDefinitions(partitionInfo.Definitions) {
 		if i > 0 {
 			fmt.Fprintf(buf, ",\n ")
 		}
@@ -2843,12 +3628,42 @@ func AppendPartitionDefs(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 			}
 			fmt.Fprintf(buf, " VALUES IN (%s)", values.String())
 		}
-		if len(def.Comment) > 0 {
-			buf.WriteString(fmt.Sprintf(" COMMENT '%s'", format.OutputFormat(def.Comment)))
+		appendPartitionDefOptions(&def, buf, sqlMode)
+		if showSubPartitions {
+			buf.WriteString("\n (")
+			for j, subDef := range partitionInfo.Definitions[i*num : (i+1)*num] {
+				if j > 0 {
+					buf.WriteString(",\n  ")
+				}
+				fmt.Fprintf(buf, "SUBPARTITION %s", stringutil.Escape(subDef.Name.O, sqlMode))
+				appendPartitionDefOptions(&subDef, buf, sqlMode)
+			}
+			buf.WriteString(")")
+		}
+	}
+}
+
+func appendPartitionDefOptions(def *model.PartitionDefinition, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	if len(def.Comment) > 0 {
+		buf.WriteString(fmt.Sprintf(" COMMENT '%s'", format.OutputFormat(def.Comment)))
+	}
+	if def.PlacementPolicyRef != nil {
+		// add placement ref info here
+		fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+	}
+}
+
+// hasDefaultSubPartitionDefinitions returns whether all the subpartitions have the default names and no options,
+// so they can be omitted from the partition definitions.
+func hasDefaultSubPartitionDefinitions(pi *model.PartitionInfo) bool {
+	num := pi.SubPartitionNum()
+	for i, def := range pi.Definitions {
+		if def.Name.L != strings.ToLower(fmt.Sprintf("%ssp%d", def.ParentName.O, i%num)) {
+			return false
 		}
-		if def.PlacementPolicyRef != nil {
-			// add placement ref info here
-			fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+		if len(def.Comment) > 0 || def.PlacementPolicyRef != nil {
+			return false
 		}
 	}
+	return true
 }
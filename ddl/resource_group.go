@@ -0,0 +1,233 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onCreateResourceGroup(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	groupInfo := &model.ResourceGroupInfo{}
+	if err := job.DecodeArgs(groupInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	groupInfo.State = model.StateNone
+
+	if err := checkResourceGroupValidation(groupInfo.ResourceGroupSettings); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	existGroup, err := getResourceGroupByName(d, t, groupInfo.Name)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if existGroup != nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrResourceGroupExists.GenWithStackByArgs(existGroup.Name)
+	}
+
+	switch groupInfo.State {
+	case model.StateNone:
+		// none -> public
+		groupInfo.State = model.StatePublic
+		err = t.AddResourceGroup(groupInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaID = groupInfo.ID
+
+		ver, err = updateSchemaVersion(d, t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+		return ver, nil
+	default:
+		return ver, dbterror.ErrInvalidDDLState.GenWithStackByArgs("resource_group", groupInfo.State)
+	}
+}
+
+func onAlterResourceGroup(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	alterGroupInfo := &model.ResourceGroupInfo{}
+	if err := job.DecodeArgs(alterGroupInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	oldGroup, err := checkResourceGroupExist(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	newGroup := *oldGroup
+	newGroup.ResourceGroupSettings = alterGroupInfo.ResourceGroupSettings
+	if err = checkResourceGroupValidation(newGroup.ResourceGroupSettings); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if err = t.UpdateResourceGroup(&newGroup); err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+	return ver, nil
+}
+
+func onDropResourceGroup(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	groupInfo, err := checkResourceGroupExist(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	switch groupInfo.State {
+	case model.StatePublic:
+		// public -> none
+		// A resource group only limits the requests of the sessions, so it can be removed in one step.
+		if err = t.DropResourceGroup(groupInfo.ID); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateSchemaVersion(d, t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishDBJob(model.JobStateDone, model.StateNone, ver, nil)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("resource_group", groupInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+func checkResourceGroupExist(t *meta.Meta, job *model.Job, groupID int64) (*model.ResourceGroupInfo, error) {
+	groupInfo, err := t.GetResourceGroup(groupID)
+	if err == nil {
+		return groupInfo, nil
+	}
+	if meta.ErrResourceGroupNotExists.Equal(err) {
+		job.State = model.JobStateCancelled
+		return nil, infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(fmt.Sprintf("(Group ID %d)", groupID))
+	}
+	return nil, err
+}
+
+func getResourceGroupByName(d *ddlCtx, t *meta.Meta, groupName model.CIStr) (*model.ResourceGroupInfo, error) {
+	currVer, err := t.GetSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	is := d.infoCache.GetLatest()
+	if is.SchemaMetaVersion() == currVer {
+		// Use cached group.
+		group, ok := is.ResourceGroupByName(groupName)
+		if ok {
+			return group, nil
+		}
+		return nil, nil
+	}
+	// Check in meta directly.
+	groups, err := t.ListResourceGroups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, group := range groups {
+		if group.Name.L == groupName.L {
+			return group, nil
+		}
+	}
+	return nil, nil
+}
+
+func checkResourceGroupValidation(settings *model.ResourceGroupSettings) error {
+	if settings.RURate == 0 {
+		return dbterror.ErrInvalidResourceGroup.GenWithStackByArgs("RU_PER_SEC must be specified and greater than 0")
+	}
+	if runaway := settings.Runaway; runaway != nil {
+		if runaway.ExecElapsed <= 0 {
+			return dbterror.ErrInvalidResourceGroup.GenWithStackByArgs("EXEC_ELAPSED must be specified and greater than 0 in QUERY_LIMIT")
+		}
+		if runaway.Action == model.RunawayActionNone {
+			return dbterror.ErrInvalidResourceGroup.GenWithStackByArgs("ACTION must be specified in QUERY_LIMIT")
+		}
+		if runaway.WatchType != model.RunawayWatchNone && runaway.WatchDuration <= 0 {
+			return dbterror.ErrInvalidResourceGroup.GenWithStackByArgs("DURATION of WATCH must be greater than 0")
+		}
+	}
+	return nil
+}
+
+func buildResourceGroup(name model.CIStr, options []*ast.ResourceGroupOption) (*model.ResourceGroupInfo, error) {
+	groupInfo := &model.ResourceGroupInfo{
+		Name:                  name,
+		ResourceGroupSettings: &model.ResourceGroupSettings{},
+	}
+	for _, opt := range options {
+		switch opt.Tp {
+		case ast.ResourceRURate:
+			groupInfo.RURate = opt.UintValue
+		case ast.ResourceBurstable:
+			groupInfo.Burstable = opt.BoolValue
+		case ast.ResourceRunaway:
+			runaway, err := buildRunawaySettings(opt.RunawayOptionList)
+			if err != nil {
+				return nil, err
+			}
+			groupInfo.Runaway = runaway
+		default:
+			return nil, dbterror.ErrInvalidResourceGroup.GenWithStackByArgs("unknown resource group option")
+		}
+	}
+	return groupInfo, nil
+}
+
+func buildRunawaySettings(options []*ast.ResourceGroupRunawayOption) (*model.ResourceGroupRunawaySettings, error) {
+	settings := &model.ResourceGroupRunawaySettings{}
+	for _, opt := range options {
+		var err error
+		switch opt.Tp {
+		case ast.RunawayExecElapsed:
+			settings.ExecElapsed, err = time.ParseDuration(opt.StrValue)
+		case ast.RunawayAction:
+			settings.Action = opt.ActionType
+		case ast.RunawayWatch:
+			settings.WatchType = opt.WatchType
+			settings.WatchDuration, err = time.ParseDuration(opt.StrValue)
+		default:
+			return nil, dbterror.ErrInvalidResourceGroup.GenWithStackByArgs("unknown QUERY_LIMIT option")
+		}
+		if err != nil {
+			return nil, dbterror.ErrInvalidResourceGroup.GenWithStackByArgs(fmt.Sprintf("invalid duration '%s' in QUERY_LIMIT", opt.StrValue))
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceGroupBasic(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create resource group x ru_per_sec=1000", errno.ErrResourceGroupSupportDisabled)
+	tk.MustExec("set global tidb_enable_resource_control = 'on'")
+	defer tk.MustExec("set global tidb_enable_resource_control = default")
+
+	tk.MustExec("create resource group x ru_per_sec=1000")
+	group, ok := dom.InfoSchema().ResourceGroupByName(model.NewCIStr("x"))
+	require.True(t, ok)
+	require.Equal(t, uint64(1000), group.RURate)
+	require.False(t, group.Burstable)
+	require.Equal(t, model.StatePublic, group.State)
+	require.NotZero(t, group.ID)
+
+	tk.MustGetErrCode("create resource group x ru_per_sec=1000", errno.ErrResourceGroupExists)
+	tk.MustExec("create resource group if not exists x ru_per_sec=1000")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 8248 Resource group 'x' already exists"))
+	tk.MustGetErrCode("create resource group y burstable", errno.ErrInvalidResourceGroup)
+
+	tk.MustExec("alter resource group x ru_per_sec=2000 burstable")
+	group, ok = dom.InfoSchema().ResourceGroupByName(model.NewCIStr("x"))
+	require.True(t, ok)
+	require.Equal(t, uint64(2000), group.RURate)
+	require.True(t, group.Burstable)
+	tk.MustGetErrCode("alter resource group y ru_per_sec=2000", errno.ErrResourceGroupNotExists)
+	tk.MustExec("alter resource group if exists y ru_per_sec=2000")
+
+	tk.MustExec("create resource group y ru_per_sec=500")
+	tk.MustQuery("select name, ru_per_sec, burstable from information_schema.resource_groups order by name").
+		Check(testkit.Rows("x 2000 YES", "y 500 NO"))
+
+	// Bind users to the resource groups.
+	tk.MustGetErrCode("create user u1 resource group z", errno.ErrResourceGroupNotExists)
+	tk.MustExec("create user u1 resource group x")
+	tk.MustQuery("select json_extract(user_attributes, '$.resource_group') from mysql.user where user = 'u1'").Check(testkit.Rows(`"x"`))
+	tk.MustExec("alter user u1 resource group y")
+	tk.MustQuery("select json_extract(user_attributes, '$.resource_group') from mysql.user where user = 'u1'").Check(testkit.Rows(`"y"`))
+	tk.MustExec("alter user u1 resource group default")
+	tk.MustQuery("select json_extract(user_attributes, '$.resource_group') from mysql.user where user = 'u1'").Check(testkit.Rows("<nil>"))
+	tk.MustExec("drop user u1")
+
+	tk.MustExec("drop resource group x")
+	_, ok = dom.InfoSchema().ResourceGroupByName(model.NewCIStr("x"))
+	require.False(t, ok)
+	tk.MustGetErrCode("drop resource group x", errno.ErrResourceGroupNotExists)
+	tk.MustExec("drop resource group if exists x")
+	tk.MustQuery("select name from information_schema.resource_groups").Check(testkit.Rows("y"))
+}
+
+func TestResourceGroupRunaway(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set global tidb_enable_resource_control = 'on'")
+	defer tk.MustExec("set global tidb_enable_resource_control = default")
+
+	tk.MustExec("create resource group x ru_per_sec=1000 query_limit=(exec_elapsed='60s', action=kill, watch=similar duration='10m')")
+	group, ok := dom.InfoSchema().ResourceGroupByName(model.NewCIStr("x"))
+	require.True(t, ok)
+	require.Equal(t, &model.ResourceGroupRunawaySettings{
+		ExecElapsed:   time.Minute,
+		Action:        model.RunawayActionKill,
+		WatchType:     model.RunawayWatchSimilar,
+		WatchDuration: 10 * time.Minute,
+	}, group.Runaway)
+	tk.MustQuery("select name, query_limit from information_schema.resource_groups").
+		Check(testkit.Rows(`x EXEC_ELAPSED="1m0s" ACTION=KILL WATCH=SIMILAR DURATION="10m0s"`))
+
+	tk.MustExec("alter resource group x ru_per_sec=1000 query_limit=(exec_elapsed='1s' action=cooldown)")
+	tk.MustQuery("select name, query_limit from information_schema.resource_groups").
+		Check(testkit.Rows(`x EXEC_ELAPSED="1s" ACTION=COOLDOWN`))
+	tk.MustExec("alter resource group x ru_per_sec=1000")
+	tk.MustQuery("select name, query_limit from information_schema.resource_groups").Check(testkit.Rows("x <nil>"))
+
+	tk.MustGetErrCode("alter resource group x ru_per_sec=1000 query_limit=(exec_elapsed='1x', action=kill)", errno.ErrInvalidResourceGroup)
+	tk.MustGetErrCode("alter resource group x ru_per_sec=1000 query_limit=(action=kill)", errno.ErrInvalidResourceGroup)
+	tk.MustGetErrCode("alter resource group x ru_per_sec=1000 query_limit=(exec_elapsed='1s')", errno.ErrInvalidResourceGroup)
+	tk.MustGetErrCode("alter resource group x ru_per_sec=1000 query_limit=(exec_elapsed='1s', action=kill, watch=plan duration='0s')", errno.ErrInvalidResourceGroup)
+
+	// The RESOURCE_GROUP hint binds the query to the group.
+	tk.MustExec("alter resource group x ru_per_sec=1000 query_limit=(exec_elapsed='60s', action=dryrun, watch=plan duration='1m')")
+	tk.MustQuery("select /*+ resource_group(x) */ 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select /*+ resource_group(x) resource_group(y) */ 1").Check(testkit.Rows("1"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1105 RESOURCE_GROUP() is defined more than once, only the last definition takes effect: RESOURCE_GROUP(y)",
+		"Warning 8249 Unknown resource group 'y'"))
+	tk.MustQuery("select count(*) from mysql.tidb_runaway_queries").Check(testkit.Rows("0"))
+}
+
+func TestResourceGroupHint(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create resource group x ru_per_sec=1000")
+	tk.MustExec("create resource group y ru_per_sec=1000")
+	tk.MustExec("create user u1 resource group x")
+	tk.MustExec("create user u2")
+	tk.MustExec("grant resource_group_admin on *.* to u2")
+
+	// The hint naming a group which doesn't exist is ignored.
+	tk.MustQuery("select /*+ resource_group(z) */ 1").Check(testkit.Rows("1"))
+	require.False(t, tk.Session().GetSessionVars().StmtCtx.HasResourceGroup)
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8249 Unknown resource group 'z'"))
+
+	// Switching to another group needs the RESOURCE_GROUP_ADMIN privilege, the bound group is used instead.
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil))
+	tk1.MustQuery("select /*+ resource_group(x) */ 1").Check(testkit.Rows("1"))
+	require.True(t, tk1.Session().GetSessionVars().StmtCtx.HasResourceGroup)
+	tk1.MustQuery("select /*+ resource_group(y) */ 1").Check(testkit.Rows("1"))
+	require.False(t, tk1.Session().GetSessionVars().StmtCtx.HasResourceGroup)
+	require.Equal(t, "x", tk1.Session().GetSessionVars().ResourceGroupName)
+	tk1.MustQuery("show warnings").Check(testkit.Rows("Warning 1227 Access denied; you need (at least one of) the SUPER or RESOURCE_GROUP_ADMIN privilege(s) for this operation"))
+
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil))
+	tk2.MustQuery("select /*+ resource_group(y) */ 1").Check(testkit.Rows("1"))
+	require.True(t, tk2.Session().GetSessionVars().StmtCtx.HasResourceGroup)
+	tk2.MustQuery("show warnings").Check(testkit.Rows())
+}
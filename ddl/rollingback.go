@@ -152,7 +152,7 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 }
 
 func rollingbackAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
-	tblInfo, columnInfo, col, _, _, err := checkAddColumn(t, job)
+	tblInfo, columnInfo, col, _, constraints, _, err := checkAddColumn(t, job)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -160,6 +160,10 @@ func rollingbackAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, e
 		job.State = model.JobStateCancelled
 		return ver, dbterror.ErrCancelledDDLJob
 	}
+	if columnInfo.State == model.StatePublic {
+		// The column is public and its check constraints are being added.
+		return rollbackAddColumnWithCheckConstraints(d, t, job, tblInfo, columnInfo, constraints, dbterror.ErrCancelledDDLJob)
+	}
 
 	originalState := columnInfo.State
 	columnInfo.State = model.StateDeleteOnly
@@ -207,6 +211,44 @@ func rollingbackDropColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	return ver, nil
 }
 
+func rollingbackAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	constraintInfoInJob := &model.ConstraintInfo{}
+	if err = job.DecodeArgs(constraintInfoInJob); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintInfoInJob.Name.L)
+	if constraintInfo == nil || constraintInfo.State == model.StatePublic {
+		// The job isn't handled yet.
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	return rollbackAddCheckConstraint(d, t, job, tblInfo, constraintInfo, dbterror.ErrCancelledDDLJob)
+}
+
+func rollingbackAlterCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	var constrName model.CIStr
+	if err = job.DecodeArgs(&constrName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil || constraintInfo.State == model.StatePublic {
+		// The job isn't handled yet.
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	return rollbackAlterCheckConstraint(d, t, job, tblInfo, constraintInfo, dbterror.ErrCancelledDDLJob)
+}
+
 func rollingbackDropIndex(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	_, indexInfo, _, err := checkDropIndex(d, t, job)
 	if err != nil {
@@ -355,6 +397,27 @@ func rollingbackTruncateTable(t *meta.Meta, job *model.Job) (ver int64, err erro
 	return cancelOnlyNotHandledJob(job, model.StateNone)
 }
 
+func rollingbackReorganizePartition(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	switch job.SchemaState {
+	case model.StateNone:
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	case model.StateDeleteReorganization:
+		// The new partitions are public, it's too late to rollback.
+		// Normally won't fetch here, because there is check when cancel ddl jobs. see function: isJobRollbackable.
+		job.State = model.JobStateRunning
+		return ver, nil
+	}
+	if needNotifyAndStopReorgWorker(job) {
+		// The reorg workers are started, need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		d.notifyReorgCancel(job)
+		return w.onReorganizePartition(d, t, job)
+	}
+	job.State = model.JobStateRollingback
+	return ver, dbterror.ErrCancelledDDLJob
+}
+
 func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	switch job.Type {
 	case model.ActionAddColumn:
@@ -373,6 +436,8 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		err = rollingbackDropTableOrView(t, job)
 	case model.ActionDropTablePartition:
 		ver, err = rollingbackDropTablePartition(t, job)
+	case model.ActionReorganizePartition:
+		ver, err = rollingbackReorganizePartition(w, d, t, job)
 	case model.ActionDropSchema:
 		err = rollingbackDropSchema(t, job)
 	case model.ActionRenameIndex:
@@ -381,8 +446,12 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackTruncateTable(t, job)
 	case model.ActionModifyColumn:
 		ver, err = rollingbackModifyColumn(w, d, t, job)
-	case model.ActionDropForeignKey:
+	case model.ActionDropForeignKey, model.ActionDropCheckConstraint:
 		ver, err = cancelOnlyNotHandledJob(job, model.StatePublic)
+	case model.ActionAddCheckConstraint:
+		ver, err = rollingbackAddCheckConstraint(d, t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = rollingbackAlterCheckConstraint(d, t, job)
 	case model.ActionRebaseAutoID, model.ActionShardRowID, model.ActionAddForeignKey,
 		model.ActionRenameTable, model.ActionRenameTables,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
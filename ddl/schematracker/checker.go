@@ -432,6 +432,21 @@ func (d Checker) AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPla
 	panic("implement me")
 }
 
+// CreateResourceGroup implements the DDL interface.
+func (d Checker) CreateResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error {
+	return d.realDDL.CreateResourceGroup(ctx, stmt)
+}
+
+// AlterResourceGroup implements the DDL interface.
+func (d Checker) AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error {
+	return d.realDDL.AlterResourceGroup(ctx, stmt)
+}
+
+// DropResourceGroup implements the DDL interface.
+func (d Checker) DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error {
+	return d.realDDL.DropResourceGroup(ctx, stmt)
+}
+
 // CreateSchemaWithInfo implements the DDL interface.
 func (d Checker) CreateSchemaWithInfo(ctx sessionctx.Context, info *model.DBInfo, onExist ddl.OnExist) error {
 	err := d.realDDL.CreateSchemaWithInfo(ctx, info, onExist)
@@ -0,0 +1,209 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onTTLInfoRemove(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
+
+	tblInfo.TTLInfo = nil
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// at least one of them is not nil
+	var ttlInfo *model.TTLInfo
+	var ttlInfoEnable *bool
+
+	if err := job.DecodeArgs(&ttlInfo, &ttlInfoEnable); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if ttlInfo != nil {
+		// if the TTL_ENABLE is not set explicitly, use the original value
+		if ttlInfoEnable == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.Enable = tblInfo.TTLInfo.Enable
+		}
+		tblInfo.TTLInfo = ttlInfo
+	}
+	if ttlInfoEnable != nil {
+		if tblInfo.TTLInfo == nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ENABLE"))
+		}
+		tblInfo.TTLInfo.Enable = *ttlInfoEnable
+	}
+
+	if err = checkTTLInfoColumnType(tblInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
+
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func checkTTLInfoValid(ctx sessionctx.Context, tblInfo *model.TableInfo) error {
+	if err := checkTTLIntervalExpr(ctx, tblInfo.TTLInfo); err != nil {
+		return err
+	}
+
+	if err := checkTTLTableSuitable(tblInfo); err != nil {
+		return err
+	}
+
+	return checkTTLInfoColumnType(tblInfo)
+}
+
+// checkTTLIntervalExpr evaluates `NOW() + INTERVAL <expr> <unit>` to make sure the interval expression is valid.
+func checkTTLIntervalExpr(ctx sessionctx.Context, ttlInfo *model.TTLInfo) error {
+	unit := ast.TimeUnitType(ttlInfo.IntervalTimeUnit)
+	expr := fmt.Sprintf("select NOW() + INTERVAL %s %s", ttlInfo.IntervalExprStr, unit.String())
+	stmts, _, err := parser.New().ParseSQL(expr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	nowAddIntervalExpr := stmts[0].(*ast.SelectStmt).Fields.Fields[0].Expr
+	_, err = expression.EvalAstExpr(ctx, nowAddIntervalExpr)
+	return errors.Trace(err)
+}
+
+func checkTTLTableSuitable(tblInfo *model.TableInfo) error {
+	if tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTempTableNotAllowedWithTTL
+	}
+	return nil
+}
+
+func checkTTLInfoColumnType(tblInfo *model.TableInfo) error {
+	if tblInfo.TTLInfo == nil {
+		return nil
+	}
+
+	colName := tblInfo.TTLInfo.ColumnName.L
+	tableCol := model.FindColumnInfo(tblInfo.Columns, colName)
+	if tableCol == nil {
+		return errors.Trace(dbterror.ErrBadField.GenWithStackByArgs(colName, "TTL config"))
+	}
+	if !isTTLSupportedColumnType(tableCol.GetType()) {
+		return errors.Trace(dbterror.ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(tableCol.Name.O))
+	}
+	return nil
+}
+
+func isTTLSupportedColumnType(tp byte) bool {
+	switch tp {
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		return true
+	}
+	return false
+}
+
+// checkModifyColumnWithTTLConfig checks that the TTL column is still a time column after it is modified.
+func checkModifyColumnWithTTLConfig(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) error {
+	if tblInfo.TTLInfo == nil || tblInfo.TTLInfo.ColumnName.L != oldCol.Name.L {
+		return nil
+	}
+	if !isTTLSupportedColumnType(newCol.GetType()) {
+		return errors.Trace(dbterror.ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(newCol.Name.O))
+	}
+	return nil
+}
+
+// updateTTLInfoWhenModifyColumn renames the TTL column in the TTL config if the column is renamed.
+func updateTTLInfoWhenModifyColumn(tblInfo *model.TableInfo, oldCol, newCol model.CIStr) {
+	if tblInfo.TTLInfo == nil || oldCol.L == newCol.L {
+		return
+	}
+	if tblInfo.TTLInfo.ColumnName.L == oldCol.L {
+		tblInfo.TTLInfo.ColumnName = newCol
+	}
+}
+
+// getTTLInfoInOptions returns the aggregated ttlInfo and ttlInfoEnable. If the TTL_ENABLE is not set explicitly,
+// `ttlInfoEnable` will be nil. The returned `ttlInfo` is nil if the TTL option is not set.
+func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlInfoEnable *bool, err error) {
+	for _, op := range options {
+		switch op.Tp {
+		case ast.TableOptionTTL:
+			var sb strings.Builder
+			restoreFlags := format.RestoreStringSingleQuotes | format.RestoreNameBackQuotes
+			restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+			err := op.Value.Restore(restoreCtx)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			intervalExpr := sb.String()
+			ttlInfo = &model.TTLInfo{
+				ColumnName:       op.ColumnName.Name,
+				IntervalExprStr:  intervalExpr,
+				IntervalTimeUnit: int(op.TimeUnitValue.Unit),
+				Enable:           true,
+			}
+		case ast.TableOptionTTLEnable:
+			ttlEnable := op.BoolValue
+			ttlInfoEnable = &ttlEnable
+		}
+	}
+
+	if ttlInfo != nil && ttlInfoEnable != nil {
+		ttlInfo.Enable = *ttlInfoEnable
+	}
+	return ttlInfo, ttlInfoEnable, nil
+}
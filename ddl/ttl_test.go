@@ -0,0 +1,92 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestTTLTableOptions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, created_at datetime) ttl = `created_at` + interval 5 year")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 5 YEAR */ /*T![ttl] TTL_ENABLE='ON' */"))
+
+	tk.MustExec("alter table t ttl_enable = 'OFF'")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 5 YEAR */ /*T![ttl] TTL_ENABLE='OFF' */"))
+
+	// changing the TTL config keeps the TTL_ENABLE
+	tk.MustExec("alter table t ttl = `created_at` + interval 1 day")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */"))
+
+	// renaming the TTL column also renames it in the TTL config
+	tk.MustExec("alter table t rename column created_at to create_time")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `create_time` datetime DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`create_time` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */"))
+
+	tk.MustGetErrCode("alter table t drop column create_time", errno.ErrTTLColumnCannotDrop)
+	tk.MustGetErrCode("alter table t modify column create_time int", errno.ErrUnsupportedColumnInTTLConfig)
+
+	tk.MustExec("alter table t remove ttl")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `create_time` datetime DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustGetErrCode("alter table t ttl_enable = 'ON'", errno.ErrSetTTLOptionForNonTTLTable)
+	tk.MustExec("alter table t drop column create_time")
+}
+
+func TestTTLTableOptionsInvalid(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (id int, created_at int) ttl = `created_at` + interval 1 day", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl = `id` + interval 1 day", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl = `unknown` + interval 1 day", errno.ErrBadField)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl_enable = 'ON'", errno.ErrSetTTLOptionForNonTTLTable)
+	tk.MustGetErrCode("create temporary table t (id int, created_at datetime) ttl = `created_at` + interval 1 day", errno.ErrTempTableNotAllowedWithTTL)
+	tk.MustGetErrCode("create global temporary table t (id int, created_at datetime) ttl = `created_at` + interval 1 day on commit delete rows", errno.ErrTempTableNotAllowedWithTTL)
+
+	tk.MustExec("create table t (id int, created_at datetime)")
+	tk.MustGetErrCode("alter table t ttl = `id` + interval 1 day", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustGetErrCode("alter table t ttl_enable = 'OFF'", errno.ErrSetTTLOptionForNonTTLTable)
+	tk.MustExec("alter table t ttl = `created_at` + interval 1 day ttl_enable = 'OFF'")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */"))
+}
@@ -231,6 +231,10 @@ func (builder *RequestBuilder) getIsolationLevel() kv.IsoLevel {
 }
 
 func (*RequestBuilder) getKVPriority(sv *variable.SessionVars) int {
+	// The runaway queries are cooled down by sending the later requests with the low priority.
+	if sv.StmtCtx.RunawayChecker.ShouldCooldown() {
+		return kv.PriorityLow
+	}
 	switch sv.StmtCtx.Priority {
 	case mysql.NoPriority, mysql.DelayedPriority:
 		return kv.PriorityNormal
@@ -263,6 +267,7 @@ func (builder *RequestBuilder) SetFromSessionVars(sv *variable.SessionVars) *Req
 	builder.Request.Priority = builder.getKVPriority(sv)
 	builder.Request.ReplicaRead = replicaReadType
 	builder.SetResourceGroupTagger(sv.StmtCtx.GetResourceGroupTagger())
+	builder.SetResourceGroupName(sv.GetResourceGroupName())
 	{
 		builder.SetPaging(sv.EnablePaging)
 		builder.Request.Paging.MinPagingSize = uint64(sv.MinPagingSize)
@@ -312,6 +317,12 @@ func (builder *RequestBuilder) SetResourceGroupTagger(tagger tikvrpc.ResourceGro
 	return builder
 }
 
+// SetResourceGroupName sets the resource group name of the request.
+func (builder *RequestBuilder) SetResourceGroupName(name string) *RequestBuilder {
+	builder.Request.ResourceGroupName = name
+	return builder
+}
+
 func (builder *RequestBuilder) verifyTxnScope() error {
 	txnScope := builder.TxnScope
 	if txnScope == "" || txnScope == kv.GlobalReplicaScope || builder.is == nil {
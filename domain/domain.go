@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net"
 	"strconv"
 	"strings"
 	"sync"
@@ -57,6 +58,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics/handle"
 	"github.com/pingcap/tidb/telemetry"
+	"github.com/pingcap/tidb/ttl"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/dbterror"
@@ -65,6 +67,7 @@ import (
 	"github.com/pingcap/tidb/util/expensivequery"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/memoryusagealarm"
+	"github.com/pingcap/tidb/util/resourcegroup"
 	"github.com/pingcap/tidb/util/servermemorylimit"
 	"github.com/pingcap/tidb/util/sqlexec"
 	"github.com/tikv/client-go/v2/txnkv/transaction"
@@ -201,7 +204,12 @@ func (do *Domain) loadInfoSchema(startTS uint64) (infoschema.InfoSchema, bool, i
 		return nil, false, currentSchemaVersion, nil, err
 	}
 
-	newISBuilder, err := infoschema.NewBuilder(do.Store(), do.sysFacHack).InitWithDBInfos(schemas, policies, neededSchemaVersion)
+	resourceGroups, err := do.fetchResourceGroups(m)
+	if err != nil {
+		return nil, false, currentSchemaVersion, nil, err
+	}
+
+	newISBuilder, err := infoschema.NewBuilder(do.Store(), do.sysFacHack).InitWithDBInfos(schemas, policies, resourceGroups, neededSchemaVersion)
 	if err != nil {
 		return nil, false, currentSchemaVersion, nil, err
 	}
@@ -233,6 +241,14 @@ func (do *Domain) fetchPolicies(m *meta.Meta) ([]*model.PolicyInfo, error) {
 	return allPolicies, nil
 }
 
+func (do *Domain) fetchResourceGroups(m *meta.Meta) ([]*model.ResourceGroupInfo, error) {
+	allResourceGroups, err := m.ListResourceGroups()
+	if err != nil {
+		return nil, err
+	}
+	return allResourceGroups, nil
+}
+
 func (do *Domain) fetchAllSchemasWithTables(m *meta.Meta) ([]*model.DBInfo, error) {
 	allSchemas, err := m.ListDatabases()
 	if err != nil {
@@ -475,6 +491,7 @@ func (do *Domain) Reload() error {
 			logutil.BgLogger().Info("full load and reset schema validator")
 			do.SchemaValidator.Reset()
 		}
+		resourcegroup.GlobalController().UpdateGroups(is.AllResourceGroups())
 	}
 
 	// lease renew, so it must be executed despite it is cache or not
@@ -1911,6 +1928,73 @@ func (do *Domain) LoadSigningCertLoop() {
 	}()
 }
 
+// StartTTLJobManager creates and starts the ttl job manager, which deletes the expired rows of TTL tables
+// in background. It should be called only once in BootstrapSession.
+func (do *Domain) StartTTLJobManager() {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("ttlJobManager exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "ttlJobManager", nil, false)
+		ownerManager := do.newOwnerManager(ttl.Prompt, ttl.OwnerKey)
+		jobManager := ttl.NewJobManager(do.ddl.GetID(), do.sysSessionPool, ownerManager, do.InfoSchema)
+		jobManager.Run(do.exit)
+	})
+}
+
+// runawayRecordFlushInterval is the interval to flush the records of the runaway queries.
+const runawayRecordFlushInterval = 5 * time.Second
+
+// RunawayRecordFlushLoop flushes the records of the runaway queries to mysql.tidb_runaway_queries in background.
+// It should be called only once in BootstrapSession.
+func (do *Domain) RunawayRecordFlushLoop() {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("runawayRecordFlushLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "runawayRecordFlushLoop", nil, false)
+		ticker := time.NewTicker(runawayRecordFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				records := resourcegroup.GlobalRunawayManager().TakeRecords()
+				if len(records) == 0 {
+					continue
+				}
+				if err := do.flushRunawayRecords(records); err != nil {
+					logutil.BgLogger().Warn("flush runaway records failed", zap.Int("count", len(records)), zap.Error(err))
+				}
+			case <-do.exit:
+				return
+			}
+		}
+	})
+}
+
+func (do *Domain) flushRunawayRecords(records []*resourcegroup.RunawayRecord) error {
+	se, err := do.sysSessionPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer do.sysSessionPool.Put(se)
+	cfg := config.GetGlobalConfig()
+	server := net.JoinHostPort(cfg.AdvertiseAddress, strconv.Itoa(int(cfg.Port)))
+	var sql strings.Builder
+	sql.WriteString("INSERT INTO mysql.tidb_runaway_queries VALUES ")
+	for i, r := range records {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sqlexec.MustFormatSQL(&sql, "(%?, %?, %?, %?, %?, %?, %?, %?)",
+			r.ResourceGroupName, r.Time, r.Match, r.Action, r.SQLText, r.SQLDigest, r.PlanDigest, server)
+	}
+	exec := se.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, sql.String())
+	return errors.Trace(err)
+}
+
 // ServerID gets serverID.
 func (do *Domain) ServerID() uint64 {
 	return atomic.LoadUint64(&do.serverID)
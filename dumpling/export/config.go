@@ -75,6 +75,10 @@ const (
 	flagReadTimeout              = "read-timeout"
 	flagTransactionalConsistency = "transactional-consistency"
 	flagCompress                 = "compress"
+	flagCrypterMethod            = "crypter.method"
+	flagCrypterKey               = "crypter.key"
+	flagCrypterKeyFile           = "crypter.key-file"
+	flagCrypterMasterKey         = "crypter.master-key"
 
 	// FlagHelp represents the help flag
 	FlagHelp = "help"
@@ -98,6 +102,7 @@ type Config struct {
 	DumpEmptyDatabase        bool
 	PosAfterConnect          bool
 	CompressType             storage.CompressType
+	Encryption               storage.EncryptionConfig
 
 	Host     string
 	Port     int
@@ -275,7 +280,7 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 		"If not specified, dumpling will dump table without inner-concurrency which could be relatively slow. default unlimited")
 	flags.String(flagWhere, "", "Dump only selected records")
 	flags.Bool(flagEscapeBackslash, true, "use backslash to escape special characters")
-	flags.String(flagFiletype, "", "The type of export file (sql/csv)")
+	flags.String(flagFiletype, "", "The type of export file (sql/csv/parquet)")
 	flags.Bool(flagNoHeader, false, "whether not to dump CSV table header")
 	flags.BoolP(flagNoSchemas, "m", false, "Do not dump table schemas with the data")
 	flags.BoolP(flagNoData, "d", false, "Do not dump table data")
@@ -300,6 +305,10 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 	flags.Bool(flagTransactionalConsistency, true, "Only support transactional consistency")
 	_ = flags.MarkHidden(flagTransactionalConsistency)
 	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'no-compression' now")
+	flags.String(flagCrypterMethod, "", "Encrypt output files, be one of plaintext|aes128-ctr|aes192-ctr|aes256-ctr|aes128-gcm|aes192-gcm|aes256-gcm")
+	flags.String(flagCrypterKey, "", "The hexadecimal key to encrypt output files")
+	flags.String(flagCrypterKeyFile, "", "The path of the file containing the hexadecimal key to encrypt output files")
+	flags.String(flagCrypterMasterKey, "", "The master key to wrap the random data key which encrypts output files, be the path of a local keyring file or the URI of a registered KMS provider")
 }
 
 // ParseFromFlags parses dumpling's export.Config from flags
@@ -533,6 +542,23 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 		return errors.Trace(err)
 	}
 
+	conf.Encryption.Method, err = flags.GetString(flagCrypterMethod)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.Encryption.Key, err = flags.GetString(flagCrypterKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.Encryption.KeyFile, err = flags.GetString(flagCrypterKeyFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.Encryption.MasterKey, err = flags.GetString(flagCrypterMasterKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	for k, v := range params {
 		conf.SessionParams[k] = v
 	}
@@ -684,6 +710,10 @@ func registerTLSConfig(conf *Config) error {
 	return errors.Trace(err)
 }
 
+func validateEncryption(conf *Config) error {
+	return errors.Trace(conf.Encryption.Validate())
+}
+
 func validateSpecifiedSQL(conf *Config) error {
 	if conf.SQL != "" && conf.Where != "" {
 		return errors.New("can't specify both --sql and --where at the same time. Please try to combine them into --sql")
@@ -705,6 +735,11 @@ func adjustFileFormat(conf *Config) error {
 			return errors.Errorf("unsupported config.FileType '%s' when we specify --sql, please unset --filetype or set it to 'csv'", conf.FileType)
 		}
 	case FileFormatCSVString:
+	case FileFormatParquetString:
+		// parquet files are compressed page by page with snappy, compressing the whole file again is useless
+		if conf.CompressType != storage.NoCompression {
+			return errors.Errorf("unsupported config.FileType '%s' when we specify --compress, please unset --compress", conf.FileType)
+		}
 	default:
 		return errors.Errorf("unknown config.FileType '%s'", conf.FileType)
 	}
@@ -98,6 +98,7 @@ func NewDumper(ctx context.Context, conf *Config) (*Dumper, error) {
 	err = adjustConfig(conf,
 		registerTLSConfig,
 		validateSpecifiedSQL,
+		validateEncryption,
 		adjustFileFormat)
 	if err != nil {
 		return nil, err
@@ -1343,6 +1344,11 @@ func createExternalStore(d *Dumper) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// the files are compressed before encrypted, so the encryption wraps the storage directly
+	extStore, err = storage.WithEncryption(tctx, extStore, &conf.Encryption)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	d.extStore = extStore
 	return nil
 }
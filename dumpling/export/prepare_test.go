@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/stretchr/testify/require"
 )
@@ -293,8 +294,24 @@ func TestConfigValidation(t *testing.T) {
 	require.NoError(t, adjustFileFormat(conf))
 	require.Equal(t, FileFormatSQLTextString, conf.FileType)
 
+	conf.FileType = FileFormatParquetString
+	require.NoError(t, adjustFileFormat(conf))
+	conf.CompressType = storage.Gzip
+	err = adjustFileFormat(conf)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "please unset --compress")
+	conf.CompressType = storage.NoCompression
+
 	conf.FileType = "rand_str"
 	require.EqualError(t, adjustFileFormat(conf), "unknown config.FileType 'rand_str'")
+
+	require.NoError(t, validateEncryption(conf))
+	conf.Encryption.Method = "aes128-gcm"
+	require.ErrorContains(t, validateEncryption(conf), "exactly one of")
+	conf.Encryption.Key = "0123456789abcdef0123456789abcdef"
+	require.NoError(t, validateEncryption(conf))
+	conf.Encryption.Method = "rand_str"
+	require.ErrorContains(t, validateEncryption(conf), "invalid encryption method 'rand_str'")
 }
 
 func TestValidateResolveAutoConsistency(t *testing.T) {
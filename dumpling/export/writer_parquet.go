@@ -0,0 +1,454 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/summary"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/dumpling/log"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/zap"
+)
+
+const (
+	// parquetMaxRowGroupSize is the default row group size of the parquet writer
+	parquetMaxRowGroupSize = 128 * 1024 * 1024
+	// parquetMaxInt64DecimalPrecision is the max precision of the decimal that can be stored in an int64
+	parquetMaxInt64DecimalPrecision = 18
+
+	parquetDateLayout     = "2006-01-02"
+	parquetDatetimeLayout = "2006-01-02 15:04:05"
+	secondsPerDay         = 24 * 60 * 60
+)
+
+var (
+	int64ScanType  = reflect.TypeOf(int64(0))
+	uint64ScanType = reflect.TypeOf(uint64(0))
+)
+
+// parquetColumn describes how a column is stored in the parquet file.
+type parquetColumn struct {
+	schema *parquet.SchemaElement
+	// convert converts the text value returned by the server to the value of the parquet physical type
+	convert func(raw []byte) (interface{}, error)
+}
+
+// newParquetColumn maps the column of the given database type name to the parquet type. colType can be nil,
+// it's used to get the precision of decimal and the signedness of bigint.
+func newParquetColumn(name, tp string, colType *sql.ColumnType) *parquetColumn {
+	col := &parquetColumn{
+		schema: parquet.NewSchemaElement(),
+	}
+	col.schema.Name = name
+	col.schema.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+
+	switch strings.ToUpper(tp) {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "YEAR":
+		col.setType(parquet.Type_INT32, parquet.ConvertedType_INT_32, parseParquetInt32)
+	case "INT", "INTEGER":
+		// unsigned int exceeds the range of int32
+		col.setType(parquet.Type_INT64, parquet.ConvertedType_INT_64, parseParquetInt64)
+	case "BIGINT":
+		var scanType reflect.Type
+		if colType != nil {
+			scanType = colType.ScanType()
+		}
+		switch scanType {
+		case int64ScanType:
+			col.setType(parquet.Type_INT64, parquet.ConvertedType_INT_64, parseParquetInt64)
+		case uint64ScanType:
+			col.setType(parquet.Type_INT64, parquet.ConvertedType_UINT_64, parseParquetUint64)
+		default:
+			// the signedness is unknown, use decimal to hold both signed and unsigned values
+			col.setDecimalType(20, 0)
+		}
+	case "FLOAT":
+		col.setType(parquet.Type_FLOAT, -1, parseParquetFloat)
+	case "DOUBLE":
+		col.setType(parquet.Type_DOUBLE, -1, parseParquetDouble)
+	case "DECIMAL":
+		if colType == nil {
+			col.setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, parseParquetString)
+			break
+		}
+		if precision, scale, ok := colType.DecimalSize(); ok {
+			col.setDecimalType(int(precision), int(scale))
+		} else {
+			col.setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, parseParquetString)
+		}
+	case "DATE":
+		col.setType(parquet.Type_INT32, parquet.ConvertedType_DATE, parseParquetDate)
+	case "DATETIME", "TIMESTAMP":
+		// dumpling doesn't change the time zone of the session, so the values are written as local time
+		col.setType(parquet.Type_INT64, parquet.ConvertedType_TIMESTAMP_MICROS, parseParquetTimestamp)
+		col.schema.LogicalType = &parquet.LogicalType{
+			TIMESTAMP: &parquet.TimestampType{
+				IsAdjustedToUTC: false,
+				Unit:            &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()},
+			},
+		}
+	case "JSON":
+		col.setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_JSON, parseParquetString)
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "BIT", "GEOMETRY":
+		col.setType(parquet.Type_BYTE_ARRAY, -1, parseParquetString)
+	default:
+		// CHAR, VARCHAR, TEXT, TIME, ENUM, SET and the unknown types are written as strings. TIME exceeds the
+		// range of the parquet TIME type, and ENUM is not supported by the parquet writer.
+		col.setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, parseParquetString)
+	}
+	return col
+}
+
+// setType sets the physical type and the converted type of the column, -1 means no converted type.
+func (c *parquetColumn) setType(tp parquet.Type, convertedType parquet.ConvertedType, convert func([]byte) (interface{}, error)) {
+	c.schema.Type = parquet.TypePtr(tp)
+	if convertedType >= 0 {
+		c.schema.ConvertedType = parquet.ConvertedTypePtr(convertedType)
+	}
+	c.convert = convert
+}
+
+// setDecimalType stores the decimal as int64 if the precision allows, otherwise as the big-endian two's
+// complement byte array.
+func (c *parquetColumn) setDecimalType(precision, scale int) {
+	if precision <= parquetMaxInt64DecimalPrecision {
+		c.setType(parquet.Type_INT64, parquet.ConvertedType_DECIMAL, func(raw []byte) (interface{}, error) {
+			v, err := parseParquetUnscaledDecimal(raw, scale)
+			if err != nil {
+				return nil, err
+			}
+			return v.Int64(), nil
+		})
+	} else {
+		c.setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_DECIMAL, func(raw []byte) (interface{}, error) {
+			v, err := parseParquetUnscaledDecimal(raw, scale)
+			if err != nil {
+				return nil, err
+			}
+			return bigIntToTwosComplement(v), nil
+		})
+	}
+	p, s := int32(precision), int32(scale)
+	c.schema.Precision, c.schema.Scale = &p, &s
+	c.schema.LogicalType = &parquet.LogicalType{
+		DECIMAL: &parquet.DecimalType{Scale: s, Precision: p},
+	}
+}
+
+func parseParquetString(raw []byte) (interface{}, error) {
+	return string(raw), nil
+}
+
+func parseParquetInt32(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return int32(v), nil
+}
+
+func parseParquetInt64(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return v, nil
+}
+
+// parseParquetUint64 returns the bits of the uint64 in int64, which is how parquet stores UINT_64.
+func parseParquetUint64(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return int64(v), nil
+}
+
+func parseParquetFloat(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseFloat(string(raw), 32)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return float32(v), nil
+}
+
+func parseParquetDouble(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return v, nil
+}
+
+// parseParquetDate returns the days since the unix epoch. The zero date '0000-00-00' and the invalid dates
+// allowed by the sql mode, e.g. '2020-02-30', can't be represented in parquet, so they are written as NULL.
+func parseParquetDate(raw []byte) (interface{}, error) {
+	t, err := time.Parse(parquetDateLayout, string(raw))
+	if err != nil {
+		return nil, nil
+	}
+	return int32(t.Unix() / secondsPerDay), nil
+}
+
+// parseParquetTimestamp returns the microseconds since the unix epoch, the value is regarded as UTC time.
+// Like parseParquetDate, the zero and invalid datetimes are written as NULL.
+func parseParquetTimestamp(raw []byte) (interface{}, error) {
+	t, err := time.Parse(parquetDatetimeLayout, string(raw))
+	if err != nil {
+		return nil, nil
+	}
+	return t.UnixMicro(), nil
+}
+
+// parseParquetUnscaledDecimal returns the decimal value multiplied by 10^scale, e.g. "-1.5" with scale 2 is -150.
+func parseParquetUnscaledDecimal(raw []byte, scale int) (*big.Int, error) {
+	str := string(raw)
+	intPart, fracPart := str, ""
+	if idx := strings.IndexByte(str, '.'); idx >= 0 {
+		intPart, fracPart = str[:idx], str[idx+1:]
+	}
+	if len(fracPart) > scale {
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", scale-len(fracPart))
+	v, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid decimal value '%s'", str)
+	}
+	return v, nil
+}
+
+// bigIntToTwosComplement encodes the integer in the minimal big-endian two's complement representation.
+func bigIntToTwosComplement(v *big.Int) string {
+	buf := make([]byte, v.BitLen()/8+1)
+	if v.Sign() >= 0 {
+		v.FillBytes(buf)
+	} else {
+		// 2^(8*len) + v is the two's complement of the negative v
+		complement := new(big.Int).Lsh(big.NewInt(1), uint(len(buf)*8))
+		complement.Add(complement, v).FillBytes(buf)
+	}
+	return string(buf)
+}
+
+func buildParquetColumns(meta TableMeta, tblIR TableDataIR) []*parquetColumn {
+	colTypes := meta.ColumnTypes()
+	colNames := meta.ColumnNames()
+	var sqlColTypes []*sql.ColumnType
+	if rows := tblIR.RawRows(); rows != nil {
+		if tps, err := rows.ColumnTypes(); err == nil && len(tps) == len(colTypes) {
+			sqlColTypes = tps
+		}
+	}
+	columns := make([]*parquetColumn, 0, len(colTypes))
+	for i, tp := range colTypes {
+		name := fmt.Sprintf("column_%d", i)
+		if len(colNames) == len(colTypes) {
+			name = colNames[i]
+		}
+		var colType *sql.ColumnType
+		if sqlColTypes != nil {
+			colType = sqlColTypes[i]
+		}
+		columns = append(columns, newParquetColumn(name, tp, colType))
+	}
+	return columns
+}
+
+func buildParquetSchema(columns []*parquetColumn) []*parquet.SchemaElement {
+	root := parquet.NewSchemaElement()
+	root.Name = "schema"
+	root.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	numChildren := int32(len(columns))
+	root.NumChildren = &numChildren
+	schemas := make([]*parquet.SchemaElement, 0, len(columns)+1)
+	schemas = append(schemas, root)
+	for _, col := range columns {
+		schemas = append(schemas, col.schema)
+	}
+	return schemas
+}
+
+// parquetRowReceiver receives the raw bytes of a row, nil means NULL.
+type parquetRowReceiver []sql.RawBytes
+
+// BindAddress implements RowReceiver.BindAddress
+func (r parquetRowReceiver) BindAddress(args []interface{}) {
+	for i := range args {
+		args[i] = &r[i]
+	}
+}
+
+// parquetOutput is the io.Writer used by the parquet writer, it sends the written bytes to the writerPipe
+// in chunks of lengthLimit.
+type parquetOutput struct {
+	tctx    *tcontext.Context
+	wp      *writerPipe
+	bf      *bytes.Buffer
+	flushed bool
+}
+
+// Write implements io.Writer.
+func (o *parquetOutput) Write(p []byte) (int, error) {
+	o.bf.Write(p)
+	if o.bf.Len() < lengthLimit {
+		return len(p), nil
+	}
+	select {
+	case <-o.tctx.Done():
+		return 0, o.tctx.Err()
+	case err := <-o.wp.errCh:
+		return 0, err
+	case o.wp.input <- o.bf:
+		o.bf = pool.Get().(*bytes.Buffer)
+		if bfCap := o.bf.Cap(); bfCap < lengthLimit {
+			o.bf.Grow(lengthLimit - bfCap)
+		}
+		o.flushed = true
+	}
+	return len(p), nil
+}
+
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet type
+func WriteInsertInParquet(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+	if meta.SelectedField() == "" {
+		// a parquet file must have at least one column
+		pCtx.L().Warn("skip dumping table(chunk) without any column in parquet",
+			zap.String("database", meta.DatabaseName()),
+			zap.String("table", meta.TableName()))
+		return 0, nil
+	}
+
+	bf := pool.Get().(*bytes.Buffer)
+	if bfCap := bf.Cap(); bfCap < lengthLimit {
+		bf.Grow(lengthLimit - bfCap)
+	}
+
+	wp := newWriterPipe(w, cfg.FileSize, UnspecifiedSize, metrics, cfg.Labels)
+
+	// use context.Background here to make sure writerPipe can deplete all the chunks in pipeline
+	ctx, cancel := tcontext.Background().WithLogger(pCtx.L()).WithCancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		wp.Run(ctx)
+		wg.Done()
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var (
+		columns     = buildParquetColumns(meta, tblIR)
+		row         = make(parquetRowReceiver, len(columns))
+		output      = &parquetOutput{tctx: pCtx, wp: wp, bf: bf}
+		counter     uint64
+		lastCounter uint64
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", wp.finishedFileSize),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(wp.finishedFileSize))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", wp.finishedFileSize))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, wp.finishedFileSize)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	pw, err := writer.NewParquetWriterFromWriter(output, buildParquetSchema(columns), 1)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+	// roll the row group over before the file reaches the size limit
+	if cfg.FileSize != UnspecifiedSize && cfg.FileSize < parquetMaxRowGroupSize {
+		pw.RowGroupSize = int64(cfg.FileSize)
+	}
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		values := make([]interface{}, len(columns))
+		var rowSize uint64
+		for i, raw := range row {
+			if raw == nil {
+				continue
+			}
+			rowSize += uint64(len(raw))
+			if values[i], err = columns[i].convert(raw); err != nil {
+				return counter, errors.Annotatef(err, "fail to convert value of column %s", columns[i].schema.Name)
+			}
+		}
+		if err = pw.Write(values); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		wp.currentFileSize += rowSize
+
+		if output.flushed {
+			AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+			lastCounter = counter
+			output.flushed = false
+		}
+
+		fileRowIter.Next()
+		if wp.ShouldSwitchFile() {
+			break
+		}
+	}
+
+	// write the remaining row group and the footer
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	if output.bf.Len() > 0 {
+		wp.input <- output.bf
+	}
+	close(wp.input)
+	<-wp.closed
+	AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+	lastCounter = counter
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, wp.Error()
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/stretchr/testify/require"
+	pqt_buf_src "github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestParquetColumnConvert(t *testing.T) {
+	cases := []struct {
+		tp            string
+		raw           string
+		physicalType  parquet.Type
+		convertedType parquet.ConvertedType
+		expected      interface{}
+	}{
+		{"TINYINT", "-12", parquet.Type_INT32, parquet.ConvertedType_INT_32, int32(-12)},
+		{"YEAR", "2022", parquet.Type_INT32, parquet.ConvertedType_INT_32, int32(2022)},
+		{"INT", "4294967295", parquet.Type_INT64, parquet.ConvertedType_INT_64, int64(4294967295)},
+		{"DOUBLE", "1.5", parquet.Type_DOUBLE, -1, 1.5},
+		{"FLOAT", "-0.25", parquet.Type_FLOAT, -1, float32(-0.25)},
+		{"DATE", "2022-01-02", parquet.Type_INT32, parquet.ConvertedType_DATE, int32(18994)},
+		{"DATE", "1969-12-31", parquet.Type_INT32, parquet.ConvertedType_DATE, int32(-1)},
+		{"DATETIME", "2022-01-02 03:04:05.123456", parquet.Type_INT64, parquet.ConvertedType_TIMESTAMP_MICROS, int64(1641092645123456)},
+		{"TIMESTAMP", "2022-01-02 03:04:05", parquet.Type_INT64, parquet.ConvertedType_TIMESTAMP_MICROS, int64(1641092645000000)},
+		{"JSON", `{"a": 1}`, parquet.Type_BYTE_ARRAY, parquet.ConvertedType_JSON, `{"a": 1}`},
+		{"ENUM", "male", parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, "male"},
+		{"SET", "a,b", parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, "a,b"},
+		{"TIME", "-838:59:59", parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, "-838:59:59"},
+		{"VARBINARY", "\x00\xff", parquet.Type_BYTE_ARRAY, -1, "\x00\xff"},
+		// the precision is unknown without the column type of the driver
+		{"DECIMAL", "1.50", parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8, "1.50"},
+		// the signedness is unknown without the column type of the driver
+		{"BIGINT", "18446744073709551615", parquet.Type_BYTE_ARRAY, parquet.ConvertedType_DECIMAL, "\x00\xff\xff\xff\xff\xff\xff\xff\xff"},
+		{"BIGINT", "-1", parquet.Type_BYTE_ARRAY, parquet.ConvertedType_DECIMAL, "\xff"},
+	}
+	for _, ca := range cases {
+		col := newParquetColumn("c", ca.tp, nil)
+		require.Equal(t, ca.physicalType, col.schema.GetType(), ca.tp)
+		if ca.convertedType < 0 {
+			require.False(t, col.schema.IsSetConvertedType(), ca.tp)
+		} else {
+			require.Equal(t, ca.convertedType, col.schema.GetConvertedType(), ca.tp)
+		}
+		v, err := col.convert([]byte(ca.raw))
+		require.NoError(t, err, ca.tp)
+		require.Equal(t, ca.expected, v, ca.tp)
+	}
+
+	col := newParquetColumn("c", "DATETIME", nil)
+	require.False(t, col.schema.LogicalType.TIMESTAMP.IsAdjustedToUTC)
+	// the zero and invalid dates are written as NULL
+	for _, raw := range []string{"0000-00-00 00:00:00", "2020-02-30 00:00:00", "2020-00-01 12:00:00"} {
+		v, err := col.convert([]byte(raw))
+		require.NoError(t, err, raw)
+		require.Nil(t, v, raw)
+	}
+	col = newParquetColumn("c", "DATE", nil)
+	for _, raw := range []string{"0000-00-00", "2020-02-30", "2020-01-00"} {
+		v, err := col.convert([]byte(raw))
+		require.NoError(t, err, raw)
+		require.Nil(t, v, raw)
+	}
+}
+
+func TestParquetDecimal(t *testing.T) {
+	col := &parquetColumn{schema: parquet.NewSchemaElement()}
+	col.setDecimalType(10, 2)
+	require.Equal(t, parquet.Type_INT64, col.schema.GetType())
+	require.Equal(t, int32(10), col.schema.GetPrecision())
+	require.Equal(t, int32(2), col.schema.GetScale())
+	require.Equal(t, int32(2), col.schema.LogicalType.DECIMAL.Scale)
+	for raw, expected := range map[string]int64{
+		"1.50":   150,
+		"-0.05":  -5,
+		"123":    12300,
+		"-12.3":  -1230,
+		"0.00":   0,
+		"999.99": 99999,
+	} {
+		v, err := col.convert([]byte(raw))
+		require.NoError(t, err)
+		require.Equal(t, expected, v, raw)
+	}
+
+	col = &parquetColumn{schema: parquet.NewSchemaElement()}
+	col.setDecimalType(30, 3)
+	require.Equal(t, parquet.Type_BYTE_ARRAY, col.schema.GetType())
+	for raw, expected := range map[string]string{
+		"1.000":                     "03e8",
+		"-1.000":                    "fc18",
+		"0.127":                     "7f",
+		"0.128":                     "0080",
+		"-0.128":                    "ff80",
+		"-12345678901234567890.123": "fd62bd49b1898ebdbb35",
+	} {
+		v, err := col.convert([]byte(raw))
+		require.NoError(t, err)
+		require.Equal(t, expected, hex.EncodeToString([]byte(v.(string))), raw)
+	}
+	_, err := col.convert([]byte("abc"))
+	require.Error(t, err)
+}
+
+func TestWriteInsertInParquet(t *testing.T) {
+	cfg := createMockConfig()
+
+	data := [][]driver.Value{
+		{"1", "male", "2022-01-02 03:04:05", `{"a": 1}`, nil},
+		{"2", "female", "2022-01-03 03:04:05", nil, "healthy"},
+		{"3", "male", nil, `[1, 2]`, "healthy"},
+		{"4", "female", "2022-01-05 03:04:05", `{}`, "healthy"},
+	}
+	colTypes := []string{"INT", "ENUM", "DATETIME", "JSON", "TEXT"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "gender", "birthday", "profile", "status"}
+	bf := storage.NewBufferWriter()
+
+	m := newMetrics(cfg.PromFactory, cfg.Labels)
+	n, err := WriteInsertInParquet(tcontext.Background(), cfg, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), n)
+	require.Equal(t, float64(len(data)), ReadGauge(m.finishedRowsGauge))
+	require.Equal(t, float64(len(bf.Bytes())), ReadGauge(m.finishedSizeGauge))
+
+	content := bf.Bytes()
+	require.Equal(t, "PAR1", string(content[:4]))
+	require.Equal(t, "PAR1", string(content[len(content)-4:]))
+	pf, err := pqt_buf_src.NewBufferFile(content)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(pf, nil, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(4), pr.GetNumRows())
+	require.Len(t, pr.Footer.RowGroups, 1)
+	schemas := pr.Footer.Schema
+	require.Len(t, schemas, 6)
+	require.Equal(t, parquet.ConvertedType_INT_64, schemas[1].GetConvertedType())
+	require.Equal(t, parquet.ConvertedType_UTF8, schemas[2].GetConvertedType())
+	require.Equal(t, parquet.ConvertedType_TIMESTAMP_MICROS, schemas[3].GetConvertedType())
+	require.Equal(t, parquet.ConvertedType_JSON, schemas[4].GetConvertedType())
+	require.Equal(t, parquet.ConvertedType_UTF8, schemas[5].GetConvertedType())
+	for _, s := range schemas[1:] {
+		require.Equal(t, parquet.FieldRepetitionType_OPTIONAL, s.GetRepetitionType())
+	}
+
+	// the file is switched when it reaches the file size limit
+	bf.Reset()
+	cfg.FileSize = 40
+	tableIR = newMockTableIR("test", "employee", data, nil, colTypes)
+	m = newMetrics(cfg.PromFactory, cfg.Labels)
+	n, err = WriteInsertInParquet(tcontext.Background(), cfg, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+	pf, err = pqt_buf_src.NewBufferFile(bf.Bytes())
+	require.NoError(t, err)
+	pr, err = reader.NewParquetReader(pf, nil, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(2), pr.GetNumRows())
+}
@@ -887,6 +887,9 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, max_execution_time exceeded.", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' cannot be set using SET_VAR hint.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%-.192s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar JSON_TABLE column '%-.192s'", nil),
+	ErrTFForbiddenJoinType:                                   mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%-.192s'", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
 	ErrForeignKeyNoColumnInParent:                            mysql.Message("Failed to add the foreign key constraint. Missing column '%s' for constraint '%s' in the referenced table '%s'", nil),
@@ -904,12 +907,23 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFunctionalIndexOnField:                                mysql.Message("Expression index on a column is not supported. Consider using a regular index instead", nil),
 	ErrFKIncompatibleColumns:                                 mysql.Message("Referencing column '%s' and referenced column '%s' in foreign key constraint '%s' are incompatible.", nil),
 	ErrFunctionalIndexRowValueIsNotAllowed:                   mysql.Message("Expression of expression index '%s' cannot refer to a row value", nil),
+	ErrNonBooleanExprForCheckConstraint:                      mysql.Message("An expression of non-boolean type specified to check constraint '%-.192s'.", nil),
+	ErrColumnCheckConstraintReferencesOtherColumn:            mysql.Message("Column check constraint '%-.192s' references other column.", nil),
+	ErrCheckConstraintNamedFunctionIsNotAllowed:              mysql.Message("An expression of a check constraint '%-.192s' contains disallowed function: %s.", nil),
+	ErrCheckConstraintFunctionIsNotAllowed:                   mysql.Message("An expression of a check constraint '%-.192s' contains disallowed function.", nil),
+	ErrCheckConstraintVariables:                              mysql.Message("An expression of a check constraint '%-.192s' cannot refer to a user or system variable.", nil),
+	ErrCheckConstraintRefersAutoIncrementColumn:              mysql.Message("Check constraint '%-.192s' cannot refer to an auto-increment column.", nil),
+	ErrCheckConstraintViolated:                               mysql.Message("Check constraint '%-.192s' is violated.", nil),
+	ErrCheckConstraintRefersUnknownColumn:                    mysql.Message("Check constraint '%-.192s' refers to non-existing column '%-.192s'.", nil),
+	ErrCheckConstraintNotFound:                               mysql.Message("Check constraint '%-.192s' is not found in the table.", nil),
+	ErrCheckConstraintDupName:                                mysql.Message("Duplicate check constraint name '%-.192s'.", nil),
 	ErrDependentByFunctionalIndex:                            mysql.Message("Column '%s' has an expression index dependency and cannot be dropped or renamed", nil),
 	ErrCannotConvertString:                                   mysql.Message("Cannot convert string '%.64s' from %s to %s", nil),
 	ErrInvalidJSONValueForFuncIndex:                          mysql.Message("Invalid JSON value for CAST for expression index '%s'", nil),
 	ErrJSONValueOutOfRangeForFuncIndex:                       mysql.Message("Out of range JSON value for CAST for expression index '%s'", nil),
 	ErrFunctionalIndexDataIsTooLong:                          mysql.Message("Data too long for expression index '%s'", nil),
 	ErrFunctionalIndexNotApplicable:                          mysql.Message("Cannot use expression index '%s' due to type or collation conversion", nil),
+	ErrDependentByCheckConstraint:                            mysql.Message("Check constraint '%-.192s' uses column '%-.192s', hence column cannot be dropped or renamed.", nil),
 	ErrUnsupportedConstraintCheck:                            mysql.Message("%s is not supported", nil),
 	ErrDynamicPrivilegeNotRegistered:                         mysql.Message("Dynamic privilege '%s' is not registered with the server.", nil),
 	ErrIllegalPrivilegeLevel:                                 mysql.Message("Illegal privilege level specified for %s", nil),
@@ -1029,6 +1043,10 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGettingNoopVariable:           mysql.Message("variable %s has no effect in TiDB", nil),
 	ErrCannotMigrateSession:          mysql.Message("cannot migrate the current session: %s", nil),
 	ErrLazyUniquenessCheckFailure:    mysql.Message("transaction aborted because lazy uniqueness check is enabled and an error occurred: %s", nil),
+	ErrUnsupportedColumnInTTLConfig:  mysql.Message("Field '%-.192s' is of a not supported type for TTL config, expect DATETIME, DATE or TIMESTAMP", nil),
+	ErrTTLColumnCannotDrop:           mysql.Message("Cannot drop column '%-.192s': needed in TTL config", nil),
+	ErrSetTTLOptionForNonTTLTable:    mysql.Message("Cannot set %s on a table without TTL config", nil),
+	ErrTempTableNotAllowedWithTTL:    mysql.Message("Set TTL for temporary table is not allowed", nil),
 
 	ErrWarnOptimizerHintInvalidInteger:  mysql.Message("integer value is out of range in '%s'", nil),
 	ErrWarnOptimizerHintUnsupportedHint: mysql.Message("Optimizer hint %s is not supported by TiDB and is ignored", nil),
@@ -1087,6 +1105,11 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPlacementPolicyWithDirectOption: mysql.Message("Placement policy '%s' can't co-exist with direct placement options", nil),
 	ErrPlacementPolicyInUse:            mysql.Message("Placement policy '%-.192s' is still in use", nil),
 	ErrOptOnCacheTable:                 mysql.Message("'%s' is unsupported on cache tables.", nil),
+	ErrResourceGroupExists:             mysql.Message("Resource group '%-.192s' already exists", nil),
+	ErrResourceGroupNotExists:          mysql.Message("Unknown resource group '%-.192s'", nil),
+	ErrResourceGroupSupportDisabled:    mysql.Message("Resource control feature is disabled. Run `SET GLOBAL tidb_enable_resource_control='on'` to enable the feature", nil),
+	ErrInvalidResourceGroup:            mysql.Message("Invalid resource group: %s", nil),
+	ErrResourceGroupQueryRunaway:       mysql.Message("Quarantined and interrupted because of being in runaway watch list", nil),
 
 	ErrColumnInChange: mysql.Message("column %s id %d does not exist, this column may have been updated by other DDL ran in parallel", nil),
 	// TiKV/PD errors.
@@ -43,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/planner"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/plugin"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -57,6 +58,7 @@ import (
 	"github.com/pingcap/tidb/util/mathutil"
 	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/plancodec"
+	"github.com/pingcap/tidb/util/resourcegroup"
 	"github.com/pingcap/tidb/util/sqlexec"
 	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/pingcap/tidb/util/stringutil"
@@ -482,10 +484,14 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 		sctx.GetSessionVars().StmtCtx.MemTracker.SetBytesLimit(sctx.GetSessionVars().StmtCtx.MemQuotaQuery)
 	}
 
+	a.checkResourceGroupHint()
 	e, err := a.buildExecutor()
 	if err != nil {
 		return nil, err
 	}
+	if err = a.checkRunawayQuery(); err != nil {
+		return nil, err
+	}
 	// ExecuteExec will rewrite `a.Plan`, so set plan label should be executed after `a.buildExecutor`.
 	ctx = a.observeStmtBeginForTopSQL(ctx)
 
@@ -1592,6 +1598,53 @@ func getPlanTree(stmtCtx *stmtctx.StatementContext) string {
 	return variable.SlowLogPlanPrefix + planTree + variable.SlowLogPlanSuffix
 }
 
+// checkResourceGroupHint ignores the RESOURCE_GROUP hint with a warning if the group doesn't exist, or the user
+// switches to a group other than the bound one without the RESOURCE_GROUP_ADMIN privilege. The bound group of the
+// user is used instead, so the quota and the runaway rule of it can't be bypassed by the hint.
+func (a *ExecStmt) checkResourceGroupHint() {
+	sessVars := a.Ctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	if !stmtCtx.HasResourceGroup {
+		return
+	}
+	name := stmtCtx.ResourceGroup
+	var warn error
+	if _, ok := a.InfoSchema.ResourceGroupByName(model.NewCIStr(name)); !ok {
+		warn = infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(name)
+	} else if pm := privilege.GetPrivilegeManager(a.Ctx); pm != nil && name != strings.ToLower(sessVars.ResourceGroupName) &&
+		!pm.RequestDynamicVerification(sessVars.ActiveRoles, "RESOURCE_GROUP_ADMIN", false) {
+		warn = plannercore.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
+	}
+	if warn != nil {
+		stmtCtx.AppendWarning(warn)
+		stmtCtx.HasResourceGroup = false
+		stmtCtx.ResourceGroup = ""
+	}
+}
+
+// checkRunawayQuery derives the runaway checker of the query if its resource group has a QUERY_LIMIT rule,
+// and checks the query against the watch list before it's executed.
+func (a *ExecStmt) checkRunawayQuery() error {
+	sessVars := a.Ctx.GetSessionVars()
+	name := sessVars.GetResourceGroupName()
+	if name == "" {
+		return nil
+	}
+	group, ok := a.InfoSchema.ResourceGroupByName(model.NewCIStr(name))
+	if !ok || group.ResourceGroupSettings == nil || group.Runaway == nil {
+		return nil
+	}
+	stmtCtx := sessVars.StmtCtx
+	_, sqlDigest := stmtCtx.SQLDigest()
+	var planDigest string
+	if _, digest := getPlanDigest(stmtCtx); digest != nil {
+		planDigest = digest.String()
+	}
+	stmtCtx.RunawayChecker = resourcegroup.GlobalRunawayManager().DeriveChecker(group.Name.L, group.Runaway,
+		a.OriginText(), sqlDigest.String(), planDigest, sessVars.StartTime)
+	return stmtCtx.RunawayChecker.BeforeExecutor()
+}
+
 // getPlanDigest will try to get the select plan tree if the plan is select or the select plan of delete/update/insert statement.
 func getPlanDigest(stmtCtx *stmtctx.StatementContext) (string, *parser.Digest) {
 	normalized, planDigest := stmtCtx.GetPlanDigest()
@@ -649,7 +649,7 @@ func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -657,7 +657,7 @@ func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
@@ -675,7 +675,10 @@ func buildNthValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	}
 	// Already checked when building the function description.
 	nth, _, _ := expression.GetUint64FromConstant(aggFuncDesc.Args[1])
-	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth}
+	if aggFuncDesc.FromLast {
+		return &nthValueFromLast{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: aggFuncDesc.IgnoreNull}
+	}
+	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildNtile(aggFuncDes *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -716,7 +719,7 @@ func buildLeadLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc,
 		ordinal: ordinal,
 	}
 	ve, _ := buildValueEvaluator(aggFuncDesc.RetTp)
-	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve}
+	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildLead(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
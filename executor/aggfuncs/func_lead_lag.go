@@ -33,6 +33,7 @@ type baseLeadLag struct {
 
 	defaultExpr expression.Expression
 	offset      uint64
+	ignoreNull  bool
 }
 
 type partialResult4LeadLag struct {
@@ -57,6 +58,30 @@ func (v *baseLeadLag) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup [
 	return memDelta, nil
 }
 
+// findNonNullRow finds the row which is the `offset`-th row with non-NULL value after (or before if backward) the
+// current row for `IGNORE NULLS`, it returns false if there is no such row.
+func (v *baseLeadLag) findNonNullRow(p *partialResult4LeadLag, backward bool) (uint64, bool, error) {
+	step, idx := 1, int(p.curIdx)+1
+	if backward {
+		step, idx = -1, int(p.curIdx)-1
+	}
+	seen := uint64(0)
+	for ; idx >= 0 && idx < len(p.rows); idx += step {
+		isNull, err := evalIsNull(v.args[0], p.rows[idx])
+		if err != nil {
+			return 0, false, err
+		}
+		if isNull {
+			continue
+		}
+		seen++
+		if seen == v.offset {
+			return uint64(idx), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
 type lead struct {
 	baseLeadLag
 }
@@ -64,7 +89,18 @@ type lead struct {
 func (v *lead) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
 	var err error
-	if p.curIdx+v.offset < uint64(len(p.rows)) {
+	if v.ignoreNull && v.offset > 0 {
+		var idx uint64
+		var found bool
+		idx, found, err = v.findNonNullRow(p, false)
+		if err == nil {
+			if found {
+				_, err = v.evaluateRow(sctx, v.args[0], p.rows[idx])
+			} else {
+				_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+			}
+		}
+	} else if p.curIdx+v.offset < uint64(len(p.rows)) {
 		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx+v.offset])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
@@ -84,7 +120,18 @@ type lag struct {
 func (v *lag) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
 	var err error
-	if p.curIdx >= v.offset {
+	if v.ignoreNull && v.offset > 0 {
+		var idx uint64
+		var found bool
+		idx, found, err = v.findNonNullRow(p, true)
+		if err == nil {
+			if found {
+				_, err = v.evaluateRow(sctx, v.args[0], p.rows[idx])
+			} else {
+				_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+			}
+		}
+	} else if p.curIdx >= v.offset {
 		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx-v.offset])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
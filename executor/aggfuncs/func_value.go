@@ -31,6 +31,8 @@ const (
 	DefPartialResult4LastValueSize = int64(unsafe.Sizeof(partialResult4LastValue{}))
 	// DefPartialResult4NthValueSize is the size of partialResult4NthValue
 	DefPartialResult4NthValueSize = int64(unsafe.Sizeof(partialResult4NthValue{}))
+	// DefPartialResult4NthValueFromLastSize is the size of partialResult4NthValueFromLast
+	DefPartialResult4NthValueFromLastSize = int64(unsafe.Sizeof(partialResult4NthValueFromLast{}))
 
 	// DefValue4IntSize is the size of value4Int
 	DefValue4IntSize = int64(unsafe.Sizeof(value4Int{}))
@@ -208,6 +210,12 @@ func (v *value4JSON) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+// evalIsNull checks whether the expression evaluated on the row is NULL, it's used to skip the NULL values for `IGNORE NULLS`.
+func evalIsNull(expr expression.Expression, row chunk.Row) (bool, error) {
+	d, err := expr.Eval(row)
+	return d.IsNull(), err
+}
+
 func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64) {
 	evalType := tp.EvalType()
 	if tp.GetType() == mysql.TypeBit {
@@ -240,7 +248,8 @@ func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64
 type firstValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4FirstValue struct {
@@ -264,14 +273,20 @@ func (v *firstValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []
 	if p.gotFirstValue {
 		return 0, nil
 	}
-	if len(rowsInGroup) > 0 {
-		p.gotFirstValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[0])
-		if err != nil {
-			return 0, err
+	for _, row := range rowsInGroup {
+		if v.ignoreNull {
+			isNull, err := evalIsNull(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
 		}
+		p.gotFirstValue = true
+		return p.evaluator.evaluateRow(sctx, v.args[0], row)
 	}
-	return memDelta, nil
+	return 0, nil
 }
 
 func (v *firstValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
@@ -287,7 +302,8 @@ func (v *firstValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr Partial
 type lastValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4LastValue struct {
@@ -308,14 +324,20 @@ func (v *lastValue) ResetPartialResult(pr PartialResult) {
 
 func (v *lastValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LastValue)(pr)
-	if len(rowsInGroup) > 0 {
-		p.gotLastValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
-		if err != nil {
-			return 0, err
+	for i := len(rowsInGroup) - 1; i >= 0; i-- {
+		if v.ignoreNull {
+			isNull, err := evalIsNull(v.args[0], rowsInGroup[i])
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
 		}
+		p.gotLastValue = true
+		return p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[i])
 	}
-	return memDelta, nil
+	return 0, nil
 }
 
 func (v *lastValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
@@ -331,8 +353,9 @@ func (v *lastValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialR
 type nthValue struct {
 	baseAggFunc
 
-	tp  *types.FieldType
-	nth uint64
+	tp         *types.FieldType
+	nth        uint64
+	ignoreNull bool
 }
 
 type partialResult4NthValue struct {
@@ -356,6 +379,29 @@ func (v *nthValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []ch
 		return 0, nil
 	}
 	p := (*partialResult4NthValue)(pr)
+	if v.ignoreNull {
+		// Only the rows with non-NULL values are counted.
+		for _, row := range rowsInGroup {
+			if p.seenRows >= v.nth {
+				break
+			}
+			isNull, err := evalIsNull(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+			p.seenRows++
+			if p.seenRows == v.nth {
+				memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], row)
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+		return memDelta, nil
+	}
 	numRows := uint64(len(rowsInGroup))
 	if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
 		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
@@ -376,3 +422,61 @@ func (v *nthValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialRe
 	}
 	return nil
 }
+
+// nthValueFromLast is the `NTH_VALUE(expr, N) FROM LAST`, which counts the rows from the last row of the frame.
+// The rows of the frame are kept until the final result is appended.
+type nthValueFromLast struct {
+	baseAggFunc
+
+	tp         *types.FieldType
+	nth        uint64
+	ignoreNull bool
+}
+
+type partialResult4NthValueFromLast struct {
+	rows      []chunk.Row
+	evaluator valueEvaluator
+}
+
+func (v *nthValueFromLast) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	ve, veMemDelta := buildValueEvaluator(v.tp)
+	p := &partialResult4NthValueFromLast{evaluator: ve}
+	return PartialResult(p), DefPartialResult4NthValueFromLastSize + veMemDelta
+}
+
+func (v *nthValueFromLast) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4NthValueFromLast)(pr)
+	p.rows = p.rows[:0]
+}
+
+func (v *nthValueFromLast) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4NthValueFromLast)(pr)
+	p.rows = append(p.rows, rowsInGroup...)
+	return int64(len(rowsInGroup)) * DefRowSize, nil
+}
+
+func (v *nthValueFromLast) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4NthValueFromLast)(pr)
+	seenRows := uint64(0)
+	for i := len(p.rows) - 1; i >= 0 && v.nth > 0; i-- {
+		if v.ignoreNull {
+			isNull, err := evalIsNull(v.args[0], p.rows[i])
+			if err != nil {
+				return err
+			}
+			if isNull {
+				continue
+			}
+		}
+		seenRows++
+		if seenRows == v.nth {
+			if _, err := p.evaluator.evaluateRow(sctx, v.args[0], p.rows[i]); err != nil {
+				return err
+			}
+			p.evaluator.appendResult(chk, v.ordinal)
+			return nil
+		}
+	}
+	chk.AppendNull(v.ordinal)
+	return nil
+}
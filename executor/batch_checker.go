@@ -169,26 +169,33 @@ func getKeysNeedCheckOneRow(ctx sessionctx.Context, t table.Table, row []types.D
 		if err1 != nil {
 			return nil, err1
 		}
-		// Pass handle = 0 to GenIndexKey,
-		// due to we only care about distinct key.
-		key, distinct, err1 := v.GenIndexKey(ctx.GetSessionVars().StmtCtx,
-			colVals, kv.IntHandle(0), nil)
+		// A multi-valued index has a unique key for every element of the array.
+		colValsList, err1 := tables.GetIndexedValues(ctx.GetSessionVars().StmtCtx, t.Meta(), v.Meta(), colVals)
 		if err1 != nil {
 			return nil, err1
 		}
-		// Skip the non-distinct keys.
-		if !distinct {
-			continue
-		}
-		colValStr, err1 := formatDataForDupError(colVals)
-		if err1 != nil {
-			return nil, err1
+		for _, colVals := range colValsList {
+			// Pass handle = 0 to GenIndexKey,
+			// due to we only care about distinct key.
+			key, distinct, err1 := v.GenIndexKey(ctx.GetSessionVars().StmtCtx,
+				colVals, kv.IntHandle(0), nil)
+			if err1 != nil {
+				return nil, err1
+			}
+			// Skip the non-distinct keys.
+			if !distinct {
+				continue
+			}
+			colValStr, err1 := formatDataForDupError(colVals)
+			if err1 != nil {
+				return nil, err1
+			}
+			uniqueKeys = append(uniqueKeys, &keyValueWithDupInfo{
+				newKey:       key,
+				dupErr:       kv.ErrKeyExists.FastGenByArgs(colValStr, fmt.Sprintf("%s.%s", v.TableMeta().Name.String(), v.Meta().Name.String())),
+				commonHandle: t.Meta().IsCommonHandle,
+			})
 		}
-		uniqueKeys = append(uniqueKeys, &keyValueWithDupInfo{
-			newKey:       key,
-			dupErr:       kv.ErrKeyExists.FastGenByArgs(colValStr, fmt.Sprintf("%s.%s", v.TableMeta().Name.String(), v.Meta().Name.String())),
-			commonHandle: t.Meta().IsCommonHandle,
-		})
 	}
 	if addChangingColTimes == 1 {
 		row = row[:len(row)-1]
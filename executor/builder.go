@@ -66,6 +66,7 @@ import (
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/rowcodec"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/pingcap/tidb/util/timeutil"
 	"github.com/pingcap/tipb/go-tipb"
 	clientkv "github.com/tikv/client-go/v2/kv"
@@ -219,6 +220,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildShowDDL(v)
 	case *plannercore.PhysicalShowDDLJobs:
 		return b.buildShowDDLJobs(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.ShowDDLJobQueries:
 		return b.buildShowDDLJobQueries(v)
 	case *plannercore.ShowDDLJobQueriesWithRange:
@@ -384,6 +387,19 @@ func (b *executorBuilder) buildShowDDLJobs(v *plannercore.PhysicalShowDDLJobs) E
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) Executor {
+	e := &JSONTableExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		jsonExpr:     v.JSONExpr,
+	}
+	offset := 0
+	e.root, b.err = e.buildScope(v.Path, v.Columns, &offset)
+	if b.err != nil {
+		return nil
+	}
+	return e
+}
+
 func (b *executorBuilder) buildShowDDLJobQueries(v *plannercore.ShowDDLJobQueries) Executor {
 	e := &ShowDDLJobQueriesExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -1164,9 +1180,14 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) Executor {
 	if b.err != nil {
 		return nil
 	}
+	colNames := make([]string, 0, len(v.TargetNames))
+	for _, name := range v.TargetNames {
+		colNames = append(colNames, name.ColName.O)
+	}
 	return &SelectIntoExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:      v.IntoOpt,
+		colNames:     colNames,
 	}
 }
 
@@ -1839,6 +1860,7 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableViews),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
+			strings.ToLower(infoschema.TableCheckConstraints),
 			strings.ToLower(infoschema.TableSequences),
 			strings.ToLower(infoschema.TablePartitions),
 			strings.ToLower(infoschema.TableEngines),
@@ -1870,6 +1892,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableTrxSummary),
 			strings.ToLower(infoschema.TableVariablesInfo),
 			strings.ToLower(infoschema.TableUserAttributes),
+			strings.ToLower(infoschema.TableTiDBTTLTableStatus),
+			strings.ToLower(infoschema.TableResourceGroups),
 			strings.ToLower(infoschema.ClusterTableTrxSummary):
 			return &MemTableReaderExec{
 				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -1909,9 +1933,21 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 					columns: v.Columns,
 				},
 			}
+		case strings.ToLower(infoschema.TableStatementsSummaryHistory),
+			strings.ToLower(infoschema.ClusterTableStatementsSummaryHistory):
+			if stmtsummary.StmtSummaryByDigestMap.PersistentEnabled() {
+				return &MemTableReaderExec{
+					baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+					table:        v.Table,
+					retriever: &stmtSummaryHistoryRetriever{
+						table:     v.Table,
+						columns:   v.Columns,
+						extractor: v.Extractor.(*plannercore.StatementsSummaryExtractor),
+					},
+				}
+			}
+			fallthrough
 		case strings.ToLower(infoschema.TableStatementsSummary),
-			strings.ToLower(infoschema.TableStatementsSummaryHistory),
-			strings.ToLower(infoschema.ClusterTableStatementsSummaryHistory),
 			strings.ToLower(infoschema.ClusterTableStatementsSummary):
 			return &MemTableReaderExec{
 				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -3454,6 +3490,13 @@ func keyColumnsIncludeAllPartitionColumns(keyColumns []int, pe *tables.Partition
 			return false
 		}
 	}
+	if pe.Sub != nil {
+		for _, offset := range pe.Sub.ColumnOffset {
+			if _, ok := tmp[offset]; !ok {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -3500,14 +3543,8 @@ func (builder *dataReaderBuilder) prunePartitionForInnerExecutor(tbl table.Table
 		keyColOffsets[i] = offset
 	}
 
-	offsetMap := make(map[int]bool)
-	for _, offset := range keyColOffsets {
-		offsetMap[offset] = true
-	}
-	for _, offset := range pe.ColumnOffset {
-		if _, ok := offsetMap[offset]; !ok {
-			return condPruneResult, false, nil, nil
-		}
+	if !keyColumnsIncludeAllPartitionColumns(keyColOffsets, pe) {
+		return condPruneResult, false, nil, nil
 	}
 
 	locateKey := make([]types.Datum, len(partitionTbl.Cols()))
@@ -4565,7 +4602,7 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 	partialResults := make([]aggfuncs.PartialResult, 0, len(v.WindowFuncDescs))
 	resultColIdx := v.Schema().Len() - len(v.WindowFuncDescs)
 	for _, desc := range v.WindowFuncDescs {
-		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(b.ctx, desc, false)
+		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(b.ctx, desc, desc.HasDistinct)
 		if err != nil {
 			b.err = err
 			return nil
@@ -4606,6 +4643,10 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 				exec.orderByCols = orderByCols
 				exec.expectedCmpResult = cmpResult
 				exec.isRangeFrame = true
+			} else if v.Frame.Type == ast.Groups {
+				exec.orderByCols = orderByCols
+				exec.peerCmpFuncs = buildPeerCmpFuncs(b.ctx, orderByCols)
+				exec.isGroupsFrame = true
 			}
 		}
 		return exec
@@ -4623,6 +4664,15 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
 		}
+	} else if v.Frame.Type == ast.Groups {
+		processor = &groupsFrameWindowProcessor{
+			windowFuncs:    windowFuncs,
+			partialResults: partialResults,
+			start:          v.Frame.Start,
+			end:            v.Frame.End,
+			orderByCols:    orderByCols,
+			peerCmpFuncs:   buildPeerCmpFuncs(b.ctx, orderByCols),
+		}
 	} else {
 		cmpResult := int64(-1)
 		if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -4644,6 +4694,15 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 	}
 }
 
+// buildPeerCmpFuncs builds the functions to compare the order by columns of two rows of a window.
+func buildPeerCmpFuncs(ctx sessionctx.Context, orderByCols []*expression.Column) []expression.CompareFunc {
+	cmpFuncs := make([]expression.CompareFunc, 0, len(orderByCols))
+	for _, col := range orderByCols {
+		cmpFuncs = append(cmpFuncs, expression.GetCmpFunction(ctx, col, col))
+	}
+	return cmpFuncs
+}
+
 func (b *executorBuilder) buildShuffle(v *plannercore.PhysicalShuffle) *ShuffleExec {
 	base := newBaseExecutor(b.ctx, v.Schema(), v.ID())
 	shuffle := &ShuffleExec{
@@ -17,9 +17,12 @@ package executor_test
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCharsetFeature(t *testing.T) {
@@ -107,3 +110,65 @@ func TestCharsetWithPrefixIndex(t *testing.T) {
 	tk.MustExec("insert into t values ('a', '中文'), ('中文', '中文'), ('一二三', '一二三'), ('b', '一二三')")
 	tk.MustQuery("select * from t").Check(testkit.Rows("a 中文", "中文 中文", "一二三 一二三", "b 一二三"))
 }
+
+func TestUnicodeCharsets(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select hex(convert('a中😂' using utf16)), hex(convert('a中😂' using utf16le)), hex(convert('a中😂' using utf32))").
+		Check(testkit.Rows("00614E2DD83DDE02 61002D4E3DD802DE 0000006100004E2D0001F602"))
+	tk.MustQuery("select hex(convert('a中' using ucs2)), hex(cast('a' as char character set utf16))").Check(testkit.Rows("00614E2D 0061"))
+	tk.MustQuery("select convert(convert('a中😂' using utf16) using utf8mb4), charset(convert('a' using utf32))").Check(testkit.Rows("a中😂 utf32"))
+	tk.MustQuery("select collation(convert('a' using utf16)), collation(convert('a' using utf16le)), collation(convert('a' using ucs2))").
+		Check(testkit.Rows("utf16_general_ci utf16le_general_ci ucs2_general_ci"))
+
+	tk.MustExec("create table t(a varchar(10) charset utf16, b varchar(10) charset utf32 collate utf32_bin, c varchar(10) charset ucs2)")
+	tk.MustExec("insert into t values ('A中😂', 'a', 'Ab')")
+	tk.MustQuery("select hex(a), hex(b), hex(c), length(a), char_length(a) from t").Check(testkit.Rows("00414E2DD83DDE02 00000061 00410062 8 3"))
+	tk.MustQuery("select count(*) from t where a = 'a中😂' and b = 'a' and c = 'ab'").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from t where b = 'A'").Check(testkit.Rows("0"))
+
+	tk.MustGetErrCode("set names utf16", errno.ErrWrongValueForVar)
+	tk.MustGetErrCode("set @@character_set_client = ucs2", errno.ErrWrongValueForVar)
+	tk.MustExec("set @@character_set_connection = utf16")
+	tk.MustQuery("select @@collation_connection").Check(testkit.Rows("utf16_general_ci"))
+}
+
+func TestLatin1CP1252(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a varchar(10) charset latin1)")
+	tk.MustExec("insert into t values ('café €')")
+	// latin1 is treated as utf-8 by default.
+	tk.MustQuery("select hex(a), hex(convert('€' using latin1)) from t").Check(testkit.Rows("636166C3A920E282AC E282AC"))
+
+	// The encoding of latin1 is decided when the cluster bootstraps for the first time.
+	restoreConfig := config.RestoreFunc()
+	defer restoreConfig()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Latin1CP1252EnabledOnFirstBootstrap = true
+	})
+	defer charset.SetLatin1CP1252Enabled(false)
+	store = testkit.CreateMockStore(t)
+	require.True(t, charset.Latin1CP1252Enabled())
+
+	tk = testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a varchar(10) charset latin1)")
+	tk.MustExec("insert into t values ('café €')")
+	tk.MustQuery("select a, hex(a), hex(convert('€' using latin1)), length(a) from t").Check(testkit.Rows("café € 636166E92080 80 6"))
+	tk.MustQuery("select convert(0x636166E92080 using latin1)").Check(testkit.Rows("café €"))
+	tk.MustQuery("select hex(convert('中' using latin1))").Check(testkit.Rows("3F"))
+
+	// latin1_bin compares the cp1252 bytes like MySQL, '€'(0x80) and 'Œ'(0x8C) are less than 'é'(0xE9).
+	tk.MustExec("create table t2(a varchar(10) charset latin1 collate latin1_bin, key(a))")
+	tk.MustExec("insert into t2 values ('é'), ('€'), ('a'), ('ÿ'), ('Œ')")
+	tk.MustQuery("select a from t2 ignore index(a) order by a").Check(testkit.Rows("a", "€", "Œ", "é", "ÿ"))
+	tk.MustQuery("select a from t2 use index(a) order by a").Check(testkit.Rows("a", "€", "Œ", "é", "ÿ"))
+	tk.MustQuery("select a from t2 use index(a) where a > 'a' and a < 'é' order by a").Check(testkit.Rows("€", "Œ"))
+	tk.MustQuery("select a from t2 ignore index(a) where a > 'a' and a < 'é' order by a").Check(testkit.Rows("€", "Œ"))
+	tk.MustQuery("select a from t2 use index(a) where a >= '€' order by a desc").Check(testkit.Rows("ÿ", "é", "Œ", "€"))
+}
@@ -421,6 +421,11 @@ func TestExplainStatementsSummary(t *testing.T) {
 		`MemTableScan_5 10000.00 root table:STATEMENTS_SUMMARY digests: ["abcdefg"]`))
 	tk.MustQuery("desc select * from information_schema.statements_summary where digest in ('a','b','c')").Check(testkit.RowsWithSep(" ",
 		`MemTableScan_5 10000.00 root table:STATEMENTS_SUMMARY digests: ["a","b","c"]`))
+	// The time predicates are kept to filter the rows, the time range is only used to skip the persisted history.
+	rows := tk.MustQuery("desc select * from information_schema.statements_summary_history where digest = 'a' and " +
+		"summary_begin_time >= '2022-10-10 10:00:00' and summary_end_time <= '2022-10-10 11:00:00'").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, `digests: ["a"], start_time:2022-10-10 10:00:00, end_time:2022-10-10 11:00:00`, rows[1][4])
 }
 
 func TestFix29401(t *testing.T) {
@@ -16,14 +16,28 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	plannerutil "github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 // IndexAdviseExec represents a index advise executor.
@@ -123,13 +137,668 @@ func (e *IndexAdviseInfo) GetIndexAdvice(ctx context.Context, data []byte) error
 	if err := e.prepareInfo(data); err != nil {
 		return err
 	}
-	// TODO: Finish the index advise process. It will be done in another PR.
+	advisor := &indexAdvisor{
+		info:      e,
+		sctx:      e.Ctx,
+		startTime: time.Now(),
+	}
+	sessVars := e.Ctx.GetSessionVars()
+	originHypoIndexes := sessVars.HypoIndexes
+	defer func() {
+		sessVars.HypoIndexes = originHypoIndexes
+	}()
+	result, err := advisor.advise(ctx)
+	if err != nil {
+		return err
+	}
+	e.Result = result
+	return nil
+}
+
+const (
+	// maxAdviseIndexColumns is the maximum number of columns of a recommended index.
+	maxAdviseIndexColumns = 3
+)
+
+// indexAdviseQuery is a statement of the workload which can benefit from indexes.
+type indexAdviseQuery struct {
+	stmt     ast.StmtNode
+	tableIDs map[int64]struct{}
+	// cost is the estimated cost with the recommended indexes chosen so far.
+	cost float64
+}
+
+// hypoIndex is a candidate index which only exists in the optimizer.
+type hypoIndex struct {
+	dbName  model.CIStr
+	tblInfo *model.TableInfo
+	cols    []*model.ColumnInfo
+	info    *model.IndexInfo
+}
+
+func (h *hypoIndex) key() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatInt(h.tblInfo.ID, 10))
+	for _, col := range h.cols {
+		sb.WriteByte(',')
+		sb.WriteString(col.Name.L)
+	}
+	return sb.String()
+}
+
+// indexAdvisor searches the indexes which reduce the estimated cost of the workload most.
+type indexAdvisor struct {
+	info      *IndexAdviseInfo
+	sctx      sessionctx.Context
+	startTime time.Time
+
+	queries    []*indexAdviseQuery
+	candidates []*hypoIndex
+	chosen     []*hypoIndex
+	// nextIndexID records the next ID of the hypothetical index for each table.
+	nextIndexID map[int64]int64
+}
+
+func (a *indexAdvisor) advise(ctx context.Context) (*IndexAdvice, error) {
+	a.nextIndexID = make(map[int64]int64)
+	a.sctx.GetSessionVars().HypoIndexes = nil
+	candidateKeys := make(map[string]struct{})
+	for _, stmts := range a.info.StmtNodes {
+		for _, stmt := range stmts {
+			switch stmt.(type) {
+			case *ast.SelectStmt, *ast.SetOprStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+			default:
+				continue
+			}
+			if err := plannercore.Preprocess(ctx, a.sctx, stmt); err != nil {
+				a.appendSkipWarning(stmt, err)
+				continue
+			}
+			p, err := a.optimize(ctx, stmt)
+			if err != nil {
+				a.appendSkipWarning(stmt, err)
+				continue
+			}
+			physicalPlan := getAdvisePhysicalPlan(p)
+			if physicalPlan == nil {
+				continue
+			}
+			cost, err := plannercore.GetPlanCost(physicalPlan)
+			if err != nil {
+				return nil, err
+			}
+			query := &indexAdviseQuery{stmt: stmt, tableIDs: make(map[int64]struct{}), cost: cost}
+			for _, cand := range a.extractCandidates(physicalPlan) {
+				query.tableIDs[cand.tblInfo.ID] = struct{}{}
+				key := cand.key()
+				if _, ok := candidateKeys[key]; ok {
+					continue
+				}
+				candidateKeys[key] = struct{}{}
+				a.candidates = append(a.candidates, cand)
+			}
+			if len(query.tableIDs) > 0 {
+				a.queries = append(a.queries, query)
+			}
+		}
+	}
+
+	originCost := a.workloadCost()
+	result := &IndexAdvice{}
+	for len(a.candidates) > 0 {
+		if a.timeout() {
+			a.sctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("Index Advise: the search is stopped because the maximum execution time is reached"))
+			break
+		}
+		best, bestCosts, err := a.searchBestCandidate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if best < 0 {
+			break
+		}
+		cand := a.candidates[best]
+		a.candidates = append(a.candidates[:best], a.candidates[best+1:]...)
+		before := a.workloadCost()
+		for i, query := range a.queries {
+			query.cost = bestCosts[i]
+		}
+		a.chosen = append(a.chosen, cand)
+		reduction := before - a.workloadCost()
+		ratio := 0.0
+		if originCost > 0 {
+			ratio = reduction / originCost
+		}
+		result.rows = append(result.rows, newIndexAdviceRow(cand, reduction, ratio))
+	}
+	return result, nil
+}
+
+// searchBestCandidate returns the candidate which reduces the workload cost most and the query costs with it.
+// -1 is returned if none of the candidates reduces the cost.
+func (a *indexAdvisor) searchBestCandidate(ctx context.Context) (int, []float64, error) {
+	best, bestCost := -1, a.workloadCost()
+	var bestCosts []float64
+	for i, cand := range a.candidates {
+		if a.timeout() {
+			break
+		}
+		if !a.withinIndexNumLimit(cand) {
+			continue
+		}
+		a.setHypoIndexes(cand)
+		costs := make([]float64, len(a.queries))
+		totalCost := 0.0
+		for j, query := range a.queries {
+			costs[j] = query.cost
+			if _, ok := query.tableIDs[cand.tblInfo.ID]; ok {
+				p, err := a.optimize(ctx, query.stmt)
+				if err != nil {
+					return -1, nil, err
+				}
+				if physicalPlan := getAdvisePhysicalPlan(p); physicalPlan != nil {
+					cost, err := plannercore.GetPlanCost(physicalPlan)
+					if err != nil {
+						return -1, nil, err
+					}
+					costs[j] = math.Min(cost, query.cost)
+				}
+			}
+			totalCost += costs[j]
+		}
+		if totalCost < bestCost {
+			best, bestCost, bestCosts = i, totalCost, costs
+		}
+	}
+	return best, bestCosts, nil
+}
+
+func (a *indexAdvisor) optimize(ctx context.Context, stmt ast.StmtNode) (plannercore.Plan, error) {
+	// The statements are only explained to get the estimated cost, which makes the hypothetical indexes visible.
+	stmtCtx := a.sctx.GetSessionVars().StmtCtx
+	inExplain, inExplainAnalyze := stmtCtx.InExplainStmt, stmtCtx.InExplainAnalyzeStmt
+	stmtCtx.InExplainStmt, stmtCtx.InExplainAnalyzeStmt = true, false
+	defer func() {
+		stmtCtx.InExplainStmt, stmtCtx.InExplainAnalyzeStmt = inExplain, inExplainAnalyze
+	}()
+	is := sessiontxn.GetTxnManager(a.sctx).GetTxnInfoSchema()
+	p, _, err := planner.Optimize(ctx, a.sctx, stmt, is)
+	return p, err
+}
+
+// setHypoIndexes makes the chosen indexes and the candidate visible to the optimizer.
+func (a *indexAdvisor) setHypoIndexes(cand *hypoIndex) {
+	hypoIndexes := make(map[int64][]*model.IndexInfo, len(a.chosen)+1)
+	for _, idx := range a.chosen {
+		hypoIndexes[idx.tblInfo.ID] = append(hypoIndexes[idx.tblInfo.ID], idx.info)
+	}
+	hypoIndexes[cand.tblInfo.ID] = append(hypoIndexes[cand.tblInfo.ID], cand.info)
+	a.sctx.GetSessionVars().HypoIndexes = hypoIndexes
+}
+
+func (a *indexAdvisor) withinIndexNumLimit(cand *hypoIndex) bool {
+	limit := a.info.MaxIndexNum
+	if limit == nil {
+		return true
+	}
+	var tblCnt, dbCnt uint64
+	for _, idx := range a.chosen {
+		if idx.dbName.L != cand.dbName.L {
+			continue
+		}
+		dbCnt++
+		if idx.tblInfo.ID == cand.tblInfo.ID {
+			tblCnt++
+		}
+	}
+	return (limit.PerTable == ast.UnspecifiedSize || tblCnt < limit.PerTable) &&
+		(limit.PerDB == ast.UnspecifiedSize || dbCnt < limit.PerDB)
+}
+
+func (a *indexAdvisor) timeout() bool {
+	if a.info.MaxMinutes == ast.UnspecifiedSize || a.info.MaxMinutes > math.MaxInt64/uint64(time.Minute) {
+		return false
+	}
+	return time.Since(a.startTime) >= time.Duration(a.info.MaxMinutes)*time.Minute
+}
+
+func (a *indexAdvisor) workloadCost() float64 {
+	cost := 0.0
+	for _, query := range a.queries {
+		cost += query.cost
+	}
+	return cost
+}
+
+func (a *indexAdvisor) appendSkipWarning(stmt ast.StmtNode, err error) {
+	a.sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("Index Advise: skip statement '%s': %v", stmt.Text(), err))
+}
+
+func getAdvisePhysicalPlan(p plannercore.Plan) plannercore.PhysicalPlan {
+	switch x := p.(type) {
+	case *plannercore.Update:
+		return x.SelectPlan
+	case *plannercore.Delete:
+		return x.SelectPlan
+	case plannercore.PhysicalPlan:
+		return x
+	}
 	return nil
 }
 
-// IndexAdvice represents the index advice.
+// adviseColumn is a column of the table which is read by the plan.
+type adviseColumn struct {
+	dbName  model.CIStr
+	tblInfo *model.TableInfo
+	col     *model.ColumnInfo
+}
+
+// adviseTableUsage records how the columns of a table are used by a query.
+type adviseTableUsage struct {
+	dbName  model.CIStr
+	tblInfo *model.TableInfo
+	// eqCols are the columns compared with constants by equal conditions or used as join keys.
+	eqCols []*model.ColumnInfo
+	// rangeCols are the columns compared with constants by range conditions.
+	rangeCols []*model.ColumnInfo
+	// orderCols are the columns used by sort or group by.
+	orderCols []*model.ColumnInfo
+}
+
+func appendAdviseColumn(cols []*model.ColumnInfo, col *model.ColumnInfo) []*model.ColumnInfo {
+	for _, c := range cols {
+		if c.ID == col.ID {
+			return cols
+		}
+	}
+	return append(cols, col)
+}
+
+// adviseCollector collects the indexable columns from a physical plan.
+type adviseCollector struct {
+	columns map[int64]*adviseColumn
+	visited map[int]struct{}
+
+	eqExprs    []*expression.Column
+	rangeExprs []*expression.Column
+	orderExprs [][]*expression.Column
+}
+
+func (c *adviseCollector) visit(p plannercore.PhysicalPlan) {
+	if p == nil {
+		return
+	}
+	if _, ok := c.visited[p.ID()]; ok {
+		return
+	}
+	c.visited[p.ID()] = struct{}{}
+	switch x := p.(type) {
+	case *plannercore.PhysicalTableReader:
+		c.visitPlans(x.TablePlans)
+	case *plannercore.PhysicalIndexReader:
+		c.visitPlans(x.IndexPlans)
+	case *plannercore.PhysicalIndexLookUpReader:
+		c.visitPlans(x.IndexPlans)
+		c.visitPlans(x.TablePlans)
+	case *plannercore.PhysicalIndexMergeReader:
+		for _, partialPlans := range x.PartialPlans {
+			c.visitPlans(partialPlans)
+		}
+		c.visitPlans(x.TablePlans)
+	case *plannercore.PhysicalTableScan:
+		c.addSchemaColumns(x.DBName, x.Table, x.Schema())
+		c.addConditions(x.AccessCondition)
+	case *plannercore.PhysicalIndexScan:
+		c.addSchemaColumns(x.DBName, x.Table, x.Schema())
+		c.addConditions(x.AccessCondition)
+	case *plannercore.PhysicalSelection:
+		c.addConditions(x.Conditions)
+	case *plannercore.PhysicalHashJoin:
+		c.eqExprs = append(append(c.eqExprs, x.LeftJoinKeys...), x.RightJoinKeys...)
+	case *plannercore.PhysicalMergeJoin:
+		c.eqExprs = append(append(c.eqExprs, x.LeftJoinKeys...), x.RightJoinKeys...)
+	case *plannercore.PhysicalIndexJoin:
+		c.eqExprs = append(append(c.eqExprs, x.OuterJoinKeys...), x.InnerJoinKeys...)
+	case *plannercore.PhysicalIndexHashJoin:
+		c.eqExprs = append(append(c.eqExprs, x.OuterJoinKeys...), x.InnerJoinKeys...)
+	case *plannercore.PhysicalIndexMergeJoin:
+		c.eqExprs = append(append(c.eqExprs, x.OuterJoinKeys...), x.InnerJoinKeys...)
+	case *plannercore.PhysicalSort:
+		c.addOrderItems(x.ByItems)
+	case *plannercore.PhysicalTopN:
+		c.addOrderItems(x.ByItems)
+	case *plannercore.PhysicalHashAgg:
+		c.addGroupByItems(x.GroupByItems)
+	case *plannercore.PhysicalStreamAgg:
+		c.addGroupByItems(x.GroupByItems)
+	}
+	for _, child := range p.Children() {
+		c.visit(child)
+	}
+}
+
+func (c *adviseCollector) visitPlans(plans []plannercore.PhysicalPlan) {
+	for _, p := range plans {
+		c.visit(p)
+	}
+}
+
+func (c *adviseCollector) addSchemaColumns(dbName model.CIStr, tblInfo *model.TableInfo, schema *expression.Schema) {
+	for _, col := range schema.Columns {
+		colInfo := model.FindColumnInfoByID(tblInfo.Columns, col.ID)
+		if colInfo == nil || colInfo.IsGenerated() || types.IsTypeBlob(colInfo.GetType()) || colInfo.GetType() == mysql.TypeJSON {
+			continue
+		}
+		c.columns[col.UniqueID] = &adviseColumn{dbName: dbName, tblInfo: tblInfo, col: colInfo}
+	}
+}
+
+func (c *adviseCollector) addConditions(conds []expression.Expression) {
+	for _, cond := range conds {
+		sf, ok := cond.(*expression.ScalarFunction)
+		if !ok {
+			continue
+		}
+		args := sf.GetArgs()
+		switch sf.FuncName.L {
+		case ast.LogicAnd:
+			c.addConditions(args)
+		case ast.EQ, ast.NullEQ:
+			if col, ok := args[0].(*expression.Column); ok {
+				c.eqExprs = append(c.eqExprs, col)
+			}
+			if col, ok := args[1].(*expression.Column); ok {
+				c.eqExprs = append(c.eqExprs, col)
+			}
+		case ast.In, ast.IsNull:
+			if col, ok := args[0].(*expression.Column); ok {
+				c.eqExprs = append(c.eqExprs, col)
+			}
+		case ast.LT, ast.LE, ast.GT, ast.GE, ast.Like:
+			if col, ok := args[0].(*expression.Column); ok {
+				c.rangeExprs = append(c.rangeExprs, col)
+			}
+			if col, ok := args[1].(*expression.Column); ok && sf.FuncName.L != ast.Like {
+				c.rangeExprs = append(c.rangeExprs, col)
+			}
+		}
+	}
+}
+
+func (c *adviseCollector) addOrderItems(items []*plannerutil.ByItems) {
+	exprs := make([]expression.Expression, 0, len(items))
+	for _, item := range items {
+		exprs = append(exprs, item.Expr)
+	}
+	c.addGroupByItems(exprs)
+}
+
+func (c *adviseCollector) addGroupByItems(items []expression.Expression) {
+	cols := make([]*expression.Column, 0, len(items))
+	for _, item := range items {
+		col, ok := item.(*expression.Column)
+		if !ok {
+			break
+		}
+		cols = append(cols, col)
+	}
+	if len(cols) > 0 {
+		c.orderExprs = append(c.orderExprs, cols)
+	}
+}
+
+// extractCandidates extracts the indexable columns from the physical plan and builds the candidate indexes.
+func (a *indexAdvisor) extractCandidates(p plannercore.PhysicalPlan) []*hypoIndex {
+	c := &adviseCollector{columns: make(map[int64]*adviseColumn), visited: make(map[int]struct{})}
+	c.visit(p)
+
+	usages := make(map[int64]*adviseTableUsage)
+	tableIDs := make([]int64, 0, 2)
+	getUsage := func(col *expression.Column) (*adviseTableUsage, *model.ColumnInfo) {
+		advCol, ok := c.columns[col.UniqueID]
+		if !ok {
+			return nil, nil
+		}
+		usage, ok := usages[advCol.tblInfo.ID]
+		if !ok {
+			usage = &adviseTableUsage{dbName: advCol.dbName, tblInfo: advCol.tblInfo}
+			usages[advCol.tblInfo.ID] = usage
+			tableIDs = append(tableIDs, advCol.tblInfo.ID)
+		}
+		return usage, advCol.col
+	}
+	for _, col := range c.eqExprs {
+		if usage, colInfo := getUsage(col); usage != nil {
+			usage.eqCols = appendAdviseColumn(usage.eqCols, colInfo)
+		}
+	}
+	for _, col := range c.rangeExprs {
+		if usage, colInfo := getUsage(col); usage != nil {
+			usage.rangeCols = appendAdviseColumn(usage.rangeCols, colInfo)
+		}
+	}
+	for _, cols := range c.orderExprs {
+		// Only the order on the columns of a single table can be provided by an index.
+		var orderUsage *adviseTableUsage
+		orderCols := make([]*model.ColumnInfo, 0, len(cols))
+		for _, col := range cols {
+			usage, colInfo := getUsage(col)
+			if usage == nil || (orderUsage != nil && orderUsage != usage) {
+				orderCols = nil
+				break
+			}
+			orderUsage = usage
+			orderCols = append(orderCols, colInfo)
+		}
+		if orderUsage != nil && len(orderCols) > 0 && len(orderUsage.orderCols) == 0 {
+			orderUsage.orderCols = orderCols
+		}
+	}
+
+	candidates := make([]*hypoIndex, 0, len(tableIDs)*4)
+	for _, tblID := range tableIDs {
+		usage := usages[tblID]
+		for _, cols := range usage.candidateColumns() {
+			if cand := a.newHypoIndex(usage.dbName, usage.tblInfo, cols); cand != nil {
+				candidates = append(candidates, cand)
+			}
+		}
+	}
+	return candidates
+}
+
+// candidateColumns returns the columns of the candidate indexes, which are the single columns and
+// the composite of the equal columns followed by a range column or the order columns.
+func (u *adviseTableUsage) candidateColumns() [][]*model.ColumnInfo {
+	result := make([][]*model.ColumnInfo, 0, len(u.eqCols)+len(u.rangeCols)+2)
+	for _, cols := range [][]*model.ColumnInfo{u.eqCols, u.rangeCols, u.orderCols} {
+		for _, col := range cols {
+			result = append(result, []*model.ColumnInfo{col})
+		}
+	}
+	composite := make([]*model.ColumnInfo, 0, maxAdviseIndexColumns)
+	for _, col := range u.eqCols {
+		if len(composite) >= maxAdviseIndexColumns-1 {
+			break
+		}
+		composite = append(composite, col)
+	}
+	if len(u.rangeCols) > 0 {
+		composite = appendAdviseColumn(composite, u.rangeCols[0])
+	} else {
+		for _, col := range u.orderCols {
+			if len(composite) >= maxAdviseIndexColumns {
+				break
+			}
+			composite = appendAdviseColumn(composite, col)
+		}
+	}
+	if len(composite) > 1 {
+		result = append(result, composite)
+	}
+	return result
+}
+
+// newHypoIndex builds a hypothetical index on the columns. It returns nil if an existing index
+// already starts with the columns.
+func (a *indexAdvisor) newHypoIndex(dbName model.CIStr, tblInfo *model.TableInfo, cols []*model.ColumnInfo) *hypoIndex {
+	if len(cols) == 1 && tblInfo.PKIsHandle && mysql.HasPriKeyFlag(cols[0].GetFlag()) {
+		return nil
+	}
+	for _, idx := range tblInfo.Indices {
+		if len(idx.Columns) < len(cols) {
+			continue
+		}
+		covered := true
+		for i, col := range cols {
+			if idx.Columns[i].Name.L != col.Name.L || idx.Columns[i].Length != types.UnspecifiedLength {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return nil
+		}
+	}
+
+	id, ok := a.nextIndexID[tblInfo.ID]
+	if !ok {
+		id = tblInfo.MaxIndexID
+	}
+	id++
+	a.nextIndexID[tblInfo.ID] = id
+
+	names := make([]string, 0, len(cols)+1)
+	names = append(names, "idx")
+	idxCols := make([]*model.IndexColumn, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name.L)
+		idxCols = append(idxCols, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	name := strings.Join(names, "_")
+	for i := 1; tblInfo.FindIndexByName(strings.ToLower(name)) != nil; i++ {
+		name = fmt.Sprintf("%s_%d", strings.Join(names, "_"), i)
+	}
+	return &hypoIndex{
+		dbName:  dbName,
+		tblInfo: tblInfo,
+		cols:    cols,
+		info: &model.IndexInfo{
+			ID:      id,
+			Name:    model.NewCIStr(name),
+			Table:   tblInfo.Name,
+			Columns: idxCols,
+			State:   model.StatePublic,
+			Tp:      model.IndexTypeBtree,
+		},
+	}
+}
+
+// IndexAdvice represents the index advice. It implements the sqlexec.RecordSet interface.
 type IndexAdvice struct {
-	// TODO: Define index advice data structure and implements the ResultSet interface. It will be done in another PR
+	rows   [][]interface{}
+	cursor int
+}
+
+func newIndexAdviceRow(idx *hypoIndex, reduction, ratio float64) []interface{} {
+	colNames := make([]string, 0, len(idx.cols))
+	for _, col := range idx.cols {
+		colNames = append(colNames, stringutil.Escape(col.Name.O, mysql.ModeNone))
+	}
+	ddl := fmt.Sprintf("CREATE INDEX %s ON %s.%s (%s)",
+		stringutil.Escape(idx.info.Name.O, mysql.ModeNone),
+		stringutil.Escape(idx.dbName.O, mysql.ModeNone),
+		stringutil.Escape(idx.tblInfo.Name.O, mysql.ModeNone),
+		strings.Join(colNames, ", "))
+	indexColumns := make([]string, 0, len(idx.cols))
+	for _, col := range idx.cols {
+		indexColumns = append(indexColumns, col.Name.O)
+	}
+	return []interface{}{
+		idx.dbName.O,
+		idx.tblInfo.Name.O,
+		idx.info.Name.O,
+		strings.Join(indexColumns, ","),
+		ddl,
+		reduction,
+		ratio,
+	}
+}
+
+var indexAdviceFields = []struct {
+	name string
+	tp   byte
+	flen int
+}{
+	{"Db_name", mysql.TypeVarchar, 64},
+	{"Table_name", mysql.TypeVarchar, 64},
+	{"Index_name", mysql.TypeVarchar, 64},
+	{"Index_columns", mysql.TypeVarchar, 256},
+	{"Index_DDL", mysql.TypeVarchar, 512},
+	{"Est_cost_reduction", mysql.TypeDouble, 22},
+	{"Est_cost_reduction_ratio", mysql.TypeDouble, 22},
+}
+
+// Fields implements the sqlexec.RecordSet Fields interface.
+func (a *IndexAdvice) Fields() []*ast.ResultField {
+	fields := make([]*ast.ResultField, 0, len(indexAdviceFields))
+	for _, f := range indexAdviceFields {
+		ft := types.NewFieldType(f.tp)
+		ft.SetFlen(f.flen)
+		if f.tp == mysql.TypeVarchar {
+			ft.SetCharset(mysql.DefaultCharset)
+			ft.SetCollate(mysql.DefaultCollationName)
+		} else {
+			ft.SetCharset(charset.CharsetBin)
+			ft.SetCollate(charset.CollationBin)
+		}
+		name := model.NewCIStr(f.name)
+		fields = append(fields, &ast.ResultField{
+			Column:       &model.ColumnInfo{Name: name, FieldType: *ft},
+			ColumnAsName: name,
+		})
+	}
+	return fields
+}
+
+// Next implements the sqlexec.RecordSet Next interface.
+func (a *IndexAdvice) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for ; a.cursor < len(a.rows) && !req.IsFull(); a.cursor++ {
+		row := a.rows[a.cursor]
+		for i, d := range row {
+			switch x := d.(type) {
+			case string:
+				req.AppendString(i, x)
+			case float64:
+				req.AppendFloat64(i, x)
+			}
+		}
+	}
+	return nil
+}
+
+// NewChunk implements the sqlexec.RecordSet NewChunk interface.
+func (a *IndexAdvice) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	fields := a.Fields()
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	if alloc != nil {
+		return alloc.Alloc(fieldTypes, 0, len(a.rows)+1)
+	}
+	return chunk.New(fieldTypes, len(a.rows), len(a.rows)+1)
+}
+
+// Close implements the sqlexec.RecordSet Close interface.
+func (a *IndexAdvice) Close() error {
+	a.cursor = 0
+	return nil
 }
 
 // IndexAdviseVarKeyType is a dummy type to avoid naming collision in context.
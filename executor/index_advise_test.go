@@ -15,7 +15,9 @@
 package executor_test
 
 import (
+	"context"
 	"os"
+	"sort"
 	"testing"
 
 	"github.com/pingcap/tidb/executor"
@@ -55,7 +57,6 @@ func TestIndexAdvise(t *testing.T) {
 		"\n")
 	require.NoError(t, err)
 
-	// TODO: Using "tastCase" to do more test when we finish the index advisor completely.
 	tk.MustExec("index advise local infile '/tmp/index_advise.sql' max_minutes 3 max_idxnum per_table 4 per_db 5")
 	ctx := tk.Session().(sessionctx.Context)
 	ia, ok := ctx.Value(executor.IndexAdviseVarKey).(*executor.IndexAdviseInfo)
@@ -65,3 +66,51 @@ func TestIndexAdvise(t *testing.T) {
 	require.Equal(t, uint64(4), ia.MaxIndexNum.PerTable)
 	require.Equal(t, uint64(5), ia.MaxIndexNum.PerDB)
 }
+
+func TestIndexAdviseResult(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int, key idx_c(c))")
+
+	workload := []byte("select * from t where a = 1;\n" +
+		"select * from t where b > 1 and b < 10;\n" +
+		"select * from t where c = 1;\n" +
+		"insert into t values (1, 1, 1);\n")
+	getAdvice := func(sql string) [][]string {
+		tk.MustExec(sql)
+		ctx := tk.Session().(sessionctx.Context)
+		ia, ok := ctx.Value(executor.IndexAdviseVarKey).(*executor.IndexAdviseInfo)
+		require.True(t, ok)
+		ctx.SetValue(executor.IndexAdviseVarKey, nil)
+		require.NoError(t, ia.GetIndexAdvice(context.Background(), workload))
+
+		rs := ia.Result
+		require.Len(t, rs.Fields(), 7)
+		chk := rs.NewChunk(nil)
+		require.NoError(t, rs.Next(context.Background(), chk))
+		result := make([][]string, 0, chk.NumRows())
+		for i := 0; i < chk.NumRows(); i++ {
+			row := chk.GetRow(i)
+			require.Greater(t, row.GetFloat64(5), 0.0)
+			result = append(result, []string{row.GetString(0), row.GetString(1), row.GetString(2), row.GetString(3), row.GetString(4)})
+		}
+		require.NoError(t, rs.Close())
+		require.Nil(t, tk.Session().GetSessionVars().HypoIndexes)
+		return result
+	}
+
+	result := getAdvice("index advise local infile '/tmp/index_advise.sql' max_minutes 1")
+	sort.Slice(result, func(i, j int) bool { return result[i][2] < result[j][2] })
+	require.Equal(t, [][]string{
+		{"test", "t", "idx_a", "a", "CREATE INDEX `idx_a` ON `test`.`t` (`a`)"},
+		{"test", "t", "idx_b", "b", "CREATE INDEX `idx_b` ON `test`.`t` (`b`)"},
+	}, result)
+
+	result = getAdvice("index advise local infile '/tmp/index_advise.sql' max_minutes 1 max_idxnum per_table 1 per_db 1")
+	require.Len(t, result, 1)
+	require.NotEqual(t, "idx_c", result[0][2])
+
+	// The table is not changed by the index advisor.
+	tk.MustQuery("select index_name from information_schema.statistics where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("idx_c"))
+}
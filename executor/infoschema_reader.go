@@ -63,6 +63,7 @@ import (
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mathutil"
 	"github.com/pingcap/tidb/util/pdapi"
+	"github.com/pingcap/tidb/util/resourcegroup"
 	"github.com/pingcap/tidb/util/resourcegrouptag"
 	"github.com/pingcap/tidb/util/sem"
 	"github.com/pingcap/tidb/util/set"
@@ -109,6 +110,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromTables(ctx, sctx, dbs)
 		case infoschema.TableReferConst:
 			err = e.setDataFromReferConst(ctx, sctx, dbs)
+		case infoschema.TableCheckConstraints:
+			e.setDataFromCheckConstraints(sctx, dbs)
 		case infoschema.TableSequences:
 			e.setDataFromSequences(sctx, dbs)
 		case infoschema.TablePartitions:
@@ -166,6 +169,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForAttributes(sctx, is)
 		case infoschema.TablePlacementPolicies:
 			err = e.setDataFromPlacementPolicies(sctx)
+		case infoschema.TableResourceGroups:
+			e.setDataFromResourceGroups(sctx)
 		case infoschema.TableTrxSummary:
 			err = e.setDataForTrxSummary(sctx)
 		case infoschema.ClusterTableTrxSummary:
@@ -174,6 +179,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForVariablesInfo(sctx)
 		case infoschema.TableUserAttributes:
 			err = e.setDataForUserAttributes(ctx, sctx)
+		case infoschema.TableTiDBTTLTableStatus:
+			err = e.setDataForTiDBTTLTableStatus(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -639,6 +646,34 @@ func (e *memtableRetriever) setDataFromReferConst(ctx context.Context, sctx sess
 	return nil
 }
 
+func (e *memtableRetriever) setDataFromCheckConstraints(sctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(sctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			if len(table.Constraints) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			for _, constr := range table.Constraints {
+				if constr.State != model.StatePublic {
+					continue
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,                  // CONSTRAINT_CATALOG
+					schema.Name.O,                          // CONSTRAINT_SCHEMA
+					constr.Name.O,                          // CONSTRAINT_NAME
+					fmt.Sprintf("(%s)", constr.ExprString), // CHECK_CLAUSE
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) setDataFromTables(ctx context.Context, sctx sessionctx.Context, schemas []*model.DBInfo) error {
 	err := tableStatsCache.update(ctx, sctx)
 	if err != nil {
@@ -1124,6 +1159,22 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 							buf.WriteString(col.String())
 						}
 						partitionExpr = buf.String()
+					} else if table.Partition.Type == model.PartitionTypeKey {
+						partitionExpr = joinPartitionColumns(table.Partition.Columns)
+					}
+
+					// The subpartitions are the physical partitions of a subpartitioned table.
+					partitionName, partitionPos := pi.Name.O, i+1
+					var subPartitionName, subPartitionPos, subPartitionMethod, subPartitionExpr interface{}
+					if sub := table.Partition.Sub; sub != nil {
+						num := int(sub.Num)
+						partitionName, partitionPos = pi.ParentName.O, i/num+1
+						subPartitionName, subPartitionPos = pi.Name.O, i%num+1
+						subPartitionMethod = sub.Type.String()
+						subPartitionExpr = sub.Expr
+						if sub.Type == model.PartitionTypeKey {
+							subPartitionExpr = joinPartitionColumns(sub.Columns)
+						}
 					}
 
 					var policyName interface{}
@@ -1134,14 +1185,14 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						infoschema.CatalogVal, // TABLE_CATALOG
 						schema.Name.O,         // TABLE_SCHEMA
 						table.Name.O,          // TABLE_NAME
-						pi.Name.O,             // PARTITION_NAME
-						nil,                   // SUBPARTITION_NAME
-						i+1,                   // PARTITION_ORDINAL_POSITION
-						nil,                   // SUBPARTITION_ORDINAL_POSITION
+						partitionName,         // PARTITION_NAME
+						subPartitionName,      // SUBPARTITION_NAME
+						partitionPos,          // PARTITION_ORDINAL_POSITION
+						subPartitionPos,       // SUBPARTITION_ORDINAL_POSITION
 						partitionMethod,       // PARTITION_METHOD
-						nil,                   // SUBPARTITION_METHOD
+						subPartitionMethod,    // SUBPARTITION_METHOD
 						partitionExpr,         // PARTITION_EXPRESSION
-						nil,                   // SUBPARTITION_EXPRESSION
+						subPartitionExpr,      // SUBPARTITION_EXPRESSION
 						partitionDesc,         // PARTITION_DESCRIPTION
 						rowCount,              // TABLE_ROWS
 						avgRowLength,          // AVG_ROW_LENGTH
@@ -1168,6 +1219,14 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 	return nil
 }
 
+func joinPartitionColumns(columns []model.CIStr) string {
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, col.String())
+	}
+	return strings.Join(names, ",")
+}
+
 func (e *memtableRetriever) setDataFromIndexes(ctx sessionctx.Context, schemas []*model.DBInfo) {
 	checker := privilege.GetPrivilegeManager(ctx)
 	var rows [][]types.Datum
@@ -2140,6 +2199,33 @@ func dataForAnalyzeStatusHelper(sctx sessionctx.Context) (rows [][]types.Datum,
 			procID,        // PROCESS_ID
 		))
 	}
+	// The pending jobs are the tables waiting in the auto analyze queue.
+	const queueSQL = "SELECT table_schema, table_name, partition_name, weight, reason FROM mysql.analyze_queue ORDER BY weight DESC LIMIT %?"
+	chunkRows, _, err = exec.ExecRestrictedSQL(ctx, nil, queueSQL, maxAnalyzeJobs)
+	if err != nil {
+		return nil, err
+	}
+	for _, chunkRow := range chunkRows {
+		dbName := chunkRow.GetString(0)
+		tableName := chunkRow.GetString(1)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, dbName, tableName, "", mysql.AllPrivMask) {
+			continue
+		}
+		jobInfo := fmt.Sprintf("auto analyze pending, priority %.4f: %s", chunkRow.GetFloat64(3), chunkRow.GetString(4))
+		rows = append(rows, types.MakeDatums(
+			dbName,                // TABLE_SCHEMA
+			tableName,             // TABLE_NAME
+			chunkRow.GetString(2), // PARTITION_NAME
+			jobInfo,               // JOB_INFO
+			0,                     // ROW_COUNT
+			nil,                   // START_TIME
+			nil,                   // END_TIME
+			"pending",             // STATE
+			nil,                   // FAIL_REASON
+			"",                    // INSTANCE
+			nil,                   // PROCESS_ID
+		))
+	}
 	return
 }
 
@@ -2149,6 +2235,67 @@ func (e *memtableRetriever) setDataForAnalyzeStatus(sctx sessionctx.Context) (er
 	return
 }
 
+func (e *memtableRetriever) setDataForTiDBTTLTableStatus(sctx sessionctx.Context) error {
+	const sql = "SELECT table_schema, table_name, partition_name, table_id, job_status, job_owner, CONVERT_TZ(job_start_time, @@TIME_ZONE, '+00:00'), CONVERT_TZ(job_end_time, @@TIME_ZONE, '+00:00'), CONVERT_TZ(expire_time, @@TIME_ZONE, '+00:00'), scanned_rows, deleted_rows, error_message FROM mysql.tidb_ttl_table_status ORDER BY table_schema, table_name, partition_name"
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnTTL)
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql)
+	if err != nil {
+		return err
+	}
+	convertTime := func(row chunk.Row, idx int) (interface{}, error) {
+		if row.IsNull(idx) {
+			return nil, nil
+		}
+		t, err := row.GetTime(idx).GoTime(time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTime(types.FromGoTime(t.In(sctx.GetSessionVars().TimeZone)), mysql.TypeDatetime, 0), nil
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	rows := make([][]types.Datum, 0, len(chunkRows))
+	for _, chunkRow := range chunkRows {
+		dbName := chunkRow.GetString(0)
+		tableName := chunkRow.GetString(1)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, dbName, tableName, "", mysql.AllPrivMask) {
+			continue
+		}
+		startTime, err := convertTime(chunkRow, 6)
+		if err != nil {
+			return err
+		}
+		endTime, err := convertTime(chunkRow, 7)
+		if err != nil {
+			return err
+		}
+		expireTime, err := convertTime(chunkRow, 8)
+		if err != nil {
+			return err
+		}
+		var errMsg interface{}
+		if !chunkRow.IsNull(11) {
+			errMsg = chunkRow.GetString(11)
+		}
+		rows = append(rows, types.MakeDatums(
+			dbName,                 // TABLE_SCHEMA
+			tableName,              // TABLE_NAME
+			chunkRow.GetString(2),  // PARTITION_NAME
+			chunkRow.GetInt64(3),   // TABLE_ID
+			chunkRow.GetString(4),  // JOB_STATUS
+			chunkRow.GetString(5),  // JOB_OWNER
+			startTime,              // JOB_START_TIME
+			endTime,                // JOB_END_TIME
+			expireTime,             // EXPIRE_TIME
+			chunkRow.GetUint64(9),  // SCANNED_ROWS
+			chunkRow.GetUint64(10), // DELETED_ROWS
+			errMsg,                 // ERROR_MESSAGE
+		))
+	}
+	e.rows = rows
+	return nil
+}
+
 // setDataForPseudoProfiling returns pseudo data for table profiling when system variable `profiling` is set to `ON`.
 func (e *memtableRetriever) setDataForPseudoProfiling(sctx sessionctx.Context) {
 	if v, ok := sctx.GetSessionVars().GetSystemVar("profiling"); ok && variable.TiDBOptOn(v) {
@@ -3287,6 +3434,37 @@ func (e *memtableRetriever) setDataFromPlacementPolicies(sctx sessionctx.Context
 	return nil
 }
 
+func (e *memtableRetriever) setDataFromResourceGroups(sctx sessionctx.Context) {
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	resourceGroups := is.AllResourceGroups()
+	consumptions := make(map[string]resourcegroup.Consumption)
+	for _, c := range resourcegroup.GlobalController().Consumptions() {
+		consumptions[c.Name] = c
+	}
+	rows := make([][]types.Datum, 0, len(resourceGroups))
+	for _, group := range resourceGroups {
+		burstable := "NO"
+		if group.Burstable {
+			burstable = "YES"
+		}
+		var queryLimit interface{}
+		if group.Runaway != nil {
+			queryLimit = group.Runaway.String()
+		}
+		consumption := consumptions[group.Name.L]
+		row := types.MakeDatums(
+			group.Name.O,
+			group.RURate,
+			burstable,
+			consumption.ReadRU,
+			consumption.WriteRU,
+			queryLimit,
+		)
+		rows = append(rows, row)
+	}
+	e.rows = rows
+}
+
 func checkRule(rule *label.Rule) (dbName, tableName string, partitionName string, err error) {
 	s := strings.Split(rule.ID, "/")
 	if len(s) < 3 {
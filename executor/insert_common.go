@@ -1258,6 +1258,14 @@ func (e *InsertValues) addRecord(ctx context.Context, row []types.Datum) error {
 
 func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.Datum, reserveAutoIDCount int) (err error) {
 	vars := e.ctx.GetSessionVars()
+	if err = table.CheckRowConstraint(e.ctx, e.Table.WritableConstraint(), row); err != nil {
+		// For `INSERT IGNORE`, the row violating a check constraint is skipped with a warning.
+		if vars.StmtCtx.DupKeyAsWarning {
+			vars.StmtCtx.AppendWarning(err)
+			return nil
+		}
+		return err
+	}
 	if !vars.ConstraintCheckInPlace {
 		vars.PresumeKeyNotExists = true
 	}
@@ -0,0 +1,260 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// jsonTableColumn is a column of JSON_TABLE except the nested path.
+type jsonTableColumn struct {
+	*ast.JSONTableColumn
+
+	offset   int
+	path     types.JSONPathExpression
+	tp       *types.FieldType
+	onEmpty  *types.Datum
+	onError  *types.Datum
+	errEmpty bool
+	errError bool
+}
+
+// jsonTableScope is the root path or a nested path of JSON_TABLE, the rows are produced
+// for every value matched by the path.
+type jsonTableScope struct {
+	path    types.JSONPathExpression
+	columns []*jsonTableColumn
+	nested  []*jsonTableScope
+	// offsets are the output offsets of all the columns in this scope and the nested scopes.
+	offsets []int
+}
+
+// JSONTableExec is the executor of JSON_TABLE. The JSON document is evaluated when it's
+// opened, so it's re-evaluated for every outer row when it's the inner side of an Apply.
+type JSONTableExec struct {
+	baseExecutor
+
+	jsonExpr expression.Expression
+	root     *jsonTableScope
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+func (e *JSONTableExec) buildScope(path string, columns []*ast.JSONTableColumn, offset *int) (*jsonTableScope, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	scope := &jsonTableScope{path: pathExpr}
+	for _, col := range columns {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := e.buildScope(col.Path, col.Columns, offset)
+			if err != nil {
+				return nil, err
+			}
+			scope.nested = append(scope.nested, nested)
+			scope.offsets = append(scope.offsets, nested.offsets...)
+			continue
+		}
+		c := &jsonTableColumn{JSONTableColumn: col, offset: *offset, tp: e.retFieldTypes[*offset]}
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			if c.path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+		}
+		if c.onEmpty, c.errEmpty, err = e.buildOnResponse(c, col.OnEmpty); err != nil {
+			return nil, err
+		}
+		if c.onError, c.errError, err = e.buildOnResponse(c, col.OnError); err != nil {
+			return nil, err
+		}
+		scope.columns = append(scope.columns, c)
+		scope.offsets = append(scope.offsets, *offset)
+		*offset++
+	}
+	return scope, nil
+}
+
+// buildOnResponse returns the default value of the ON EMPTY or ON ERROR clause, and whether it raises an error.
+func (e *JSONTableExec) buildOnResponse(col *jsonTableColumn, on *ast.JSONTableOnResponse) (*types.Datum, bool, error) {
+	if on == nil {
+		return nil, false, nil
+	}
+	switch on.Tp {
+	case ast.JSONTableOnResponseError:
+		return nil, true, nil
+	case ast.JSONTableOnResponseDefault:
+		bj, err := types.ParseBinaryJSONFromString(on.Default)
+		if err != nil {
+			return nil, false, err
+		}
+		d, err := e.convertJSON(col, bj)
+		if err != nil {
+			return nil, false, err
+		}
+		return &d, false, nil
+	}
+	return nil, false, nil
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.rows = e.rows[:0]
+	e.cursor = 0
+
+	d, err := e.jsonExpr.Eval(chunk.Row{})
+	if err != nil || d.IsNull() {
+		return err
+	}
+	sc := e.ctx.GetSessionVars().StmtCtx
+	d, err = d.ConvertTo(sc, types.NewFieldType(mysql.TypeJSON))
+	if err != nil {
+		return err
+	}
+	row := make([]types.Datum, len(e.retFieldTypes))
+	_, err = e.produceRows(e.root, d.GetMysqlJSON(), row)
+	return err
+}
+
+// produceRows produces the rows for every value matched by the path of the scope, and returns the number of rows.
+// The sibling nested paths produce their rows one by one, the columns of the other siblings are NULL.
+func (e *JSONTableExec) produceRows(scope *jsonTableScope, doc types.BinaryJSON, row []types.Datum) (int, error) {
+	cnt := 0
+	for i, value := range doc.ExtractAll(scope.path) {
+		for _, col := range scope.columns {
+			d, err := e.evalColumn(col, value, i+1)
+			if err != nil {
+				return 0, err
+			}
+			row[col.offset] = d
+		}
+		nestedCnt := 0
+		for _, nested := range scope.nested {
+			for _, sibling := range scope.nested {
+				setNullDatums(row, sibling.offsets)
+			}
+			n, err := e.produceRows(nested, value, row)
+			if err != nil {
+				return 0, err
+			}
+			nestedCnt += n
+		}
+		if nestedCnt == 0 {
+			// The nested paths match nothing, output the row like a LEFT JOIN.
+			for _, nested := range scope.nested {
+				setNullDatums(row, nested.offsets)
+			}
+			e.rows = append(e.rows, append([]types.Datum(nil), row...))
+			nestedCnt = 1
+		}
+		cnt += nestedCnt
+	}
+	return cnt, nil
+}
+
+func setNullDatums(row []types.Datum, offsets []int) {
+	for _, offset := range offsets {
+		row[offset].SetNull()
+	}
+}
+
+func (e *JSONTableExec) evalColumn(col *jsonTableColumn, value types.BinaryJSON, ordinality int) (types.Datum, error) {
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExists:
+		exists := int64(0)
+		if len(value.ExtractAll(col.path)) > 0 {
+			exists = 1
+		}
+		d := types.NewIntDatum(exists)
+		return d.ConvertTo(e.ctx.GetSessionVars().StmtCtx, col.tp)
+	}
+	values := value.ExtractAll(col.path)
+	if len(values) == 0 {
+		if col.errEmpty {
+			return types.Datum{}, ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O)
+		}
+		if col.onEmpty != nil {
+			return *col.onEmpty, nil
+		}
+		return types.Datum{}, nil
+	}
+	var err error
+	if len(values) > 1 {
+		err = ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	} else {
+		var d types.Datum
+		if d, err = e.convertJSON(col, values[0]); err == nil {
+			return d, nil
+		}
+	}
+	if col.errError {
+		return types.Datum{}, err
+	}
+	if col.onError != nil {
+		return *col.onError, nil
+	}
+	return types.Datum{}, nil
+}
+
+// convertJSON converts the matched JSON value to the type of the column.
+func (e *JSONTableExec) convertJSON(col *jsonTableColumn, value types.BinaryJSON) (types.Datum, error) {
+	if col.tp.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(value), nil
+	}
+	if value.TypeCode == types.JSONTypeCodeObject || value.TypeCode == types.JSONTypeCodeArray {
+		return types.Datum{}, ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	}
+	var d types.Datum
+	if col.tp.EvalType() == types.ETString {
+		str, err := value.Unquote()
+		if err != nil {
+			return types.Datum{}, err
+		}
+		d = types.NewStringDatum(str)
+	} else {
+		d = types.NewJSONDatum(value)
+	}
+	return d.ConvertTo(e.ctx.GetSessionVars().StmtCtx, col.tp)
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return errors.Trace(e.baseExecutor.Close())
+}
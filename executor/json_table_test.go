@@ -0,0 +1,61 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": "2"}]', '$[*]' columns (
+		id for ordinality,
+		a int path '$.a',
+		b varchar(10) path '$.b' default '"none"' on empty,
+		c int exists path '$.b')) as jt`).Check(testkit.Rows("1 1 x 1", "2 2 none 0"))
+	tk.MustQuery(`select * from json_table('{"a": {"x": 1}, "b": [1, 2]}', '$' columns (
+		a json path '$.a',
+		b int path '$.b',
+		c int path '$.b[*]' default '-1' on error)) jt`).Check(testkit.Rows(`{"x": 1} <nil> -1`))
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [1, 2], "c": [3]}, {"a": 2}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (b int path '$'),
+		nested path '$.c[*]' columns (id for ordinality, c int path '$'))) jt`).
+		Check(testkit.Rows("1 1 <nil> <nil>", "1 2 <nil> <nil>", "1 <nil> 1 3", "2 <nil> <nil> <nil>"))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) jt`).Check(testkit.Rows())
+
+	tk.MustExec("create table t (id int, j json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[]'), (3, null)`)
+	tk.MustQuery("select t.id, jt.v from t, json_table(t.j, '$[*]' columns (v int path '$')) jt order by t.id, jt.v").
+		Check(testkit.Rows("1 1", "1 2"))
+	tk.MustQuery("select t.id, jt.v from t join json_table(t.j, '$[*]' columns (v int path '$')) jt on jt.v > 1").
+		Check(testkit.Rows("1 2"))
+	tk.MustQuery("select t.id, jt.v from t left join json_table(t.j, '$[*]' columns (v int path '$')) jt on true order by t.id, jt.v").
+		Check(testkit.Rows("1 1", "1 2", "2 <nil>", "3 <nil>"))
+	tk.MustQuery("select t.id, (select sum(v) from json_table(t.j, '$[*]' columns (v int path '$')) jt) from t order by t.id").
+		Check(testkit.Rows("1 3", "2 <nil>", "3 <nil>"))
+
+	tk.MustGetErrCode(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) jt`, errno.ErrMissingJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) jt`, errno.ErrWrongJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[' columns (a int path '$')) jt`, errno.ErrInvalidJSONPath)
+	tk.MustGetErrCode("select * from t right join json_table(t.j, '$[*]' columns (v int path '$')) jt on true", errno.ErrTFForbiddenJoinType)
+	tk.MustGetErrCode("select * from json_table(t.j, '$[*]' columns (v int path '$')) jt, t", errno.ErrBadField)
+}
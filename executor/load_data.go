@@ -54,9 +54,12 @@ type LoadDataExec struct {
 // Next implements the Executor Next interface.
 func (e *LoadDataExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.maxChunkSize)
-	// TODO: support load data without local field.
+	// Only the files in the external storage, e.g. 's3://bucket/prefix/*.csv', can be loaded without local field.
 	if !e.IsLocal {
-		return errors.New("Load Data: don't support load data without local field")
+		if !isExternalStoragePath(e.loadDataInfo.Path) {
+			return errors.New("Load Data: don't support load data without local field")
+		}
+		e.loadDataInfo.FromExternalStorage = true
 	}
 	e.loadDataInfo.OnDuplicate = e.OnDuplicate
 	// TODO: support lines terminated is "".
@@ -112,6 +115,8 @@ type LoadDataInfo struct {
 	Ctx         sessionctx.Context
 	rows        [][]types.Datum
 	Drained     bool
+	// FromExternalStorage indicates the data is read from the external storage by the server instead of the client.
+	FromExternalStorage bool
 
 	ColumnAssignments  []*ast.Assignment
 	ColumnsAndUserVars []*ast.ColumnNameOrUserVar
@@ -308,6 +313,30 @@ func (e *LoadDataInfo) EnqOneTask(ctx context.Context) error {
 	return err
 }
 
+// InsertDataWithCommit inserts the data like InsertData, and enqueues the commit task every time the number of rows
+// reaches the batch limit. It returns the rest of data which isn't completed the processing.
+func (e *LoadDataInfo) InsertDataWithCommit(ctx context.Context, prevData, curData []byte) ([]byte, error) {
+	var err error
+	var reachLimit bool
+	for {
+		prevData, reachLimit, err = e.InsertData(ctx, prevData, curData)
+		if err != nil {
+			return nil, err
+		}
+		if !reachLimit {
+			break
+		}
+		// push into commit task queue
+		err = e.EnqOneTask(ctx)
+		if err != nil {
+			return prevData, err
+		}
+		curData = prevData
+		prevData = nil
+	}
+	return prevData, nil
+}
+
 // CommitOneTask insert Data from LoadDataInfo.rows, then make commit and refresh txn
 func (e *LoadDataInfo) CommitOneTask(ctx context.Context, task CommitTask) error {
 	var err error
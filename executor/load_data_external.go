@@ -0,0 +1,426 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mathutil"
+	"github.com/pingcap/tidb/util/size"
+	"go.uber.org/zap"
+)
+
+const (
+	// loadDataSplitSize is the size of the chunks which the large uncompressed files are split into.
+	loadDataSplitSize = int64(256 * size.MB)
+	// loadDataReadConcurrency is the maximum number of the chunks read concurrently.
+	loadDataReadConcurrency = 8
+	// loadDataReadBlockSize is the size of the data read from the external storage at a time.
+	loadDataReadBlockSize = int(64 * size.KB)
+	// loadDataReadAheadBlocks is the maximum number of the blocks read ahead for each chunk.
+	loadDataReadAheadBlocks = 16
+	// loadDataEscapeWindow is the number of the bytes before the split point checked for the escape characters.
+	loadDataEscapeWindow = 64
+)
+
+// externalChunk is a part of the file in the external storage to load. The large files are split into chunks at the
+// line terminators, so the chunks can be read concurrently.
+type externalChunk struct {
+	path         string
+	compressType storage.CompressType
+	fileSize     int64
+	offset       int64
+	// endOffset is -1 if the chunk is read to the end of the file, e.g. the compressed file.
+	endOffset int64
+
+	// data is closed after the chunk is read, err is set before that.
+	data chan []byte
+	err  error
+}
+
+func (c *externalChunk) size() int64 {
+	if c.endOffset < 0 {
+		return c.fileSize
+	}
+	return c.endOffset - c.offset
+}
+
+// isExternalStoragePath checks whether the path of LOAD DATA is an URI of the external storage, e.g.
+// 's3://bucket/prefix/*.csv.gz' or 'file:///path/to/file.csv'.
+func isExternalStoragePath(path string) bool {
+	return strings.Contains(path, "://")
+}
+
+// LoadFromExternalStorage loads the files in the external storage specified by the path of LOAD DATA INFILE. The file
+// name in the path can contain the wildcards of path.Match. The chunks of the files are read concurrently and
+// inserted in order.
+func (e *LoadDataInfo) LoadFromExternalStorage(ctx context.Context) error {
+	if !e.Table.Meta().IsBaseTable() {
+		return errors.New("can only load data into base tables")
+	}
+	store, chunks, err := e.prepareExternalChunks(ctx)
+	if err != nil {
+		return err
+	}
+
+	stmtCtx := e.Ctx.GetSessionVars().StmtCtx
+	defer stmtCtx.SetProgress("")
+	e.InitQueues()
+	e.SetMaxRowsInBatch(uint64(e.Ctx.GetSessionVars().DMLBatchSize))
+	e.StartStopWatcher()
+	// let stop watcher goroutine quit
+	defer e.ForceQuit()
+	err = sessiontxn.NewTxn(ctx, e.Ctx)
+	if err != nil {
+		return err
+	}
+	// processExternalChunks reads the files, enqueue commit task
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go e.processExternalChunks(ctx, store, chunks, wg)
+	err = e.CommitWork(ctx)
+	wg.Wait()
+	e.SetMessage()
+	return err
+}
+
+// prepareExternalChunks opens the external storage of the path, and splits the matched files into chunks. The path
+// isn't included in the errors since it may contain the credentials.
+func (e *LoadDataInfo) prepareExternalChunks(ctx context.Context) (storage.ExternalStorage, []*externalChunk, error) {
+	u, err := url.Parse(e.Path)
+	if err != nil {
+		return nil, nil, errors.New("Load Data: invalid external storage URI")
+	}
+	dir, pattern := path.Split(u.Path)
+	if len(pattern) == 0 {
+		return nil, nil, errors.New("Load Data: the file name is missing in the external storage URI")
+	}
+	if _, err = path.Match(pattern, ""); err != nil {
+		return nil, nil, errors.Errorf("Load Data: invalid file name pattern '%s'", pattern)
+	}
+	u.Path = dir
+	backend, err := storage.ParseBackend(u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := storage.New(ctx, backend, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var names []string
+	sizes := make(map[string]int64)
+	if strings.ContainsAny(pattern, `*?[\`) {
+		// the pattern doesn't contain '/', so only the files in the directory are matched
+		err = store.WalkDir(ctx, &storage.WalkOption{}, func(name string, size int64) error {
+			if ok, _ := path.Match(pattern, name); ok {
+				names = append(names, name)
+				sizes[name] = size
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(names) == 0 {
+			return nil, nil, errors.Errorf("Load Data: no file matches the pattern '%s'", pattern)
+		}
+		sort.Strings(names)
+	} else {
+		size, err := externalFileSize(ctx, store, pattern)
+		if err != nil {
+			return nil, nil, err
+		}
+		names = append(names, pattern)
+		sizes[pattern] = size
+	}
+
+	splitSize := loadDataSplitSize
+	failpoint.Inject("loadDataSplitSize", func(val failpoint.Value) {
+		splitSize = int64(val.(int))
+	})
+	var chunks []*externalChunk
+	for _, name := range names {
+		compressType, err := compressTypeOfFile(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		size := sizes[name]
+		if compressType == storage.NoCompression && size > splitSize && e.canSplitFile() {
+			fileChunks, err := e.splitExternalFile(ctx, store, name, size, splitSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			chunks = append(chunks, fileChunks...)
+			continue
+		}
+		chunks = append(chunks, &externalChunk{path: name, compressType: compressType, fileSize: size, endOffset: -1})
+	}
+	for _, chunk := range chunks {
+		chunk.data = make(chan []byte, loadDataReadAheadBlocks)
+	}
+	return store, chunks, nil
+}
+
+func externalFileSize(ctx context.Context, store storage.ExternalStorage, name string) (int64, error) {
+	r, err := store.Open(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	//nolint: errcheck
+	defer r.Close()
+	return r.Seek(0, io.SeekEnd)
+}
+
+func compressTypeOfFile(name string) (storage.CompressType, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".gz", ".gzip":
+		return storage.Gzip, nil
+	case ".zst", ".zstd":
+		return storage.Zstd, nil
+	case ".bz2", ".lz4", ".lzo", ".snappy", ".xz":
+		return storage.NoCompression, errors.Errorf("Load Data: unsupported compression of the file '%s'", name)
+	default:
+		return storage.NoCompression, nil
+	}
+}
+
+// canSplitFile checks whether the files can be split at the line terminators. The line terminators in the enclosed
+// fields can't be distinguished without parsing the file from the beginning.
+func (e *LoadDataInfo) canSplitFile() bool {
+	return e.FieldsInfo.Enclosed == 0 && len(e.FieldsInfo.Terminated) > 0 &&
+		len(e.LinesInfo.Starting) == 0 && len(e.LinesInfo.Terminated) > 0
+}
+
+// splitExternalFile splits the large file into chunks in the same way as Lightning splits the large CSV files.
+func (e *LoadDataInfo) splitExternalFile(ctx context.Context, store storage.ExternalStorage, name string, size, splitSize int64) ([]*externalChunk, error) {
+	cfg := config.NewConfig()
+	cfg.Mydumper.MaxRegionSize = config.ByteSize(splitSize)
+	cfg.Mydumper.ReadBlockSize = config.ByteSize(loadDataReadBlockSize)
+	cfg.Mydumper.DataCharacterSet = "binary"
+	cfg.Mydumper.CSV = config.CSVConfig{
+		Separator:  e.FieldsInfo.Terminated,
+		Terminator: e.LinesInfo.Terminated,
+	}
+	meta := &mydump.MDTableMeta{Name: e.Table.Meta().Name.O}
+	dataFile := mydump.FileInfo{
+		FileMeta: mydump.SourceFileMeta{Path: name, Type: mydump.SourceTypeCSV, FileSize: size},
+	}
+	_, regions, _, err := mydump.SplitLargeFile(ctx, meta, cfg, dataFile, 1, 0, worker.NewPool(ctx, 1, "load data"), store)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]*externalChunk, 0, len(regions))
+	for _, region := range regions {
+		if n := len(chunks); n > 0 {
+			escaped, err := e.isEscapedTerminator(ctx, store, name, region.Chunk.Offset)
+			if err != nil {
+				return nil, err
+			}
+			// the escaped line terminator is a part of the field, so the chunk is merged into the previous one
+			if escaped {
+				chunks[n-1].endOffset = region.Chunk.EndOffset
+				continue
+			}
+		}
+		chunks = append(chunks, &externalChunk{
+			path:      name,
+			fileSize:  size,
+			offset:    region.Chunk.Offset,
+			endOffset: region.Chunk.EndOffset,
+		})
+	}
+	return chunks, nil
+}
+
+// isEscapedTerminator checks whether the line terminator ends at the offset is escaped by the escape character. It's
+// treated as escaped if all the checked bytes are escape characters.
+func (e *LoadDataInfo) isEscapedTerminator(ctx context.Context, store storage.ExternalStorage, name string, offset int64) (bool, error) {
+	if e.FieldsInfo.Escaped == 0 {
+		return false, nil
+	}
+	end := offset - int64(len(e.LinesInfo.Terminated))
+	start := end - loadDataEscapeWindow
+	if start < 0 {
+		start = 0
+	}
+	r, err := store.Open(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	//nolint: errcheck
+	defer r.Close()
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return false, err
+	}
+	buf := make([]byte, end-start)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return false, err
+	}
+	escapes := 0
+	for i := len(buf) - 1; i >= 0 && buf[i] == e.FieldsInfo.Escaped; i-- {
+		escapes++
+	}
+	if escapes == len(buf) && start > 0 {
+		return true, nil
+	}
+	return escapes%2 == 1, nil
+}
+
+// processExternalChunks inserts the data of the chunks in order, while the chunks are read concurrently.
+func (e *LoadDataInfo) processExternalChunks(ctx context.Context, store storage.ExternalStorage, chunks []*externalChunk, wg *sync.WaitGroup) {
+	var err error
+	readCtx, cancel := context.WithCancel(ctx)
+	readerWg := new(sync.WaitGroup)
+	defer func() {
+		r := recover()
+		if r != nil {
+			logutil.Logger(ctx).Error("process routine panicked",
+				zap.Reflect("r", r),
+				zap.Stack("stack"))
+		}
+		if err != nil || r != nil {
+			logutil.Logger(ctx).Error("load data process external storage error", zap.Error(err))
+			e.ForceQuit()
+		} else {
+			e.CloseTaskQueue()
+		}
+		// let the readers quit
+		cancel()
+		readerWg.Wait()
+		wg.Done()
+	}()
+	startExternalChunkReaders(readCtx, store, chunks, readerWg)
+
+	var totalSize, processedSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size()
+	}
+	stmtCtx := e.Ctx.GetSessionVars().StmtCtx
+	ignoreLines := e.IgnoreLines
+	for i, chunk := range chunks {
+		// the lines are ignored at the beginning of every file
+		if chunk.offset == 0 {
+			e.IgnoreLines = ignoreLines
+		}
+		var prevData []byte
+		for data := range chunk.data {
+			select {
+			case <-e.QuitCh:
+				err = errors.New("processExternalChunks forced to quit")
+				return
+			default:
+			}
+			if prevData, err = e.InsertDataWithCommit(ctx, prevData, data); err != nil {
+				return
+			}
+			if chunk.compressType == storage.NoCompression {
+				processedSize += int64(len(data))
+				stmtCtx.SetProgress(loadDataProgress(i, len(chunks), processedSize, totalSize))
+			}
+		}
+		if err = chunk.err; err != nil {
+			return
+		}
+		// the last line of the chunk may not end with the line terminator
+		for len(prevData) > 0 {
+			if prevData, err = e.InsertDataWithCommit(ctx, prevData, nil); err != nil {
+				return
+			}
+		}
+		if chunk.compressType != storage.NoCompression {
+			processedSize += chunk.size()
+		}
+		stmtCtx.SetProgress(loadDataProgress(i+1, len(chunks), processedSize, totalSize))
+	}
+	err = e.EnqOneTask(ctx)
+}
+
+func loadDataProgress(finishedChunks, totalChunks int, processedSize, totalSize int64) string {
+	percent := 100.0
+	if totalSize > 0 {
+		percent = float64(processedSize) * 100 / float64(totalSize)
+	}
+	return fmt.Sprintf("load data: %d/%d chunks, %.2f%%", finishedChunks, totalChunks, percent)
+}
+
+// startExternalChunkReaders starts the readers of the chunks. The chunks are assigned to the readers in order, so the
+// chunk being processed is always being read or finished.
+func startExternalChunkReaders(ctx context.Context, store storage.ExternalStorage, chunks []*externalChunk, wg *sync.WaitGroup) {
+	chunkCh := make(chan *externalChunk, len(chunks))
+	for _, chunk := range chunks {
+		chunkCh <- chunk
+	}
+	close(chunkCh)
+	concurrency := mathutil.Min(loadDataReadConcurrency, len(chunks))
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunkCh {
+				chunk.err = readExternalChunk(ctx, store, chunk)
+				close(chunk.data)
+			}
+		}()
+	}
+}
+
+func readExternalChunk(ctx context.Context, store storage.ExternalStorage, chunk *externalChunk) error {
+	r, err := storage.WithCompression(store, chunk.compressType).Open(ctx, chunk.path)
+	if err != nil {
+		return err
+	}
+	//nolint: errcheck
+	defer r.Close()
+	var reader io.Reader = r
+	if chunk.endOffset >= 0 {
+		if _, err = r.Seek(chunk.offset, io.SeekStart); err != nil {
+			return err
+		}
+		reader = io.LimitReader(r, chunk.endOffset-chunk.offset)
+	}
+	for {
+		buf := make([]byte, loadDataReadBlockSize)
+		n, err := io.ReadFull(reader, buf)
+		if n > 0 {
+			select {
+			case chunk.data <- buf[:n]:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
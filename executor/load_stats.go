@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/statistics/handle"
 	"github.com/pingcap/tidb/util/chunk"
@@ -87,5 +88,34 @@ func (e *LoadStatsInfo) Update(data []byte) error {
 	if h == nil {
 		return errors.New("Load Stats: handle is nil")
 	}
-	return h.LoadStatsFromJSON(e.Ctx.GetInfoSchema().(infoschema.InfoSchema), jsonTbl)
+	is := e.Ctx.GetInfoSchema().(infoschema.InfoSchema)
+	if err := checkStatsLocked(h, is, jsonTbl); err != nil {
+		return err
+	}
+	return h.LoadStatsFromJSON(is, jsonTbl)
+}
+
+// checkStatsLocked refuses to load the statistics if the statistics of the table or its partitions are locked.
+func checkStatsLocked(h *handle.Handle, is infoschema.InfoSchema, jsonTbl *handle.JSONTable) error {
+	tbl, err := is.TableByName(model.NewCIStr(jsonTbl.DatabaseName), model.NewCIStr(jsonTbl.TableName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	lockedTables, err := h.GetLockedTables()
+	if err != nil {
+		return err
+	}
+	tableInfo := tbl.Meta()
+	ids := []int64{tableInfo.ID}
+	if pi := tableInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			ids = append(ids, def.ID)
+		}
+	}
+	for _, id := range ids {
+		if _, ok := lockedTables[id]; ok {
+			return errors.Errorf("Load Stats: the statistics of table %s.%s are locked, please unlock them first", jsonTbl.DatabaseName, jsonTbl.TableName)
+		}
+	}
+	return nil
 }
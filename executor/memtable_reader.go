@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/pdapi"
 	"github.com/pingcap/tidb/util/set"
+	"github.com/pingcap/tidb/util/stmtsummary"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
@@ -55,6 +56,7 @@ import (
 
 const clusterLogBatchSize = 256
 const hotRegionsHistoryBatchSize = 256
+const stmtSummaryHistoryBatchSize = 256
 
 type dummyCloser struct{}
 
@@ -1114,3 +1116,52 @@ func (e *tikvRegionPeersRetriever) packTiKVRegionPeersRows(
 	}
 	return rows, nil
 }
+
+// stmtSummaryHistoryRetriever is used to read the statements summary history from the persisted files.
+type stmtSummaryHistoryRetriever struct {
+	dummyCloser
+	table       *model.TableInfo
+	columns     []*model.ColumnInfo
+	extractor   *plannercore.StatementsSummaryExtractor
+	initialized bool
+	reader      *stmtsummary.HistoryReader
+}
+
+func (e *stmtSummaryHistoryRetriever) retrieve(_ context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	if e.extractor.SkipRequest {
+		return nil, nil
+	}
+	if !e.initialized {
+		e.initialized = true
+		var instanceAddr string
+		if e.table.Name.O == infoschema.ClusterTableStatementsSummaryHistory {
+			var err error
+			instanceAddr, err = infoschema.GetInstanceAddr(sctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		user := sctx.GetSessionVars().User
+		reader := stmtsummary.NewStmtSummaryReader(user, hasPriv(sctx, mysql.ProcessPriv), e.columns, instanceAddr, sctx.GetSessionVars().StmtCtx.TimeZone)
+		if e.extractor.Enable {
+			reader.SetChecker(stmtsummary.NewStmtSummaryChecker(e.extractor.Digests))
+		}
+		var startTime, endTime time.Time
+		if e.extractor.CoarseTimeRange != nil {
+			startTime, endTime = e.extractor.CoarseTimeRange.StartTime, e.extractor.CoarseTimeRange.EndTime
+		}
+		historyReader, err := reader.NewHistoryReader(startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		e.reader = historyReader
+	}
+	return e.reader.Rows(stmtSummaryHistoryBatchSize)
+}
+
+func (e *stmtSummaryHistoryRetriever) close() error {
+	if e.reader != nil {
+		return e.reader.Close()
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiValuedIndexDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("select cast('[1, 2]' as unsigned array)", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table t (j json, index idx((cast(j as unsigned array)), (cast(j as char(10) array))))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t (j json, index idx((cast(j as char array))))", errno.ErrNotSupportedYet)
+
+	tk.MustExec("create table t (id int primary key, j json, index idx((cast(j->'$.tags' as unsigned array))))")
+	tk.MustExec(`insert into t values (1, '{"tags": [1, 2, 2]}'), (2, '{"tags": 3}'), (3, '{"tags": []}'), (4, '{}')`)
+	tk.MustGetErrCode(`insert into t values (5, '{"tags": [-1]}')`, errno.ErrJSONValueOutOfRangeForFuncIndex)
+	tk.MustGetErrCode(`insert into t values (5, '{"tags": ["a"]}')`, errno.ErrInvalidJSONValueForFuncIndex)
+	tk.MustGetErrCode(`insert into t values (5, '{"tags": {"a": 1}}')`, errno.ErrNotSupportedYet)
+	tk.MustExec("admin check table t")
+
+	tk.MustQuery("select id from t where 2 member of (j->'$.tags')").Check(testkit.Rows("1"))
+	tk.MustExec(`update t set j = '{"tags": [3, 4]}' where id = 1`)
+	tk.MustQuery("select id from t where 2 member of (j->'$.tags')").Check(testkit.Rows())
+	tk.MustQuery("select id from t where 3 member of (j->'$.tags') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select id from t where 3 member of (j->'$.tags')").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from t use index(idx)").Check(testkit.Rows("3"))
+
+	tk.MustExec("create table t2 (id int primary key, j json, unique index idx((cast(j as char(10) array))))")
+	tk.MustExec(`insert into t2 values (1, '["a", "b", "a"]'), (2, '["c"]'), (3, '[]'), (4, '[]')`)
+	tk.MustGetErrCode(`insert into t2 values (5, '["d", "b"]')`, errno.ErrDupEntry)
+	tk.MustGetErrCode(`insert into t2 values (5, '["abcdefghijk"]')`, errno.ErrFunctionalIndexDataIsTooLong)
+	tk.MustExec(`insert ignore into t2 values (5, '["d", "b"]'), (6, '["e"]')`)
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1062 Duplicate entry 'b' for key 't2.idx'"))
+	tk.MustExec(`update t2 set j = '["a", "f"]' where id = 1`)
+	tk.MustExec(`insert into t2 values (7, '["b"]')`)
+	tk.MustQuery("select id from t2 where 'b' member of (j)").Check(testkit.Rows("7"))
+
+	// The index is backfilled with an entry for every element.
+	tk.MustExec("create table t3 (id int primary key, j json)")
+	tk.MustExec(`insert into t3 values (1, '[1, 2]'), (2, '[2, 3]'), (3, null)`)
+	tk.MustExec("alter table t3 add index idx((cast(j as signed array)))")
+	tk.MustQuery("select id from t3 where 2 member of (j) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustGetErrCode("alter table t3 add unique index uk((cast(j as signed array)))", errno.ErrDupEntry)
+	tk.MustExec("delete from t3 where id = 2")
+	tk.MustExec("alter table t3 add unique index uk((cast(j as signed array)))")
+	tk.MustGetErrCode(`insert into t3 values (2, '[2, 3]')`, errno.ErrDupEntry)
+}
+
+func TestMultiValuedIndexQuery(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, j json, index idx((cast(j as unsigned array))))")
+	tk.MustExec(`insert into t values (1, 1, '[1, 2, 3]'), (2, 2, '[2, 4]'), (3, 3, '[5]'), (4, 4, '[]'), (5, 5, null), (6, 6, '1')`)
+
+	for _, query := range []string{
+		"select id from t where 2 member of (j) order by id",
+		"select id from t where json_contains(j, '[2, 4]') order by id",
+		"select id from t where json_overlaps(j, '[3, 4]') order by id",
+		"select id from t where json_overlaps('[1]', j) and a > 1 order by id",
+	} {
+		require.True(t, tk.MustUseIndex(query, "idx"), query)
+	}
+	tk.MustQuery("select id from t where 2 member of (j) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where 1 member of (j) order by id").Check(testkit.Rows("1", "6"))
+	tk.MustQuery("select id from t where json_contains(j, '[2, 4]') order by id").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where json_overlaps(j, '[3, 4]') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where json_overlaps('[1]', j) and a > 1 order by id").Check(testkit.Rows("6"))
+	tk.MustQuery("select id from t where '2' member of (j)").Check(testkit.Rows())
+	tk.MustQuery("select id from t where -1 member of (j)").Check(testkit.Rows())
+	tk.MustQuery("select count(*) from t where 2 member of (j) or 5 member of (j)").Check(testkit.Rows("3"))
+	tk.MustExec("update t set a = a + 10 where json_overlaps(j, '[2, 5]')")
+	tk.MustQuery("select id, a from t where a > 10 order by id").Check(testkit.Rows("1 11", "2 12", "3 13"))
+	tk.MustExec("delete from t where 4 member of (j)")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("1", "3", "4", "5", "6"))
+	tk.MustExec("admin check table t")
+}
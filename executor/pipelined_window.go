@@ -67,11 +67,19 @@ type PipelinedWindowExec struct {
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
 
+	// peerCmpFuncs is used to decide whether two rows are peers for the groups frame.
+	peerCmpFuncs []expression.CompareFunc
+	// peerGroups keeps the index of the peer group of every row in e.rows for the groups frame.
+	peerGroups    []uint64
+	prevPeerRow   chunk.Row
+	prevPeerGroup uint64
+
 	// rows keeps rows starting from curStartRow
 	rows                     []chunk.Row
 	rowCnt                   uint64
 	whole                    bool
 	isRangeFrame             bool
+	isGroupsFrame            bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
 }
@@ -96,6 +104,8 @@ func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
 		}
 	}
 	e.rows = make([]chunk.Row, 0)
+	e.peerGroups = make([]uint64, 0)
+	e.prevPeerRow = chunk.Row{}
 	return e.baseExecutor.Open(ctx)
 }
 
@@ -198,11 +208,39 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 	begin, end := e.groupChecker.getNextGroup()
 	e.rowToConsume += uint64(end - begin)
 	for i := begin; i < end; i++ {
-		e.rows = append(e.rows, e.childResult.GetRow(i))
+		row := e.childResult.GetRow(i)
+		if e.isGroupsFrame {
+			if err = e.appendPeerGroup(row, i == begin && e.newPartition); err != nil {
+				return err
+			}
+		}
+		e.rows = append(e.rows, row)
 	}
 	return
 }
 
+// appendPeerGroup appends the index of the peer group of the row which is going to be appended to e.rows.
+func (e *PipelinedWindowExec) appendPeerGroup(row chunk.Row, partitionStart bool) error {
+	group := uint64(0)
+	if !partitionStart && !e.prevPeerRow.IsEmpty() {
+		peer, err := isPeerRow(e.ctx, e.orderByCols, e.peerCmpFuncs, e.prevPeerRow, row)
+		if err != nil {
+			return err
+		}
+		group = e.prevPeerGroup
+		if !peer {
+			group++
+		}
+	}
+	e.peerGroups = append(e.peerGroups, group)
+	e.prevPeerRow, e.prevPeerGroup = row, group
+	return nil
+}
+
+func (e *PipelinedWindowExec) getPeerGroup(i uint64) uint64 {
+	return e.peerGroups[i-e.rowStart]
+}
+
 func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (EOF bool, err error) {
 	// TODO: reuse chunks
 	childResult := newFirstChunk(e.children[0])
@@ -279,6 +317,26 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 		e.stagedStartRow = start
 		return start, nil
 	}
+	if e.isGroupsFrame {
+		group, target := e.getPeerGroup(e.curRowIdx), uint64(0)
+		switch e.start.Type {
+		case ast.Preceding:
+			if group > e.start.Num {
+				target = group - e.start.Num
+			}
+		case ast.Following:
+			target = group + e.start.Num
+		default: // ast.CurrentRow
+			target = group
+		}
+		// The frame starts from the first row of the target peer group.
+		start := mathutil.Max(e.lastStartRow, e.stagedStartRow)
+		for start < e.rowCnt && e.getPeerGroup(start) < target {
+			start++
+		}
+		e.stagedStartRow = start
+		return start, nil
+	}
 	switch e.start.Type {
 	case ast.Preceding:
 		if e.curRowIdx > e.start.Num {
@@ -319,6 +377,27 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 		e.stagedEndRow = end
 		return end, nil
 	}
+	if e.isGroupsFrame {
+		group, target := e.getPeerGroup(e.curRowIdx), uint64(0)
+		switch e.end.Type {
+		case ast.Preceding:
+			if group < e.end.Num {
+				return 0, nil
+			}
+			target = group - e.end.Num
+		case ast.Following:
+			target = group + e.end.Num
+		default: // ast.CurrentRow
+			target = group
+		}
+		// The frame ends at the last row of the target peer group.
+		end := mathutil.Max(e.lastEndRow, e.stagedEndRow)
+		for end < e.rowCnt && e.getPeerGroup(end) <= target {
+			end++
+		}
+		e.stagedEndRow = end
+		return end, nil
+	}
 	switch e.end.Type {
 	case ast.Preceding:
 		if e.curRowIdx >= e.end.Num {
@@ -417,6 +496,9 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		numDrop := extend - e.rowStart
 		e.dropped += numDrop
 		e.rows = e.rows[numDrop:]
+		if e.isGroupsFrame {
+			e.peerGroups = e.peerGroups[numDrop:]
+		}
 		e.rowStart = extend
 	}
 	return
@@ -452,6 +534,9 @@ func (e *PipelinedWindowExec) reset() {
 	numDrop := e.rowCnt - e.rowStart
 	e.dropped += numDrop
 	e.rows = e.rows[numDrop:]
+	if e.isGroupsFrame {
+		e.peerGroups = e.peerGroups[numDrop:]
+	}
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
@@ -15,34 +15,56 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"math"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/size"
 )
 
+// outfileBufferSize is the size of the buffer of the output file before it's compressed and written.
+const outfileBufferSize = int(256 * size.KB)
+
 // SelectIntoExec represents a SelectInto executor.
 type SelectIntoExec struct {
 	baseExecutor
 	intoOpt *ast.SelectIntoOption
+	// colNames are the names of the output columns, which are written as the header of the output files.
+	colNames []string
 
 	lineBuf   []byte
 	realBuf   []byte
 	fieldBuf  []byte
 	escapeBuf []byte
 	enclosed  bool
-	writer    *bufio.Writer
-	dstFile   *os.File
 	chk       *chunk.Chunk
 	started   bool
+
+	// store is the external storage of the output files, it's nil if the files are written to the local filesystem.
+	store storage.ExternalStorage
+	// dir is the directory of the output files in the local filesystem.
+	dir string
+	// baseName is the name of the first output file, the names of the other files are derived from it.
+	baseName     string
+	compressType storage.CompressType
+	header       []byte
+	writer       storage.ExternalFileWriter
+	fileIdx      int
+	fileSize     uint64
+	fileRows     uint64
 }
 
 // Open implements the Executor Open interface.
@@ -51,22 +73,160 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
-
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+	compressType, err := parseOutfileCompressType(s.intoOpt.Compression)
 	if err != nil {
-		return errors.Trace(err)
+		return err
+	}
+	s.compressType = compressType
+	if isExternalStoragePath(s.intoOpt.FileName) {
+		if err = s.openExternalStorage(ctx); err != nil {
+			return err
+		}
+	} else {
+		s.dir, s.baseName = filepath.Split(s.intoOpt.FileName)
 	}
-	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
-	s.chk = newFirstChunk(s.children[0])
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
 	s.escapeBuf = make([]byte, 0, 64)
+	if s.intoOpt.Header {
+		s.header = s.buildHeader()
+	}
+	if err = s.createFile(ctx); err != nil {
+		return err
+	}
+	s.started = true
+	s.chk = newFirstChunk(s.children[0])
 	return s.baseExecutor.Open(ctx)
 }
 
+// openExternalStorage opens the external storage of the directory of the output files. The URI isn't included in the
+// errors since it may contain the credentials.
+func (s *SelectIntoExec) openExternalStorage(ctx context.Context) error {
+	u, err := url.Parse(s.intoOpt.FileName)
+	if err != nil {
+		return errors.New("SELECT INTO OUTFILE: invalid external storage URI")
+	}
+	dir, name := path.Split(u.Path)
+	if len(name) == 0 {
+		return errors.New("SELECT INTO OUTFILE: the file name is missing in the external storage URI")
+	}
+	u.Path = dir
+	backend, err := storage.ParseBackend(u.String(), nil)
+	if err != nil {
+		return err
+	}
+	store, err := storage.New(ctx, backend, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return err
+	}
+	s.store = storage.WithCompression(store, s.compressType)
+	s.baseName = name
+	return nil
+}
+
+func parseOutfileCompressType(compression string) (storage.CompressType, error) {
+	switch strings.ToLower(compression) {
+	case "", "none":
+		return storage.NoCompression, nil
+	case "gzip", "gz":
+		return storage.Gzip, nil
+	case "zstd":
+		return storage.Zstd, nil
+	default:
+		return storage.NoCompression, errors.Errorf("unsupported compression '%s' of SELECT INTO OUTFILE", compression)
+	}
+}
+
+// outfileName returns the name of the idx-th output file. The first file keeps the original name, and the index is
+// inserted before the extensions for the others, e.g. "result.csv.gz" -> "result.1.csv.gz".
+func outfileName(baseName string, idx int) string {
+	if idx == 0 {
+		return baseName
+	}
+	if i := strings.IndexByte(baseName, '.'); i > 0 {
+		return baseName[:i] + "." + strconv.Itoa(idx) + baseName[i:]
+	}
+	return baseName + "." + strconv.Itoa(idx)
+}
+
+// localOutfile is the output file in the local filesystem.
+type localOutfile struct {
+	*os.File
+}
+
+// Write implements storage.ExternalFileWriter.
+func (f localOutfile) Write(_ context.Context, p []byte) (int, error) {
+	return f.File.Write(p)
+}
+
+// Close implements storage.ExternalFileWriter.
+func (f localOutfile) Close(_ context.Context) error {
+	return f.File.Close()
+}
+
+// createFile creates the next output file, and writes the header to it.
+func (s *SelectIntoExec) createFile(ctx context.Context) error {
+	name := outfileName(s.baseName, s.fileIdx)
+	if s.store == nil {
+		// MySQL-compatible behavior: allow files to be group-readable
+		f, err := os.OpenFile(s.dir+name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if s.writer, err = storage.NewUploaderWriter(localOutfile{f}, outfileBufferSize, s.compressType); err != nil {
+			terror.Log(f.Close())
+			return errors.Trace(err)
+		}
+	} else {
+		// The external storages can't create the files exclusively, so the file created by another statement
+		// between FileExists and Create is overwritten. The statements exporting to the same path concurrently
+		// should use different file names.
+		exists, err := s.store.FileExists(ctx, name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if exists {
+			return errors.Errorf("file '%s' already exists", name)
+		}
+		if s.writer, err = s.store.Create(ctx, name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	s.fileIdx++
+	s.fileSize = 0
+	s.fileRows = 0
+	if len(s.header) > 0 {
+		if _, err := s.writer.Write(ctx, s.header); err != nil {
+			terror.Log(s.writer.Close(ctx))
+			s.writer = nil
+			return errors.Trace(err)
+		}
+		s.fileSize += uint64(len(s.header))
+	}
+	return nil
+}
+
+// writeLine writes a line to the output file. The line is written to a new file if the size of the current file
+// would exceed MAX_FILE_SIZE, unless there isn't any line in the current file.
+func (s *SelectIntoExec) writeLine(ctx context.Context, line []byte) error {
+	if s.intoOpt.MaxFileSize > 0 && s.fileRows > 0 && s.fileSize+uint64(len(line)) > s.intoOpt.MaxFileSize {
+		err := s.writer.Close(ctx)
+		s.writer = nil
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = s.createFile(ctx); err != nil {
+			return err
+		}
+	}
+	if _, err := s.writer.Write(ctx, line); err != nil {
+		return errors.Trace(err)
+	}
+	s.fileSize += uint64(len(line))
+	s.fileRows++
+	return nil
+}
+
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	for {
@@ -76,7 +236,7 @@ func (s *SelectIntoExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		if err := s.dumpToOutfile(ctx); err != nil {
 			return err
 		}
 	}
@@ -118,15 +278,41 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
-	lineTerm := "\n"
+func (s *SelectIntoExec) terminators() (fieldTerm, lineTerm string) {
+	lineTerm = "\n"
 	if s.intoOpt.LinesInfo.Terminated != "" {
 		lineTerm = s.intoOpt.LinesInfo.Terminated
 	}
-	fieldTerm := "\t"
+	fieldTerm = "\t"
 	if s.intoOpt.FieldsInfo.Terminated != "" {
 		fieldTerm = s.intoOpt.FieldsInfo.Terminated
 	}
+	return fieldTerm, lineTerm
+}
+
+// buildHeader builds the line of the column names, which are enclosed and escaped like the string fields.
+func (s *SelectIntoExec) buildHeader() []byte {
+	fieldTerm, lineTerm := s.terminators()
+	encloseByte := s.intoOpt.FieldsInfo.Enclosed
+	s.enclosed = encloseByte != byte(0)
+	var header []byte
+	for i, name := range s.colNames {
+		if i != 0 {
+			header = append(header, fieldTerm...)
+		}
+		if s.enclosed {
+			header = append(header, encloseByte)
+		}
+		header = append(header, s.escapeField([]byte(name))...)
+		if s.enclosed {
+			header = append(header, encloseByte)
+		}
+	}
+	return append(header, lineTerm...)
+}
+
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
+	fieldTerm, lineTerm := s.terminators()
 	encloseFlag := false
 	var encloseByte byte
 	encloseOpt := false
@@ -208,8 +394,8 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, lineTerm...)
-		if _, err := s.writer.Write(s.lineBuf); err != nil {
-			return errors.Trace(err)
+		if err := s.writeLine(ctx, s.lineBuf); err != nil {
+			return err
 		}
 	}
 	s.ctx.GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
@@ -221,15 +407,15 @@ func (s *SelectIntoExec) Close() error {
 	if !s.started {
 		return nil
 	}
-	err1 := s.writer.Flush()
-	err2 := s.dstFile.Close()
-	err3 := s.baseExecutor.Close()
+	var err1 error
+	if s.writer != nil {
+		err1 = s.writer.Close(context.Background())
+	}
+	err2 := s.baseExecutor.Close()
 	if err1 != nil {
 		return errors.Trace(err1)
-	} else if err2 != nil {
-		return errors.Trace(err2)
 	}
-	return err3
+	return err2
 }
 
 const (
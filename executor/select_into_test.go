@@ -15,6 +15,7 @@
 package executor_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/testkit"
@@ -290,3 +292,47 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoOutfileOptions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c')")
+
+	// the output is split into files with the header
+	dir := t.TempDir()
+	outfile := filepath.Join(dir, "result.csv")
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile %q fields terminated by ',' max_file_size = 10 header = true", outfile))
+	require.Equal(t, uint64(3), tk.Session().AffectedRows())
+	cmpAndRm("a,b\n1,a\n", outfile, t)
+	cmpAndRm("a,b\n2,b\n", filepath.Join(dir, "result.1.csv"), t)
+	cmpAndRm("a,b\n3,c\n", filepath.Join(dir, "result.2.csv"), t)
+	tk.MustExec(fmt.Sprintf("select b as `x\ty` from t order by a into outfile %q fields enclosed by '\"' header = true", outfile))
+	cmpAndRm("\"x\ty\"\n\"a\"\n\"b\"\n\"c\"\n", outfile, t)
+
+	// the output is written to the external storage with compression
+	extStore, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+	for _, ca := range []struct {
+		compression  string
+		compressType storage.CompressType
+	}{
+		{"gzip", storage.Gzip},
+		{"zstd", storage.Zstd},
+	} {
+		name := "result.csv." + ca.compression
+		sql := fmt.Sprintf("select * from t order by a into outfile 'file://%s/%s' compression = '%s'", filepath.ToSlash(dir), name, ca.compression)
+		tk.MustExec(sql)
+		content, err := storage.WithCompression(extStore, ca.compressType).ReadFile(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, "1\ta\n2\tb\n3\tc\n", string(content))
+		err = tk.ExecToErr(sql)
+		require.EqualError(t, err, fmt.Sprintf("file '%s' already exists", name))
+	}
+
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q compression = 'lz4'", outfile))
+	require.EqualError(t, err, "unsupported compression 'lz4' of SELECT INTO OUTFILE")
+}
@@ -1201,9 +1201,19 @@ func TestShowForNewCollations(t *testing.T) {
 		"gbk_bin gbk 87  Yes 1",
 		"gbk_chinese_ci gbk 28 Yes Yes 1",
 		"latin1_bin latin1 47 Yes Yes 1",
+		"ucs2_bin ucs2 90  Yes 1",
+		"ucs2_general_ci ucs2 35 Yes Yes 1",
+		"utf16_bin utf16 55  Yes 1",
+		"utf16_general_ci utf16 54 Yes Yes 1",
+		"utf16le_bin utf16le 62  Yes 1",
+		"utf16le_general_ci utf16le 56 Yes Yes 1",
+		"utf32_bin utf32 61  Yes 1",
+		"utf32_general_ci utf32 60 Yes Yes 1",
 		"utf8_bin utf8 83 Yes Yes 1",
 		"utf8_general_ci utf8 33  Yes 1",
 		"utf8_unicode_ci utf8 192  Yes 1",
+		"utf8mb4_0900_ai_ci utf8mb4 255  Yes 1",
+		"utf8mb4_0900_bin utf8mb4 309  Yes 1",
 		"utf8mb4_bin utf8mb4 46 Yes Yes 1",
 		"utf8mb4_general_ci utf8mb4 45  Yes 1",
 		"utf8mb4_unicode_ci utf8mb4 224  Yes 1",
@@ -39,9 +39,11 @@ import (
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
+	parserformat "github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/parser/tidb"
 	field_types "github.com/pingcap/tidb/parser/types"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/plugin"
@@ -226,6 +228,8 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowStatsHealthy:
 		e.fetchShowStatsHealthy()
 		return nil
+	case ast.ShowStatsLocked:
+		return e.fetchShowStatsLocked()
 	case ast.ShowHistogramsInFlight:
 		e.fetchShowHistogramsInFlight()
 		return nil
@@ -1141,6 +1145,16 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		}
 	}
 
+	for _, constr := range tableInfo.Constraints {
+		if constr.State != model.StatePublic {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s CHECK ((%s))", stringutil.Escape(constr.Name.O, sqlMode), constr.ExprString))
+		if !constr.Enforced {
+			buf.WriteString(" /*!80016 NOT ENFORCED */")
+		}
+	}
+
 	buf.WriteString("\n")
 
 	buf.WriteString(") ENGINE=InnoDB")
@@ -1215,6 +1229,39 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		fmt.Fprintf(buf, " /* CACHED ON */")
 	}
 
+	if tableInfo.TTLInfo != nil {
+		restoreFlags := parserformat.RestoreStringSingleQuotes | parserformat.RestoreNameBackQuotes | parserformat.RestoreTiDBSpecialComment
+		restoreCtx := parserformat.NewRestoreCtx(restoreFlags, buf)
+
+		restoreCtx.WritePlain(" ")
+		err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			timeUnit := ast.TimeUnitExpr{Unit: ast.TimeUnitType(tableInfo.TTLInfo.IntervalTimeUnit)}
+			restoreCtx.WriteKeyWord("TTL")
+			restoreCtx.WritePlain("=")
+			restoreCtx.WriteName(tableInfo.TTLInfo.ColumnName.O)
+			restoreCtx.WritePlainf(" + INTERVAL %s ", tableInfo.TTLInfo.IntervalExprStr)
+			return timeUnit.Restore(restoreCtx)
+		})
+		if err != nil {
+			return err
+		}
+
+		restoreCtx.WritePlain(" ")
+		err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			restoreCtx.WriteKeyWord("TTL_ENABLE")
+			restoreCtx.WritePlain("=")
+			if tableInfo.TTLInfo.Enable {
+				restoreCtx.WriteString("ON")
+			} else {
+				restoreCtx.WriteString("OFF")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// add partition info here.
 	appendPartitionInfo(tableInfo.Partition, buf, sqlMode)
 	return nil
@@ -1357,7 +1404,7 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 	// include the /*!50100 or /*!50500 comments for TiDB.
 	// This also solves the issue with comments within comments that would happen for
 	// PLACEMENT POLICY options.
-	if partitionInfo.Type == model.PartitionTypeHash {
+	if partitionInfo.Type == model.PartitionTypeHash || partitionInfo.Type == model.PartitionTypeKey {
 		defaultPartitionDefinitions := true
 		for i, def := range partitionInfo.Definitions {
 			if def.Name.O != fmt.Sprintf("p%d", i) {
@@ -1371,28 +1418,47 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 		}
 
 		if defaultPartitionDefinitions {
-			fmt.Fprintf(buf, "\nPARTITION BY HASH (%s) PARTITIONS %d", partitionInfo.Expr, partitionInfo.Num)
+			buf.WriteString("\nPARTITION BY ")
+			appendPartitionMethod(buf, partitionInfo.Type, partitionInfo.Expr, partitionInfo.Columns, sqlMode)
+			fmt.Fprintf(buf, " PARTITIONS %d", partitionInfo.Num)
 			return
 		}
 	}
-	// this if statement takes care of lists/range columns case
-	if len(partitionInfo.Columns) > 0 {
-		// partitionInfo.Type == model.PartitionTypeRange || partitionInfo.Type == model.PartitionTypeList
-		// Notice that MySQL uses two spaces between LIST and COLUMNS...
-		fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", partitionInfo.Type.String())
-		for i, col := range partitionInfo.Columns {
+	buf.WriteString("\nPARTITION BY ")
+	appendPartitionMethod(buf, partitionInfo.Type, partitionInfo.Expr, partitionInfo.Columns, sqlMode)
+	if sub := partitionInfo.Sub; sub != nil {
+		buf.WriteString("\nSUBPARTITION BY ")
+		appendPartitionMethod(buf, sub.Type, sub.Expr, sub.Columns, sqlMode)
+		fmt.Fprintf(buf, "\nSUBPARTITIONS %d", sub.Num)
+	}
+	buf.WriteString("\n(")
+	ddl.AppendPartitionDefs(partitionInfo, buf, sqlMode)
+	buf.WriteString(")")
+}
+
+func appendPartitionMethod(buf *bytes.Buffer, tp model.PartitionType, expr string, columns []model.CIStr, sqlMode mysql.SQLMode) {
+	writeColumns := func() {
+		for i, col := range columns {
 			buf.WriteString(stringutil.Escape(col.O, sqlMode))
-			if i < len(partitionInfo.Columns)-1 {
+			if i < len(columns)-1 {
 				buf.WriteString(",")
 			}
 		}
-		buf.WriteString(")\n(")
-	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionInfo.Type.String(), partitionInfo.Expr)
 	}
-
-	ddl.AppendPartitionDefs(partitionInfo, buf, sqlMode)
-	buf.WriteString(")")
+	switch {
+	case tp == model.PartitionTypeKey:
+		buf.WriteString("KEY (")
+		writeColumns()
+		buf.WriteString(")")
+	case len(columns) > 0:
+		// tp == model.PartitionTypeRange || tp == model.PartitionTypeList
+		// Notice that MySQL uses two spaces between LIST and COLUMNS...
+		fmt.Fprintf(buf, "%s COLUMNS(", tp.String())
+		writeColumns()
+		buf.WriteString(")")
+	default:
+		fmt.Fprintf(buf, "%s (%s)", tp.String(), expr)
+	}
 }
 
 // ConstructResultOfShowCreateDatabase constructs the result for show create database.
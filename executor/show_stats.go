@@ -448,6 +448,29 @@ func (e *ShowExec) appendTableForStatsHealthy(dbName, tblName, partitionName str
 	})
 }
 
+func (e *ShowExec) fetchShowStatsLocked() error {
+	do := domain.GetDomain(e.ctx)
+	lockedTables, err := do.StatsHandle().GetLockedTables()
+	if err != nil || len(lockedTables) == 0 {
+		return err
+	}
+	for _, db := range do.InfoSchema().AllSchemas() {
+		for _, tbl := range db.Tables {
+			if _, ok := lockedTables[tbl.ID]; ok {
+				e.appendRow([]interface{}{db.Name.O, tbl.Name.O, "", "locked"})
+			}
+			if pi := tbl.GetPartitionInfo(); pi != nil {
+				for _, def := range pi.Definitions {
+					if _, ok := lockedTables[def.ID]; ok {
+						e.appendRow([]interface{}{db.Name.O, tbl.Name.O, def.Name.O, "locked"})
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (e *ShowExec) fetchShowHistogramsInFlight() {
 	e.appendRow([]interface{}{statistics.HistogramNeededItems.Length()})
 }
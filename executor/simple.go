@@ -49,6 +49,7 @@ import (
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/sem"
@@ -162,6 +163,10 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		return nil
 	case *ast.DropStatsStmt:
 		err = e.executeDropStats(x)
+	case *ast.LockStatsStmt:
+		err = e.executeLockStats(x)
+	case *ast.UnlockStatsStmt:
+		err = e.executeUnlockStats(x)
 	case *ast.SetRoleStmt:
 		err = e.executeSetRole(x)
 	case *ast.RevokeRoleStmt:
@@ -827,14 +832,27 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 		lockAccount = "Y"
 	}
 
-	var userAttributes any = nil
+	attributes := make([]string, 0, 2)
 	if s.CommentOrAttributeOption != nil {
 		if s.CommentOrAttributeOption.Type == ast.UserCommentType {
-			userAttributes = fmt.Sprintf("{\"metadata\": {\"comment\": \"%s\"}}", s.CommentOrAttributeOption.Value)
+			attributes = append(attributes, fmt.Sprintf("\"metadata\": {\"comment\": \"%s\"}", s.CommentOrAttributeOption.Value))
 		} else if s.CommentOrAttributeOption.Type == ast.UserAttributeType {
-			userAttributes = fmt.Sprintf("{\"metadata\": %s}", s.CommentOrAttributeOption.Value)
+			attributes = append(attributes, fmt.Sprintf("\"metadata\": %s", s.CommentOrAttributeOption.Value))
 		}
 	}
+	if s.ResourceGroupNameOption != nil {
+		groupName, err := e.checkUserResourceGroup(s.ResourceGroupNameOption.Value)
+		if err != nil {
+			return err
+		}
+		if groupName != "" {
+			attributes = append(attributes, fmt.Sprintf("\"resource_group\": \"%s\"", groupName))
+		}
+	}
+	var userAttributes any = nil
+	if len(attributes) > 0 {
+		userAttributes = "{" + strings.Join(attributes, ", ") + "}"
+	}
 
 	sql := new(strings.Builder)
 	sqlexec.MustFormatSQL(sql, `INSERT INTO %n.%n (Host, User, authentication_string, plugin, user_attributes, Account_locked) VALUES `, mysql.SystemDB, mysql.UserTable)
@@ -930,6 +948,22 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 	return domain.GetDomain(e.ctx).NotifyUpdatePrivilege()
 }
 
+// checkUserResourceGroup checks the resource group a user is bound to exists, and returns its name.
+// An empty name is returned for the default resource group.
+func (e *SimpleExec) checkUserResourceGroup(name string) (string, error) {
+	if strings.EqualFold(name, "default") {
+		return "", nil
+	}
+	if !variable.EnableResourceControl.Load() {
+		return "", dbterror.ErrResourceGroupSupportDisabled
+	}
+	group, ok := e.ctx.GetInfoSchema().(infoschema.InfoSchema).ResourceGroupByName(model.NewCIStr(name))
+	if !ok {
+		return "", infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(name)
+	}
+	return group.Name.L, nil
+}
+
 func (e *SimpleExec) executeAlterUser(ctx context.Context, s *ast.AlterUserStmt) error {
 	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnPrivilege)
 	if s.CurrentAuth != nil {
@@ -967,6 +1001,20 @@ func (e *SimpleExec) executeAlterUser(ctx context.Context, s *ast.AlterUserStmt)
 		return err
 	}
 
+	resourceGroupAttribute := ""
+	if s.ResourceGroupNameOption != nil {
+		groupName, err := e.checkUserResourceGroup(s.ResourceGroupNameOption.Value)
+		if err != nil {
+			return err
+		}
+		// Binding the user to the default resource group removes the attribute.
+		if groupName == "" {
+			resourceGroupAttribute = `{"resource_group": null}`
+		} else {
+			resourceGroupAttribute = fmt.Sprintf(`{"resource_group": "%s"}`, groupName)
+		}
+	}
+
 	failedUsers := make([]string, 0, len(s.Specs))
 	checker := privilege.GetPrivilegeManager(e.ctx)
 	if checker == nil {
@@ -1064,6 +1112,10 @@ func (e *SimpleExec) executeAlterUser(ctx context.Context, s *ast.AlterUserStmt)
 			fields = append(fields, alterField{"user_attributes=json_merge_patch(user_attributes, %?)", newAttributesStr})
 		}
 
+		if len(resourceGroupAttribute) != 0 {
+			fields = append(fields, alterField{"user_attributes=json_merge_patch(coalesce(user_attributes, '{}'), %?)", resourceGroupAttribute})
+		}
+
 		if len(fields) > 0 {
 			sql := new(strings.Builder)
 			sqlexec.MustFormatSQL(sql, "UPDATE %n.%n SET ", mysql.SystemDB, mysql.UserTable)
@@ -1722,6 +1774,47 @@ func (e *SimpleExec) executeDropStats(s *ast.DropStatsStmt) (err error) {
 	return h.Update(e.ctx.GetInfoSchema().(infoschema.InfoSchema))
 }
 
+// statsPhysicalIDs returns the IDs of the tables and their partitions whose statistics are locked or unlocked.
+// Only the given partitions are returned if the partition names are specified.
+func statsPhysicalIDs(tables []*ast.TableName, partitionNames []model.CIStr) ([]int64, error) {
+	var statsIDs []int64
+	for _, tbl := range tables {
+		ids, _, err := core.GetPhysicalIDsAndPartitionNames(tbl.TableInfo, partitionNames)
+		if err != nil {
+			return nil, err
+		}
+		statsIDs = append(statsIDs, ids...)
+		if len(partitionNames) == 0 && tbl.TableInfo.GetPartitionInfo() != nil {
+			statsIDs = append(statsIDs, tbl.TableInfo.ID)
+		}
+	}
+	return statsIDs, nil
+}
+
+func (e *SimpleExec) executeLockStats(s *ast.LockStatsStmt) error {
+	statsIDs, err := statsPhysicalIDs(s.Tables, s.PartitionNames)
+	if err != nil {
+		return err
+	}
+	h := domain.GetDomain(e.ctx).StatsHandle()
+	if err := h.AddLockedTables(statsIDs); err != nil {
+		return err
+	}
+	return h.Update(e.ctx.GetInfoSchema().(infoschema.InfoSchema))
+}
+
+func (e *SimpleExec) executeUnlockStats(s *ast.UnlockStatsStmt) error {
+	statsIDs, err := statsPhysicalIDs(s.Tables, s.PartitionNames)
+	if err != nil {
+		return err
+	}
+	h := domain.GetDomain(e.ctx).StatsHandle()
+	if err := h.RemoveLockedTables(statsIDs); err != nil {
+		return err
+	}
+	return h.Update(e.ctx.GetInfoSchema().(infoschema.InfoSchema))
+}
+
 func (e *SimpleExec) autoNewTxn() bool {
 	// Some statements cause an implicit commit
 	// See https://dev.mysql.com/doc/refman/5.7/en/implicit-commit.html
@@ -1825,7 +1918,13 @@ func (e *SimpleExec) executeAdminFlushPlanCache(s *ast.AdminStmt) error {
 	}
 	now := types.NewTime(types.FromGoTime(time.Now().In(e.ctx.GetSessionVars().StmtCtx.TimeZone)), mysql.TypeTimestamp, 3)
 	e.ctx.GetSessionVars().LastUpdateTime4PC = now
-	e.ctx.GetPlanCache(false).DeleteAll()
+	cache := e.ctx.GetPlanCache(false)
+	if instanceCache, ok := cache.(*core.InstancePlanCache); ok && s.StatementScope != ast.StatementScopeInstance {
+		// The instance plan cache is shared by the sessions, only the plans of this session are flushed.
+		instanceCache.DeleteSessionPlans(e.ctx.GetSessionVars().ConnectionID)
+	} else {
+		cache.DeleteAll()
+	}
 	if s.StatementScope == ast.StatementScopeInstance {
 		// Record the timestamp. When other sessions want to use the plan cache,
 		// it will check the timestamp first to decide whether the plan cache should be flushed.
@@ -293,6 +293,22 @@ func (p *rowFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows
 
 func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
 	numRows := uint64(len(rows))
+	err := appendFrameResult2Chunk(ctx, p.windowFuncs, p.partialResults, rows, chk, remained, func() (start, end uint64, err error) {
+		start = p.getStartOffset(numRows)
+		end = p.getEndOffset(numRows)
+		p.curRowIdx++
+		return start, end, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// appendFrameResult2Chunk appends the results of the next `remained` rows to chk, nextFrame returns the frame of the
+// next row. The functions implementing SlidingWindowAggFunc slide the frame, the others are recalculated for every frame.
+func appendFrameResult2Chunk(ctx sessionctx.Context, windowFuncs []aggfuncs.AggFunc, partialResults []aggfuncs.PartialResult,
+	rows []chunk.Row, chk *chunk.Chunk, remained int, nextFrame func() (uint64, uint64, error)) error {
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -303,44 +319,45 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 		shiftStart               uint64
 		shiftEnd                 uint64
 	)
-	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
-	for i, windowFunc := range p.windowFuncs {
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(windowFuncs))
+	for i, windowFunc := range windowFuncs {
 		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
 			slidingWindowAggFuncs[i] = slidingWindowAggFunc
 		}
 	}
 	for ; remained > 0; lastStart, lastEnd = start, end {
-		start = p.getStartOffset(numRows)
-		end = p.getEndOffset(numRows)
-		p.curRowIdx++
+		start, end, err = nextFrame()
+		if err != nil {
+			return err
+		}
 		remained--
 		shiftStart = start - lastStart
 		shiftEnd = end - lastEnd
 		if start >= end {
-			for i, windowFunc := range p.windowFuncs {
+			for i, windowFunc := range windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
 					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
 						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					}, lastStart, lastEnd, shiftStart, shiftEnd, partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
-				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+				err = windowFunc.AppendFinalResult2Chunk(ctx, partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
 		}
 
-		for i, windowFunc := range p.windowFuncs {
+		for i, windowFunc := range windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
 				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
 					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				}, lastStart, lastEnd, shiftStart, shiftEnd, partialResults[i])
 			} else {
 				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
 				// whether elements inside deque are out of current window.
@@ -348,27 +365,27 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], partialResults[i])
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
-			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+			err = windowFunc.AppendFinalResult2Chunk(ctx, partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
-				windowFunc.ResetPartialResult(p.partialResults[i])
+				windowFunc.ResetPartialResult(partialResults[i])
 			}
 		}
 		if !initializedSlidingWindow {
 			initializedSlidingWindow = true
 		}
 	}
-	for i, windowFunc := range p.windowFuncs {
-		windowFunc.ResetPartialResult(p.partialResults[i])
+	for i, windowFunc := range windowFuncs {
+		windowFunc.ResetPartialResult(partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
@@ -441,93 +458,144 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 }
 
 func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	var (
-		err                      error
-		initializedSlidingWindow bool
-		start                    uint64
-		end                      uint64
-		lastStart                uint64
-		lastEnd                  uint64
-		shiftStart               uint64
-		shiftEnd                 uint64
-	)
-	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
-	for i, windowFunc := range p.windowFuncs {
-		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
-			slidingWindowAggFuncs[i] = slidingWindowAggFunc
-		}
-	}
-	for ; remained > 0; lastStart, lastEnd = start, end {
+	err := appendFrameResult2Chunk(ctx, p.windowFuncs, p.partialResults, rows, chk, remained, func() (start, end uint64, err error) {
 		start, err = p.getStartOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return 0, 0, err
 		}
 		end, err = p.getEndOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return 0, 0, err
 		}
 		p.curRowIdx++
-		remained--
-		shiftStart = start - lastStart
-		shiftEnd = end - lastEnd
-		if start >= end {
-			for i, windowFunc := range p.windowFuncs {
-				slidingWindowAggFunc := slidingWindowAggFuncs[i]
-				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-					if err != nil {
-						return nil, err
-					}
-				}
-				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
-				if err != nil {
-					return nil, err
-				}
-			}
-			continue
-		}
+		return start, end, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
 
-		for i, windowFunc := range p.windowFuncs {
-			slidingWindowAggFunc := slidingWindowAggFuncs[i]
-			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-			} else {
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
-				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
-			}
-			if err != nil {
-				return nil, err
-			}
-			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+func (p *rangeFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+	return rows, nil
+}
+
+func (p *rangeFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.lastStartOffset = 0
+	p.lastEndOffset = 0
+}
+
+type groupsFrameWindowProcessor struct {
+	windowFuncs    []aggfuncs.AggFunc
+	partialResults []aggfuncs.PartialResult
+	start          *core.FrameBound
+	end            *core.FrameBound
+	curRowIdx      uint64
+	orderByCols    []*expression.Column
+	peerCmpFuncs   []expression.CompareFunc
+	// peerGroups[i] is the index of the peer group of the i-th row in the partition.
+	peerGroups []uint64
+	// groupStarts[i] is the offset of the first row of the i-th peer group, the last one is the number of rows.
+	groupStarts []uint64
+}
+
+func (p *groupsFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+	if len(p.groupStarts) > 0 {
+		// The peer groups of the partition have been split.
+		return rows, nil
+	}
+	for i := range rows {
+		if i == 0 {
+			p.groupStarts = append(p.groupStarts, 0)
+		} else {
+			peer, err := isPeerRow(ctx, p.orderByCols, p.peerCmpFuncs, rows[i-1], rows[i])
 			if err != nil {
 				return nil, err
 			}
-			if slidingWindowAggFunc == nil {
-				windowFunc.ResetPartialResult(p.partialResults[i])
+			if !peer {
+				p.groupStarts = append(p.groupStarts, uint64(i))
 			}
 		}
-		if !initializedSlidingWindow {
-			initializedSlidingWindow = true
+		p.peerGroups = append(p.peerGroups, uint64(len(p.groupStarts)-1))
+	}
+	p.groupStarts = append(p.groupStarts, uint64(len(rows)))
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) getStartOffset(numRows uint64) uint64 {
+	if p.start.UnBounded {
+		return 0
+	}
+	group, numGroups := p.peerGroups[p.curRowIdx], uint64(len(p.groupStarts)-1)
+	switch p.start.Type {
+	case ast.Preceding:
+		if group >= p.start.Num {
+			return p.groupStarts[group-p.start.Num]
+		}
+		return 0
+	case ast.Following:
+		if group+p.start.Num >= numGroups {
+			return numRows
 		}
+		return p.groupStarts[group+p.start.Num]
+	case ast.CurrentRow:
+		return p.groupStarts[group]
 	}
-	for i, windowFunc := range p.windowFuncs {
-		windowFunc.ResetPartialResult(p.partialResults[i])
+	// It will never reach here.
+	return 0
+}
+
+func (p *groupsFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
+	if p.end.UnBounded {
+		return numRows
 	}
-	return rows, nil
+	group, numGroups := p.peerGroups[p.curRowIdx], uint64(len(p.groupStarts)-1)
+	switch p.end.Type {
+	case ast.Preceding:
+		if group >= p.end.Num {
+			return p.groupStarts[group-p.end.Num+1]
+		}
+		return 0
+	case ast.Following:
+		if group+p.end.Num >= numGroups {
+			return numRows
+		}
+		return p.groupStarts[group+p.end.Num+1]
+	case ast.CurrentRow:
+		return p.groupStarts[group+1]
+	}
+	// It will never reach here.
+	return 0
 }
 
-func (p *rangeFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+func (p *groupsFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+	numRows := uint64(len(rows))
+	err := appendFrameResult2Chunk(ctx, p.windowFuncs, p.partialResults, rows, chk, remained, func() (start, end uint64, err error) {
+		start = p.getStartOffset(numRows)
+		end = p.getEndOffset(numRows)
+		p.curRowIdx++
+		return start, end, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return rows, nil
 }
 
-func (p *rangeFrameWindowProcessor) resetPartialResult() {
+func (p *groupsFrameWindowProcessor) resetPartialResult() {
 	p.curRowIdx = 0
-	p.lastStartOffset = 0
-	p.lastEndOffset = 0
+	p.peerGroups = p.peerGroups[:0]
+	p.groupStarts = p.groupStarts[:0]
+}
+
+// isPeerRow checks whether the two rows are peers, i.e. they are equal on all the order by columns.
+func isPeerRow(ctx sessionctx.Context, orderByCols []*expression.Column, cmpFuncs []expression.CompareFunc, lhs, rhs chunk.Row) (bool, error) {
+	for i, col := range orderByCols {
+		res, _, err := cmpFuncs[i](ctx, col, col, lhs, rhs)
+		if err != nil || res != 0 {
+			return false, err
+		}
+	}
+	return true, nil
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/testkit"
 )
 
@@ -222,6 +223,57 @@ func doTestWindowFunctions(tk *testkit.TestKit) {
 		Check(testkit.Rows("1 1", "1 2", "2 1", "2 2"))
 }
 
+func TestWindowFunctionsNullTreatmentAndGroups(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table gaps (id int, v int)")
+	tk.MustExec("insert into gaps values (1, 10), (2, null), (3, null), (4, 40), (5, null)")
+	tk.MustExec("create table s (p varchar(10), x int)")
+	tk.MustExec("insert into s values ('a', 1), ('a', 1), ('a', 2), ('b', 3), ('b', null)")
+	tk.MustExec("create table g (k int, v int)")
+	tk.MustExec("insert into g values (1, 1), (1, 2), (2, 3), (3, 4), (3, 5)")
+
+	for _, pipelined := range []int{0, 1} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %d", pipelined))
+
+		tk.MustQuery("select id, last_value(v) ignore nulls over (order by id rows between unbounded preceding and current row) from gaps").
+			Check(testkit.Rows("1 10", "2 10", "3 10", "4 40", "5 40"))
+		tk.MustQuery("select id, first_value(v) ignore nulls over (order by id rows between current row and unbounded following) from gaps").
+			Check(testkit.Rows("1 10", "2 40", "3 40", "4 40", "5 <nil>"))
+		tk.MustQuery("select id, lag(v) ignore nulls over (order by id), lead(v) ignore nulls over (order by id) from gaps").
+			Check(testkit.Rows("1 <nil> 40", "2 10 40", "3 10 40", "4 10 <nil>", "5 40 <nil>"))
+		tk.MustQuery("select id, nth_value(v, 1) from last over w, nth_value(v, 2) from last ignore nulls over w from gaps " +
+			"window w as (order by id rows between unbounded preceding and unbounded following)").
+			Check(testkit.Rows("1 <nil> 10", "2 <nil> 10", "3 <nil> 10", "4 <nil> 10", "5 <nil> 10"))
+
+		tk.MustQuery("select p, x, count(distinct x) over (partition by p) from s order by p, x").
+			Check(testkit.Rows("a 1 2", "a 1 2", "a 2 2", "b <nil> 1", "b 3 1"))
+		tk.MustQuery("select p, x, sum(distinct x) over (partition by p order by x rows between unbounded preceding and current row) from s order by p, x").
+			Check(testkit.Rows("a 1 1", "a 1 1", "a 2 3", "b <nil> <nil>", "b 3 3"))
+		tk.MustQuery("select p, x, group_concat(x) over (partition by p order by x rows between unbounded preceding and current row) from s").Sort().
+			Check(testkit.Rows("a 1 1", "a 1 1,1", "a 2 1,1,2", "b 3 3", "b <nil> <nil>"))
+		tk.MustQuery("select p, group_concat(distinct x separator ';') over (partition by p order by x rows between unbounded preceding and unbounded following) from s").Sort().
+			Check(testkit.Rows("a 1;2", "a 1;2", "a 1;2", "b 3", "b 3"))
+
+		tk.MustQuery("select k, v, sum(v) over (order by k groups between 1 preceding and current row) from g order by k, v").
+			Check(testkit.Rows("1 1 3", "1 2 3", "2 3 6", "3 4 12", "3 5 12"))
+		tk.MustQuery("select k, v, sum(v) over (order by k groups between current row and 1 following) from g order by k, v").
+			Check(testkit.Rows("1 1 6", "1 2 6", "2 3 12", "3 4 9", "3 5 9"))
+		tk.MustQuery("select k, v, sum(v) over (order by k groups between 1 following and 2 following) from g order by k, v").
+			Check(testkit.Rows("1 1 12", "1 2 12", "2 3 9", "3 4 <nil>", "3 5 <nil>"))
+		tk.MustQuery("select k, v, count(*) over (order by k groups unbounded preceding) from g order by k, v").
+			Check(testkit.Rows("1 1 2", "1 2 2", "2 3 3", "3 4 5", "3 5 5"))
+	}
+
+	tk.MustGetErrCode("select group_concat(x order by x) over () from s", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("select sum(v) over (order by k groups interval 1 day preceding) from g", errno.ErrWindowRowsIntervalUse)
+	// the modifiers are only accepted by the functions supporting them.
+	tk.MustGetErrCode("select row_number() ignore nulls over () from g", errno.ErrParse)
+	tk.MustGetErrCode("select lag(v) from last over (order by k) from g", errno.ErrParse)
+	tk.MustGetErrCode("select rank(distinct k) over (order by k) from g", errno.ErrParse)
+}
+
 func TestWindowFunctionsDataReference(t *testing.T) {
 	// see https://github.com/pingcap/tidb/issues/11614
 	store := testkit.CreateMockStore(t)
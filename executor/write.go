@@ -175,6 +175,10 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		}
 	}
 
+	if err = table.CheckRowConstraint(sctx, t.WritableConstraint(), newData); err != nil {
+		return false, err
+	}
+
 	// If handle changed, remove the old then add the new record, otherwise update the record.
 	if handleChanged {
 		// For `UPDATE IGNORE`/`INSERT IGNORE ON DUPLICATE KEY UPDATE`
@@ -15,12 +15,18 @@
 package executor_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/executor"
@@ -2273,6 +2279,77 @@ func TestLoadDataIgnoreLines(t *testing.T) {
 	checkCases(tests, ld, t, tk, ctx, selectSQL, deleteSQL)
 }
 
+func TestLoadDataFromExternalStorage(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test; drop table if exists load_data_test;")
+	tk.MustExec("CREATE TABLE load_data_test (id INT NOT NULL PRIMARY KEY, value TEXT NOT NULL)")
+
+	dir := t.TempDir()
+	// the escaped line terminator is right after the first split point
+	bigData := "1\t" + strings.Repeat("a", 18) + "\\\nb\n"
+	expected := []string{"1 " + strings.Repeat("a", 18) + "\nb"}
+	for i := 2; i <= 9; i++ {
+		bigData += fmt.Sprintf("%d\tv%d\n", i, i)
+		expected = append(expected, fmt.Sprintf("%d v%d", i, i))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "big.csv"), []byte(bigData), 0o644))
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte("10\tv10\n11\tv11"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.csv.gz"), buf.Bytes(), 0o644))
+	zw, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.csv.zst"), zw.EncodeAll([]byte("12\tv12\n13\tv13\n"), nil), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.csv.zstd"), zw.EncodeAll([]byte("14\tv14\n15\tv15"), nil), 0o644))
+	require.NoError(t, zw.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("6\tg\n"), 0o644))
+
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/executor/loadDataSplitSize", "return(20)"))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/executor/loadDataSplitSize"))
+	}()
+	ctx := tk.Session().(sessionctx.Context)
+	defer ctx.SetValue(executor.LoadDataVarKey, nil)
+	loadFromExternalStorage := func(sql string) error {
+		tk.MustExec(sql)
+		ld, ok := ctx.Value(executor.LoadDataVarKey).(*executor.LoadDataInfo)
+		require.True(t, ok)
+		require.True(t, ld.FromExternalStorage)
+		ctx.GetSessionVars().StmtCtx.DupKeyAsWarning = true
+		ctx.GetSessionVars().StmtCtx.BadNullAsWarning = true
+		ctx.GetSessionVars().StmtCtx.InLoadDataStmt = true
+		if err := ld.LoadFromExternalStorage(context.Background()); err != nil {
+			return err
+		}
+		require.Empty(t, ctx.GetSessionVars().StmtCtx.GetProgress())
+		txn, err := ctx.Txn(true)
+		require.NoError(t, err)
+		return txn.Commit(context.Background())
+	}
+
+	require.NoError(t, loadFromExternalStorage(fmt.Sprintf("load data infile 'file://%s/*.csv*' into table load_data_test", dir)))
+	require.Equal(t, "Records: 15  Deleted: 0  Skipped: 0  Warnings: 0", tk.Session().LastMessage())
+	expected = append(expected, "10 v10", "11 v11", "12 v12", "13 v13", "14 v14", "15 v15")
+	tk.MustQuery("select * from load_data_test order by id").Check(testkit.Rows(expected...))
+	tk.MustExec("delete from load_data_test")
+
+	// the first line of every file is ignored
+	ignoreDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(ignoreDir, "a.csv"), []byte("1\tx\n2\ty\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(ignoreDir, "b.csv"), []byte("3\tz\n4\tw\n"), 0o644))
+	require.NoError(t, loadFromExternalStorage(fmt.Sprintf("load data infile 'file://%s/?.csv' into table load_data_test ignore 1 lines", ignoreDir)))
+	tk.MustQuery("select * from load_data_test order by id").Check(testkit.Rows("2 y", "4 w"))
+
+	err = loadFromExternalStorage(fmt.Sprintf("load data infile 'file://%s/*.tsv' into table load_data_test", dir))
+	require.EqualError(t, err, "Load Data: no file matches the pattern '*.tsv'")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.csv.snappy"), []byte("7\th\n"), 0o644))
+	err = loadFromExternalStorage(fmt.Sprintf("load data infile 'file://%s/*.csv.snappy' into table load_data_test", dir))
+	require.EqualError(t, err, "Load Data: unsupported compression of the file 'data.csv.snappy'")
+}
+
 func TestLoadDataReplace(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
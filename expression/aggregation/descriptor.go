@@ -42,6 +42,9 @@ type AggFuncDesc struct {
 	HasDistinct bool
 	// OrderByItems represents the order by clause used in GROUP_CONCAT
 	OrderByItems []*util.ByItems
+	// IgnoreNull and FromLast are only used by the window functions, see WindowFuncDesc.
+	IgnoreNull bool
+	FromLast   bool
 }
 
 // NewAggFuncDesc creates an aggregation function signature descriptor.
@@ -57,9 +60,19 @@ func NewAggFuncDesc(ctx sessionctx.Context, name string, args []expression.Expre
 // NewAggFuncDescForWindowFunc creates an aggregation function from window functions, where baseFuncDesc may be ready.
 func NewAggFuncDescForWindowFunc(ctx sessionctx.Context, Desc *WindowFuncDesc, hasDistinct bool) (*AggFuncDesc, error) {
 	if Desc.RetTp == nil { // safety check
-		return NewAggFuncDesc(ctx, Desc.Name, Desc.Args, hasDistinct)
+		desc, err := NewAggFuncDesc(ctx, Desc.Name, Desc.Args, hasDistinct)
+		if err != nil {
+			return nil, err
+		}
+		desc.IgnoreNull, desc.FromLast = Desc.IgnoreNull, Desc.FromLast
+		return desc, nil
 	}
-	return &AggFuncDesc{baseFuncDesc: baseFuncDesc{Desc.Name, Desc.Args, Desc.RetTp}, HasDistinct: hasDistinct}, nil
+	return &AggFuncDesc{
+		baseFuncDesc: baseFuncDesc{Desc.Name, Desc.Args, Desc.RetTp},
+		HasDistinct:  hasDistinct,
+		IgnoreNull:   Desc.IgnoreNull,
+		FromLast:     Desc.FromLast,
+	}, nil
 }
 
 // String implements the fmt.Stringer interface.
@@ -90,7 +103,7 @@ func (a *AggFuncDesc) String() string {
 
 // Equal checks whether two aggregation function signatures are equal.
 func (a *AggFuncDesc) Equal(ctx sessionctx.Context, other *AggFuncDesc) bool {
-	if a.HasDistinct != other.HasDistinct {
+	if a.HasDistinct != other.HasDistinct || a.IgnoreNull != other.IgnoreNull || a.FromLast != other.FromLast {
 		return false
 	}
 	if len(a.OrderByItems) != len(other.OrderByItems) {
@@ -15,6 +15,7 @@
 package aggregation
 
 import (
+	"bytes"
 	"strings"
 
 	"github.com/pingcap/tidb/expression"
@@ -27,6 +28,12 @@ import (
 // WindowFuncDesc describes a window function signature, only used in planner.
 type WindowFuncDesc struct {
 	baseFuncDesc
+	// HasDistinct represents whether the aggregate window function contains distinct attribute.
+	HasDistinct bool
+	// IgnoreNull represents whether the NULL values are skipped by `IGNORE NULLS`.
+	IgnoreNull bool
+	// FromLast represents whether `NTH_VALUE` counts the rows from the last row of the frame.
+	FromLast bool
 }
 
 // NewWindowFuncDesc creates a window function signature descriptor.
@@ -61,7 +68,7 @@ func NewWindowFuncDesc(ctx sessionctx.Context, name string, args []expression.Ex
 	if err != nil {
 		return nil, err
 	}
-	return &WindowFuncDesc{base}, nil
+	return &WindowFuncDesc{baseFuncDesc: base}, nil
 }
 
 // noFrameWindowFuncs is the functions that operate on the entire partition,
@@ -101,7 +108,37 @@ func NeedFrame(name string) bool {
 
 // Clone makes a copy of SortItem.
 func (s *WindowFuncDesc) Clone() *WindowFuncDesc {
-	return &WindowFuncDesc{*s.baseFuncDesc.clone()}
+	clone := *s
+	clone.baseFuncDesc = *s.baseFuncDesc.clone()
+	return &clone
+}
+
+// String implements the fmt.Stringer interface.
+func (s *WindowFuncDesc) String() string {
+	buffer := bytes.NewBufferString(s.Name)
+	buffer.WriteString("(")
+	if s.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
+	for i, arg := range s.Args {
+		if s.Name == ast.AggFuncGroupConcat && i == len(s.Args)-1 {
+			buffer.WriteString(" separator ")
+			buffer.WriteString(arg.ExplainInfo())
+			continue
+		}
+		if i != 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(arg.String())
+	}
+	buffer.WriteString(")")
+	if s.FromLast {
+		buffer.WriteString(" from last")
+	}
+	if s.IgnoreNull {
+		buffer.WriteString(" ignore nulls")
+	}
+	return buffer.String()
 }
 
 // WindowFuncToPBExpr converts aggregate function to pb.
@@ -125,6 +162,10 @@ func WindowFuncToPBExpr(sctx sessionctx.Context, client kv.Client, desc *WindowF
 
 // CanPushDownToTiFlash control whether a window function desc can be push down to tiflash.
 func (s *WindowFuncDesc) CanPushDownToTiFlash(ctx sessionctx.Context) bool {
+	// TiFlash doesn't support the distinct attribute and the null treatment of the window functions.
+	if s.HasDistinct || s.IgnoreNull || s.FromLast {
+		return false
+	}
 	// args
 	if !expression.CanExprsPushDown(ctx.GetSessionVars().StmtCtx, s.Args, ctx.GetClient(), kv.TiFlash) {
 		return false
@@ -23,10 +23,12 @@
 package expression
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
 	gotime "time"
+	"unicode/utf8"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
@@ -49,9 +51,12 @@ var (
 	_ functionClass = &castAsTimeFunctionClass{}
 	_ functionClass = &castAsDurationFunctionClass{}
 	_ functionClass = &castAsJSONFunctionClass{}
+	_ functionClass = &castJSONAsArrayFunctionClass{}
 )
 
 var (
+	_ builtinFunc = &castJSONAsArrayFunctionSig{}
+
 	_ builtinFunc = &builtinCastIntAsIntSig{}
 	_ builtinFunc = &builtinCastIntAsRealSig{}
 	_ builtinFunc = &builtinCastIntAsStringSig{}
@@ -451,6 +456,162 @@ func (c *castAsJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Exp
 	return sig, nil
 }
 
+type castJSONAsArrayFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castJSONAsArrayFunctionClass) verifyArgs(args []Expression) error {
+	if err := c.baseFunctionClass.verifyArgs(args); err != nil {
+		return err
+	}
+	if args[0].GetType().EvalType() != types.ETJson {
+		return ErrNotSupportedYet.GenWithStackByArgs("CAST-ing Non-JSON type to array")
+	}
+	switch elemTp := c.tp.ArrayType(); elemTp.EvalType() {
+	case types.ETInt, types.ETReal, types.ETDatetime, types.ETDuration:
+		if elemTp.GetType() == mysql.TypeFloat || elemTp.GetType() == mysql.TypeYear {
+			return ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("CAST-ing JSON to the array of %s", elemTp.CompactStr()))
+		}
+	case types.ETString:
+		if elemTp.GetFlen() == types.UnspecifiedLength {
+			return ErrNotSupportedYet.GenWithStackByArgs("CAST-ing JSON to the array of CHAR without length")
+		}
+	default:
+		return ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("CAST-ing JSON to the array of %s", elemTp.CompactStr()))
+	}
+	return nil
+}
+
+func (c *castJSONAsArrayFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFunc(ctx, c.funcName, args, c.tp)
+	if err != nil {
+		return nil, err
+	}
+	sig = &castJSONAsArrayFunctionSig{bf}
+	return sig, nil
+}
+
+// castJSONAsArrayFunctionSig casts the JSON value to a JSON array whose elements are all converted to
+// the element type of the array. A scalar value is casted to an array with one element.
+type castJSONAsArrayFunctionSig struct {
+	baseBuiltinFunc
+}
+
+func (b *castJSONAsArrayFunctionSig) Clone() builtinFunc {
+	newSig := &castJSONAsArrayFunctionSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *castJSONAsArrayFunctionSig) evalJSON(row chunk.Row) (res types.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if val.TypeCode == types.JSONTypeCodeObject {
+		return res, false, ErrNotSupportedYet.GenWithStackByArgs("CAST-ing JSON OBJECT type to array")
+	}
+	elemTp := b.tp.ArrayType()
+	if val.TypeCode != types.JSONTypeCodeArray {
+		elem, err := b.convertElem(val, elemTp)
+		if err != nil {
+			return res, false, err
+		}
+		return types.CreateBinaryJSON([]interface{}{elem}), false, nil
+	}
+	elems := make([]interface{}, 0, val.GetElemCount())
+	for i := 0; i < val.GetElemCount(); i++ {
+		elem, err := b.convertElem(val.ArrayGetElem(i), elemTp)
+		if err != nil {
+			return res, false, err
+		}
+		elems = append(elems, elem)
+	}
+	return types.CreateBinaryJSON(elems), false, nil
+}
+
+// convertElem converts the element of the JSON array to the element type of the array. Unlike the
+// normal CAST, the value is never truncated, an error is returned if the value doesn't fit the type.
+func (b *castJSONAsArrayFunctionSig) convertElem(item types.BinaryJSON, tp *types.FieldType) (interface{}, error) {
+	sc := b.ctx.GetSessionVars().StmtCtx
+	unsigned := mysql.HasUnsignedFlag(tp.GetFlag())
+	switch tp.EvalType() {
+	case types.ETInt:
+		switch item.TypeCode {
+		case types.JSONTypeCodeInt64:
+			if v := item.GetInt64(); !unsigned {
+				return v, nil
+			} else if v >= 0 {
+				return uint64(v), nil
+			}
+			return nil, ErrJSONValueOutOfRange.GenWithStackByArgs(tp.String())
+		case types.JSONTypeCodeUint64:
+			if v := item.GetUint64(); unsigned {
+				return v, nil
+			} else if v <= math.MaxInt64 {
+				return int64(v), nil
+			}
+			return nil, ErrJSONValueOutOfRange.GenWithStackByArgs(tp.String())
+		}
+	case types.ETReal:
+		switch item.TypeCode {
+		case types.JSONTypeCodeInt64:
+			return float64(item.GetInt64()), nil
+		case types.JSONTypeCodeUint64:
+			return float64(item.GetUint64()), nil
+		case types.JSONTypeCodeFloat64:
+			return item.GetFloat64(), nil
+		}
+	case types.ETString:
+		if item.TypeCode == types.JSONTypeCodeString {
+			str := string(item.GetString())
+			if utf8.RuneCountInString(str) > tp.GetFlen() {
+				return nil, ErrFuncIndexDataIsTooLong.GenWithStackByArgs(tp.String())
+			}
+			return str, nil
+		}
+	case types.ETDatetime:
+		var t types.Time
+		var err error
+		switch item.TypeCode {
+		case types.JSONTypeCodeDate, types.JSONTypeCodeDatetime, types.JSONTypeCodeTimestamp:
+			t, err = item.GetTime().Convert(sc, tp.GetType())
+		case types.JSONTypeCodeString:
+			t, err = types.ParseTime(sc, string(item.GetString()), tp.GetType(), tp.GetDecimal())
+		default:
+			return nil, ErrInvalidJSONForFuncIndex.GenWithStackByArgs(tp.String())
+		}
+		if err == nil {
+			t, err = t.RoundFrac(sc, tp.GetDecimal())
+		}
+		if err != nil {
+			return nil, ErrInvalidJSONForFuncIndex.GenWithStackByArgs(tp.String())
+		}
+		return t, nil
+	case types.ETDuration:
+		var d types.Duration
+		var err error
+		switch item.TypeCode {
+		case types.JSONTypeCodeDuration:
+			d, err = item.GetDuration().RoundFrac(tp.GetDecimal(), b.ctx.GetSessionVars().Location())
+		case types.JSONTypeCodeString:
+			d, _, err = types.ParseDuration(sc, string(item.GetString()), tp.GetDecimal())
+		default:
+			return nil, ErrInvalidJSONForFuncIndex.GenWithStackByArgs(tp.String())
+		}
+		if err != nil {
+			return nil, ErrInvalidJSONForFuncIndex.GenWithStackByArgs(tp.String())
+		}
+		return d, nil
+	}
+	return nil, ErrInvalidJSONForFuncIndex.GenWithStackByArgs(tp.String())
+}
+
 type builtinCastIntAsIntSig struct {
 	baseBuiltinCastFunc
 }
@@ -1910,6 +2071,13 @@ func BuildCastCollationFunction(ctx sessionctx.Context, expr Expression, ec *Exp
 
 // BuildCastFunction builds a CAST ScalarFunction from the Expression.
 func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldType) (res Expression) {
+	res, err := BuildCastFunctionWithCheck(ctx, expr, tp)
+	terror.Log(err)
+	return
+}
+
+// BuildCastFunctionWithCheck builds a CAST ScalarFunction from the Expression and return error if any.
+func BuildCastFunctionWithCheck(ctx sessionctx.Context, expr Expression, tp *types.FieldType) (res Expression, err error) {
 	argType := expr.GetType()
 	// If source argument's nullable, then target type should be nullable
 	if !mysql.HasNotNullFlag(argType.GetFlag()) {
@@ -1918,6 +2086,12 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 	expr = TryPushCastIntoControlFunctionForHybridType(ctx, expr, tp)
 	var fc functionClass
 	switch tp.EvalType() {
+	case types.ETJson:
+		if tp.IsArray() {
+			fc = &castJSONAsArrayFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		} else {
+			fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		}
 	case types.ETInt:
 		fc = &castAsIntFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	case types.ETDecimal:
@@ -1928,8 +2102,6 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 		fc = &castAsTimeFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	case types.ETDuration:
 		fc = &castAsDurationFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
-	case types.ETJson:
-		fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	case types.ETString:
 		fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 		if expr.GetType().GetType() == mysql.TypeBit {
@@ -1937,7 +2109,9 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 		}
 	}
 	f, err := fc.getFunction(ctx, []Expression{expr})
-	terror.Log(err)
+	if err != nil {
+		return nil, err
+	}
 	res = &ScalarFunction{
 		FuncName: model.NewCIStr(ast.Cast),
 		RetType:  tp,
@@ -1949,7 +2123,7 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 	if tp.EvalType() != types.ETJson {
 		res = FoldConstant(res)
 	}
-	return res
+	return res, nil
 }
 
 // WrapWithCastAsInt wraps `expr` with `cast` if the return type of expr is not
@@ -334,8 +334,11 @@ func HandleBinaryLiteral(ctx sessionctx.Context, expr Expression, ec *ExprCollat
 
 func isLegacyCharset(chs string) bool {
 	switch chs {
-	case charset.CharsetUTF8, charset.CharsetUTF8MB4, charset.CharsetASCII, charset.CharsetLatin1, charset.CharsetBin:
+	case charset.CharsetUTF8, charset.CharsetUTF8MB4, charset.CharsetASCII, charset.CharsetBin:
 		return true
+	case charset.CharsetLatin1:
+		// latin1 is treated as utf-8 unless the real cp1252 encoding is enabled.
+		return !charset.Latin1CP1252Enabled()
 	}
 	return false
 }
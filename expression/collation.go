@@ -485,7 +485,9 @@ func isUnicodeCollation(ch string) bool {
 func isBinCollation(collate string) bool {
 	return collate == charset.CollationASCII || collate == charset.CollationLatin1 ||
 		collate == charset.CollationUTF8 || collate == charset.CollationUTF8MB4 ||
-		collate == charset.CollationGBKBin
+		collate == charset.CollationGBKBin || collate == charset.CollationUTF16Bin ||
+		collate == charset.CollationUTF16LEBin || collate == charset.CollationUTF32Bin ||
+		collate == charset.CollationUCS2Bin
 }
 
 // getBinCollation get binary collation by charset
@@ -497,6 +499,14 @@ func getBinCollation(cs string) string {
 		return charset.CollationUTF8MB4
 	case charset.CharsetGBK:
 		return charset.CollationGBKBin
+	case charset.CharsetUTF16:
+		return charset.CollationUTF16Bin
+	case charset.CharsetUTF16LE:
+		return charset.CollationUTF16LEBin
+	case charset.CharsetUTF32:
+		return charset.CollationUTF32Bin
+	case charset.CharsetUCS2:
+		return charset.CollationUCS2Bin
 	}
 
 	logutil.BgLogger().Error("unexpected charset " + cs)
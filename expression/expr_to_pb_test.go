@@ -1475,6 +1475,29 @@ func TestExprOnlyPushDownToTiKV(t *testing.T) {
 	require.Len(t, remained, 0)
 }
 
+func TestExprPushDownWithUnsupportedCollation(t *testing.T) {
+	sc := new(stmtctx.StatementContext)
+	client := new(mock.Client)
+
+	for _, coll := range []string{charset.CollationUTF16Bin, charset.CollationUTF16LEGeneralCI, charset.CollationUTF32Bin, charset.CollationUCS2GeneralCI,
+		charset.CollationUTF8MB40900AICI, charset.CollationUTF8MB40900Bin} {
+		col := columnCollation(genColumn(mysql.TypeVarchar, 0), "binary", coll)
+		function, err := NewFunction(mock.NewContext(), ast.Length, types.NewFieldType(mysql.TypeLonglong), col)
+		require.NoError(t, err)
+		exprs := []Expression{function}
+		for _, storeType := range []kv.StoreType{kv.TiKV, kv.TiFlash, kv.UnSpecified} {
+			pushed, remained := PushDownExprs(sc, exprs, client, storeType)
+			require.Len(t, pushed, 0, coll)
+			require.Len(t, remained, 1, coll)
+		}
+	}
+
+	col := columnCollation(genColumn(mysql.TypeVarchar, 0), "binary", charset.CollationUTF8MB4)
+	function, err := NewFunction(mock.NewContext(), ast.Length, types.NewFieldType(mysql.TypeLonglong), col)
+	require.NoError(t, err)
+	require.True(t, CanExprsPushDown(sc, []Expression{function}, client, kv.TiKV))
+}
+
 func TestGroupByItem2Pb(t *testing.T) {
 	sc := new(stmtctx.StatementContext)
 	client := new(mock.Client)
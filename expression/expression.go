@@ -1374,7 +1374,34 @@ func canScalarFuncPushDown(scalarFunc *ScalarFunction, pc PbConverter, storeType
 	return true
 }
 
+// collationsNotSupportedByStorage contains the collations which are only supported by TiDB,
+// TiKV and TiFlash can't evaluate the expressions with them.
+var collationsNotSupportedByStorage = map[string]struct{}{
+	charset.CollationUTF16Bin:         {},
+	charset.CollationUTF16GeneralCI:   {},
+	charset.CollationUTF16LEBin:       {},
+	charset.CollationUTF16LEGeneralCI: {},
+	charset.CollationUTF32Bin:         {},
+	charset.CollationUTF32GeneralCI:   {},
+	charset.CollationUCS2Bin:          {},
+	charset.CollationUCS2GeneralCI:    {},
+	charset.CollationUTF8MB40900AICI:  {},
+	charset.CollationUTF8MB40900Bin:   {},
+}
+
 func canExprPushDown(expr Expression, pc PbConverter, storeType kv.StoreType, canEnumPush bool) bool {
+	if storeType != kv.TiDB && expr.GetType().EvalType() == types.ETString {
+		if _, ok := collationsNotSupportedByStorage[expr.GetType().GetCollate()]; ok {
+			if pc.sc.InExplainStmt {
+				storageName := storeType.Name()
+				if storeType == kv.UnSpecified {
+					storageName = "storage layer"
+				}
+				pc.sc.AppendWarning(errors.New("Expression about '" + expr.String() + "' can not be pushed to " + storageName + " because it contains unsupported collation '" + expr.GetType().GetCollate() + "'."))
+			}
+			return false
+		}
+	}
 	if storeType == kv.TiFlash {
 		switch expr.GetType().GetType() {
 		case mysql.TypeEnum, mysql.TypeBit, mysql.TypeSet, mysql.TypeGeometry, mysql.TypeUnspecified:
@@ -26,6 +26,7 @@ var GeneralPlanCacheableOp = map[string]struct{}{
 	ast.GE:       {},
 	ast.LE:       {},
 	ast.EQ:       {},
+	ast.NE:       {},
 	ast.LT:       {},
 	ast.GT:       {},
 }
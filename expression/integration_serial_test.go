@@ -1285,6 +1285,27 @@ func TestLikeWithCollation(t *testing.T) {
 	tk.MustQuery(`select '😛' collate utf8mb4_unicode_ci = '😋';`).Check(testkit.Rows("1"))
 }
 
+func TestUTF8MB40900Collations(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a varchar(10) collate utf8mb4_0900_ai_ci, b varchar(10) collate utf8mb4_0900_bin, unique key ua(a), unique key ub(b))")
+	// Both collations are NO PAD, so the trailing spaces are significant.
+	tk.MustExec("insert into t values ('a', 'a'), ('a ', 'a ')")
+	tk.MustGetErrCode("insert into t values ('A', 'c')", mysql.ErrDupEntry)
+	tk.MustGetErrCode("insert into t values ('À', 'c')", mysql.ErrDupEntry)
+	tk.MustGetErrCode("insert into t values ('c', 'a ')", mysql.ErrDupEntry)
+	tk.MustExec("insert into t values ('ß', 'A')")
+	tk.MustGetErrCode("insert into t values ('ss', 'd')", mysql.ErrDupEntry)
+	tk.MustQuery("select hex(a) from t use index(ua) where a = 'A'").Check(testkit.Rows("61"))
+	tk.MustQuery("select count(*) from t where a = 'SS'").Check(testkit.Rows("1"))
+	tk.MustQuery("select hex(b) from t use index(ub) order by b").Check(testkit.Rows("41", "61", "6120"))
+	tk.MustQuery("select 'a' = 'a ' collate utf8mb4_0900_ai_ci, 'a' = 'a ' collate utf8mb4_0900_bin, 'a' = 'a ' collate utf8mb4_bin").Check(testkit.Rows("0 0 1"))
+	tk.MustQuery("select 'ǎ' like 'A' collate utf8mb4_0900_ai_ci, '😛' collate utf8mb4_0900_ai_ci = '😋'").Check(testkit.Rows("1 0"))
+	tk.MustExec("admin check table t")
+}
+
 func TestCollationPrefixClusteredIndex(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
@@ -983,6 +983,26 @@ func ParamMarkerExpression(ctx sessionctx.Context, v *driver.ParamMarkerExpr, ne
 	return value, nil
 }
 
+// SetCtxForExprs binds the cloned expressions to the session, it's used when a plan is shared by the sessions.
+// The parameter markers are bound to the parameters of the session, so the expressions must not be shared with others.
+func SetCtxForExprs(ctx sessionctx.Context, exprs []Expression) {
+	for _, expr := range exprs {
+		switch x := expr.(type) {
+		case *ScalarFunction:
+			x.Function.setCtx(ctx)
+			SetCtxForExprs(ctx, x.GetArgs())
+		case *Constant:
+			if x.ParamMarker != nil {
+				x.ParamMarker = &ParamMarker{order: x.ParamMarker.order, ctx: ctx}
+			}
+			if x.DeferredExpr != nil {
+				x.DeferredExpr = x.DeferredExpr.Clone()
+				SetCtxForExprs(ctx, []Expression{x.DeferredExpr})
+			}
+		}
+	}
+}
+
 // ParamMarkerInPrepareChecker checks whether the given ast tree has paramMarker and is in prepare statement.
 type ParamMarkerInPrepareChecker struct {
 	InPrepareStmt bool
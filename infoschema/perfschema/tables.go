@@ -207,6 +207,11 @@ func (vt *perfSchemaTable) Indices() []table.Index {
 	return vt.indices
 }
 
+// WritableConstraint implements table.Table WritableConstraint interface.
+func (vt *perfSchemaTable) WritableConstraint() []*table.Constraint {
+	return nil
+}
+
 // initTableIndices initializes the indices of the perfSchemaTable.
 func initTableIndices(t *perfSchemaTable) error {
 	tblInfo := t.meta
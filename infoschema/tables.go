@@ -186,6 +186,12 @@ const (
 	TableVariablesInfo = "VARIABLES_INFO"
 	// TableUserAttributes is the string constant of user_attributes view.
 	TableUserAttributes = "USER_ATTRIBUTES"
+	// TableTiDBTTLTableStatus is the string constant of the TTL job status of tables.
+	TableTiDBTTLTableStatus = "TIDB_TTL_TABLE_STATUS"
+	// TableCheckConstraints is the string constant of CHECK_CONSTRAINTS.
+	TableCheckConstraints = "CHECK_CONSTRAINTS"
+	// TableResourceGroups is the string constant of resource groups table.
+	TableResourceGroups = "RESOURCE_GROUPS"
 )
 
 const (
@@ -288,6 +294,9 @@ var tableIDMap = map[string]int64{
 	ClusterTableTrxSummary:               autoid.InformationSchemaDBID + 81,
 	TableVariablesInfo:                   autoid.InformationSchemaDBID + 82,
 	TableUserAttributes:                  autoid.InformationSchemaDBID + 83,
+	TableTiDBTTLTableStatus:              autoid.InformationSchemaDBID + 84,
+	TableCheckConstraints:                autoid.InformationSchemaDBID + 85,
+	TableResourceGroups:                  autoid.InformationSchemaDBID + 86,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -1545,6 +1554,38 @@ var tableUserAttributesCols = []columnInfo{
 	{name: "ATTRIBUTE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
 }
 
+var tableTiDBTTLTableStatusCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "PARTITION_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "JOB_STATUS", tp: mysql.TypeVarchar, size: 64},
+	{name: "JOB_OWNER", tp: mysql.TypeVarchar, size: 512},
+	{name: "JOB_START_TIME", tp: mysql.TypeDatetime},
+	{name: "JOB_END_TIME", tp: mysql.TypeDatetime},
+	{name: "EXPIRE_TIME", tp: mysql.TypeDatetime},
+	{name: "SCANNED_ROWS", tp: mysql.TypeLonglong, size: 64, flag: mysql.UnsignedFlag},
+	{name: "DELETED_ROWS", tp: mysql.TypeLonglong, size: 64, flag: mysql.UnsignedFlag},
+	{name: "ERROR_MESSAGE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+}
+
+// See https://dev.mysql.com/doc/refman/8.0/en/information-schema-check-constraints-table.html
+var tableCheckConstraintsCols = []columnInfo{
+	{name: "CONSTRAINT_CATALOG", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CONSTRAINT_SCHEMA", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CONSTRAINT_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CHECK_CLAUSE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength, flag: mysql.NotNullFlag},
+}
+
+var tableResourceGroupsCols = []columnInfo{
+	{name: "NAME", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
+	{name: "RU_PER_SEC", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "BURSTABLE", tp: mysql.TypeVarchar, size: 3},
+	{name: "READ_RU", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag, comment: "RU consumed by reads on this TiDB instance"},
+	{name: "WRITE_RU", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag, comment: "RU consumed by writes on this TiDB instance"},
+	{name: "QUERY_LIMIT", tp: mysql.TypeVarchar, size: 256},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //   - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -2005,6 +2046,9 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableTrxSummary:                         tableTrxSummaryCols,
 	TableVariablesInfo:                      tableVariablesInfoCols,
 	TableUserAttributes:                     tableUserAttributesCols,
+	TableTiDBTTLTableStatus:                 tableTiDBTTLTableStatusCols,
+	TableCheckConstraints:                   tableCheckConstraintsCols,
+	TableResourceGroups:                     tableResourceGroupsCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
@@ -2065,6 +2109,11 @@ func (it *infoschemaTable) Indices() []table.Index {
 	return nil
 }
 
+// WritableConstraint implements table.Table WritableConstraint interface.
+func (it *infoschemaTable) WritableConstraint() []*table.Constraint {
+	return nil
+}
+
 // RecordPrefix implements table.Table RecordPrefix interface.
 func (it *infoschemaTable) RecordPrefix() kv.Key {
 	return nil
@@ -2148,6 +2197,11 @@ func (vt *VirtualTable) Indices() []table.Index {
 	return nil
 }
 
+// WritableConstraint implements table.Table WritableConstraint interface.
+func (vt *VirtualTable) WritableConstraint() []*table.Constraint {
+	return nil
+}
+
 // RecordPrefix implements table.Table RecordPrefix interface.
 func (vt *VirtualTable) RecordPrefix() kv.Key {
 	return nil
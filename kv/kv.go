@@ -384,6 +384,8 @@ type Request struct {
 	MatchStoreLabels []*metapb.StoreLabel
 	// ResourceGroupTagger indicates the kv request task group tagger.
 	ResourceGroupTagger tikvrpc.ResourceGroupTagger
+	// ResourceGroupName is the name of the resource group whose quota throttles the request.
+	ResourceGroupName string
 	// Paging indicates whether the request is a paging request.
 	Paging struct {
 		Enable bool
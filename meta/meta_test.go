@@ -104,6 +104,49 @@ func TestPlacementPolicy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestResourceGroup(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+
+	defer func() {
+		err := store.Close()
+		require.NoError(t, err)
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMeta(txn)
+
+	group := &model.ResourceGroupInfo{
+		ID:   1,
+		Name: model.NewCIStr("aa"),
+		ResourceGroupSettings: &model.ResourceGroupSettings{
+			RURate: 100,
+		},
+	}
+	require.NoError(t, m.AddResourceGroup(group))
+	err = m.AddResourceGroup(group)
+	require.True(t, meta.ErrResourceGroupExists.Equal(err))
+
+	val, err := m.GetResourceGroup(1)
+	require.NoError(t, err)
+	require.Equal(t, group, val)
+
+	group.RURate = 200
+	group.Burstable = true
+	require.NoError(t, m.UpdateResourceGroup(group))
+	groups, err := m.ListResourceGroups()
+	require.NoError(t, err)
+	require.Equal(t, []*model.ResourceGroupInfo{group}, groups)
+
+	require.NoError(t, m.DropResourceGroup(1))
+	_, err = m.GetResourceGroup(1)
+	require.True(t, meta.ErrResourceGroupNotExists.Equal(err))
+	err = m.UpdateResourceGroup(group)
+	require.True(t, meta.ErrResourceGroupNotExists.Equal(err))
+	require.NoError(t, txn.Commit(context.Background()))
+}
+
 func TestBackupAndRestoreAutoIDs(t *testing.T) {
 	store, err := mockstore.NewMockStore()
 	require.NoError(t, err)
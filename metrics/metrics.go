@@ -191,6 +191,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(ReadFromTableCacheCounter)
 	prometheus.MustRegister(LoadTableCacheDurationHistogram)
 	prometheus.MustRegister(NonTransactionalDeleteCount)
+	prometheus.MustRegister(NonTransactionalInsertCount)
 	prometheus.MustRegister(MemoryUsage)
 	prometheus.MustRegister(StatsCacheLRUCounter)
 	prometheus.MustRegister(StatsCacheLRUGauge)
@@ -204,6 +205,8 @@ func RegisterMetrics() {
 	prometheus.MustRegister(RegionCheckpointRequest)
 	prometheus.MustRegister(RegionCheckpointFailure)
 	prometheus.MustRegister(RCCheckTSWriteConfilictCounter)
+	prometheus.MustRegister(ResourceGroupRUCounter)
+	prometheus.MustRegister(ResourceGroupThrottleDuration)
 
 	tikvmetrics.InitMetrics(TiDB, TiKVClient)
 	tikvmetrics.RegisterMetrics()
@@ -228,6 +231,7 @@ func ToggleSimplifiedMode(simplified bool) {
 		TiFlashQueryTotalCounter,
 		CampaignOwnerCounter,
 		NonTransactionalDeleteCount,
+		NonTransactionalInsertCount,
 		MemoryUsage,
 		TokenGauge,
 		tikvmetrics.TiKVRawkvSizeHistogram,
@@ -0,0 +1,44 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Resource group metrics.
+var (
+	ResourceGroupRUCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "resource_group",
+			Name:      "ru_consumed_total",
+			Help:      "Counter of request units consumed by each resource group.",
+		}, []string{LblName, LblType})
+
+	ResourceGroupThrottleDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "resource_group",
+			Name:      "throttle_duration_seconds",
+			Help:      "Bucket histogram of the time (s) requests wait for the tokens of their resource group.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 20), // 0.5ms ~ 262s
+		}, []string{LblName})
+)
+
+// Label constants of the resource group metrics.
+const (
+	LblName    = "name"
+	LblRURead  = "read"
+	LblRUWrite = "write"
+)
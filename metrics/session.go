@@ -135,6 +135,13 @@ var (
 			Name:      "non_transactional_delete_count",
 			Help:      "Counter of non-transactional delete",
 		})
+	NonTransactionalInsertCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "session",
+			Name:      "non_transactional_insert_count",
+			Help:      "Counter of non-transactional insert",
+		})
 	TxnStatusEnteringCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "tidb",
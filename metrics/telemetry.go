@@ -325,12 +325,14 @@ func GetTablePartitionCounter() TablePartitionUsageCounter {
 // NonTransactionalStmtCounter records the usages of non-transactional statements.
 type NonTransactionalStmtCounter struct {
 	DeleteCount int64 `json:"delete"`
+	InsertCount int64 `json:"insert"`
 }
 
 // Sub returns the difference of two counters.
 func (n NonTransactionalStmtCounter) Sub(rhs NonTransactionalStmtCounter) NonTransactionalStmtCounter {
 	return NonTransactionalStmtCounter{
 		DeleteCount: n.DeleteCount - rhs.DeleteCount,
+		InsertCount: n.InsertCount - rhs.InsertCount,
 	}
 }
 
@@ -338,6 +340,7 @@ func (n NonTransactionalStmtCounter) Sub(rhs NonTransactionalStmtCounter) NonTra
 func GetNonTransactionalStmtCounter() NonTransactionalStmtCounter {
 	return NonTransactionalStmtCounter{
 		DeleteCount: readCounter(NonTransactionalDeleteCount),
+		InsertCount: readCounter(NonTransactionalInsertCount),
 	}
 }
 
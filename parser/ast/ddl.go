@@ -28,18 +28,21 @@ var (
 	_ DDLNode = &AlterTableStmt{}
 	_ DDLNode = &AlterSequenceStmt{}
 	_ DDLNode = &AlterPlacementPolicyStmt{}
+	_ DDLNode = &AlterResourceGroupStmt{}
 	_ DDLNode = &CreateDatabaseStmt{}
 	_ DDLNode = &CreateIndexStmt{}
 	_ DDLNode = &CreateTableStmt{}
 	_ DDLNode = &CreateViewStmt{}
 	_ DDLNode = &CreateSequenceStmt{}
 	_ DDLNode = &CreatePlacementPolicyStmt{}
+	_ DDLNode = &CreateResourceGroupStmt{}
 	_ DDLNode = &DropDatabaseStmt{}
 	_ DDLNode = &FlashBackDatabaseStmt{}
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
 	_ DDLNode = &DropSequenceStmt{}
 	_ DDLNode = &DropPlacementPolicyStmt{}
+	_ DDLNode = &DropResourceGroupStmt{}
 	_ DDLNode = &RenameTableStmt{}
 	_ DDLNode = &TruncateTableStmt{}
 	_ DDLNode = &RepairTableStmt{}
@@ -1745,11 +1748,17 @@ type DropIndexStmt struct {
 	IndexName string
 	Table     *TableName
 	LockAlg   *IndexLockAndAlgorithm
+	// IsHypo indicates whether the dropped index is a hypothetical index.
+	IsHypo bool
 }
 
 // Restore implements Node interface.
 func (n *DropIndexStmt) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("DROP INDEX ")
+	ctx.WriteKeyWord("DROP ")
+	if n.IsHypo {
+		ctx.WriteKeyWord("HYPO ")
+	}
+	ctx.WriteKeyWord("INDEX ")
 	if n.IfExists {
 		_ = ctx.WriteWithSpecialComments("", func() error {
 			ctx.WriteKeyWord("IF EXISTS ")
@@ -2074,6 +2083,8 @@ const (
 	TableOptionTableCheckSum
 	TableOptionUnion
 	TableOptionEncryption
+	TableOptionTTL
+	TableOptionTTLEnable
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2120,13 +2131,15 @@ const (
 
 // TableOption is used for parsing table option from SQL.
 type TableOption struct {
-	Tp         TableOptionType
-	Default    bool
-	StrValue   string
-	UintValue  uint64
-	BoolValue  bool
-	Value      ValueExpr
-	TableNames []*TableName
+	Tp            TableOptionType
+	Default       bool
+	StrValue      string
+	UintValue     uint64
+	BoolValue     bool
+	Value         ValueExpr
+	TableNames    []*TableName
+	ColumnName    *ColumnName
+	TimeUnitValue *TimeUnitExpr
 }
 
 func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
@@ -2355,6 +2368,29 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ENCRYPTION ")
 		ctx.WritePlain("= ")
 		ctx.WriteString(n.StrValue)
+	case TableOptionTTL:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL ")
+			ctx.WritePlain("= ")
+			ctx.WriteName(n.ColumnName.Name.String())
+			ctx.WritePlain(" + INTERVAL ")
+			if err := n.Value.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore TableOptionTTL Value")
+			}
+			ctx.WritePlain(" ")
+			return n.TimeUnitValue.Restore(ctx)
+		})
+	case TableOptionTTLEnable:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_ENABLE ")
+			ctx.WritePlain("= ")
+			if n.BoolValue {
+				ctx.WriteString("ON")
+			} else {
+				ctx.WriteString("OFF")
+			}
+			return nil
+		})
 	case TableOptionPlacementPolicy:
 		if ctx.Flags.HasSkipPlacementRuleForRestoreFlag() {
 			return nil
@@ -2599,6 +2635,7 @@ const (
 	AlterTableAddLastPartition
 	AlterTableReorganizeLastPartition
 	AlterTableReorganizeFirstPartition
+	AlterTableRemoveTTL
 )
 
 // LockType is the type for AlterTableSpec.
@@ -3171,6 +3208,11 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("DISABLE KEYS")
 	case AlterTableRemovePartitioning:
 		ctx.WriteKeyWord("REMOVE PARTITIONING")
+	case AlterTableRemoveTTL:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("REMOVE TTL")
+			return nil
+		})
 	case AlterTableWithValidation:
 		ctx.WriteKeyWord("WITH VALIDATION")
 	case AlterTableWithoutValidation:
@@ -4204,6 +4246,191 @@ func (n *AlterPlacementPolicyStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ResourceGroupOptionType is the type of resource group option.
+type ResourceGroupOptionType int
+
+// ResourceGroupOption types.
+const (
+	ResourceRURate ResourceGroupOptionType = iota + 1
+	ResourceBurstable
+	ResourceRunaway
+)
+
+// ResourceGroupOption is used for parsing resource group option.
+type ResourceGroupOption struct {
+	Tp        ResourceGroupOptionType
+	UintValue uint64
+	BoolValue bool
+	// RunawayOptionList is the options of QUERY_LIMIT.
+	RunawayOptionList []*ResourceGroupRunawayOption
+}
+
+// Restore implements Node interface.
+func (n *ResourceGroupOption) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case ResourceRURate:
+		ctx.WriteKeyWord("RU_PER_SEC ")
+		ctx.WritePlain("= ")
+		ctx.WritePlainf("%d", n.UintValue)
+	case ResourceBurstable:
+		ctx.WriteKeyWord("BURSTABLE")
+	case ResourceRunaway:
+		ctx.WriteKeyWord("QUERY_LIMIT ")
+		ctx.WritePlain("= (")
+		for i, option := range n.RunawayOptionList {
+			if i > 0 {
+				ctx.WritePlain(" ")
+			}
+			if err := option.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while splicing ResourceGroupRunawayOption: [%v]", i)
+			}
+		}
+		ctx.WritePlain(")")
+	default:
+		return errors.Errorf("invalid ResourceGroupOption: %d", n.Tp)
+	}
+	return nil
+}
+
+// RunawayOptionType is the type of the option of QUERY_LIMIT.
+type RunawayOptionType int
+
+// RunawayOption types.
+const (
+	RunawayExecElapsed RunawayOptionType = iota + 1
+	RunawayAction
+	RunawayWatch
+)
+
+// ResourceGroupRunawayOption is used for parsing the options of QUERY_LIMIT.
+type ResourceGroupRunawayOption struct {
+	Tp RunawayOptionType
+	// StrValue is the elapsed time of EXEC_ELAPSED, or the duration of WATCH.
+	StrValue   string
+	ActionType model.RunawayActionType
+	WatchType  model.RunawayWatchType
+}
+
+// Restore implements Node interface.
+func (n *ResourceGroupRunawayOption) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case RunawayExecElapsed:
+		ctx.WriteKeyWord("EXEC_ELAPSED ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
+	case RunawayAction:
+		ctx.WriteKeyWord("ACTION ")
+		ctx.WritePlain("= ")
+		ctx.WriteKeyWord(n.ActionType.String())
+	case RunawayWatch:
+		ctx.WriteKeyWord("WATCH ")
+		ctx.WritePlain("= ")
+		ctx.WriteKeyWord(n.WatchType.String())
+		ctx.WriteKeyWord(" DURATION ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
+	default:
+		return errors.Errorf("invalid ResourceGroupRunawayOption: %d", n.Tp)
+	}
+	return nil
+}
+
+func restoreResourceGroupOptions(ctx *format.RestoreCtx, options []*ResourceGroupOption) error {
+	for i, option := range options {
+		ctx.WritePlain(" ")
+		if err := option.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while splicing ResourceGroupOption: [%v]", i)
+		}
+	}
+	return nil
+}
+
+// CreateResourceGroupStmt is a statement to create a resource group.
+type CreateResourceGroupStmt struct {
+	ddlNode
+
+	IfNotExists             bool
+	ResourceGroupName       model.CIStr
+	ResourceGroupOptionList []*ResourceGroupOption
+}
+
+// Restore implements Node interface.
+func (n *CreateResourceGroupStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE RESOURCE GROUP ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	ctx.WriteName(n.ResourceGroupName.O)
+	return restoreResourceGroupOptions(ctx, n.ResourceGroupOptionList)
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateResourceGroupStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateResourceGroupStmt)
+	return v.Leave(n)
+}
+
+// AlterResourceGroupStmt is a statement to alter resource group option.
+type AlterResourceGroupStmt struct {
+	ddlNode
+
+	IfExists                bool
+	ResourceGroupName       model.CIStr
+	ResourceGroupOptionList []*ResourceGroupOption
+}
+
+// Restore implements Node interface.
+func (n *AlterResourceGroupStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER RESOURCE GROUP ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	ctx.WriteName(n.ResourceGroupName.O)
+	return restoreResourceGroupOptions(ctx, n.ResourceGroupOptionList)
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterResourceGroupStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterResourceGroupStmt)
+	return v.Leave(n)
+}
+
+// DropResourceGroupStmt is a statement to drop a resource group.
+type DropResourceGroupStmt struct {
+	ddlNode
+
+	IfExists          bool
+	ResourceGroupName model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *DropResourceGroupStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP RESOURCE GROUP ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	ctx.WriteName(n.ResourceGroupName.O)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropResourceGroupStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropResourceGroupStmt)
+	return v.Leave(n)
+}
+
 // AlterSequenceStmt is a statement to alter sequence option.
 type AlterSequenceStmt struct {
 	ddlNode
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -520,6 +521,10 @@ type TableSource struct {
 
 	// AsName is the alias name of the table source.
 	AsName model.CIStr
+
+	// Lateral indicates whether the derived table is a LATERAL derived table, which can reference
+	// the columns of the preceding tables in the same FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -565,6 +570,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -1022,6 +1030,164 @@ func (s *TableSample) Accept(v Visitor) (node Node, ok bool) {
 	return v.Leave(s)
 }
 
+// JSONTableColumnType is the type of the column in JSON_TABLE.
+type JSONTableColumnType int
+
+const (
+	// JSONTableColumnPath is the column which extracts a value by the path, e.g. `a INT PATH '$.a'`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnOrdinality is the row counter column, e.g. `id FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnExists is the column which checks whether the path exists, e.g. `a INT EXISTS PATH '$.a'`.
+	JSONTableColumnExists
+	// JSONTableColumnNested is the nested path which flattens the nested arrays, e.g. `NESTED PATH '$.a[*]' COLUMNS (...)`.
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the behavior of the column when the path is empty or an error occurs.
+type JSONTableOnResponseType int
+
+const (
+	// JSONTableOnResponseNull sets the column to NULL, it is the default behavior.
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	// JSONTableOnResponseError returns an error.
+	JSONTableOnResponseError
+	// JSONTableOnResponseDefault sets the column to the default value.
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse is the `{NULL | ERROR | DEFAULT json_string} ON {EMPTY | ERROR}` clause of the JSON_TABLE column.
+type JSONTableOnResponse struct {
+	Tp JSONTableOnResponseType
+	// Default is the JSON string of the default value.
+	Default string
+}
+
+// Restore implements Node interface.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+	return nil
+}
+
+// JSONTableColumn is a column definition in the COLUMNS clause of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp   JSONTableColumnType
+	Name model.CIStr
+	// FieldType is the type of the path and exists column.
+	FieldType *types.FieldType
+	// Path is the path of the path, exists and nested column.
+	Path    string
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+	// Columns are the columns of the nested path.
+	Columns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WriteKeyWord(" COLUMNS ")
+		return restoreJSONTableColumns(ctx, n.Columns)
+	}
+	ctx.WriteName(n.Name.O)
+	if n.Tp == JSONTableColumnOrdinality {
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	if n.Tp == JSONTableColumnExists {
+		ctx.WriteKeyWord(" EXISTS")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnEmpty.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnEmpty")
+		}
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnError.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnError")
+		}
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, columns []*JSONTableColumn) error {
+	ctx.WritePlain("(")
+	for i, col := range columns {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable is the JSON_TABLE table function, which extracts the data of a JSON document as a table.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	Expr    ExprNode
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WriteKeyWord(" COLUMNS ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 type SelectStmtKind uint8
 
 const (
@@ -2197,6 +2363,32 @@ func (n *InsertStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// WhereExpr implements ShardableDMLStmt interface. It's the WHERE clause of the SELECT in INSERT INTO ... SELECT.
+func (n *InsertStmt) WhereExpr() ExprNode {
+	if s, ok := n.Select.(*SelectStmt); ok {
+		return s.Where
+	}
+	return nil
+}
+
+// SetWhereExpr implements ShardableDMLStmt interface.
+func (n *InsertStmt) SetWhereExpr(e ExprNode) {
+	if s, ok := n.Select.(*SelectStmt); ok {
+		s.Where = e
+	}
+}
+
+// TableSource implements ShardableDMLStmt interface. It's the table read by the SELECT in INSERT INTO ... SELECT,
+// which is split into batches by the non-transactional DML.
+func (n *InsertStmt) TableSource() (*TableSource, bool) {
+	s, ok := n.Select.(*SelectStmt)
+	if !ok || s.From == nil || s.From.TableRefs == nil {
+		return nil, false
+	}
+	table, ok := s.From.TableRefs.Left.(*TableSource)
+	return table, ok
+}
+
 // DeleteStmt is a statement to delete rows from table.
 // See https://dev.mysql.com/doc/refman/5.7/en/delete.html
 type DeleteStmt struct {
@@ -2395,6 +2587,7 @@ type ShardableDMLStmt = interface {
 
 var _ ShardableDMLStmt = &DeleteStmt{}
 var _ ShardableDMLStmt = &UpdateStmt{}
+var _ ShardableDMLStmt = &InsertStmt{}
 
 type NonTransactionalDMLStmt struct {
 	dmlNode
@@ -2727,6 +2920,7 @@ const (
 	ShowPlacementForPartition
 	ShowPlacementLabels
 	ShowSessionStates
+	ShowStatsLocked
 )
 
 const (
@@ -2894,6 +3088,11 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := restoreShowLikeOrWhereOpt(); err != nil {
 			return err
 		}
+	case ShowStatsLocked:
+		ctx.WriteKeyWord("STATS_LOCKED")
+		if err := restoreShowLikeOrWhereOpt(); err != nil {
+			return err
+		}
 	case ShowHistogramsInFlight:
 		ctx.WriteKeyWord("HISTOGRAMS_IN_FLIGHT")
 		if err := restoreShowLikeOrWhereOpt(); err != nil {
@@ -3149,7 +3348,7 @@ func (n *ShowStmt) NeedLimitRSRow() bool {
 		//
 		// 2) The STMT is a MySQL syntax extend, so just keep it behavior as before:
 		//    ShowCreateSequence, ShowCreatePlacementPolicy, ShowConfig, ShowStatsExtended,
-		//    ShowStatsMeta, ShowStatsHistograms, ShowStatsTopN, ShowStatsBuckets, ShowStatsHealthy
+		//    ShowStatsMeta, ShowStatsHistograms, ShowStatsTopN, ShowStatsBuckets, ShowStatsHealthy, ShowStatsLocked
 		//    ShowHistogramsInFlight, ShowColumnStatsUsage, ShowBindings, ShowBindingCacheStatus,
 		//    ShowPumpStatus, ShowDrainerStatus, ShowAnalyzeStatus, ShowRegions, ShowBuiltins,
 		//    ShowTableNextRowId, ShowBackups, ShowRestores, ShowImports, ShowCreateImport, ShowPlacement
@@ -3271,6 +3470,30 @@ type SelectIntoOption struct {
 	FileName   string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	// Compression is the compression algorithm of the output files, e.g. "gzip" or "zstd".
+	Compression string
+	// MaxFileSize is the maximum size of each output file. The output is split into multiple files if it's not 0.
+	MaxFileSize uint64
+	// Header indicates whether the column names are written as the first line of each output file.
+	Header bool
+}
+
+// SelectIntoOutfileOptionType is the type of SelectIntoOutfileOption.
+type SelectIntoOutfileOptionType int
+
+// SelectIntoOutfileOption types.
+const (
+	SelectIntoOutfileOptionCompression SelectIntoOutfileOptionType = iota + 1
+	SelectIntoOutfileOptionMaxFileSize
+	SelectIntoOutfileOptionHeader
+)
+
+// SelectIntoOutfileOption is an option of SELECT ... INTO OUTFILE, which is only used by the parser.
+type SelectIntoOutfileOption struct {
+	Tp        SelectIntoOutfileOptionType
+	StrValue  string
+	UintValue uint64
+	BoolValue bool
 }
 
 // Restore implements Node interface.
@@ -3292,6 +3515,20 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	if n.Compression != "" {
+		ctx.WriteKeyWord(" COMPRESSION ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.Compression)
+	}
+	if n.MaxFileSize != 0 {
+		ctx.WriteKeyWord(" MAX_FILE_SIZE ")
+		ctx.WritePlainf("= %d", n.MaxFileSize)
+	}
+	if n.Header {
+		ctx.WriteKeyWord(" HEADER ")
+		ctx.WritePlain("= ")
+		ctx.WriteKeyWord("TRUE")
+	}
 	return nil
 }
 
@@ -3368,6 +3605,8 @@ func (n *FrameClause) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ROWS")
 	case Ranges:
 		ctx.WriteKeyWord("RANGE")
+	case Groups:
+		ctx.WriteKeyWord("GROUPS")
 	default:
 		return errors.New("Unsupported window function frame type")
 	}
@@ -346,6 +346,8 @@ const (
 	JSONDepth         = "json_depth"
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
+	JSONMemberOf      = "json_memberof"
+	JSONOverlaps      = "json_overlaps"
 
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
@@ -396,6 +398,18 @@ func (n *FuncCallExpr) Restore(ctx *format.RestoreCtx) error {
 		}
 		return nil
 	}
+	if n.FnName.L == JSONMemberOf {
+		if err := n.Args[0].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncCallExpr.Args[0]")
+		}
+		ctx.WriteKeyWord(" MEMBER OF ")
+		ctx.WritePlain("(")
+		if err := n.Args[1].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncCallExpr.Args[1]")
+		}
+		ctx.WritePlain(")")
+		return nil
+	}
 
 	if len(n.Schema.String()) != 0 {
 		ctx.WriteName(n.Schema.O)
@@ -865,6 +879,8 @@ type WindowFuncExpr struct {
 	// FromLast indicates the calculation direction of this window function.
 	// MySQL only supports calculation from first, so we need to raise error if it is true.
 	FromLast bool
+	// Order is only used in GROUP_CONCAT.
+	Order *OrderByClause
 	// Spec is the specification of this window.
 	Spec WindowSpec
 }
@@ -873,7 +889,12 @@ type WindowFuncExpr struct {
 func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord(n.F)
 	ctx.WritePlain("(")
-	for i, v := range n.Args {
+	args := n.Args
+	if strings.ToLower(n.F) == AggFuncGroupConcat {
+		// The last argument is the separator.
+		args = n.Args[:len(n.Args)-1]
+	}
+	for i, v := range args {
 		if i != 0 {
 			ctx.WritePlain(", ")
 		} else if n.Distinct {
@@ -883,6 +904,18 @@ func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
 		}
 	}
+	if len(args) < len(n.Args) {
+		if n.Order != nil {
+			ctx.WritePlain(" ")
+			if err := n.Order.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Order")
+			}
+		}
+		ctx.WriteKeyWord(" SEPARATOR ")
+		if err := n.Args[len(n.Args)-1].Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Args SEPARATOR")
+		}
+	}
 	ctx.WritePlain(")")
 	if n.FromLast {
 		ctx.WriteKeyWord(" FROM LAST")
@@ -917,6 +950,13 @@ func (n *WindowFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	node, ok := n.Spec.Accept(v)
 	if !ok {
 		return n, false
@@ -23,6 +23,8 @@ var (
 	_ StmtNode = &AnalyzeTableStmt{}
 	_ StmtNode = &DropStatsStmt{}
 	_ StmtNode = &LoadStatsStmt{}
+	_ StmtNode = &LockStatsStmt{}
+	_ StmtNode = &UnlockStatsStmt{}
 )
 
 // AnalyzeTableStmt is used to create table statistics.
@@ -259,3 +261,89 @@ func (n *LoadStatsStmt) Accept(v Visitor) (Node, bool) {
 	n = newNode.(*LoadStatsStmt)
 	return v.Leave(n)
 }
+
+// LockStatsStmt is the statement node for locking the statistics of the tables.
+// The locked statistics are not updated by ANALYZE, LOAD STATS and the auto analyze.
+type LockStatsStmt struct {
+	stmtNode
+
+	Tables []*TableName
+	// PartitionNames is only used when there is only one table.
+	PartitionNames []model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *LockStatsStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("LOCK STATS ")
+	return restoreStatsTables(ctx, n.Tables, n.PartitionNames)
+}
+
+// Accept implements Node Accept interface.
+func (n *LockStatsStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*LockStatsStmt)
+	for i, val := range n.Tables {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// UnlockStatsStmt is the statement node for unlocking the statistics of the tables.
+type UnlockStatsStmt struct {
+	stmtNode
+
+	Tables []*TableName
+	// PartitionNames is only used when there is only one table.
+	PartitionNames []model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *UnlockStatsStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("UNLOCK STATS ")
+	return restoreStatsTables(ctx, n.Tables, n.PartitionNames)
+}
+
+// Accept implements Node Accept interface.
+func (n *UnlockStatsStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*UnlockStatsStmt)
+	for i, val := range n.Tables {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+func restoreStatsTables(ctx *format.RestoreCtx, tables []*TableName, partitionNames []model.CIStr) error {
+	for i, table := range tables {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := table.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore Tables[%d]", i)
+		}
+	}
+	if len(partitionNames) != 0 {
+		ctx.WriteKeyWord(" PARTITION ")
+	}
+	for i, partition := range partitionNames {
+		if i != 0 {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(partition.O)
+	}
+	return nil
+}
@@ -62,16 +62,24 @@ var CharacterSetInfos = map[string]*Charset{
 	CharsetLatin1:  {CharsetLatin1, CollationLatin1, make(map[string]*Collation), "Latin1", 1},
 	CharsetBin:     {CharsetBin, CollationBin, make(map[string]*Collation), "binary", 1},
 	CharsetGBK:     {CharsetGBK, CollationGBKBin, make(map[string]*Collation), "Chinese Internal Code Specification", 2},
+	CharsetUTF16:   {CharsetUTF16, CollationUTF16GeneralCI, make(map[string]*Collation), "UTF-16 Unicode", 4},
+	CharsetUTF16LE: {CharsetUTF16LE, CollationUTF16LEGeneralCI, make(map[string]*Collation), "UTF-16LE Unicode", 4},
+	CharsetUTF32:   {CharsetUTF32, CollationUTF32GeneralCI, make(map[string]*Collation), "UTF-32 Unicode", 4},
+	CharsetUCS2:    {CharsetUCS2, CollationUCS2GeneralCI, make(map[string]*Collation), "UCS-2 Unicode", 2},
 }
 
 // All the names supported collations should be in the following table.
 var supportedCollationNames = map[string]struct{}{
-	CollationUTF8:    {},
-	CollationUTF8MB4: {},
-	CollationASCII:   {},
-	CollationLatin1:  {},
-	CollationBin:     {},
-	CollationGBKBin:  {},
+	CollationUTF8:       {},
+	CollationUTF8MB4:    {},
+	CollationASCII:      {},
+	CollationLatin1:     {},
+	CollationBin:        {},
+	CollationGBKBin:     {},
+	CollationUTF16Bin:   {},
+	CollationUTF16LEBin: {},
+	CollationUTF32Bin:   {},
+	CollationUCS2Bin:    {},
 }
 
 // TiFlashSupportedCharsets is a map which contains TiFlash supports charsets.
@@ -214,6 +222,26 @@ const (
 	CollationGBKBin = "gbk_bin"
 	// CollationGBKChineseCI is the default collation for CharsetGBK when new collation is enabled.
 	CollationGBKChineseCI = "gbk_chinese_ci"
+	// CollationUTF16Bin is the default collation for CharsetUTF16 when new collation is disabled.
+	CollationUTF16Bin = "utf16_bin"
+	// CollationUTF16GeneralCI is the default collation for CharsetUTF16 when new collation is enabled.
+	CollationUTF16GeneralCI = "utf16_general_ci"
+	// CollationUTF16LEBin is the default collation for CharsetUTF16LE when new collation is disabled.
+	CollationUTF16LEBin = "utf16le_bin"
+	// CollationUTF16LEGeneralCI is the default collation for CharsetUTF16LE when new collation is enabled.
+	CollationUTF16LEGeneralCI = "utf16le_general_ci"
+	// CollationUTF32Bin is the default collation for CharsetUTF32 when new collation is disabled.
+	CollationUTF32Bin = "utf32_bin"
+	// CollationUTF32GeneralCI is the default collation for CharsetUTF32 when new collation is enabled.
+	CollationUTF32GeneralCI = "utf32_general_ci"
+	// CollationUCS2Bin is the default collation for CharsetUCS2 when new collation is disabled.
+	CollationUCS2Bin = "ucs2_bin"
+	// CollationUCS2GeneralCI is the default collation for CharsetUCS2 when new collation is enabled.
+	CollationUCS2GeneralCI = "ucs2_general_ci"
+	// CollationUTF8MB40900AICI is the default collation for CharsetUTF8MB4 in MySQL 8.0, it's based on UCA 9.0.0.
+	CollationUTF8MB40900AICI = "utf8mb4_0900_ai_ci"
+	// CollationUTF8MB40900Bin is the NO PAD binary collation for CharsetUTF8MB4.
+	CollationUTF8MB40900Bin = "utf8mb4_0900_bin"
 )
 
 const (
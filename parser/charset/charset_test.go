@@ -36,7 +36,8 @@ func TestValidCharset(t *testing.T) {
 		{"utf8mb4", "utf8mb4_bin", true},
 		{"latin1", "latin1_bin", true},
 		{"utf8", "utf8_invalid_ci", false},
-		{"utf16", "utf16_bin", false},
+		{"utf16", "utf16_bin", true},
+		{"koi8r", "koi8r_bin", false},
 		{"gb2312", "gb2312_chinese_ci", false},
 		{"UTF8", "UTF8_BIN", true},
 		{"UTF8", "utf8_bin", true},
@@ -13,7 +13,10 @@
 
 package charset
 
-import "bytes"
+import (
+	"bytes"
+	"sync/atomic"
+)
 
 // Make sure all of them implement Encoding interface.
 var (
@@ -23,6 +26,10 @@ var (
 	_ Encoding = &encodingLatin1{}
 	_ Encoding = &encodingBin{}
 	_ Encoding = &encodingGBK{}
+	_ Encoding = &encodingCP1252{}
+	_ Encoding = &encodingUTF16{}
+	_ Encoding = &encodingUTF32{}
+	_ Encoding = &encodingUCS2{}
 )
 
 // IsSupportedEncoding checks if the charset is fully supported.
@@ -47,12 +54,35 @@ func FindEncoding(charset string) Encoding {
 	if len(charset) == 0 {
 		return EncodingBinImpl
 	}
+	if charset == CharsetLatin1 && Latin1CP1252Enabled() {
+		return EncodingCP1252Impl
+	}
 	if e, exist := encodingMap[charset]; exist {
 		return e
 	}
 	return EncodingBinImpl
 }
 
+// latin1CP1252Enabled indicates whether the latin1 charset uses the real cp1252 encoding.
+var latin1CP1252Enabled int32
+
+// SetLatin1CP1252Enabled sets whether the latin1 charset uses the real cp1252 encoding like MySQL.
+// TiDB treats latin1 as utf-8 by default because of the backward compatibility.
+// The flag is decided when the cluster bootstraps for the first time, it must not be changed later,
+// otherwise the existing latin1 data is decoded in the wrong way.
+func SetLatin1CP1252Enabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&latin1CP1252Enabled, 1)
+	} else {
+		atomic.StoreInt32(&latin1CP1252Enabled, 0)
+	}
+}
+
+// Latin1CP1252Enabled returns whether the latin1 charset uses the real cp1252 encoding.
+func Latin1CP1252Enabled() bool {
+	return atomic.LoadInt32(&latin1CP1252Enabled) == 1
+}
+
 var encodingMap = map[string]Encoding{
 	CharsetUTF8MB4: EncodingUTF8Impl,
 	CharsetUTF8:    EncodingUTF8Impl,
@@ -60,6 +90,10 @@ var encodingMap = map[string]Encoding{
 	CharsetLatin1:  EncodingLatin1Impl,
 	CharsetBin:     EncodingBinImpl,
 	CharsetASCII:   EncodingASCIIImpl,
+	CharsetUTF16:   EncodingUTF16Impl,
+	CharsetUTF16LE: EncodingUTF16LEImpl,
+	CharsetUTF32:   EncodingUTF32Impl,
+	CharsetUCS2:    EncodingUCS2Impl,
 }
 
 // Encoding provide encode/decode functions for a string with a specific charset.
@@ -98,6 +132,10 @@ const (
 	EncodingTpLatin1
 	EncodingTpBin
 	EncodingTpGBK
+	EncodingTpUTF16
+	EncodingTpUTF16LE
+	EncodingTpUTF32
+	EncodingTpUCS2
 )
 
 //revive:enable
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/pingcap/tidb/parser/mysql"
@@ -106,6 +107,41 @@ func (b encodingBase) Foreach(src []byte, op Op, fn func(from, to []byte, ok boo
 	}
 }
 
+// runeCodec converts a character between utf-8 and the encoding rune by rune.
+type runeCodec interface {
+	// Peek returns the next char.
+	Peek(src []byte) []byte
+	// encodeRune writes the encoded r into dst and returns the number of bytes written,
+	// false is returned if r can't be represented in the encoding.
+	encodeRune(dst []byte, r rune) (int, bool)
+	// decodeRune decodes the char returned by Peek, false is returned if it's invalid.
+	decodeRune(src []byte) (rune, bool)
+}
+
+// foreachRune implements Encoding.Foreach for the encodings which are converted rune by rune.
+func foreachRune(c runeCodec, src []byte, op Op, fn func(from, to []byte, ok bool) bool) {
+	var buf [4]byte
+	for i, w := 0, 0; i < len(src); i += w {
+		var r rune
+		var ok bool
+		n := 0
+		if op&opFromUTF8 != 0 {
+			r, w = utf8.DecodeRune(src[i:])
+			if ok = r != utf8.RuneError || w > 1; ok {
+				n, ok = c.encodeRune(buf[:], r)
+			}
+		} else {
+			w = len(c.Peek(src[i:]))
+			if r, ok = c.decodeRune(src[i : i+w]); ok {
+				n = utf8.EncodeRune(buf[:], r)
+			}
+		}
+		if !fn(src[i:i+w], buf[:n], ok) {
+			return
+		}
+	}
+}
+
 // replacementBytes are bytes for the replacement rune 0xfffd.
 var replacementBytes = []byte{0xEF, 0xBF, 0xBD}
 
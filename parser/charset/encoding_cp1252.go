@@ -0,0 +1,83 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charset
+
+import (
+	"golang.org/x/text/encoding/charmap"
+)
+
+// EncodingCP1252Impl is the instance of encodingCP1252.
+// It's used for latin1 charset when the cluster is bootstrapped with the real cp1252 encoding enabled.
+var EncodingCP1252Impl = &encodingCP1252{}
+
+func init() {
+	EncodingCP1252Impl.self = EncodingCP1252Impl
+}
+
+// encodingCP1252 is the real latin1 encoding of MySQL, which is Windows-1252 with the
+// 5 undefined bytes mapped to the C1 control characters.
+type encodingCP1252 struct {
+	encodingBase
+}
+
+// Name implements Encoding interface.
+func (*encodingCP1252) Name() string {
+	return CharsetLatin1
+}
+
+// Tp implements Encoding interface.
+func (*encodingCP1252) Tp() EncodingTp {
+	return EncodingTpLatin1
+}
+
+// Peek implements Encoding interface.
+func (*encodingCP1252) Peek(src []byte) []byte {
+	if len(src) == 0 {
+		return src
+	}
+	return src[:1]
+}
+
+// Foreach implements Encoding interface.
+func (e *encodingCP1252) Foreach(src []byte, op Op, fn func(from, to []byte, ok bool) bool) {
+	foreachRune(e, src, op, fn)
+}
+
+func (*encodingCP1252) encodeRune(dst []byte, r rune) (int, bool) {
+	if b, ok := charmap.Windows1252.EncodeRune(r); ok {
+		dst[0] = b
+		return 1, true
+	}
+	if isCP1252Undefined(r) {
+		dst[0] = byte(r)
+		return 1, true
+	}
+	return 0, false
+}
+
+func (*encodingCP1252) decodeRune(src []byte) (rune, bool) {
+	if len(src) == 0 {
+		return 0, false
+	}
+	if isCP1252Undefined(rune(src[0])) {
+		return rune(src[0]), true
+	}
+	return charmap.Windows1252.DecodeByte(src[0]), true
+}
+
+// isCP1252Undefined returns whether r is the code point of one of the bytes undefined in Windows-1252.
+func isCP1252Undefined(r rune) bool {
+	return r == 0x81 || r == 0x8D || r == 0x8F || r == 0x90 || r == 0x9D
+}
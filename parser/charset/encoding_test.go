@@ -149,3 +149,74 @@ func TestEncodingValidate(t *testing.T) {
 		require.Equal(t, tc.expected, string(replace), msg)
 	}
 }
+
+func TestUnicodeEncodings(t *testing.T) {
+	testCases := []struct {
+		chs     string
+		utf8Str string
+		encoded string
+	}{
+		{charset.CharsetUTF16, "a中😂", "\x00\x61\x4e\x2d\xd8\x3d\xde\x02"},
+		{charset.CharsetUTF16LE, "a中😂", "\x61\x00\x2d\x4e\x3d\xd8\x02\xde"},
+		{charset.CharsetUTF32, "a中😂", "\x00\x00\x00\x61\x00\x00\x4e\x2d\x00\x01\xf6\x02"},
+		{charset.CharsetUCS2, "a中", "\x00\x61\x4e\x2d"},
+	}
+	for _, tc := range testCases {
+		cmt := fmt.Sprintf("%v", tc)
+		enc := charset.FindEncoding(tc.chs)
+		require.Equal(t, tc.chs, enc.Name(), cmt)
+		require.True(t, enc.IsValid([]byte(tc.utf8Str)), cmt)
+		result, err := enc.Transform(nil, []byte(tc.utf8Str), charset.OpEncode)
+		require.NoError(t, err, cmt)
+		require.Equal(t, tc.encoded, string(result), cmt)
+		result, err = enc.Transform(nil, []byte(tc.encoded), charset.OpDecode)
+		require.NoError(t, err, cmt)
+		require.Equal(t, tc.utf8Str, string(result), cmt)
+	}
+
+	invalidCases := []struct {
+		chs     string
+		op      charset.Op
+		src     string
+		result  string
+		errData string
+	}{
+		// The supplementary characters are not supported by ucs2.
+		{charset.CharsetUCS2, charset.OpEncodeReplace, "a😂", "\x00\x61?", "F09F9882"},
+		// The unpaired surrogates and the truncated characters are invalid.
+		{charset.CharsetUTF16, charset.OpDecodeReplace, "\xd8\x3d\x00\x61", "?", "D83D0061"},
+		{charset.CharsetUTF16, charset.OpDecodeReplace, "\x00\x61\x00", "a?", "00"},
+		{charset.CharsetUCS2, charset.OpDecodeReplace, "\xd8\x3d", "?", "D83D"},
+		{charset.CharsetUTF32, charset.OpDecodeReplace, "\x00\x11\x00\x00\x00\x00\x00\x61", "?a", "00110000"},
+	}
+	for _, tc := range invalidCases {
+		cmt := fmt.Sprintf("%v", tc)
+		enc := charset.FindEncoding(tc.chs)
+		result, err := enc.Transform(nil, []byte(tc.src), tc.op)
+		require.Error(t, err, cmt)
+		require.Contains(t, err.Error(), tc.errData, cmt)
+		require.Equal(t, tc.result, string(result), cmt)
+	}
+}
+
+func TestLatin1CP1252(t *testing.T) {
+	// latin1 is treated as utf-8 by default.
+	require.False(t, charset.Latin1CP1252Enabled())
+	require.Equal(t, charset.EncodingLatin1Impl, charset.FindEncoding(charset.CharsetLatin1))
+
+	charset.SetLatin1CP1252Enabled(true)
+	defer charset.SetLatin1CP1252Enabled(false)
+	enc := charset.FindEncoding(charset.CharsetLatin1)
+	require.Equal(t, charset.CharsetLatin1, enc.Name())
+	require.Equal(t, charset.EncodingCP1252Impl, enc)
+
+	result, err := enc.Transform(nil, []byte("café €"), charset.OpEncode)
+	require.NoError(t, err)
+	require.Equal(t, "caf\xe9 \x80", string(result))
+	result, err = enc.Transform(nil, []byte("caf\xe9 \x80\x81"), charset.OpDecode)
+	require.NoError(t, err)
+	require.Equal(t, "café €\u0081", string(result))
+	result, err = enc.Transform(nil, []byte("a中"), charset.OpEncodeReplace)
+	require.Error(t, err)
+	require.Equal(t, "a?", string(result))
+}
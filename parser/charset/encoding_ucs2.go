@@ -0,0 +1,71 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charset
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// EncodingUCS2Impl is the instance of encodingUCS2.
+var EncodingUCS2Impl = &encodingUCS2{}
+
+func init() {
+	EncodingUCS2Impl.self = EncodingUCS2Impl
+}
+
+// encodingUCS2 is the UCS-2 encoding, which is the big-endian UTF-16 without the supplementary characters.
+type encodingUCS2 struct {
+	encodingBase
+}
+
+// Name implements Encoding interface.
+func (*encodingUCS2) Name() string {
+	return CharsetUCS2
+}
+
+// Tp implements Encoding interface.
+func (*encodingUCS2) Tp() EncodingTp {
+	return EncodingTpUCS2
+}
+
+// Peek implements Encoding interface.
+func (*encodingUCS2) Peek(src []byte) []byte {
+	if len(src) < 2 {
+		return src
+	}
+	return src[:2]
+}
+
+// Foreach implements Encoding interface.
+func (e *encodingUCS2) Foreach(src []byte, op Op, fn func(from, to []byte, ok bool) bool) {
+	foreachRune(e, src, op, fn)
+}
+
+func (*encodingUCS2) encodeRune(dst []byte, r rune) (int, bool) {
+	if r >= 0x10000 {
+		return 0, false
+	}
+	binary.BigEndian.PutUint16(dst, uint16(r))
+	return 2, true
+}
+
+func (*encodingUCS2) decodeRune(src []byte) (rune, bool) {
+	if len(src) < 2 {
+		return 0, false
+	}
+	r := rune(binary.BigEndian.Uint16(src))
+	return r, !utf16.IsSurrogate(r)
+}
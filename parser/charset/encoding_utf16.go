@@ -0,0 +1,110 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charset
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// EncodingUTF16Impl is the instance of encodingUTF16 for the big-endian utf16 charset.
+var EncodingUTF16Impl = &encodingUTF16{}
+
+// EncodingUTF16LEImpl is the instance of encodingUTF16 for the little-endian utf16le charset.
+var EncodingUTF16LEImpl = &encodingUTF16{littleEndian: true}
+
+func init() {
+	EncodingUTF16Impl.self = EncodingUTF16Impl
+	EncodingUTF16LEImpl.self = EncodingUTF16LEImpl
+}
+
+// encodingUTF16 is the UTF-16 encoding, the supplementary characters are encoded as surrogate pairs.
+type encodingUTF16 struct {
+	encodingBase
+	littleEndian bool
+}
+
+func (e *encodingUTF16) byteOrder() binary.ByteOrder {
+	if e.littleEndian {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+// Name implements Encoding interface.
+func (e *encodingUTF16) Name() string {
+	if e.littleEndian {
+		return CharsetUTF16LE
+	}
+	return CharsetUTF16
+}
+
+// Tp implements Encoding interface.
+func (e *encodingUTF16) Tp() EncodingTp {
+	if e.littleEndian {
+		return EncodingTpUTF16LE
+	}
+	return EncodingTpUTF16
+}
+
+// Peek implements Encoding interface.
+func (e *encodingUTF16) Peek(src []byte) []byte {
+	charLen := 2
+	if len(src) >= 2 && isHighSurrogate(rune(e.byteOrder().Uint16(src))) {
+		charLen = 4
+	}
+	if charLen < len(src) {
+		return src[:charLen]
+	}
+	return src
+}
+
+// Foreach implements Encoding interface.
+func (e *encodingUTF16) Foreach(src []byte, op Op, fn func(from, to []byte, ok bool) bool) {
+	foreachRune(e, src, op, fn)
+}
+
+func (e *encodingUTF16) encodeRune(dst []byte, r rune) (int, bool) {
+	order := e.byteOrder()
+	if r < 0x10000 {
+		order.PutUint16(dst, uint16(r))
+		return 2, true
+	}
+	r1, r2 := utf16.EncodeRune(r)
+	order.PutUint16(dst, uint16(r1))
+	order.PutUint16(dst[2:], uint16(r2))
+	return 4, true
+}
+
+func (e *encodingUTF16) decodeRune(src []byte) (rune, bool) {
+	if len(src) < 2 {
+		return 0, false
+	}
+	order := e.byteOrder()
+	r := rune(order.Uint16(src))
+	if !utf16.IsSurrogate(r) {
+		return r, true
+	}
+	if !isHighSurrogate(r) || len(src) < 4 {
+		return 0, false
+	}
+	r = utf16.DecodeRune(r, rune(order.Uint16(src[2:])))
+	return r, r != utf8.RuneError
+}
+
+func isHighSurrogate(r rune) bool {
+	return r >= 0xD800 && r < 0xDC00
+}
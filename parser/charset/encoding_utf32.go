@@ -0,0 +1,68 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charset
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// EncodingUTF32Impl is the instance of encodingUTF32.
+var EncodingUTF32Impl = &encodingUTF32{}
+
+func init() {
+	EncodingUTF32Impl.self = EncodingUTF32Impl
+}
+
+// encodingUTF32 is the big-endian UTF-32 encoding, every character is encoded in 4 bytes.
+type encodingUTF32 struct {
+	encodingBase
+}
+
+// Name implements Encoding interface.
+func (*encodingUTF32) Name() string {
+	return CharsetUTF32
+}
+
+// Tp implements Encoding interface.
+func (*encodingUTF32) Tp() EncodingTp {
+	return EncodingTpUTF32
+}
+
+// Peek implements Encoding interface.
+func (*encodingUTF32) Peek(src []byte) []byte {
+	if len(src) < 4 {
+		return src
+	}
+	return src[:4]
+}
+
+// Foreach implements Encoding interface.
+func (e *encodingUTF32) Foreach(src []byte, op Op, fn func(from, to []byte, ok bool) bool) {
+	foreachRune(e, src, op, fn)
+}
+
+func (*encodingUTF32) encodeRune(dst []byte, r rune) (int, bool) {
+	binary.BigEndian.PutUint32(dst, uint32(r))
+	return 4, true
+}
+
+func (*encodingUTF32) decodeRune(src []byte) (rune, bool) {
+	if len(src) < 4 {
+		return 0, false
+	}
+	r := binary.BigEndian.Uint32(src)
+	return rune(r), utf8.ValidRune(rune(r))
+}
@@ -232,6 +232,15 @@ func TestParseHint(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "RESOURCE_GROUP(rg1)",
+			output: []*ast.TableOptimizerHint{
+				{
+					HintName: model.NewCIStr("RESOURCE_GROUP"),
+					HintData: "rg1",
+				},
+			},
+		},
 		{
 			input: "USE_TOJA(TRUE) IGNORE_PLAN_CACHE() USE_CASCADES(TRUE) QUERY_TYPE(@qb1 OLAP) QUERY_TYPE(OLTP) NO_INDEX_MERGE()",
 			output: []*ast.TableOptimizerHint{
@@ -236,6 +236,14 @@ func (s *Scanner) Lex(v *yySymType) int {
 		v.offset = pos.Offset
 		return asof
 	}
+	if tok == member && s.getNextToken() == of {
+		_, pos, lit = s.scan()
+		v.ident = fmt.Sprintf("%s %s", v.ident, lit)
+		s.lastKeyword = memberof
+		s.lastScanOffset = pos.Offset
+		v.offset = pos.Offset
+		return memberof
+	}
 
 	switch tok {
 	case intLit:
@@ -151,6 +151,7 @@ var tokenMap = map[string]int{
 	"ANALYZE":                  analyze,
 	"AND":                      and,
 	"ANY":                      any,
+	"ARRAY":                    array,
 	"APPROX_COUNT_DISTINCT":    approxCountDistinct,
 	"APPROX_PERCENTILE":        approxPercentile,
 	"AS":                       as,
@@ -159,6 +160,12 @@ var tokenMap = map[string]int{
 	"ATTRIBUTE":                attribute,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
+	"COOLDOWN":                 cooldown,
+	"DRYRUN":                   dryRun,
+	"DURATION":                 duration,
+	"EXEC_ELAPSED":             execElapsed,
+	"QUERY_LIMIT":              queryLimit,
+	"SIMILAR":                  similar,
 	"STATS_OPTIONS":            statsOptions,
 	"STATS_SAMPLE_RATE":        statsSampleRate,
 	"STATS_COL_CHOICE":         statsColChoice,
@@ -193,6 +200,7 @@ var tokenMap = map[string]int{
 	"BOUND":                    bound,
 	"BRIEF":                    briefType,
 	"BTREE":                    btree,
+	"BURSTABLE":                burstable,
 	"BUCKETS":                  buckets,
 	"BUILTINS":                 builtins,
 	"BY":                       by,
@@ -310,6 +318,7 @@ var tokenMap = map[string]int{
 	"ELSE":                     elseKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
+	"EMPTY":                    emptyKwd,
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
@@ -368,11 +377,13 @@ var tokenMap = map[string]int{
 	"GROUP":                    group,
 	"HASH":                     hash,
 	"HAVING":                   having,
+	"HEADER":                   header,
 	"HELP":                     help,
 	"HIGH_PRIORITY":            highPriority,
 	"HISTORY":                  history,
 	"HISTOGRAM":                histogram,
 	"HOSTS":                    hosts,
+	"HYPO":                     hypo,
 	"HOUR_MICROSECOND":         hourMicrosecond,
 	"HOUR_MINUTE":              hourMinute,
 	"HOUR_SECOND":              hourSecond,
@@ -417,6 +428,7 @@ var tokenMap = map[string]int{
 	"JOIN":                     join,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
+	"JSON_TABLE":               jsonTable,
 	"JSON":                     jsonType,
 	"KEY_BLOCK_SIZE":           keyBlockSize,
 	"KEY":                      key,
@@ -427,6 +439,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -456,6 +469,7 @@ var tokenMap = map[string]int{
 	"MASTER":                   master,
 	"MATCH":                    match,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
+	"MAX_FILE_SIZE":            maxFileSize,
 	"MAX_IDXNUM":               max_idxnum,
 	"MAX_MINUTES":              max_minutes,
 	"MAX_QUERIES_PER_HOUR":     maxQueriesPerHour,
@@ -468,6 +482,7 @@ var tokenMap = map[string]int{
 	"MEDIUMBLOB":               mediumblobType,
 	"MEDIUMINT":                mediumIntType,
 	"MEDIUMTEXT":               mediumtextType,
+	"MEMBER":                   member,
 	"MEMORY":                   memory,
 	"MERGE":                    merge,
 	"MICROSECOND":              microsecond,
@@ -487,6 +502,7 @@ var tokenMap = map[string]int{
 	"NCHAR":                    ncharType,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
+	"NESTED":                   nested,
 	"NEXT":                     next,
 	"NEXTVAL":                  nextval,
 	"NO_WRITE_TO_BINLOG":       noWriteToBinLog,
@@ -523,12 +539,14 @@ var tokenMap = map[string]int{
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
+	"ORDINALITY":               ordinality,
 	"OUTER":                    outer,
 	"OUTFILE":                  outfile,
 	"PACK_KEYS":                packKeys,
 	"PAGE":                     pageSym,
 	"PARSER":                   parser,
 	"PARTIAL":                  partial,
+	"PATH":                     path,
 	"PARTITION":                partition,
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
@@ -593,6 +611,7 @@ var tokenMap = map[string]int{
 	"REQUIRE":                  require,
 	"REQUIRED":                 required,
 	"RESET":                    reset,
+	"RESOURCE":                 resource,
 	"RESPECT":                  respect,
 	"RESTART":                  restart,
 	"RESTORE":                  restore,
@@ -610,6 +629,7 @@ var tokenMap = map[string]int{
 	"ROW":                      row,
 	"ROWS":                     rows,
 	"RTREE":                    rtree,
+	"RU_PER_SEC":               ruRate,
 	"RESUME":                   resume,
 	"RUN":                      run,
 	"RUNNING":                  running,
@@ -679,6 +699,7 @@ var tokenMap = map[string]int{
 	"STATS_EXTENDED":           statsExtended,
 	"STATS_HEALTHY":            statsHealthy,
 	"STATS_HISTOGRAMS":         statsHistograms,
+	"STATS_LOCKED":             statsLocked,
 	"STATS_TOPN":               statsTopN,
 	"STATS_META":               statsMeta,
 	"HISTOGRAMS_IN_FLIGHT":     histogramsInFlight,
@@ -757,6 +778,8 @@ var tokenMap = map[string]int{
 	"TRUE":                     trueKwd,
 	"TRUNCATE":                 truncate,
 	"TRUE_CARD_COST":           trueCardCost,
+	"TTL":                      ttl,
+	"TTL_ENABLE":               ttlEnable,
 	"TYPE":                     tp,
 	"UNBOUNDED":                unbounded,
 	"UNCOMMITTED":              uncommitted,
@@ -794,6 +817,7 @@ var tokenMap = map[string]int{
 	"VIRTUAL":                  virtual,
 	"VISIBLE":                  visible,
 	"WARNINGS":                 warnings,
+	"WATCH":                    watch,
 	"WEEK":                     week,
 	"WEIGHT_STRING":            weightString,
 	"WHEN":                     when,
@@ -98,6 +98,12 @@ const (
 	ActionMultiSchemaChange             ActionType = 61
 	ActionFlashbackCluster              ActionType = 62
 	ActionRecoverSchema                 ActionType = 63
+	ActionAlterTTLInfo                  ActionType = 64
+	ActionAlterTTLRemove                ActionType = 65
+	ActionReorganizePartition           ActionType = 66
+	ActionCreateResourceGroup           ActionType = 67
+	ActionAlterResourceGroup            ActionType = 68
+	ActionDropResourceGroup             ActionType = 69
 )
 
 var actionMap = map[ActionType]string{
@@ -160,6 +166,12 @@ var actionMap = map[ActionType]string{
 	ActionMultiSchemaChange:             "alter table multi-schema change",
 	ActionFlashbackCluster:              "flashback cluster",
 	ActionRecoverSchema:                 "flashback schema",
+	ActionAlterTTLInfo:                  "alter table ttl",
+	ActionAlterTTLRemove:                "alter table no_ttl",
+	ActionReorganizePartition:           "alter table reorganize partition",
+	ActionCreateResourceGroup:           "create resource group",
+	ActionAlterResourceGroup:            "alter resource group",
+	ActionDropResourceGroup:             "drop resource group",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -764,7 +776,7 @@ func (job *Job) NotStarted() bool {
 // MayNeedReorg indicates that this job may need to reorganize the data.
 func (job *Job) MayNeedReorg() bool {
 	switch job.Type {
-	case ActionAddIndex, ActionAddPrimaryKey:
+	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition:
 		return true
 	case ActionModifyColumn:
 		if len(job.CtxVars) > 0 {
@@ -801,8 +813,11 @@ func (job *Job) IsRollbackable() bool {
 		}
 	case ActionAddTablePartition:
 		return job.SchemaState == StateNone || job.SchemaState == StateReplicaOnly
+	case ActionReorganizePartition:
+		// The new partitions are public since StateDeleteReorganization, it's too late to rollback.
+		return job.SchemaState != StateDeleteReorganization
 	case ActionDropColumn, ActionDropSchema, ActionDropTable, ActionDropSequence,
-		ActionDropForeignKey, ActionDropTablePartition:
+		ActionDropForeignKey, ActionDropTablePartition, ActionDropCheckConstraint:
 		return job.SchemaState == StatePublic
 	case ActionRebaseAutoID, ActionShardRowID,
 		ActionTruncateTable, ActionAddForeignKey, ActionRenameTable,
@@ -542,6 +542,9 @@ type TableInfo struct {
 	StatsOptions *StatsOptions `json:"stats_options"`
 
 	ExchangePartitionInfo *ExchangePartitionInfo `json:"exchange_partition_info"`
+
+	// TTLInfo is used to expire the rows of the table automatically, nil means the table has no TTL.
+	TTLInfo *TTLInfo `json:"ttl_info"`
 }
 
 // TableCacheStatusType is the type of the table cache status
@@ -742,6 +745,17 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.ForeignKeys[i] = t.ForeignKeys[i].Clone()
 	}
 
+	if t.Constraints != nil {
+		nt.Constraints = make([]*ConstraintInfo, len(t.Constraints))
+		for i := range t.Constraints {
+			nt.Constraints[i] = t.Constraints[i].Clone()
+		}
+	}
+
+	if t.TTLInfo != nil {
+		nt.TTLInfo = t.TTLInfo.Clone()
+	}
+
 	return &nt
 }
 
@@ -1149,6 +1163,22 @@ func (p PartitionType) String() string {
 	}
 }
 
+// TTLInfo records the TTL config of a table. A row expires when
+// `ColumnName + INTERVAL IntervalExprStr IntervalTimeUnit` is earlier than now.
+type TTLInfo struct {
+	ColumnName      CIStr  `json:"column"`
+	IntervalExprStr string `json:"interval_expr"`
+	// `IntervalTimeUnit` is actually ast.TimeUnitType. Use `int` to avoid cycle dependency
+	IntervalTimeUnit int  `json:"interval_time_unit"`
+	Enable           bool `json:"enable"`
+}
+
+// Clone clones TTLInfo
+func (t *TTLInfo) Clone() *TTLInfo {
+	cloned := *t
+	return &cloned
+}
+
 // ExchangePartitionInfo provides exchange partition info.
 type ExchangePartitionInfo struct {
 	ExchangePartitionFlag  bool  `json:"exchange_partition_flag"`
@@ -1174,6 +1204,36 @@ type PartitionInfo struct {
 	DroppingDefinitions []PartitionDefinition `json:"dropping_definitions"`
 	States              []PartitionState      `json:"states"`
 	Num                 uint64                `json:"num"`
+	// DDLState is the state of the ongoing partition DDL, it's only used by REORGANIZE PARTITION now.
+	// Before StateDeleteReorganization, the reorganized partitions are in DroppingDefinitions and the
+	// new partitions are in AddingDefinitions. Since StateDeleteReorganization, the new partitions are
+	// public and the reorganized partitions are left in DroppingDefinitions until the job is done.
+	DDLState SchemaState `json:"ddl_state"`
+	// DDLAction is the action of the ongoing partition DDL.
+	DDLAction ActionType `json:"ddl_action"`
+	// Sub is not nil if the partitions are subpartitioned. In that case, Definitions holds the subpartitions,
+	// see SubPartitionInfo for the layout.
+	Sub *SubPartitionInfo `json:"sub,omitempty"`
+}
+
+// SubPartitionInfo provides the subpartition info of a RANGE or LIST partitioned table.
+// Every subpartition is a physical table, so the subpartitions rather than the partitions are kept
+// in PartitionInfo.Definitions. The subpartitions of the same partition are adjacent, ordered by the
+// subpartition index and carry the values and the name of the partition in LessThan/InValues and ParentName.
+type SubPartitionInfo struct {
+	Type    PartitionType `json:"type"`
+	Expr    string        `json:"expr"`
+	Columns []CIStr       `json:"columns"`
+	// Num is the number of subpartitions of each partition.
+	Num uint64 `json:"num"`
+}
+
+// Clone clones SubPartitionInfo.
+func (si *SubPartitionInfo) Clone() *SubPartitionInfo {
+	newSi := *si
+	newSi.Columns = make([]CIStr, len(si.Columns))
+	copy(newSi.Columns, si.Columns)
+	return &newSi
 }
 
 // Clone clones itself.
@@ -1197,9 +1257,103 @@ func (pi *PartitionInfo) Clone() *PartitionInfo {
 		newPi.DroppingDefinitions[i] = pi.DroppingDefinitions[i].Clone()
 	}
 
+	if pi.Sub != nil {
+		newPi.Sub = pi.Sub.Clone()
+	}
+
+	return &newPi
+}
+
+// IsSubPartitioned returns whether the partitions are subpartitioned.
+func (pi *PartitionInfo) IsSubPartitioned() bool {
+	return pi.Sub != nil
+}
+
+// SubPartitionNum returns the number of the subpartitions of each partition, it's 1 if the table is not subpartitioned.
+func (pi *PartitionInfo) SubPartitionNum() int {
+	if pi.Sub == nil || pi.Sub.Num == 0 {
+		return 1
+	}
+	return int(pi.Sub.Num)
+}
+
+// ParentDefinitions returns the partition level definitions built from the subpartitions in `defs`.
+// Each partition takes the name and the values of its subpartitions and has no ID. It returns `defs`
+// directly if the table is not subpartitioned.
+func (pi *PartitionInfo) ParentDefinitions(defs []PartitionDefinition) []PartitionDefinition {
+	if pi.Sub == nil {
+		return defs
+	}
+	num := pi.SubPartitionNum()
+	parents := make([]PartitionDefinition, 0, len(defs)/num)
+	for i := 0; i < len(defs); i += num {
+		parents = append(parents, PartitionDefinition{
+			Name:     defs[i].ParentName,
+			LessThan: defs[i].LessThan,
+			InValues: defs[i].InValues,
+		})
+	}
+	return parents
+}
+
+// ParentPartitionInfo returns a PartitionInfo without subpartitions, whose Definitions are the partition level
+// definitions. It's used by the logic that only cares about the partitions, like locating the partition of a
+// RANGE or LIST partitioned table. It returns pi itself if the table is not subpartitioned.
+func (pi *PartitionInfo) ParentPartitionInfo() *PartitionInfo {
+	if pi.Sub == nil {
+		return pi
+	}
+	newPi := *pi
+	newPi.Sub = nil
+	newPi.Definitions = pi.ParentDefinitions(pi.Definitions)
+	newPi.AddingDefinitions = pi.ParentDefinitions(pi.AddingDefinitions)
+	newPi.DroppingDefinitions = pi.ParentDefinitions(pi.DroppingDefinitions)
+	newPi.Num = uint64(len(newPi.Definitions))
 	return &newPi
 }
 
+// SubPartitionRange returns the range [start, end) in `defs` of the subpartitions that belong to the partition
+// named `name`. It returns the range of the definition with the name if the table is not subpartitioned.
+// The returned range is empty if there is no such partition.
+func (pi *PartitionInfo) SubPartitionRange(defs []PartitionDefinition, name string) (start, end int) {
+	lowName := strings.ToLower(name)
+	for i := range defs {
+		if pi.Sub != nil && defs[i].ParentName.L == lowName {
+			return i, i + pi.SubPartitionNum()
+		}
+		if pi.Sub == nil && defs[i].Name.L == lowName {
+			return i, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// ReplacePartitionDefinitions returns a copy of `defs` with the definitions in `removed` replaced by `added`.
+// The added definitions are placed at the position of the first removed one, so the order of the range
+// partitions is kept when the removed definitions are consecutive.
+func ReplacePartitionDefinitions(defs, removed, added []PartitionDefinition) []PartitionDefinition {
+	removedIDs := make(map[int64]struct{}, len(removed))
+	for _, def := range removed {
+		removedIDs[def.ID] = struct{}{}
+	}
+	newDefs := make([]PartitionDefinition, 0, len(defs)-len(removed)+len(added))
+	inserted := false
+	for _, def := range defs {
+		if _, ok := removedIDs[def.ID]; !ok {
+			newDefs = append(newDefs, def)
+			continue
+		}
+		if !inserted {
+			newDefs = append(newDefs, added...)
+			inserted = true
+		}
+	}
+	if !inserted {
+		newDefs = append(newDefs, added...)
+	}
+	return newDefs
+}
+
 // GetNameByID gets the partition name by ID.
 func (pi *PartitionInfo) GetNameByID(id int64) string {
 	definitions := pi.Definitions
@@ -1273,6 +1427,9 @@ type PartitionDefinition struct {
 	InValues           [][]string     `json:"in_values"`
 	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
 	Comment            string         `json:"comment,omitempty"`
+	// ParentName is the name of the partition which the subpartition belongs to. It's empty if the table
+	// is not subpartitioned.
+	ParentName CIStr `json:"parent_name"`
 }
 
 // Clone clones ConstraintInfo.
@@ -1371,6 +1528,8 @@ func (t IndexType) String() string {
 		return "HASH"
 	case IndexTypeRtree:
 		return "RTREE"
+	case IndexTypeHypo:
+		return "HYPO"
 	default:
 		return ""
 	}
@@ -1382,6 +1541,8 @@ const (
 	IndexTypeBtree
 	IndexTypeHash
 	IndexTypeRtree
+	// IndexTypeHypo is the type of the hypothetical index, which is only seen by the optimizer and never built.
+	IndexTypeHypo
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1400,6 +1561,7 @@ type IndexInfo struct {
 	Primary       bool           `json:"is_primary"`   // Whether the index is primary key.
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
+	MVIndex       bool           `json:"mv_index"`     // Whether the index is multi-valued index.
 }
 
 // Clone clones IndexInfo.
@@ -1798,6 +1960,133 @@ func (p *PlacementSettings) Clone() *PlacementSettings {
 	return &cloned
 }
 
+// ResourceGroupSettings is the settings of the resource group.
+type ResourceGroupSettings struct {
+	// RURate is the number of request units (RU) the group is allowed to consume per second.
+	RURate uint64 `json:"ru_per_sec"`
+	// Burstable indicates the group can use the spare resources beyond its RU quota.
+	Burstable bool `json:"burstable"`
+	// Runaway is the rule of the runaway queries in the group, nil means the queries are not limited.
+	Runaway *ResourceGroupRunawaySettings `json:"runaway,omitempty"`
+}
+
+func (p *ResourceGroupSettings) String() string {
+	sb := new(strings.Builder)
+	if p.RURate > 0 {
+		writeSettingIntegerToBuilder(sb, "RU_PER_SEC", p.RURate)
+	}
+	if p.Burstable {
+		writeSettingItemToBuilder(sb, "BURSTABLE")
+	}
+	if p.Runaway != nil {
+		writeSettingItemToBuilder(sb, fmt.Sprintf("QUERY_LIMIT=(%s)", p.Runaway.String()))
+	}
+	return sb.String()
+}
+
+// Clone clones the resource group settings.
+func (p *ResourceGroupSettings) Clone() *ResourceGroupSettings {
+	cloned := *p
+	if p.Runaway != nil {
+		runaway := *p.Runaway
+		cloned.Runaway = &runaway
+	}
+	return &cloned
+}
+
+// RunawayActionType is the action taken on the runaway queries.
+type RunawayActionType int32
+
+// List of RunawayActionType
+const (
+	RunawayActionNone RunawayActionType = iota
+	// RunawayActionDryRun only records the runaway queries.
+	RunawayActionDryRun
+	// RunawayActionCooldown lowers the priority of the runaway queries.
+	RunawayActionCooldown
+	// RunawayActionKill kills the runaway queries.
+	RunawayActionKill
+)
+
+// String implements fmt.Stringer interface.
+func (t RunawayActionType) String() string {
+	switch t {
+	case RunawayActionDryRun:
+		return "DRYRUN"
+	case RunawayActionCooldown:
+		return "COOLDOWN"
+	case RunawayActionKill:
+		return "KILL"
+	}
+	return "NONE"
+}
+
+// RunawayWatchType is the way to match the later executions of the runaway queries.
+type RunawayWatchType int32
+
+// List of RunawayWatchType
+const (
+	RunawayWatchNone RunawayWatchType = iota
+	// RunawayWatchExact matches the queries by the SQL text.
+	RunawayWatchExact
+	// RunawayWatchSimilar matches the queries by the SQL digest.
+	RunawayWatchSimilar
+	// RunawayWatchPlan matches the queries by the plan digest.
+	RunawayWatchPlan
+)
+
+// String implements fmt.Stringer interface.
+func (t RunawayWatchType) String() string {
+	switch t {
+	case RunawayWatchExact:
+		return "EXACT"
+	case RunawayWatchSimilar:
+		return "SIMILAR"
+	case RunawayWatchPlan:
+		return "PLAN"
+	}
+	return "NONE"
+}
+
+// ResourceGroupRunawaySettings is the rule of the runaway queries in a resource group.
+// A query runs longer than ExecElapsed is identified as a runaway query, the action is taken on it, and
+// the later executions matched by WatchType are quarantined for WatchDuration.
+// The rule is only attached to resource groups: it applies to the queries of the users bound to the group and the
+// queries using the group by the RESOURCE_GROUP hint. A user or a SQL digest can't have a rule of its own, so a
+// dedicated resource group should be created for them instead.
+type ResourceGroupRunawaySettings struct {
+	ExecElapsed   time.Duration     `json:"exec_elapsed"`
+	Action        RunawayActionType `json:"action"`
+	WatchType     RunawayWatchType  `json:"watch_type"`
+	WatchDuration time.Duration     `json:"watch_duration"`
+}
+
+func (p *ResourceGroupRunawaySettings) String() string {
+	sb := new(strings.Builder)
+	writeSettingStringToBuilder(sb, "EXEC_ELAPSED", p.ExecElapsed.String())
+	writeSettingItemToBuilder(sb, "ACTION="+p.Action.String())
+	if p.WatchType != RunawayWatchNone {
+		writeSettingItemToBuilder(sb, "WATCH="+p.WatchType.String())
+		writeSettingStringToBuilder(sb, "DURATION", p.WatchDuration.String())
+	}
+	return sb.String()
+}
+
+// ResourceGroupInfo is the struct to store the resource group.
+type ResourceGroupInfo struct {
+	*ResourceGroupSettings
+	ID    int64       `json:"id"`
+	Name  CIStr       `json:"name"`
+	State SchemaState `json:"state"`
+}
+
+// Clone clones the ResourceGroupInfo.
+func (p *ResourceGroupInfo) Clone() *ResourceGroupInfo {
+	cloned := *p
+	cloned.ResourceGroupSettings = p.ResourceGroupSettings.Clone()
+	return &cloned
+}
+
 // StatsOptions is the struct to store the stats options.
 type StatsOptions struct {
 	*StatsWindowSettings
@@ -174,6 +174,14 @@ func TestModelBasic(t *testing.T) {
 	n := dbInfo.Clone()
 	require.Equal(t, dbInfo, n)
 
+	// the check constraints are cloned deeply.
+	table.Constraints = []*ConstraintInfo{{ID: 1, Name: NewCIStr("chk"), ConstraintCols: []CIStr{NewCIStr("c")}, ExprString: "c > 0"}}
+	nt := table.Clone()
+	require.Equal(t, table.Constraints, nt.Constraints)
+	nt.Constraints[0].ConstraintCols[0] = NewCIStr("d")
+	require.Equal(t, NewCIStr("c"), table.Constraints[0].ConstraintCols[0])
+	table.Constraints = nil
+
 	pkName := table.GetPkName()
 	require.Equal(t, NewCIStr("c"), pkName)
 	newColumn := table.GetPkColInfo()
@@ -562,6 +570,7 @@ func TestString(t *testing.T) {
 		{ActionAlterTablePlacement, "alter table placement"},
 		{ActionAlterTablePartitionPlacement, "alter table partition placement"},
 		{ActionAlterNoCacheTable, "alter table nocache"},
+		{ActionReorganizePartition, "alter table reorganize partition"},
 	}
 
 	for _, v := range acts {
@@ -785,3 +794,70 @@ func TestIsIndexPrefixCovered(t *testing.T) {
 	require.Equal(t, true, IsIndexPrefixCovered(tbl, i1, NewCIStr("c_4"), NewCIStr("c_2")))
 	require.Equal(t, false, IsIndexPrefixCovered(tbl, i0, NewCIStr("c_2")))
 }
+
+func TestReplacePartitionDefinitions(t *testing.T) {
+	defs := []PartitionDefinition{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	getIDs := func(defs []PartitionDefinition) []int64 {
+		ids := make([]int64, 0, len(defs))
+		for _, def := range defs {
+			ids = append(ids, def.ID)
+		}
+		return ids
+	}
+
+	newDefs := ReplacePartitionDefinitions(defs, defs[1:3], []PartitionDefinition{{ID: 5}, {ID: 6}, {ID: 7}})
+	require.Equal(t, []int64{1, 5, 6, 7, 4}, getIDs(newDefs))
+	require.Equal(t, []int64{1, 2, 3, 4}, getIDs(defs))
+
+	newDefs = ReplacePartitionDefinitions(defs, defs[3:], []PartitionDefinition{{ID: 5}})
+	require.Equal(t, []int64{1, 2, 3, 5}, getIDs(newDefs))
+
+	// Revert the replacement.
+	newDefs = ReplacePartitionDefinitions(newDefs, []PartitionDefinition{{ID: 5}}, defs[3:])
+	require.Equal(t, []int64{1, 2, 3, 4}, getIDs(newDefs))
+}
+
+func TestSubPartitionInfo(t *testing.T) {
+	pi := &PartitionInfo{
+		Type: PartitionTypeRange,
+		Expr: "`a`",
+		Num:  2,
+		Sub:  &SubPartitionInfo{Type: PartitionTypeKey, Columns: []CIStr{NewCIStr("b")}, Num: 2},
+		Definitions: []PartitionDefinition{
+			{ID: 1, Name: NewCIStr("p0sp0"), ParentName: NewCIStr("p0"), LessThan: []string{"10"}},
+			{ID: 2, Name: NewCIStr("p0sp1"), ParentName: NewCIStr("p0"), LessThan: []string{"10"}},
+			{ID: 3, Name: NewCIStr("p1sp0"), ParentName: NewCIStr("P1"), LessThan: []string{"MAXVALUE"}},
+			{ID: 4, Name: NewCIStr("p1sp1"), ParentName: NewCIStr("P1"), LessThan: []string{"MAXVALUE"}},
+		},
+	}
+	require.True(t, pi.IsSubPartitioned())
+	require.Equal(t, 2, pi.SubPartitionNum())
+
+	parent := pi.ParentPartitionInfo()
+	require.Nil(t, parent.Sub)
+	require.Equal(t, uint64(2), parent.Num)
+	require.Len(t, parent.Definitions, 2)
+	require.Equal(t, "p0", parent.Definitions[0].Name.L)
+	require.Equal(t, []string{"10"}, parent.Definitions[0].LessThan)
+	require.Equal(t, "P1", parent.Definitions[1].Name.O)
+	require.Equal(t, int64(0), parent.Definitions[1].ID)
+	require.Len(t, pi.Definitions, 4)
+
+	start, end := pi.SubPartitionRange(pi.Definitions, "p1")
+	require.Equal(t, 2, start)
+	require.Equal(t, 4, end)
+	start, end = pi.SubPartitionRange(pi.Definitions, "p2")
+	require.Equal(t, start, end)
+
+	cloned := pi.Clone()
+	cloned.Sub.Columns[0] = NewCIStr("c")
+	require.Equal(t, "b", pi.Sub.Columns[0].L)
+
+	pi.Sub = nil
+	require.False(t, pi.IsSubPartitioned())
+	require.Equal(t, 1, pi.SubPartitionNum())
+	require.Same(t, pi, pi.ParentPartitionInfo())
+	start, end = pi.SubPartitionRange(pi.Definitions, "p1sp0")
+	require.Equal(t, 2, start)
+	require.Equal(t, 3, end)
+}
@@ -933,6 +933,9 @@ var MySQLErrName = map[uint16]*ErrMessage{
 	ErrWindowNoGroupOrderUnused:                              Message("ASC or DESC with GROUP BY isn't allowed with window functions; put ASC or DESC in ORDER BY", nil),
 	ErrWindowExplainJson:                                     Message("To get information about window functions use EXPLAIN FORMAT=JSON", nil),
 	ErrWindowFunctionIgnoresFrame:                            Message("Window function '%s' ignores the frame clause of window '%s' and aggregates over the whole partition", nil),
+	ErrMissingJSONTableValue:                                 Message("Missing value for JSON_TABLE column '%-.192s'", nil),
+	ErrWrongJSONTableValue:                                   Message("Can't store an array or an object in the scalar JSON_TABLE column '%-.192s'", nil),
+	ErrTFForbiddenJoinType:                                   Message("INNER or LEFT JOIN must be used for LATERAL references made by '%-.192s'", nil),
 	ErrRoleNotGranted:                                        Message("%s is not granted to %s", nil),
 	ErrMaxExecTimeExceeded:                                   Message("Query execution was interrupted, max_execution_time exceeded.", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
@@ -950,11 +953,22 @@ var MySQLErrName = map[uint16]*ErrMessage{
 	ErrFunctionalIndexOnField:                                Message("Functional index on a column is not supported. Consider using a regular index instead", nil),
 	ErrFKIncompatibleColumns:                                 Message("Referencing column '%s' and referenced column '%s' in foreign key constraint '%s' are incompatible.", nil),
 	ErrFunctionalIndexRowValueIsNotAllowed:                   Message("Expression of functional index '%s' cannot refer to a row value", nil),
+	ErrNonBooleanExprForCheckConstraint:                      Message("An expression of non-boolean type specified to check constraint '%-.192s'.", nil),
+	ErrColumnCheckConstraintReferencesOtherColumn:            Message("Column check constraint '%-.192s' references other column.", nil),
+	ErrCheckConstraintNamedFunctionIsNotAllowed:              Message("An expression of a check constraint '%-.192s' contains disallowed function: %s.", nil),
+	ErrCheckConstraintFunctionIsNotAllowed:                   Message("An expression of a check constraint '%-.192s' contains disallowed function.", nil),
+	ErrCheckConstraintVariables:                              Message("An expression of a check constraint '%-.192s' cannot refer to a user or system variable.", nil),
+	ErrCheckConstraintRefersAutoIncrementColumn:              Message("Check constraint '%-.192s' cannot refer to an auto-increment column.", nil),
+	ErrCheckConstraintViolated:                               Message("Check constraint '%-.192s' is violated.", nil),
+	ErrCheckConstraintRefersUnknownColumn:                    Message("Check constraint '%-.192s' refers to non-existing column '%-.192s'.", nil),
+	ErrCheckConstraintNotFound:                               Message("Check constraint '%-.192s' is not found in the table.", nil),
+	ErrCheckConstraintDupName:                                Message("Duplicate check constraint name '%-.192s'.", nil),
 	ErrDependentByFunctionalIndex:                            Message("Column '%s' has a functional index dependency and cannot be dropped or renamed", nil),
 	ErrInvalidJsonValueForFuncIndex:                          Message("Invalid JSON value for CAST for functional index '%s'", nil),
 	ErrJsonValueOutOfRangeForFuncIndex:                       Message("Out of range JSON value for CAST for functional index '%s'", nil),
 	ErrFunctionalIndexDataIsTooLong:                          Message("Data too long for functional index '%s'", nil),
 	ErrFunctionalIndexNotApplicable:                          Message("Cannot use functional index '%s' due to type or collation conversion", nil),
+	ErrDependentByCheckConstraint:                            Message("Check constraint '%-.192s' uses column '%-.192s', hence column cannot be dropped or renamed.", nil),
 
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed:         Message("Only one DEFAULT partition allowed", nil),
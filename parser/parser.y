@@ -52,6 +52,7 @@ import (
 	/*yy:token "%c"     */
 	identifier "identifier"
 	asof       "AS OF"
+	memberof   "MEMBER OF"
 
 	/*yy:token "_%c"    */
 	underscoreCS "UNDERSCORE_CHARSET"
@@ -158,6 +159,7 @@ import (
 	into              "INTO"
 	outfile           "OUTFILE"
 	is                "IS"
+	jsonTable         "JSON_TABLE"
 	insert            "INSERT"
 	intType           "INT"
 	int1Type          "INT1"
@@ -171,6 +173,7 @@ import (
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	left              "LEFT"
@@ -297,9 +300,16 @@ import (
 	algorithm             "ALGORITHM"
 	always                "ALWAYS"
 	any                   "ANY"
+	array                 "ARRAY"
 	ascii                 "ASCII"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
+	cooldown              "COOLDOWN"
+	dryRun                "DRYRUN"
+	duration              "DURATION"
+	execElapsed           "EXEC_ELAPSED"
+	queryLimit            "QUERY_LIMIT"
+	similar               "SIMILAR"
 	statsOptions          "STATS_OPTIONS"
 	statsSampleRate       "STATS_SAMPLE_RATE"
 	statsColChoice        "STATS_COL_CHOICE"
@@ -324,6 +334,7 @@ import (
 	booleanType           "BOOLEAN"
 	boolType              "BOOL"
 	btree                 "BTREE"
+	burstable             "BURSTABLE"
 	byteType              "BYTE"
 	cache                 "CACHE"
 	capture               "CAPTURE"
@@ -382,6 +393,7 @@ import (
 	dynamic               "DYNAMIC"
 	enable                "ENABLE"
 	enabled               "ENABLED"
+	emptyKwd              "EMPTY"
 	encryption            "ENCRYPTION"
 	end                   "END"
 	enforced              "ENFORCED"
@@ -413,10 +425,12 @@ import (
 	global                "GLOBAL"
 	grants                "GRANTS"
 	hash                  "HASH"
+	header                "HEADER"
 	help                  "HELP"
 	histogram             "HISTOGRAM"
 	history               "HISTORY"
 	hosts                 "HOSTS"
+	hypo                  "HYPO"
 	hour                  "HOUR"
 	identified            "IDENTIFIED"
 	identSQLErrors        "ERRORS"
@@ -451,11 +465,13 @@ import (
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
+	maxFileSize           "MAX_FILE_SIZE"
 	maxQueriesPerHour     "MAX_QUERIES_PER_HOUR"
 	maxRows               "MAX_ROWS"
 	maxUpdatesPerHour     "MAX_UPDATES_PER_HOUR"
 	maxUserConnections    "MAX_USER_CONNECTIONS"
 	mb                    "MB"
+	member                "MEMBER"
 	memory                "MEMORY"
 	merge                 "MERGE"
 	microsecond           "MICROSECOND"
@@ -468,6 +484,7 @@ import (
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -489,6 +506,7 @@ import (
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -496,6 +514,7 @@ import (
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
+	path                  "PATH"
 	percent               "PERCENT"
 	per_db                "PER_DB"
 	per_table             "PER_TABLE"
@@ -530,6 +549,7 @@ import (
 	replicas              "REPLICAS"
 	replication           "REPLICATION"
 	required              "REQUIRED"
+	resource              "RESOURCE"
 	respect               "RESPECT"
 	restart               "RESTART"
 	restore               "RESTORE"
@@ -542,6 +562,7 @@ import (
 	rowCount              "ROW_COUNT"
 	rowFormat             "ROW_FORMAT"
 	rtree                 "RTREE"
+	ruRate                "RU_PER_SEC"
 	san                   "SAN"
 	savepoint             "SAVEPOINT"
 	second                "SECOND"
@@ -611,6 +632,8 @@ import (
 	transaction           "TRANSACTION"
 	triggers              "TRIGGERS"
 	truncate              "TRUNCATE"
+	ttl                   "TTL"
+	ttlEnable             "TTL_ENABLE"
 	unbounded             "UNBOUNDED"
 	uncommitted           "UNCOMMITTED"
 	undefined             "UNDEFINED"
@@ -623,6 +646,7 @@ import (
 	view                  "VIEW"
 	visible               "VISIBLE"
 	warnings              "WARNINGS"
+	watch                 "WATCH"
 	week                  "WEEK"
 	weightString          "WEIGHT_STRING"
 	without               "WITHOUT"
@@ -749,6 +773,7 @@ import (
 	statsHistograms            "STATS_HISTOGRAMS"
 	statsBuckets               "STATS_BUCKETS"
 	statsHealthy               "STATS_HEALTHY"
+	statsLocked                "STATS_LOCKED"
 	statsTopN                  "STATS_TOPN"
 	histogramsInFlight         "HISTOGRAMS_IN_FLIGHT"
 	telemetry                  "TELEMETRY"
@@ -861,6 +886,7 @@ import (
 	AlterImportStmt            "ALTER IMPORT statement"
 	AlterInstanceStmt          "Alter instance statement"
 	AlterPolicyStmt            "Alter Placement Policy statement"
+	AlterResourceGroupStmt     "Alter Resource Group statement"
 	AlterSequenceStmt          "Alter sequence statement"
 	AnalyzeTableStmt           "Analyze table statement"
 	BeginTransactionStmt       "BEGIN TRANSACTION statement"
@@ -876,6 +902,7 @@ import (
 	CreateImportStmt           "CREATE IMPORT statement"
 	CreateBindingStmt          "CREATE BINDING  statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
+	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
 	CreateStatisticsStmt       "CREATE STATISTICS statement"
 	DoStmt                     "Do statement"
@@ -891,6 +918,7 @@ import (
 	DropViewStmt               "DROP VIEW statement"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
+	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DeallocateStmt             "Deallocate prepared statement"
 	DeleteFromStmt             "DELETE FROM statement"
 	DeleteWithoutUsingStmt     "Normal DELETE statement"
@@ -913,6 +941,7 @@ import (
 	LoadDataStmt               "Load data statement"
 	LoadStatsStmt              "Load statistic statement"
 	LockTablesStmt             "Lock tables statement"
+	LockStatsStmt              "Lock stats statement"
 	NonTransactionalDMLStmt    "Non-transactional DML statement"
 	PlanReplayerStmt           "Plan replayer statement"
 	PreparedStmt               "PreparedStmt"
@@ -943,6 +972,7 @@ import (
 	TraceableStmt              "traceable statement"
 	TruncateTableStmt          "TRUNCATE TABLE statement"
 	UnlockTablesStmt           "Unlock tables statement"
+	UnlockStatsStmt            "Unlock stats statement"
 	UpdateStmt                 "UPDATE statement"
 	SetOprStmt                 "Union/Except/Intersect select statement"
 	SetOprStmtWithLimitOrderBy "Union/Except/Intersect select statement with limit and order by"
@@ -1079,6 +1109,10 @@ import (
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnOnOpt                   "JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR behavior"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1133,6 +1167,13 @@ import (
 	PasswordOrLockOptionList               "Password or lock options for create user statement"
 	PasswordOrLockOptions                  "Optional password or lock options for create user statement"
 	CommentOrAttributeOption               "Optional comment or attribute option for CREATE/ALTER USER statements"
+	ResourceGroupNameOption                "Optional resource group option for CREATE/ALTER USER statements"
+	ResourceGroupOptionList                "Resource group option list"
+	DirectResourceGroupOption              "Resource group option"
+	RunawayOptionList                      "Runaway query option list"
+	DirectRunawayOption                    "Runaway query option"
+	RunawayActionType                      "Runaway query action type"
+	RunawayWatchType                       "Runaway query watch type"
 	ColumnPosition                         "Column position [First|After ColumnName]"
 	PrepareSQL                             "Prepare statement sql string"
 	Priority                               "Statement priority"
@@ -1173,6 +1214,9 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectIntoOutfileOption                "SELECT INTO OUTFILE option"
+	SelectIntoOutfileOptionList            "SELECT INTO OUTFILE option list"
+	SelectIntoOutfileOptionListOpt         "optional SELECT INTO OUTFILE option list"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1407,6 +1451,7 @@ import (
 	ColumnFormat                    "Column format"
 	DBName                          "Database Name"
 	PolicyName                      "Placement Policy Name"
+	ResourceGroupName               "Resource Group Name"
 	ExplainFormatType               "explain format type"
 	FieldAsName                     "Field alias name"
 	FieldAsNameOpt                  "Field alias name opt"
@@ -1682,6 +1727,12 @@ AlterTablePartitionOpt:
 			Tp: ast.AlterTableRemovePartitioning,
 		}
 	}
+|	"REMOVE" "TTL"
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp: ast.AlterTableRemoveTTL,
+		}
+	}
 |	"REORGANIZE" "PARTITION" NoWriteToBinLogAliasOpt ReorganizePartitionRuleOpt
 	{
 		ret := $4.(*ast.AlterTableSpec)
@@ -2278,7 +2329,6 @@ AlterTableSpec:
 	}
 |	"DROP" CheckConstraintKeyword Identifier
 	{
-		// Parse it and ignore it. Just for compatibility.
 		c := &ast.Constraint{
 			Name: $3,
 		}
@@ -4698,6 +4748,10 @@ DropIndexStmt:
 		}
 		$$ = &ast.DropIndexStmt{IfExists: $3.(bool), IndexName: $4, Table: $6.(*ast.TableName), LockAlg: indexLockAndAlgorithm}
 	}
+|	"DROP" "HYPO" "INDEX" IfExists Identifier "ON" TableName
+	{
+		$$ = &ast.DropIndexStmt{IfExists: $4.(bool), IndexName: $5, Table: $7.(*ast.TableName), IsHypo: true}
+	}
 
 DropTableStmt:
 	"DROP" OptTemporary TableOrTables IfExists TableNameList RestrictOrCascadeOpt
@@ -4779,6 +4833,32 @@ DropStatsStmt:
 		}
 	}
 
+LockStatsStmt:
+	"LOCK" "STATS" TableNameList
+	{
+		$$ = &ast.LockStatsStmt{Tables: $3.([]*ast.TableName)}
+	}
+|	"LOCK" "STATS" TableName "PARTITION" PartitionNameList
+	{
+		$$ = &ast.LockStatsStmt{
+			Tables:         []*ast.TableName{$3.(*ast.TableName)},
+			PartitionNames: $5.([]model.CIStr),
+		}
+	}
+
+UnlockStatsStmt:
+	"UNLOCK" "STATS" TableNameList
+	{
+		$$ = &ast.UnlockStatsStmt{Tables: $3.([]*ast.TableName)}
+	}
+|	"UNLOCK" "STATS" TableName "PARTITION" PartitionNameList
+	{
+		$$ = &ast.UnlockStatsStmt{
+			Tables:         []*ast.TableName{$3.(*ast.TableName)},
+			PartitionNames: $5.([]model.CIStr),
+		}
+	}
+
 RestrictOrCascadeOpt:
 	{}
 |	"RESTRICT"
@@ -5728,6 +5808,10 @@ PredicateExpr:
 	{
 		$$ = &ast.PatternRegexpExpr{Expr: $1, Pattern: $3, Not: !$2.(bool)}
 	}
+|	BitExpr memberof '(' SimpleExpr ')'
+	{
+		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr(ast.JSONMemberOf), Args: []ast.ExprNode{$1, $4}}
+	}
 |	BitExpr
 
 RegexpSym:
@@ -6008,6 +6092,10 @@ IndexTypeName:
 	{
 		$$ = model.IndexTypeRtree
 	}
+|	"HYPO"
+	{
+		$$ = model.IndexTypeHypo
+	}
 
 IndexInvisible:
 	"VISIBLE"
@@ -6047,6 +6135,14 @@ UnReservedKeyword:
 |	"BOOL"
 |	"BOOLEAN"
 |	"BTREE"
+|	"BURSTABLE"
+|	"QUERY_LIMIT"
+|	"EXEC_ELAPSED"
+|	"DRYRUN"
+|	"COOLDOWN"
+|	"WATCH"
+|	"SIMILAR"
+|	"DURATION"
 |	"BYTE"
 |	"CAPTURE"
 |	"CAUSAL"
@@ -6093,6 +6189,7 @@ UnReservedKeyword:
 |	"GENERAL"
 |	"GLOBAL"
 |	"HASH"
+|	"HEADER"
 |	"HELP"
 |	"HOUR"
 |	"INSERT_METHOD"
@@ -6134,6 +6231,8 @@ UnReservedKeyword:
 |	"TRACE"
 |	"TRANSACTION"
 |	"TRUNCATE"
+|	"TTL"
+|	"TTL_ENABLE"
 |	"UNBOUNDED"
 |	"UNKNOWN"
 |	"VALUE" %prec lowerThanValueKeyword
@@ -6154,6 +6253,7 @@ UnReservedKeyword:
 |	"COMPRESSION"
 |	"KEY_BLOCK_SIZE"
 |	"MASTER"
+|	"MAX_FILE_SIZE"
 |	"MAX_ROWS"
 |	"MIN_ROWS"
 |	"NATIONAL"
@@ -6166,6 +6266,7 @@ UnReservedKeyword:
 |	"ISOLATION"
 |	"JSON"
 |	"REPEATABLE"
+|	"RESOURCE"
 |	"RESPECT"
 |	"COMMITTED"
 |	"UNCOMMITTED"
@@ -6277,6 +6378,7 @@ UnReservedKeyword:
 |	"VALIDATION"
 |	"WITHOUT"
 |	"RTREE"
+|	"RU_PER_SEC"
 |	"EXCHANGE"
 |	"COLUMN_FORMAT"
 |	"REPAIR"
@@ -6306,6 +6408,13 @@ UnReservedKeyword:
 |	"LABELS"
 |	"LOGS"
 |	"HOSTS"
+|	"HYPO"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
+|	"EMPTY"
+|	"ARRAY"
+|	"MEMBER"
 |	"AGAINST"
 |	"EXPANSION"
 |	"INCREMENT"
@@ -6397,6 +6506,7 @@ TiDBKeyword:
 |	"STATS_TOPN"
 |	"STATS_BUCKETS"
 |	"STATS_HEALTHY"
+|	"STATS_LOCKED"
 |	"HISTOGRAMS_IN_FLIGHT"
 |	"TELEMETRY"
 |	"TELEMETRY_ID"
@@ -7161,6 +7271,26 @@ SimpleExpr:
 			ExplicitCharSet: explicitCharset,
 		}
 	}
+|	builtinCast '(' Expression "AS" CastType "ARRAY" ')'
+	{
+		tp := $5.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.GetType())
+		if tp.GetFlen() == types.UnspecifiedLength {
+			tp.SetFlen(defaultFlen)
+		}
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(defaultDecimal)
+		}
+		tp.SetArray(true)
+		explicitCharset := parser.explicitCharset
+		parser.explicitCharset = false
+		$$ = &ast.FuncCastExpr{
+			Expr:            $3,
+			Tp:              tp,
+			FunctionType:    ast.CastFunction,
+			ExplicitCharSet: explicitCharset,
+		}
+	}
 |	"CASE" ExpressionOpt WhenClauseList ElseOpt "END"
 	{
 		x := &ast.CaseExpr{WhenClauses: $3.([]*ast.WhenClause)}
@@ -7672,9 +7802,13 @@ SumExpr:
 			$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 		}
 	}
-|	builtinCount '(' DistinctKwd ExpressionList ')'
+|	builtinCount '(' DistinctKwd ExpressionList ')' OptWindowingClause
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		if $6 != nil {
+			$$ = &ast.WindowFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true, Spec: *($6.(*ast.WindowSpec))}
+		} else {
+			$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		}
 	}
 |	builtinCount '(' "ALL" Expression ')' OptWindowingClause
 	{
@@ -7706,7 +7840,11 @@ SumExpr:
 		args := $4.([]ast.ExprNode)
 		args = append(args, $6.(ast.ExprNode))
 		if $8 != nil {
-			$$ = &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			windowFunc := &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			if $5 != nil {
+				windowFunc.Order = $5.(*ast.OrderByClause)
+			}
+			$$ = windowFunc
 		} else {
 			agg := &ast.AggregateFuncExpr{F: $1, Args: args, Distinct: $3.(bool)}
 			if $5 != nil {
@@ -9071,12 +9209,103 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsName
+	{
+		jt := &ast.JSONTable{
+			Expr:    $3,
+			Path:    $5,
+			Columns: $8.([]*ast.JSONTableColumn),
+		}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(model.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableColumnOnOpt
+	{
+		col := &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+		}
+		ons := $5.([]*ast.JSONTableOnResponse)
+		col.OnEmpty, col.OnError = ons[0], ons[1]
+		$$ = col
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExists,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, Columns: $5.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, Columns: $6.([]*ast.JSONTableColumn)}
+	}
+
+/* JSONTableColumnOnOpt returns the ON EMPTY and ON ERROR responses, either of them may be nil. */
+JSONTableColumnOnOpt:
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -9473,7 +9702,7 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	"INTO" "OUTFILE" stringLit Fields Lines SelectIntoOutfileOptionListOpt
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
@@ -9485,10 +9714,54 @@ SelectStmtIntoOption:
 		if $5 != nil {
 			x.LinesInfo = $5.(*ast.LinesClause)
 		}
+		for _, opt := range $6.([]*ast.SelectIntoOutfileOption) {
+			switch opt.Tp {
+			case ast.SelectIntoOutfileOptionCompression:
+				x.Compression = opt.StrValue
+			case ast.SelectIntoOutfileOptionMaxFileSize:
+				x.MaxFileSize = opt.UintValue
+			case ast.SelectIntoOutfileOptionHeader:
+				x.Header = opt.BoolValue
+			}
+		}
 
 		$$ = x
 	}
 
+SelectIntoOutfileOptionListOpt:
+	{
+		$$ = []*ast.SelectIntoOutfileOption{}
+	}
+|	SelectIntoOutfileOptionList
+
+SelectIntoOutfileOptionList:
+	SelectIntoOutfileOption
+	{
+		$$ = []*ast.SelectIntoOutfileOption{$1.(*ast.SelectIntoOutfileOption)}
+	}
+|	SelectIntoOutfileOptionList SelectIntoOutfileOption
+	{
+		$$ = append($1.([]*ast.SelectIntoOutfileOption), $2.(*ast.SelectIntoOutfileOption))
+	}
+
+SelectIntoOutfileOption:
+	"COMPRESSION" EqOpt stringLit
+	{
+		$$ = &ast.SelectIntoOutfileOption{Tp: ast.SelectIntoOutfileOptionCompression, StrValue: $3}
+	}
+|	"MAX_FILE_SIZE" EqOpt LengthNum
+	{
+		$$ = &ast.SelectIntoOutfileOption{Tp: ast.SelectIntoOutfileOptionMaxFileSize, UintValue: $3.(uint64)}
+	}
+|	"HEADER" EqOpt "TRUE"
+	{
+		$$ = &ast.SelectIntoOutfileOption{Tp: ast.SelectIntoOutfileOptionHeader, BoolValue: true}
+	}
+|	"HEADER" EqOpt "FALSE"
+	{
+		$$ = &ast.SelectIntoOutfileOption{Tp: ast.SelectIntoOutfileOptionHeader, BoolValue: false}
+	}
+
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
 	'(' SelectStmt ')'
@@ -11073,6 +11346,10 @@ ShowTargetFilterable:
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowStatsHealthy}
 	}
+|	"STATS_LOCKED"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsLocked}
+	}
 |	"HISTOGRAMS_IN_FLIGHT"
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowHistogramsInFlight}
@@ -11318,6 +11595,7 @@ Statement:
 |	AlterInstanceStmt
 |	AlterSequenceStmt
 |	AlterPolicyStmt
+|	AlterResourceGroupStmt
 |	AnalyzeTableStmt
 |	BeginTransactionStmt
 |	BinlogStmt
@@ -11337,6 +11615,7 @@ Statement:
 |	CreateRoleStmt
 |	CreateBindingStmt
 |	CreatePolicyStmt
+|	CreateResourceGroupStmt
 |	CreateSequenceStmt
 |	CreateStatisticsStmt
 |	DoStmt
@@ -11345,6 +11624,7 @@ Statement:
 |	DropIndexStmt
 |	DropTableStmt
 |	DropPolicyStmt
+|	DropResourceGroupStmt
 |	DropSequenceStmt
 |	DropViewStmt
 |	DropUserStmt
@@ -11408,6 +11688,8 @@ Statement:
 |	UseStmt
 |	UnlockTablesStmt
 |	LockTablesStmt
+|	LockStatsStmt
+|	UnlockStatsStmt
 |	ShutdownStmt
 |	RestartStmt
 |	HelpStmt
@@ -11730,6 +12012,28 @@ TableOption:
 		// Parse it but will ignore it
 		$$ = &ast.TableOption{Tp: ast.TableOptionEncryption, StrValue: $3}
 	}
+|	"TTL" EqOpt Identifier '+' "INTERVAL" Literal TimeUnit
+	{
+		// The interval is copied into a standalone value, it doesn't refer to the position in the original statement.
+		$$ = &ast.TableOption{
+			Tp:            ast.TableOptionTTL,
+			ColumnName:    &ast.ColumnName{Name: model.NewCIStr($3)},
+			Value:         ast.NewValueExpr($6.(ast.ValueExpr).GetValue(), parser.charset, parser.collation),
+			TimeUnitValue: &ast.TimeUnitExpr{Unit: $7.(ast.TimeUnitType)},
+		}
+	}
+|	"TTL_ENABLE" EqOpt stringLit
+	{
+		onOrOff := strings.ToLower($3)
+		if onOrOff == "on" {
+			$$ = &ast.TableOption{Tp: ast.TableOptionTTLEnable, BoolValue: true}
+		} else if onOrOff == "off" {
+			$$ = &ast.TableOption{Tp: ast.TableOptionTTLEnable, BoolValue: false}
+		} else {
+			yylex.AppendError(yylex.Errorf("The TTL_ENABLE option has to be set 'ON' or 'OFF'"))
+			return 1
+		}
+	}
 
 ForceOpt:
 	/* empty */
@@ -12603,7 +12907,7 @@ CommaOpt:
  *  https://dev.mysql.com/doc/refman/5.7/en/account-management-sql.html
  ************************************************************************************/
 CreateUserStmt:
-	"CREATE" "USER" IfNotExists UserSpecList RequireClauseOpt ConnectionOptions PasswordOrLockOptions CommentOrAttributeOption
+	"CREATE" "USER" IfNotExists UserSpecList RequireClauseOpt ConnectionOptions PasswordOrLockOptions CommentOrAttributeOption ResourceGroupNameOption
 	{
 		// See https://dev.mysql.com/doc/refman/8.0/en/create-user.html
 		ret := &ast.CreateUserStmt{
@@ -12617,6 +12921,9 @@ CreateUserStmt:
 		if $8 != nil {
 			ret.CommentOrAttributeOption = $8.(*ast.CommentOrAttributeOption)
 		}
+		if $9 != nil {
+			ret.ResourceGroupNameOption = $9.(*ast.ResourceGroupNameOption)
+		}
 		$$ = ret
 	}
 
@@ -12633,7 +12940,7 @@ CreateRoleStmt:
 
 /* See http://dev.mysql.com/doc/refman/8.0/en/alter-user.html */
 AlterUserStmt:
-	"ALTER" "USER" IfExists UserSpecList RequireClauseOpt ConnectionOptions PasswordOrLockOptions CommentOrAttributeOption
+	"ALTER" "USER" IfExists UserSpecList RequireClauseOpt ConnectionOptions PasswordOrLockOptions CommentOrAttributeOption ResourceGroupNameOption
 	{
 		ret := &ast.AlterUserStmt{
 			IfExists:              $3.(bool),
@@ -12645,6 +12952,9 @@ AlterUserStmt:
 		if $8 != nil {
 			ret.CommentOrAttributeOption = $8.(*ast.CommentOrAttributeOption)
 		}
+		if $9 != nil {
+			ret.ResourceGroupNameOption = $9.(*ast.ResourceGroupNameOption)
+		}
 		$$ = ret
 	}
 |	"ALTER" "USER" IfExists "USER" '(' ')' "IDENTIFIED" "BY" AuthString
@@ -12851,6 +13161,15 @@ CommentOrAttributeOption:
 		$$ = &ast.CommentOrAttributeOption{Type: ast.UserAttributeType, Value: $2}
 	}
 
+ResourceGroupNameOption:
+	{
+		$$ = nil
+	}
+|	"RESOURCE" "GROUP" ResourceGroupName
+	{
+		$$ = &ast.ResourceGroupNameOption{Value: $3}
+	}
+
 PasswordOrLockOptions:
 	{
 		$$ = []*ast.PasswordOrLockOption{}
@@ -13826,6 +14145,8 @@ NonTransactionalDMLStmt:
 ShardableStmt:
 	DeleteFromStmt
 |	UpdateStmt
+|	InsertIntoStmt
+|	ReplaceIntoStmt
 
 DryRunOptions:
 	{
@@ -13934,6 +14255,123 @@ AlterPolicyStmt:
 		}
 	}
 
+DropResourceGroupStmt:
+	"DROP" "RESOURCE" "GROUP" IfExists ResourceGroupName
+	{
+		$$ = &ast.DropResourceGroupStmt{
+			IfExists:          $4.(bool),
+			ResourceGroupName: model.NewCIStr($5),
+		}
+	}
+
+CreateResourceGroupStmt:
+	"CREATE" "RESOURCE" "GROUP" IfNotExists ResourceGroupName ResourceGroupOptionList
+	{
+		$$ = &ast.CreateResourceGroupStmt{
+			IfNotExists:             $4.(bool),
+			ResourceGroupName:       model.NewCIStr($5),
+			ResourceGroupOptionList: $6.([]*ast.ResourceGroupOption),
+		}
+	}
+
+AlterResourceGroupStmt:
+	"ALTER" "RESOURCE" "GROUP" IfExists ResourceGroupName ResourceGroupOptionList
+	{
+		$$ = &ast.AlterResourceGroupStmt{
+			IfExists:                $4.(bool),
+			ResourceGroupName:       model.NewCIStr($5),
+			ResourceGroupOptionList: $6.([]*ast.ResourceGroupOption),
+		}
+	}
+
+ResourceGroupName:
+	Identifier
+|	"DEFAULT"
+
+ResourceGroupOptionList:
+	DirectResourceGroupOption
+	{
+		$$ = []*ast.ResourceGroupOption{$1.(*ast.ResourceGroupOption)}
+	}
+|	ResourceGroupOptionList DirectResourceGroupOption
+	{
+		$$ = append($1.([]*ast.ResourceGroupOption), $2.(*ast.ResourceGroupOption))
+	}
+|	ResourceGroupOptionList ',' DirectResourceGroupOption
+	{
+		$$ = append($1.([]*ast.ResourceGroupOption), $3.(*ast.ResourceGroupOption))
+	}
+
+DirectResourceGroupOption:
+	"RU_PER_SEC" EqOpt LengthNum
+	{
+		$$ = &ast.ResourceGroupOption{Tp: ast.ResourceRURate, UintValue: $3.(uint64)}
+	}
+|	"BURSTABLE"
+	{
+		$$ = &ast.ResourceGroupOption{Tp: ast.ResourceBurstable, BoolValue: true}
+	}
+|	"QUERY_LIMIT" EqOpt '(' RunawayOptionList ')'
+	{
+		$$ = &ast.ResourceGroupOption{Tp: ast.ResourceRunaway, RunawayOptionList: $4.([]*ast.ResourceGroupRunawayOption)}
+	}
+
+RunawayOptionList:
+	DirectRunawayOption
+	{
+		$$ = []*ast.ResourceGroupRunawayOption{$1.(*ast.ResourceGroupRunawayOption)}
+	}
+|	RunawayOptionList DirectRunawayOption
+	{
+		$$ = append($1.([]*ast.ResourceGroupRunawayOption), $2.(*ast.ResourceGroupRunawayOption))
+	}
+|	RunawayOptionList ',' DirectRunawayOption
+	{
+		$$ = append($1.([]*ast.ResourceGroupRunawayOption), $3.(*ast.ResourceGroupRunawayOption))
+	}
+
+DirectRunawayOption:
+	"EXEC_ELAPSED" EqOpt stringLit
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayExecElapsed, StrValue: $3}
+	}
+|	"ACTION" EqOpt RunawayActionType
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayAction, ActionType: $3.(model.RunawayActionType)}
+	}
+|	"WATCH" EqOpt RunawayWatchType "DURATION" EqOpt stringLit
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayWatch, WatchType: $3.(model.RunawayWatchType), StrValue: $6}
+	}
+
+RunawayActionType:
+	"DRYRUN"
+	{
+		$$ = model.RunawayActionDryRun
+	}
+|	"COOLDOWN"
+	{
+		$$ = model.RunawayActionCooldown
+	}
+|	"KILL"
+	{
+		$$ = model.RunawayActionKill
+	}
+
+RunawayWatchType:
+	"EXACT"
+	{
+		$$ = model.RunawayWatchExact
+	}
+|	"SIMILAR"
+	{
+		$$ = model.RunawayWatchSimilar
+	}
+|	"PLAN"
+	{
+		$$ = model.RunawayWatchPlan
+	}
+
 /********************************************************************************************
  *
  *  Create Sequence Statement
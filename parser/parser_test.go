@@ -701,6 +701,11 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
+		{"select a from t into outfile 's3://bucket/prefix/result.csv' compression 'gzip'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/prefix/result.csv' COMPRESSION = 'gzip'"},
+		{"select a from t into outfile 's3://bucket/prefix/result.csv' fields terminated by ',' max_file_size = 1048576 header = true compression = 'zstd'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/prefix/result.csv' FIELDS TERMINATED BY ',' COMPRESSION = 'zstd' MAX_FILE_SIZE = 1048576 HEADER = TRUE"},
+		{"select a from t into outfile '/tmp/result.txt' header false", true, "SELECT `a` FROM `t` INTO OUTFILE '/tmp/result.txt'"},
+		{"select a from t into outfile '/tmp/result.txt' header 1", false, ""},
+		{"select a from t into outfile '/tmp/result.txt' max_file_size '1MB'", false, ""},
 
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
@@ -1144,6 +1149,8 @@ func TestDBAStmt(t *testing.T) {
 		// for show stats_healthy.
 		{"show stats_healthy", true, "SHOW STATS_HEALTHY"},
 		{"show stats_healthy where table_name = 't'", true, "SHOW STATS_HEALTHY WHERE `table_name`=_UTF8MB4't'"},
+		{"show stats_locked", true, "SHOW STATS_LOCKED"},
+		{"show stats_locked where table_name = 't'", true, "SHOW STATS_LOCKED WHERE `table_name`=_UTF8MB4't'"},
 		// for show stats_topn.
 		{"show stats_topn", true, "SHOW STATS_TOPN"},
 		{"show stats_topn where table_name = 't'", true, "SHOW STATS_TOPN WHERE `table_name`=_UTF8MB4't'"},
@@ -2442,6 +2449,16 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE d_n.t_n LOCK DEFAULT , UNION = ( t_n , d_n.t_n ) REMOVE PARTITIONING", true, "ALTER TABLE `d_n`.`t_n` LOCK = DEFAULT, UNION = (`t_n`,`d_n`.`t_n`) REMOVE PARTITIONING"},
 		{"ALTER TABLE d_n.t_n ALGORITHM = DEFAULT , MAX_ROWS 10, UNION ( d_n.t_n ) , ROW_FORMAT REDUNDANT, STATS_PERSISTENT = DEFAULT", true, "ALTER TABLE `d_n`.`t_n` ALGORITHM = DEFAULT, MAX_ROWS = 10, UNION = (`d_n`.`t_n`), ROW_FORMAT = REDUNDANT, STATS_PERSISTENT = DEFAULT /* TableOptionStatsPersistent is not supported */ "},
 
+		// for table option `TTL`
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1 DAY", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 DAY"},
+		{"create table t (created_at datetime) TTL created_at + INTERVAL 90 DAY TTL_ENABLE = 'OFF'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 90 DAY TTL_ENABLE = 'OFF'"},
+		{"create table t (created_at datetime) /*T![ttl] TTL = created_at + INTERVAL 3 MONTH */", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 3 MONTH"},
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1", false, ""},
+		{"create table t (created_at datetime) TTL_ENABLE = 'invalid'", false, ""},
+		{"alter table t TTL = created_at + INTERVAL 1 YEAR", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 YEAR"},
+		{"alter table t TTL_ENABLE = 'ON'", true, "ALTER TABLE `t` TTL_ENABLE = 'ON'"},
+		{"alter table t remove ttl", true, "ALTER TABLE `t` REMOVE TTL"},
+
 		// partition option
 		{"create table t (b int) partition by range columns (b) (partition p0 values less than (not 3), partition p2 values less than (20));", false, ""},
 		{"create table t (b int) partition by range columns (b) (partition p0 values less than (1 or 3), partition p2 values less than (20));", false, ""},
@@ -2654,6 +2671,14 @@ func TestDDL(t *testing.T) {
 		{"drop stats t partition p0", true, "DROP STATS `t` PARTITION `p0`"},
 		{"drop stats t partition p0, p1, p2", true, "DROP STATS `t` PARTITION `p0`,`p1`,`p2`"},
 		{"drop stats t global", true, "DROP STATS `t` GLOBAL"},
+		{"lock stats t", true, "LOCK STATS `t`"},
+		{"lock stats t, test.t1", true, "LOCK STATS `t`, `test`.`t1`"},
+		{"lock stats t partition p0, p1", true, "LOCK STATS `t` PARTITION `p0`,`p1`"},
+		{"lock stats t, t1 partition p0", false, ""},
+		{"lock stats", false, ""},
+		{"unlock stats t", true, "UNLOCK STATS `t`"},
+		{"unlock stats t, test.t1", true, "UNLOCK STATS `t`, `test`.`t1`"},
+		{"unlock stats t partition p0", true, "UNLOCK STATS `t` PARTITION `p0`"},
 		// for issue 974
 		{`CREATE TABLE address (
 		id bigint(20) NOT NULL AUTO_INCREMENT,
@@ -3115,6 +3140,7 @@ func TestDDL(t *testing.T) {
 		{"CREATE UNIQUE INDEX ident ON d_n.t_n ( ident , ident ASC ) TYPE BTREE", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE UNIQUE INDEX ident ON d_n.t_n ( ident , ident ASC ) TYPE HASH", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING HASH"},
 		{"CREATE UNIQUE INDEX ident ON d_n.t_n ( ident , ident ASC ) TYPE RTREE", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING RTREE"},
+		{"CREATE INDEX idx ON t ( a, b ) TYPE HYPO", true, "CREATE INDEX `idx` ON `t` (`a`, `b`) USING HYPO"},
 		{"CREATE UNIQUE INDEX ident TYPE BTREE ON d_n.t_n ( ident , ident ASC )", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE UNIQUE INDEX ident USING BTREE ON d_n.t_n ( ident , ident ASC )", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE SPATIAL INDEX idx ON t (a)", true, "CREATE SPATIAL INDEX `idx` ON `t` (`a`)"},
@@ -3158,6 +3184,8 @@ func TestDDL(t *testing.T) {
 		{"drop index a on db.`tb-ttb`", true, "DROP INDEX `a` ON `db`.`tb-ttb`"},
 		{"drop index if exists a on t", true, "DROP INDEX IF EXISTS `a` ON `t`"},
 		{"drop index if exists a on db.t", true, "DROP INDEX IF EXISTS `a` ON `db`.`t`"},
+		{"drop hypo index a on t", true, "DROP HYPO INDEX `a` ON `t`"},
+		{"drop hypo index if exists a on db.t", true, "DROP HYPO INDEX IF EXISTS `a` ON `db`.`t`"},
 		{"drop index if exists a on db.`tb-ttb`", true, "DROP INDEX IF EXISTS `a` ON `db`.`tb-ttb`"},
 		{"drop index idx on t algorithm = default", true, "DROP INDEX `idx` ON `t`"},
 		{"drop index idx on t algorithm default", true, "DROP INDEX `idx` ON `t`"},
@@ -3572,6 +3600,25 @@ func TestDDL(t *testing.T) {
 		{"show create placement policy if exists x", false, ""},
 		{"show create placement policy x, y", false, ""},
 		{"show create placement policy `placement`", true, "SHOW CREATE PLACEMENT POLICY `placement`"},
+		// for resource group
+		{"create resource group x ru_per_sec=1000", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000"},
+		{"create resource group x ru_per_sec=1000 burstable", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000 BURSTABLE"},
+		{"create resource group x ru_per_sec 1000, burstable", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000 BURSTABLE"},
+		{"create resource group if not exists x ru_per_sec=1000", true, "CREATE RESOURCE GROUP IF NOT EXISTS `x` RU_PER_SEC = 1000"},
+		{"create resource group x ru_per_sec='1000'", false, ""},
+		{"create resource group x", false, ""},
+		{"alter resource group x ru_per_sec=2000 burstable", true, "ALTER RESOURCE GROUP `x` RU_PER_SEC = 2000 BURSTABLE"},
+		{"alter resource group if exists x ru_per_sec=2000", true, "ALTER RESOURCE GROUP IF EXISTS `x` RU_PER_SEC = 2000"},
+		{"create resource group x ru_per_sec=1000 query_limit=(exec_elapsed='60s', action=kill, watch=similar duration='10m')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000 QUERY_LIMIT = (EXEC_ELAPSED = '60s' ACTION = KILL WATCH = SIMILAR DURATION = '10m')"},
+		{"alter resource group x query_limit (exec_elapsed '1s' action cooldown)", true, "ALTER RESOURCE GROUP `x` QUERY_LIMIT = (EXEC_ELAPSED = '1s' ACTION = COOLDOWN)"},
+		{"alter resource group x query_limit=(exec_elapsed='1s', action=dryrun, watch=exact duration='1m')", true, "ALTER RESOURCE GROUP `x` QUERY_LIMIT = (EXEC_ELAPSED = '1s' ACTION = DRYRUN WATCH = EXACT DURATION = '1m')"},
+		{"alter resource group x query_limit=(watch=plan duration='1m')", true, "ALTER RESOURCE GROUP `x` QUERY_LIMIT = (WATCH = PLAN DURATION = '1m')"},
+		{"alter resource group x query_limit=(action=stop)", false, ""},
+		{"alter resource group x query_limit=(watch=plan)", false, ""},
+		{"alter resource group x query_limit=()", false, ""},
+		{"drop resource group x", true, "DROP RESOURCE GROUP `x`"},
+		{"drop resource group if exists x", true, "DROP RESOURCE GROUP IF EXISTS `x`"},
+		{"drop resource group x, y", false, ""},
 		// for create placement policy
 		{"create placement policy x primary_region='us'", true, "CREATE PLACEMENT POLICY `x` PRIMARY_REGION = 'us'"},
 		{"create placement policy x region='us, 3'", false, ""},
@@ -3763,17 +3810,23 @@ func TestErrorMsg(t *testing.T) {
 	_, _, err = p.Parse("alter table t lock = randomStr123", "", "")
 	require.EqualError(t, err, "[parser:1801]Unknown LOCK type 'randomStr123'")
 
-	_, _, err = p.Parse("create table t (a longtext unicode)", "", "")
-	require.EqualError(t, err, "[parser:1115]Unknown character set: 'ucs2'")
+	// `UNICODE` is the shorthand of the ucs2 character set.
+	stmts, _, err := p.Parse("create table t (a longtext unicode)", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "ucs2", stmts[0].(*ast.CreateTableStmt).Cols[0].Tp.GetCharset())
 
-	_, _, err = p.Parse("create table t (a long byte, b text unicode)", "", "")
-	require.EqualError(t, err, "[parser:1115]Unknown character set: 'ucs2'")
+	stmts, _, err = p.Parse("create table t (a long byte, b text unicode)", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "ucs2", stmts[0].(*ast.CreateTableStmt).Cols[1].Tp.GetCharset())
 
-	_, _, err = p.Parse("create table t (a long ascii, b long unicode)", "", "")
-	require.EqualError(t, err, "[parser:1115]Unknown character set: 'ucs2'")
+	stmts, _, err = p.Parse("create table t (a long ascii, b long unicode)", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "latin1", stmts[0].(*ast.CreateTableStmt).Cols[0].Tp.GetCharset())
+	require.Equal(t, "ucs2", stmts[0].(*ast.CreateTableStmt).Cols[1].Tp.GetCharset())
 
-	_, _, err = p.Parse("create table t (a text unicode, b mediumtext ascii, c int)", "", "")
-	require.EqualError(t, err, "[parser:1115]Unknown character set: 'ucs2'")
+	stmts, _, err = p.Parse("create table t (a text unicode, b mediumtext ascii, c int)", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "ucs2", stmts[0].(*ast.CreateTableStmt).Cols[0].Tp.GetCharset())
 
 	_, _, err = p.Parse("select 1 collate some_unknown_collation", "", "")
 	require.EqualError(t, err, "[ddl:1273]Unknown collation: 'some_unknown_collation'")
@@ -4543,6 +4596,13 @@ func TestComment(t *testing.T) {
 		{"alter user commentUser COMMENT '123456'", true, "ALTER USER `commentUser`@`%` COMMENT '123456'"},
 		{"create user commentUser ATTRIBUTE '{\"name\": \"Tom\", \"age\", 19}'", true, "CREATE USER `commentUser`@`%` ATTRIBUTE '{\"name\": \"Tom\", \"age\", 19}'"},
 		{"alter user commentUser ATTRIBUTE '{\"name\": \"Tom\", \"age\", 19}'", true, "ALTER USER `commentUser`@`%` ATTRIBUTE '{\"name\": \"Tom\", \"age\", 19}'"},
+
+		// RESOURCE GROUP in CREATE/ALTER USER
+		{"create user u1 resource group rg1", true, "CREATE USER `u1`@`%` RESOURCE GROUP `rg1`"},
+		{"create user u1 comment 'x' resource group rg1", true, "CREATE USER `u1`@`%` COMMENT 'x' RESOURCE GROUP `rg1`"},
+		{"alter user u1 resource group rg1", true, "ALTER USER `u1`@`%` RESOURCE GROUP `rg1`"},
+		{"alter user u1 resource group default", true, "ALTER USER `u1`@`%` RESOURCE GROUP `default`"},
+		{"alter user u1 resource group", false, ""},
 	}
 	RunTest(t, table, false)
 }
@@ -5503,6 +5563,56 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		{"select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (id for ordinality, a varchar(10) path '$.a', b int exists path '$.b')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `a` VARCHAR(10) PATH '$.a', `b` INT EXISTS PATH '$.b')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' default '1' on empty)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '1' ON EMPTY)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' null on empty error on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a json path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c[*]' columns (c int path '$'))) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` JSON PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c[*]' COLUMNS (`c` INT PATH '$'))) AS `jt`"},
+		{"select * from t, json_table(t.j, '$[*]' columns (a int path '$')) as jt", true, "SELECT * FROM (`t`) JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from t left join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+		{"select nested, ordinality, path, empty from t", true, "SELECT `nested`,`ordinality`,`path`,`empty` FROM `t`"},
+
+		{"select * from json_table('[]', '$[*]' columns (a int path '$'))", false, ""},
+		{"select * from json_table('[]', '$[*]' columns ()) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' error on error null on empty)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int exists path '$' null on empty)) as jt", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestMultiValuedIndex(t *testing.T) {
+	table := []testCase{
+		{"select cast(j as unsigned array) from t", true, "SELECT CAST(`j` AS UNSIGNED ARRAY) FROM `t`"},
+		{"select cast(j->'$.a' as char(10) array) from t", true, "SELECT CAST(JSON_EXTRACT(`j`, _UTF8MB4'$.a') AS CHAR(10) ARRAY) FROM `t`"},
+		{"create table t (j json, index idx((cast(j as signed array))))", true, "CREATE TABLE `t` (`j` JSON,INDEX `idx`((CAST(`j` AS SIGNED ARRAY))))"},
+		{"alter table t add index idx((cast(j->'$.tags' as date array)))", true, "ALTER TABLE `t` ADD INDEX `idx`((CAST(JSON_EXTRACT(`j`, _UTF8MB4'$.tags') AS DATE ARRAY)))"},
+		{"select * from t where 1 member of (j)", true, "SELECT * FROM `t` WHERE 1 MEMBER OF (`j`)"},
+		{"select * from t where a + 1 member of (j->'$.a') and b = 1", true, "SELECT * FROM `t` WHERE `a`+1 MEMBER OF (JSON_EXTRACT(`j`, _UTF8MB4'$.a')) AND `b`=1"},
+		{"select * from t where json_overlaps(j, '[1, 2]')", true, "SELECT * FROM `t` WHERE JSON_OVERLAPS(`j`, _UTF8MB4'[1, 2]')"},
+		{"select member, array from t", true, "SELECT `member`,`array` FROM `t`"},
+
+		{"select * from t where 1 member of j", false, ""},
+		{"select cast(j as array) from t", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateralDerivedTable(t *testing.T) {
+	table := []testCase{
+		{"select * from t, lateral (select * from t1 where t1.a = t.a) as d", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `d`"},
+		{"select * from t join lateral (select * from t1 where t1.a = t.a order by b limit 3) d on true", true, "SELECT * FROM `t` JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a` ORDER BY `b` LIMIT 3) AS `d` ON TRUE"},
+		{"select * from t left join lateral (select max(b) m from t1 where t1.a = t.a) d on true", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT MAX(`b`) AS `m` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `d` ON TRUE"},
+
+		{"select * from t, lateral t1", false, ""},
+		{"select lateral from t", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -5863,6 +5973,9 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT COUNT(profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT COUNT(ALL profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT COUNT(*) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(1) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT COUNT(DISTINCT profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(DISTINCT `profit`) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT GROUP_CONCAT(DISTINCT product SEPARATOR ';') OVER() FROM sales;`, true, "SELECT GROUP_CONCAT(DISTINCT `product` SEPARATOR ';') OVER () FROM `sales`"},
+		{`SELECT GROUP_CONCAT(product ORDER BY year) OVER() FROM sales;`, true, "SELECT GROUP_CONCAT(`product` ORDER BY `year` SEPARATOR ',') OVER () FROM `sales`"},
 		{`SELECT MAX(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MAX(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT MIN(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MIN(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT SUM(profit) OVER() AS country_profit FROM sales;`, true, "SELECT SUM(`profit`) OVER () AS `country_profit` FROM `sales`"},
@@ -5878,6 +5991,7 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT AVG(val) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL '2:30' MINUTE_SECOND FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL _UTF8MB4'2:30' MINUTE_SECOND FOLLOWING) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
+		{`SELECT AVG(val) OVER (ORDER BY time GROUPS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (ORDER BY `time` GROUPS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM `t`"},
 
 		// For named windows.
 		// See https://dev.mysql.com/doc/refman/8.0/en/window-functions-named-windows.html
@@ -6936,6 +7050,17 @@ func TestNonTransactionalDML(t *testing.T) {
 			"BATCH LIMIT 10 DRY RUN UPDATE `t` SET `c`=10"},
 		{"batch limit 10 dry run query update t set c = 10", true,
 			"BATCH LIMIT 10 DRY RUN QUERY UPDATE `t` SET `c`=10"},
+		// inserts and replaces
+		{"batch on c limit 10 insert into t select * from s where c > 10", true,
+			"BATCH ON `c` LIMIT 10 INSERT INTO `t` SELECT * FROM `s` WHERE `c`>10"},
+		{"batch limit 10 dry run insert into t (a, b) select a, b from s", true,
+			"BATCH LIMIT 10 DRY RUN INSERT INTO `t` (`a`,`b`) SELECT `a`,`b` FROM `s`"},
+		{"batch on c limit 10 dry run query insert ignore into t select * from s on duplicate key update a = 1", true,
+			"BATCH ON `c` LIMIT 10 DRY RUN QUERY INSERT IGNORE INTO `t` SELECT * FROM `s` ON DUPLICATE KEY UPDATE `a`=1"},
+		{"batch on c limit 10 replace into t select * from s", true,
+			"BATCH ON `c` LIMIT 10 REPLACE INTO `t` SELECT * FROM `s`"},
+		{"batch limit 10 dry run replace into t select * from s where c = 10", true,
+			"BATCH LIMIT 10 DRY RUN REPLACE INTO `t` SELECT * FROM `s` WHERE `c`=10"},
 	}
 
 	RunTest(t, cases, false)
@@ -28,6 +28,8 @@ const (
 	FeatureIDForceAutoInc = "force_inc"
 	// FeatureIDPlacement is the `placement rule` feature.
 	FeatureIDPlacement = "placement"
+	// FeatureIDTTL is the `ttl` feature.
+	FeatureIDTTL = "ttl"
 )
 
 var featureIDs = map[string]struct{}{
@@ -37,6 +39,7 @@ var featureIDs = map[string]struct{}{
 	FeatureIDClusteredIndex: {},
 	FeatureIDForceAutoInc:   {},
 	FeatureIDPlacement:      {},
+	FeatureIDTTL:            {},
 }
 
 // CanParseFeature is used to check if a feature can be parsed.
@@ -56,6 +56,8 @@ type FieldType struct {
 	// elems is the element list for enum and set type.
 	elems            []string
 	elemsIsBinaryLit []bool
+	// array indicates that the field type is an array of the type, it's only used by the multi-valued index.
+	array bool
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -69,6 +71,23 @@ func NewFieldType(tp byte) *FieldType {
 	}
 }
 
+// IsArray returns true if the field type is an array.
+func (ft *FieldType) IsArray() bool {
+	return ft.array
+}
+
+// SetArray sets whether the field type is an array.
+func (ft *FieldType) SetArray(array bool) {
+	ft.array = array
+}
+
+// ArrayType returns the type of the array elements.
+func (ft *FieldType) ArrayType() *FieldType {
+	clone := ft.Clone()
+	clone.array = false
+	return clone
+}
+
 // IsDecimalValid checks whether the decimal is valid.
 func (ft *FieldType) IsDecimalValid() bool {
 	if ft.tp == mysql.TypeNewDecimal && (ft.decimal < 0 || ft.decimal > mysql.MaxDecimalScale || ft.flen <= 0 || ft.flen > mysql.MaxDecimalWidth || ft.flen < ft.decimal) {
@@ -261,7 +280,8 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
+		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag) &&
+		ft.array == other.array
 	if !partialEqual || len(ft.elems) != len(other.elems) {
 		return false
 	}
@@ -295,6 +315,9 @@ func (ft *FieldType) PartialEqual(other *FieldType, unsafe bool) bool {
 
 // EvalType gets the type in evaluation.
 func (ft *FieldType) EvalType() EvalType {
+	if ft.array {
+		return ETJson
+	}
 	switch ft.tp {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
 		mysql.TypeBit, mysql.TypeYear:
@@ -411,6 +434,9 @@ func (ft *FieldType) String() string {
 	if mysql.HasBinaryFlag(ft.flag) && ft.tp != mysql.TypeString {
 		strs = append(strs, "BINARY")
 	}
+	if ft.array {
+		strs = append(strs, "ARRAY")
+	}
 
 	if IsTypeChar(ft.tp) || IsTypeBlob(ft.tp) {
 		if ft.charset != "" && ft.charset != charset.CharsetBin {
@@ -496,7 +522,7 @@ func (ft *FieldType) RestoreAsCastType(ctx *format.RestoreCtx, explicitCharset b
 			ctx.WritePlainf("(%d)", ft.flen)
 		}
 		if !explicitCharset {
-			return
+			break
 		}
 		if !skipWriteBinary && ft.flag&mysql.BinaryFlag != 0 {
 			ctx.WriteKeyWord(" BINARY")
@@ -539,6 +565,10 @@ func (ft *FieldType) RestoreAsCastType(ctx *format.RestoreCtx, explicitCharset b
 	case mysql.TypeYear:
 		ctx.WriteKeyWord("YEAR")
 	}
+	if ft.array {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord("ARRAY")
+	}
 }
 
 // FormatAsCastType is used for write AST back to string.
@@ -592,6 +622,7 @@ type jsonFieldType struct {
 	Collate          string
 	Elems            []string
 	ElemsIsBinaryLit []bool
+	Array            bool
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -607,6 +638,7 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.collate = r.Collate
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
+		ft.array = r.Array
 	}
 	return err
 }
@@ -622,6 +654,7 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Collate = ft.collate
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
+	r.Array = ft.array
 	return json.Marshal(r)
 }
 
@@ -46,6 +46,8 @@ import (
 
 var planCacheCounter = metrics.PlanCacheCounter.WithLabelValues("prepare")
 var planCacheMissCounter = metrics.PlanCacheMissCounter.WithLabelValues("cache_miss")
+var generalPlanCacheCounter = metrics.PlanCacheCounter.WithLabelValues("general")
+var generalPlanCacheMissCounter = metrics.PlanCacheMissCounter.WithLabelValues("general_cache_miss")
 
 // ShowDDL is for showing DDL information.
 type ShowDDL struct {
@@ -627,6 +629,8 @@ type SelectInto struct {
 
 	TargetPlan Plan
 	IntoOpt    *ast.SelectIntoOption
+	// TargetNames are the output names of TargetPlan, which are written as the header of the output files.
+	TargetNames types.NameSlice
 }
 
 // Explain represents a explain plan.
@@ -330,6 +330,11 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return fmt.Sprintf("json:%s, path:%s", p.JSONExpr.ExplainInfo(), p.Path)
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -666,9 +671,12 @@ func (p *PhysicalWindow) ExplainInfo() string {
 		if !isFirst {
 			buffer.WriteString(" ")
 		}
-		if p.Frame.Type == ast.Rows {
+		switch p.Frame.Type {
+		case ast.Rows:
 			buffer.WriteString("rows")
-		} else {
+		case ast.Groups:
+			buffer.WriteString("groups")
+		default:
 			buffer.WriteString("range")
 		}
 		buffer.WriteString(" between ")
@@ -172,6 +172,16 @@ func (p *LogicalShowDDLJobs) findBestTask(prop *property.PhysicalProperty, planC
 	return &rootTask{p: pShow}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, _ *physicalOptimizeOp) (task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{JSONExpr: p.JSONExpr, Path: p.Path, Columns: p.Columns}.Init(p.ctx, p.stats, p.blockOffset)
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	return &rootTask{p: jt}, 1, nil
+}
+
 // rebuildChildTasks rebuilds the childTasks to make the clock_th combination.
 func (p *baseLogicalPlan) rebuildChildTasks(childTasks *[]task, pp PhysicalPlan, childCnts []int64, planCounter int64, ts uint64, opt *physicalOptimizeOp) error {
 	// The taskMap of children nodes should be rolled back first.
@@ -919,8 +929,8 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 		}
 
 		if canConvertPointGet && !path.IsIntHandlePath {
-			// We simply do not build [batch] point get for prefix indexes. This can be optimized.
-			canConvertPointGet = path.Index.Unique && !path.Index.HasPrefixIndex()
+			// We simply do not build [batch] point get for prefix indexes and multi-valued indexes. This can be optimized.
+			canConvertPointGet = path.Index.Unique && !path.Index.HasPrefixIndex() && !path.Index.MVIndex
 			// If any range cannot cover all columns of the index, we cannot build [batch] point get.
 			idxColsLen := len(path.Index.Columns)
 			for _, ran := range path.Ranges {
@@ -1462,9 +1472,14 @@ func (is *PhysicalIndexScan) initSchema(idxExprCols []*expression.Column, isDoub
 			indexCols = append(indexCols, idxExprCols[i])
 		} else {
 			// TODO: try to reuse the col generated when building the DataSource.
+			retType := &is.Table.Columns[is.Index.Columns[i].Offset].FieldType
+			if retType.IsArray() {
+				// The entry of the multi-valued index holds an element of the array.
+				retType = retType.ArrayType()
+			}
 			indexCols = append(indexCols, &expression.Column{
 				ID:       is.Table.Columns[is.Index.Columns[i].Offset].ID,
-				RetType:  &is.Table.Columns[is.Index.Columns[i].Offset].FieldType,
+				RetType:  retType,
 				UniqueID: is.ctx.GetSessionVars().AllocPlanColumnID(),
 			})
 		}
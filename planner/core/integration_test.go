@@ -7693,3 +7693,40 @@ func TestOuterJoinEliminationForIssue18216(t *testing.T) {
 	tk.MustExec("select group_concat(c order by (select group_concat(c order by a) from t2 where a=t1.a)) from t1; ")
 	tk.MustQuery("select group_concat(c order by (select group_concat(c order by c) from t2 where a=t1.a), c desc) from t1;").Check(testkit.Rows("2,1,4,3"))
 }
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("create table t1 (a int, b int, index idx_a(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into t1 values (1, 1), (1, 2), (1, 3), (1, 4), (2, 5), (2, 6)")
+
+	// Top-N per group can't be decorrelated.
+	sql := "select t.a, d.b from t, lateral (select b from t1 where t1.a = t.a order by b desc limit 2) d order by t.a, d.b"
+	require.True(t, tk.HasPlan(sql, "Apply"))
+	tk.MustQuery(sql).Check(testkit.Rows("1 3", "1 4", "2 5", "2 6"))
+	sql = "select t.a, d.b from t left join lateral (select b from t1 where t1.a = t.a order by b limit 1) d on true order by t.a"
+	tk.MustQuery(sql).Check(testkit.Rows("1 1", "2 5", "3 <nil>"))
+
+	// The correlated selections and aggregations are decorrelated to joins.
+	sql = "select t.a, d.b from t, lateral (select b from t1 where t1.a = t.a) d order by t.a, d.b"
+	require.False(t, tk.HasPlan(sql, "Apply"))
+	tk.MustQuery(sql).Check(testkit.Rows("1 1", "1 2", "1 3", "1 4", "2 5", "2 6"))
+	sql = "select t.a, d.c from t, lateral (select a, count(*) c from t1 where t1.a = t.a group by a) d order by t.a"
+	require.False(t, tk.HasPlan(sql, "Apply"))
+	tk.MustQuery(sql).Check(testkit.Rows("1 4", "2 2"))
+	sql = "select t.a, d.m from t, lateral (select max(b) m from t1 where t1.a = t.a) d order by t.a"
+	require.False(t, tk.HasPlan(sql, "Apply"))
+	tk.MustQuery(sql).Check(testkit.Rows("1 4", "2 6", "3 <nil>"))
+	tk.MustQuery("select t.a, d.c from t, lateral (select count(*) c from t1 where t1.a = t.a) d order by t.a").
+		Check(testkit.Rows("1 4", "2 2", "3 0"))
+	tk.MustQuery("select t.a, d.s from t join lateral (select t.b + t1.b s from t1 where t1.a = t.a) d on d.s > 5 order by d.s").
+		Check(testkit.Rows("2 7", "2 8"))
+
+	// A LATERAL derived table without outer references is a normal derived table.
+	tk.MustQuery("select count(*) from t, lateral (select * from t1) d").Check(testkit.Rows("18"))
+	tk.MustGetErrCode("select * from t right join lateral (select * from t1 where t1.a = t.a) d on true", errno.ErrTFForbiddenJoinType)
+	tk.MustGetErrCode("select * from t, (select * from t1 where t1.a = t.a) d", errno.ErrBadField)
+}
@@ -378,6 +378,10 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			// `JSON_TABLE` is not a select block either.
+			isTableName = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -728,10 +732,29 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// The JSON_TABLE and the LATERAL derived table on the right side can reference the columns of the left side,
+	// they are resolved as correlated columns and the join is converted to an Apply later.
+	rightSource, canReferLeft := joinNode.Right.(*ast.TableSource)
+	if canReferLeft {
+		_, isJSONTable := rightSource.Source.(*ast.JSONTable)
+		canReferLeft = isJSONTable || rightSource.Lateral
+	}
+	if canReferLeft {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if canReferLeft {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	isLateral := canReferLeft && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0
+	if isLateral && joinNode.Tp == ast.RightJoin {
+		return nil, ErrTFForbiddenJoinType.GenWithStackByArgs(rightSource.AsName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -842,7 +865,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			if isLateral {
+				sel.SetChildren(b.buildLateralApply(joinPlan))
+			} else {
+				sel.SetChildren(joinPlan)
+			}
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -852,9 +879,23 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
+	if isLateral {
+		return b.buildLateralApply(joinPlan), nil
+	}
 	return joinPlan, nil
 }
 
+// buildLateralApply converts the join whose right side references the columns of the left side to an Apply,
+// so the right side is evaluated for every row of the left side.
+func (b *PlanBuilder) buildLateralApply(join *LogicalJoin) *LogicalApply {
+	b.optFlag = b.optFlag | flagPredicatePushDown | flagBuildKeyInfo | flagDecorrelate
+	join.cartesianJoin = false
+	ap := &LogicalApply{LogicalJoin: *join}
+	ap.tp = plancodec.TypeApply
+	ap.self = ap
+	return ap
+}
+
 // buildUsingClause eliminate the redundant columns and ordering columns based
 // on the "USING" clause.
 //
@@ -4133,6 +4174,108 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 	return p, nil
 }
 
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	if _, err := types.ParseJSONPathExpr(jt.Path); err != nil {
+		return nil, err
+	}
+	// The JSON document is rewritten upon an empty plan, so it can only reference the columns of
+	// the outer query and the left tables of the join as correlated columns.
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	expr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("subquery in JSON_TABLE")
+	}
+	p := LogicalJSONTable{JSONExpr: expr, Path: jt.Path, Columns: jt.Columns}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	if err = b.buildJSONTableColumns(jt.Columns, asName, schema, &names); err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTableColumns appends the columns of JSON_TABLE to the schema in depth-first order,
+// the columns of the NESTED PATH are flattened into the schema.
+func (b *PlanBuilder) buildJSONTableColumns(columns []*ast.JSONTableColumn, asName model.CIStr, schema *expression.Schema, names *types.NameSlice) error {
+	for _, col := range columns {
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			if _, err := types.ParseJSONPathExpr(col.Path); err != nil {
+				return err
+			}
+		}
+		if col.Tp == ast.JSONTableColumnNested {
+			if err := b.buildJSONTableColumns(col.Columns, asName, schema, names); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, on := range []*ast.JSONTableOnResponse{col.OnEmpty, col.OnError} {
+			if on != nil && on.Tp == ast.JSONTableOnResponseDefault {
+				if _, err := types.ParseBinaryJSONFromString(on.Default); err != nil {
+					return err
+				}
+			}
+		}
+		var tp *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			tp = types.NewFieldType(mysql.TypeLonglong)
+			tp.SetFlag(mysql.UnsignedFlag)
+		} else {
+			tp = col.FieldType.Clone()
+			if err := setJSONTableColumnType(tp); err != nil {
+				return err
+			}
+		}
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  tp,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     asName,
+			ColName:     col.Name,
+			OrigTblName: asName,
+			OrigColName: col.Name,
+		})
+	}
+	return nil
+}
+
+// setJSONTableColumnType fills the charset, collation, flen and decimal of the JSON_TABLE column like a column definition.
+func setJSONTableColumnType(tp *types.FieldType) error {
+	needCharset := types.IsString(tp.GetType()) || tp.GetType() == mysql.TypeEnum || tp.GetType() == mysql.TypeSet
+	if needCharset && !mysql.HasBinaryFlag(tp.GetFlag()) {
+		if tp.GetCharset() == "" {
+			chs, coll := charset.GetDefaultCharsetAndCollate()
+			tp.SetCharset(chs)
+			tp.SetCollate(coll)
+		} else if tp.GetCollate() == "" {
+			coll, err := charset.GetDefaultCollation(tp.GetCharset())
+			if err != nil {
+				return err
+			}
+			tp.SetCollate(coll)
+		}
+	} else if tp.GetCharset() == "" {
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	return nil
+}
+
 func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	b.handleHelper.pushMap(nil)
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
@@ -4452,11 +4595,13 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		if len(tn.PartitionNames) > 0 {
 			pids := make(map[int64]struct{}, len(tn.PartitionNames))
 			for _, name := range tn.PartitionNames {
-				pid, err := tables.FindPartitionByName(tableInfo, name.L)
+				partIDs, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 				if err != nil {
 					return nil, err
 				}
-				pids[pid] = struct{}{}
+				for _, pid := range partIDs {
+					pids[pid] = struct{}{}
+				}
 			}
 			pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 		}
@@ -4621,7 +4766,9 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		if i < len(columns) {
 			if columns[i].IsGenerated() && !columns[i].GeneratedStored {
 				var err error
+				b.allowBuildCastArray = true
 				expr, _, err = b.rewrite(ctx, columns[i].GeneratedExpr, ds, nil, true)
+				b.allowBuildCastArray = false
 				if err != nil {
 					return nil, err
 				}
@@ -6086,7 +6233,7 @@ func (b *PlanBuilder) buildByItemsForWindow(
 }
 
 // buildWindowFunctionFrameBound builds the bounds of window function frames.
-// For type `Rows`, the bound expr must be an unsigned integer.
+// For type `Rows` and `Groups`, the bound expr must be an unsigned integer.
 // For type `Range`, the bound expr must be temporal or numeric types.
 func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast.WindowSpec, orderByItems []property.SortItem, boundClause *ast.FrameBound) (*FrameBound, error) {
 	frameType := spec.Frame.Type
@@ -6095,7 +6242,7 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast
 		return bound, nil
 	}
 
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Type == ast.CurrentRow {
 			return bound, nil
 		}
@@ -6196,8 +6343,8 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 func (b *PlanBuilder) checkWindowFuncArgs(ctx context.Context, p LogicalPlan, windowFuncExprs []*ast.WindowFuncExpr, windowAggMap map[*ast.AggregateFuncExpr]int) error {
 	checker := &expression.ParamMarkerInPrepareChecker{}
 	for _, windowFuncExpr := range windowFuncExprs {
-		if strings.ToLower(windowFuncExpr.F) == ast.AggFuncGroupConcat {
-			return ErrNotSupportedYet.GenWithStackByArgs("group_concat as window function")
+		if windowFuncExpr.Order != nil {
+			return ErrNotSupportedYet.GenWithStackByArgs("ORDER BY in group_concat as window function")
 		}
 		args, err := b.buildArgs4WindowFunc(ctx, p, windowFuncExpr.Args, windowAggMap)
 		if err != nil {
@@ -6331,6 +6478,9 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 				return nil, nil, ErrWrongArguments.GenWithStackByArgs(strings.ToLower(windowFunc.F))
 			}
 			preArgs += len(windowFunc.Args)
+			desc.HasDistinct = windowFunc.Distinct
+			desc.IgnoreNull = windowFunc.IgnoreNull
+			desc.FromLast = windowFunc.FromLast
 			desc.WrapCastForAggArgs(b.ctx)
 			descs = append(descs, desc)
 			windowMap[windowFunc] = schema.Len()
@@ -6348,19 +6498,44 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 	return p, windowMap, nil
 }
 
+// nullTreatmentWindowFuncs are the window functions which support `IGNORE NULLS`.
+var nullTreatmentWindowFuncs = map[string]struct{}{
+	ast.WindowFuncFirstValue: {},
+	ast.WindowFuncLastValue:  {},
+	ast.WindowFuncNthValue:   {},
+	ast.WindowFuncLag:        {},
+	ast.WindowFuncLead:       {},
+}
+
+// nonAggWindowFuncs are the window functions which are not aggregate functions, they don't support `DISTINCT`.
+var nonAggWindowFuncs = map[string]struct{}{
+	ast.WindowFuncRowNumber:   {},
+	ast.WindowFuncRank:        {},
+	ast.WindowFuncDenseRank:   {},
+	ast.WindowFuncCumeDist:    {},
+	ast.WindowFuncPercentRank: {},
+	ast.WindowFuncNtile:       {},
+	ast.WindowFuncLead:        {},
+	ast.WindowFuncLag:         {},
+	ast.WindowFuncFirstValue:  {},
+	ast.WindowFuncLastValue:   {},
+	ast.WindowFuncNthValue:    {},
+}
+
 // checkOriginWindowFuncs checks the validity for original window specifications for a group of functions.
 // Because the grouped specification is different from them, we should especially check them before build window frame.
 func (b *PlanBuilder) checkOriginWindowFuncs(funcs []*ast.WindowFuncExpr, orderByItems []property.SortItem) error {
 	for _, f := range funcs {
-		if f.IgnoreNull {
+		name := strings.ToLower(f.F)
+		if _, ok := nullTreatmentWindowFuncs[name]; f.IgnoreNull && !ok {
 			return ErrNotSupportedYet.GenWithStackByArgs("IGNORE NULLS")
 		}
-		if f.Distinct {
-			return ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
-		}
-		if f.FromLast {
+		if f.FromLast && name != ast.WindowFuncNthValue {
 			return ErrNotSupportedYet.GenWithStackByArgs("FROM LAST")
 		}
+		if _, ok := nonAggWindowFuncs[name]; f.Distinct && ok {
+			return ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
+		}
 		spec := &f.Spec
 		if f.Spec.Name.L != "" {
 			spec = b.windowSpecs[f.Spec.Name.L]
@@ -6377,9 +6552,6 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
-	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
 		return ErrWindowFrameStartIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
@@ -6411,7 +6583,7 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
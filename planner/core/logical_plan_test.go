@@ -2115,6 +2115,30 @@ func TestTraceFastPlan(t *testing.T) {
 	require.True(t, s.ctx.GetSessionVars().StmtCtx.OptimizeTracer.IsFastPlan)
 }
 
+func TestWindowFunctionUnsupportedModifiers(t *testing.T) {
+	// The parser only accepts the modifiers on the functions supporting them, so they're set on the AST directly.
+	s := createPlannerSuite()
+	for _, tc := range []struct {
+		sql    string
+		modify func(f *ast.WindowFuncExpr)
+		errMsg string
+	}{
+		{"select row_number() over () from t", func(f *ast.WindowFuncExpr) { f.IgnoreNull = true }, "IGNORE NULLS"},
+		{"select sum(a) over () from t", func(f *ast.WindowFuncExpr) { f.IgnoreNull = true }, "IGNORE NULLS"},
+		{"select lag(a) over (order by a) from t", func(f *ast.WindowFuncExpr) { f.FromLast = true }, "FROM LAST"},
+		{"select first_value(a) over () from t", func(f *ast.WindowFuncExpr) { f.FromLast = true }, "FROM LAST"},
+		{"select rank() over (order by a) from t", func(f *ast.WindowFuncExpr) { f.Distinct = true }, "<window function>(DISTINCT ..)"},
+		{"select lead(a) over (order by a) from t", func(f *ast.WindowFuncExpr) { f.Distinct = true }, "<window function>(DISTINCT ..)"},
+	} {
+		stmt, err := s.p.ParseOneStmt(tc.sql, "", "")
+		require.NoError(t, err, tc.sql)
+		tc.modify(stmt.(*ast.SelectStmt).Fields.Fields[0].Expr.(*ast.WindowFuncExpr))
+		_, _, err = BuildLogicalPlanForTest(context.Background(), s.ctx, stmt, s.is)
+		require.True(t, ErrNotSupportedYet.Equal(err), tc.sql)
+		require.EqualError(t, err, fmt.Sprintf("[planner:1235]This version of TiDB doesn't yet support '%s'", tc.errMsg), tc.sql)
+	}
+}
+
 func TestWindowLogicalPlanAmbiguous(t *testing.T) {
 	sql := "select a, max(a) over(), sum(a) over() from t"
 	var planString string
@@ -1373,10 +1373,29 @@ func (ds *DataSource) detachCondAndBuildRangeForPath(path *util.AccessPath, cond
 			path.ConstCols[i] = res.ColumnValues[i] != nil
 		}
 	}
+	if path.IsHypo {
+		path.CountAfterAccess = ds.getHypoIndexRowCount(path.AccessConds)
+		return nil
+	}
 	path.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.ctx, path.Index.ID, path.Ranges)
 	return err
 }
 
+// getHypoIndexRowCount estimates the row count after accessing a hypothetical index. The hypothetical index
+// has no statistics of its own, so the row count is derived from the column histograms of the access conditions.
+func (ds *DataSource) getHypoIndexRowCount(accessConds []expression.Expression) float64 {
+	count := float64(ds.statisticTable.Count)
+	if len(accessConds) == 0 {
+		return count
+	}
+	selectivity, _, err := ds.tableStats.HistColl.Selectivity(ds.ctx, accessConds, nil)
+	if err != nil {
+		logutil.BgLogger().Debug("calculate selectivity failed, use selection factor", zap.Error(err))
+		selectivity = SelectionFactor
+	}
+	return count * selectivity
+}
+
 func (ds *DataSource) deriveCommonHandleTablePathStats(path *util.AccessPath, conds []expression.Expression, isIm bool) error {
 	path.CountAfterAccess = float64(ds.statisticTable.Count)
 	path.Ranges = ranger.FullNotNullRange()
@@ -1921,6 +1940,22 @@ type LogicalShowDDLJobs struct {
 	JobNumber int64
 }
 
+// LogicalJSONTable is the logical plan of the JSON_TABLE table function.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// JSONExpr is the JSON document, it references the columns of the left tables as correlated columns.
+	JSONExpr expression.Expression
+	Path     string
+	// Columns are the column definitions, the schema is built from them in depth-first order.
+	Columns []*ast.JSONTableColumn
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.JSONExpr)
+}
+
 // CTEClass holds the information and plan for a CTE. Most of the fields in this struct are the same as cteInfo.
 // But the cteInfo is used when building the plan, and CTEClass is used also for building the executor.
 type CTEClass struct {
@@ -1396,11 +1396,15 @@ type StatementsSummaryExtractor struct {
 	// Enable is true means the executor should use digest to locate statement summary.
 	// Enable is false, means the executor should keep the behavior compatible with before.
 	Enable bool
+	// CoarseTimeRange is the time range which the summaries in the persisted history should overlap with.
+	// It's only used to skip the persisted history, the time predicates are still evaluated by the executor.
+	// e.g: SELECT * FROM STATEMENTS_SUMMARY_HISTORY WHERE SUMMARY_BEGIN_TIME >= '2022-10-10 10:00:00'
+	CoarseTimeRange *TimeRange
 }
 
 // Extract implements the MemTablePredicateExtractor Extract interface
 func (e *StatementsSummaryExtractor) Extract(
-	_ sessionctx.Context,
+	ctx sessionctx.Context,
 	schema *expression.Schema,
 	names []*types.FieldName,
 	predicates []expression.Expression,
@@ -1415,17 +1419,52 @@ func (e *StatementsSummaryExtractor) Extract(
 		e.Enable = true
 		e.Digests = digests
 	}
+
+	// A summary overlaps with the time range if it begins before the end time and ends after the start time,
+	// so both the bounds of `summary_begin_time` and `summary_end_time` are applicable.
+	tz := ctx.GetSessionVars().StmtCtx.TimeZone
+	_, beginStart, beginEnd := e.extractTimeRange(ctx, schema, names, remained, "summary_begin_time", tz)
+	_, endStart, endEnd := e.extractTimeRange(ctx, schema, names, remained, "summary_end_time", tz)
+	startTime := mathutil.Max(beginStart, endStart)
+	endTime := beginEnd
+	if endTime == 0 || (endEnd != 0 && endEnd < endTime) {
+		endTime = endEnd
+	}
+	if startTime > 0 || endTime > 0 {
+		e.CoarseTimeRange = &TimeRange{}
+		if startTime > 0 {
+			e.CoarseTimeRange.StartTime = time.Unix(0, startTime)
+		}
+		if endTime > 0 {
+			e.CoarseTimeRange.EndTime = time.Unix(0, endTime)
+		}
+	}
 	return remained
 }
 
-func (e *StatementsSummaryExtractor) explainInfo(_ *PhysicalMemTable) string {
+func (e *StatementsSummaryExtractor) explainInfo(p *PhysicalMemTable) string {
 	if e.SkipRequest {
 		return "skip_request: true"
 	}
-	if !e.Enable {
-		return ""
+	r := new(bytes.Buffer)
+	if e.Enable {
+		r.WriteString(fmt.Sprintf("digests: [%s], ", extractStringFromStringSet(e.Digests)))
 	}
-	return fmt.Sprintf("digests: [%s]", extractStringFromStringSet(e.Digests))
+	if e.CoarseTimeRange != nil {
+		tz := p.ctx.GetSessionVars().StmtCtx.TimeZone
+		if st := e.CoarseTimeRange.StartTime; !st.IsZero() {
+			r.WriteString(fmt.Sprintf("start_time:%v, ", st.In(tz).Format("2006-01-02 15:04:05")))
+		}
+		if et := e.CoarseTimeRange.EndTime; !et.IsZero() {
+			r.WriteString(fmt.Sprintf("end_time:%v, ", et.In(tz).Format("2006-01-02 15:04:05")))
+		}
+	}
+	// remove the last ", " in the message info
+	s := r.String()
+	if len(s) > 2 {
+		return s[:len(s)-2]
+	}
+	return s
 }
 
 // TikvRegionPeersExtractor is used to extract some predicates of cluster table.
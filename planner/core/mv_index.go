@@ -0,0 +1,213 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/ranger"
+)
+
+// extractMVIndexPaths removes the paths of the multi-valued indexes from the possible access paths and returns them.
+// A multi-valued index has an entry for every element of the array, so it can't be used like a normal index, its
+// paths are only generated by generateMVIndexPaths.
+func (ds *DataSource) extractMVIndexPaths() []*util.AccessPath {
+	var mvPaths []*util.AccessPath
+	paths := make([]*util.AccessPath, 0, len(ds.possibleAccessPaths))
+	for _, path := range ds.possibleAccessPaths {
+		if path.Index != nil && path.Index.MVIndex {
+			mvPaths = append(mvPaths, path)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	if len(mvPaths) == 0 {
+		return nil
+	}
+	if len(paths) == 0 {
+		// Only the multi-valued indexes are hinted, keep the table path in case that no filter can use them.
+		tablePath := &util.AccessPath{StoreType: kv.TiKV}
+		fillContentForTablePath(tablePath, ds.tableInfo)
+		paths = append(paths, tablePath)
+	}
+	ds.possibleAccessPaths = paths
+	return mvPaths
+}
+
+// generateMVIndexPaths generates the access paths of the multi-valued indexes for the filters
+// `value MEMBER OF(expr)`, `JSON_CONTAINS(expr, value)` and `JSON_OVERLAPS(expr, value)`, where expr is
+// the expression casted to an array by the index. The paths only scan the points of the values, so every
+// row is read at most once by a partial path. The filters are always kept as the table filters.
+func (ds *DataSource) generateMVIndexPaths(mvPaths []*util.AccessPath) {
+	for _, mvPath := range mvPaths {
+		castExpr := ds.mvIndexCastExpr(mvPath.Index)
+		if castExpr == nil {
+			continue
+		}
+		for _, filter := range ds.allConds {
+			values, anyOf := ds.mvIndexFilterValues(castExpr, filter)
+			if len(values) == 0 {
+				continue
+			}
+			if !anyOf {
+				// All of the values are contained by the array, reading the rows of any one of them is enough.
+				values = values[:1]
+			}
+			partialPaths := make([]*util.AccessPath, 0, len(values))
+			for _, value := range values {
+				partialPath := ds.buildMVIndexPointPath(mvPath, castExpr, filter, value)
+				if partialPath == nil {
+					partialPaths = nil
+					break
+				}
+				partialPaths = append(partialPaths, partialPath)
+			}
+			if len(partialPaths) == 0 {
+				continue
+			}
+			if expression.MaybeOverOptimized4PlanCache(ds.ctx, []expression.Expression{filter}) {
+				ds.ctx.GetSessionVars().StmtCtx.SkipPlanCache = true
+			}
+			if len(partialPaths) == 1 {
+				ds.possibleAccessPaths = append(ds.possibleAccessPaths, partialPaths[0])
+				continue
+			}
+			// The rows of the different values are unioned by the index merge.
+			indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths}
+			for _, partialPath := range partialPaths {
+				indexMergePath.CountAfterAccess += partialPath.CountAfterAccess
+				partialPath.TableFilters = nil
+			}
+			indexMergePath.TableFilters = ds.pushedDownConds
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, indexMergePath)
+		}
+	}
+}
+
+// mvIndexCastExpr returns the `CAST(expr AS type ARRAY)` expression of the multi-valued index. The array column
+// must be the first column of the index so that the points of the elements can be scanned.
+func (ds *DataSource) mvIndexCastExpr(idx *model.IndexInfo) *expression.ScalarFunction {
+	colInfo := ds.tableInfo.Columns[idx.Columns[0].Offset]
+	if !colInfo.FieldType.IsArray() {
+		return nil
+	}
+	for _, col := range ds.TblCols {
+		if col.ID != colInfo.ID {
+			continue
+		}
+		if sf, ok := col.VirtualExpr.(*expression.ScalarFunction); ok && sf.FuncName.L == ast.Cast {
+			return sf
+		}
+		return nil
+	}
+	return nil
+}
+
+// mvIndexFilterValues returns the constant JSON values of the filter on the expression of the multi-valued index,
+// and whether the filter requires the array to contain any one of the values rather than all of them.
+func (ds *DataSource) mvIndexFilterValues(castExpr *expression.ScalarFunction, filter expression.Expression) (values []types.BinaryJSON, anyOf bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok {
+		return nil, false
+	}
+	indexedExpr := castExpr.GetArgs()[0]
+	args := sf.GetArgs()
+	var valueExpr expression.Expression
+	switch sf.FuncName.L {
+	case ast.JSONMemberOf:
+		if args[1].Equal(ds.ctx, indexedExpr) {
+			valueExpr = args[0]
+		}
+	case ast.JSONContains:
+		if len(args) == 2 && args[0].Equal(ds.ctx, indexedExpr) {
+			valueExpr = args[1]
+		}
+	case ast.JSONOverlaps:
+		anyOf = true
+		if args[0].Equal(ds.ctx, indexedExpr) {
+			valueExpr = args[1]
+		} else if args[1].Equal(ds.ctx, indexedExpr) {
+			valueExpr = args[0]
+		}
+	}
+	if _, ok := valueExpr.(*expression.Constant); !ok {
+		return nil, false
+	}
+	value, isNull, err := valueExpr.EvalJSON(ds.ctx, chunk.Row{})
+	if isNull || err != nil {
+		return nil, false
+	}
+	if sf.FuncName.L == ast.JSONMemberOf || value.TypeCode != types.JSONTypeCodeArray {
+		if value.TypeCode == types.JSONTypeCodeArray || value.TypeCode == types.JSONTypeCodeObject {
+			return nil, false
+		}
+		return []types.BinaryJSON{value}, anyOf
+	}
+	for i := 0; i < value.GetElemCount(); i++ {
+		values = append(values, value.ArrayGetElem(i))
+	}
+	return values, anyOf
+}
+
+// buildMVIndexPointPath builds the path which scans the point of the value on the multi-valued index. The value is
+// converted to the element type in the same way as the index, nil is returned if it can't be converted.
+func (ds *DataSource) buildMVIndexPointPath(mvPath *util.AccessPath, castExpr *expression.ScalarFunction, filter expression.Expression, value types.BinaryJSON) *util.AccessPath {
+	if value.TypeCode == types.JSONTypeCodeArray || value.TypeCode == types.JSONTypeCodeObject {
+		return nil
+	}
+	arg := &expression.Constant{Value: types.NewJSONDatum(value), RetType: types.NewFieldType(mysql.TypeJSON)}
+	cast, err := expression.BuildCastFunctionWithCheck(ds.ctx, arg, castExpr.GetType().Clone())
+	if err != nil {
+		return nil
+	}
+	elems, isNull, err := cast.EvalJSON(ds.ctx, chunk.Row{})
+	if isNull || err != nil || elems.GetElemCount() != 1 {
+		return nil
+	}
+	elemTp := castExpr.GetType().ArrayType()
+	point := types.NewDatumFromJSONScalar(elems.ArrayGetElem(0), elemTp.GetCollate())
+	idx := mvPath.Index
+	path := &util.AccessPath{
+		Index:          idx,
+		FullIdxCols:    make([]*expression.Column, len(idx.Columns)),
+		FullIdxColLens: make([]int, len(idx.Columns)),
+		Ranges: []*ranger.Range{{
+			LowVal:    []types.Datum{point},
+			HighVal:   []types.Datum{point},
+			Collators: []collate.Collator{collate.GetCollator(elemTp.GetCollate())},
+		}},
+		AccessConds:  []expression.Expression{filter},
+		TableFilters: ds.pushedDownConds,
+		StoreType:    mvPath.StoreType,
+		Forced:       mvPath.Forced,
+	}
+	for i := range path.FullIdxColLens {
+		path.FullIdxColLens[i] = types.UnspecifiedLength
+	}
+	// The index has no statistics, the pseudo count of a value is used.
+	path.CountAfterAccess = ds.statisticTable.PseudoAvgCountPerValue()
+	if path.CountAfterAccess > ds.stats.RowCount {
+		path.CountAfterAccess = ds.stats.RowCount
+	}
+	path.CountAfterIndex = path.CountAfterAccess
+	return path
+}
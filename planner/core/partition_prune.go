@@ -29,9 +29,14 @@ func PartitionPruning(ctx sessionctx.Context, tbl table.PartitionedTable, conds
 	columns []*expression.Column, names types.NameSlice) ([]int, error) {
 	s := partitionProcessor{}
 	pi := tbl.Meta().Partition
+	if pi.Sub != nil {
+		return s.pruneSubPartition(ctx, tbl, partitionNames, conds, columns, names)
+	}
 	switch pi.Type {
 	case model.PartitionTypeHash:
 		return s.pruneHashPartition(ctx, tbl, partitionNames, conds, columns, names)
+	case model.PartitionTypeKey:
+		return s.pruneKeyPartition(ctx, tbl, partitionNames, conds, columns)
 	case model.PartitionTypeRange:
 		rangeOr, err := s.pruneRangePartition(ctx, pi, tbl, conds, columns, names)
 		if err != nil {
@@ -2229,6 +2229,33 @@ func (p *PhysicalShowDDLJobs) MemoryUsage() (sum int64) {
 	return p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInt64
 }
 
+// PhysicalJSONTable is the physical plan of the JSON_TABLE table function.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	JSONExpr expression.Expression
+	Path     string
+	Columns  []*ast.JSONTableColumn
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.JSONExpr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+	sum = p.physicalSchemaProducer.MemoryUsage() + int64(len(p.Path)) + size.SizeOfString + size.SizeOfSlice +
+		int64(cap(p.Columns))*size.SizeOfPointer
+	if p.JSONExpr != nil {
+		sum += p.JSONExpr.MemoryUsage()
+	}
+	return
+}
+
 // BuildMergeJoinPlan builds a PhysicalMergeJoin from the given fields. Currently, it is only used for test purpose.
 func BuildMergeJoinPlan(ctx sessionctx.Context, joinType JoinType, leftKeys, rightKeys []*expression.Column) *PhysicalMergeJoin {
 	baseJoin := basePhysicalJoin{
@@ -68,6 +68,8 @@ func planCachePreprocess(ctx context.Context, sctx sessionctx.Context, isGeneral
 		}
 		param.Datum = val
 		param.InExecute = true
+		// The parameter markers of the cached plans read their values from PreparedParams,
+		// so the constants extracted by the general plan cache are kept here as well.
 		vars.PreparedParams = append(vars.PreparedParams, val)
 	}
 
@@ -101,7 +103,11 @@ func planCachePreprocess(ctx context.Context, sctx sessionctx.Context, isGeneral
 	// And update lastUpdateTime to the newest one.
 	expiredTimeStamp4PC := domain.GetDomain(sctx).ExpiredTimeStamp4PC()
 	if stmtAst.UseCache && expiredTimeStamp4PC.Compare(vars.LastUpdateTime4PC) > 0 {
-		sctx.GetPlanCache(isGeneralPlanCache).DeleteAll()
+		if instanceCache, ok := sctx.GetPlanCache(isGeneralPlanCache).(*InstancePlanCache); ok {
+			instanceCache.flush(expiredTimeStamp4PC)
+		} else {
+			sctx.GetPlanCache(isGeneralPlanCache).DeleteAll()
+		}
 		stmtAst.CachedPlan = nil
 		vars.LastUpdateTime4PC = expiredTimeStamp4PC
 	}
@@ -149,7 +155,7 @@ func GetPlanFromSessionPlanCache(ctx context.Context, sctx sessionctx.Context,
 	paramNum, paramTypes := parseParamTypes(sctx, params)
 
 	if stmtAst.UseCache && stmtAst.CachedPlan != nil && !ignorePlanCache { // for point query plan
-		if plan, names, ok, err := getPointQueryPlan(stmtAst, sessVars, stmtCtx); ok {
+		if plan, names, ok, err := getPointQueryPlan(stmtAst, isGeneralPlanCache, sessVars, stmtCtx); ok {
 			return plan, names, err
 		}
 	}
@@ -185,7 +191,7 @@ func parseParamTypes(sctx sessionctx.Context, params []expression.Expression) (p
 	return
 }
 
-func getPointQueryPlan(stmt *ast.Prepared, sessVars *variable.SessionVars, stmtCtx *stmtctx.StatementContext) (Plan,
+func getPointQueryPlan(stmt *ast.Prepared, isGeneralPlanCache bool, sessVars *variable.SessionVars, stmtCtx *stmtctx.StatementContext) (Plan,
 	[]*types.FieldName, bool, error) {
 	// short path for point-get plans
 	// Rewriting the expression in the select.where condition  will convert its
@@ -201,6 +207,8 @@ func getPointQueryPlan(stmt *ast.Prepared, sessVars *variable.SessionVars, stmtC
 	}
 	if metrics.ResettablePlanCacheCounterFortTest {
 		metrics.PlanCacheCounter.WithLabelValues("prepare").Inc()
+	} else if isGeneralPlanCache {
+		generalPlanCacheCounter.Inc()
 	} else {
 		planCacheCounter.Inc()
 	}
@@ -215,7 +223,8 @@ func getGeneralPlan(sctx sessionctx.Context, isGeneralPlanCache bool, cacheKey k
 	sessVars := sctx.GetSessionVars()
 	stmtCtx := sessVars.StmtCtx
 
-	candidate, exist := sctx.GetPlanCache(isGeneralPlanCache).Get(cacheKey, paramTypes)
+	cache := sctx.GetPlanCache(isGeneralPlanCache)
+	candidate, exist := cache.Get(cacheKey, paramTypes)
 	if !exist {
 		return nil, nil, false, nil
 	}
@@ -223,6 +232,17 @@ func getGeneralPlan(sctx sessionctx.Context, isGeneralPlanCache bool, cacheKey k
 	if err := CheckPreparedPriv(sctx, stmt, is); err != nil {
 		return nil, nil, false, err
 	}
+	if _, ok := cache.(*InstancePlanCache); ok {
+		// The plans in the instance plan cache are shared by the sessions, the ranges are rebuilt on a clone.
+		var err error
+		if cachedVal, err = clonePlan4Session(sctx, cachedVal); err != nil {
+			logutil.BgLogger().Debug("clone the plan in instance plan cache failed", zap.Error(err))
+			return nil, nil, false, nil
+		}
+		if stmt.PlanDigest == nil { // the plan is generated by another session
+			stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(cachedVal.Plan)
+		}
+	}
 	for tblInfo, unionScan := range cachedVal.TblInfo2UnionScan {
 		if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
 			// TODO we can inject UnionScan into cached plan to avoid invalidating it, though
@@ -244,6 +264,8 @@ func getGeneralPlan(sctx sessionctx.Context, isGeneralPlanCache bool, cacheKey k
 	}
 	if metrics.ResettablePlanCacheCounterFortTest {
 		metrics.PlanCacheCounter.WithLabelValues("prepare").Inc()
+	} else if isGeneralPlanCache {
+		generalPlanCacheCounter.Inc()
 	} else {
 		planCacheCounter.Inc()
 	}
@@ -260,7 +282,11 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isGeneralPlan
 	sessVars := sctx.GetSessionVars()
 	stmtCtx := sessVars.StmtCtx
 
-	planCacheMissCounter.Inc()
+	if isGeneralPlanCache {
+		generalPlanCacheMissCounter.Inc()
+	} else {
+		planCacheMissCounter.Inc()
+	}
 	sctx.GetSessionVars().StmtCtx.InPreparedPlanBuilding = true
 	p, names, err := OptimizeAstNode(ctx, sctx, stmtAst.Stmt, is)
 	sctx.GetSessionVars().StmtCtx.InPreparedPlanBuilding = false
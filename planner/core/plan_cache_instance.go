@@ -0,0 +1,283 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// instancePlanCacheSysVars are the variables which affect the plans. The sessions sharing the instance plan cache
+// may set them differently, so their values are a part of the cache key.
+var instancePlanCacheSysVars = []string{
+	variable.SQLModeVar,
+	variable.TimeZone,
+	variable.CharacterSetConnection,
+	variable.CollationConnection,
+	"div_precision_increment",
+	variable.TiDBOptAggPushDown,
+	variable.TiDBOptDistinctAggPushDown,
+	variable.TiDBOptPreferRangeScan,
+	variable.TiDBOptEnableCorrelationAdjustment,
+	variable.TiDBOptLimitPushDownThreshold,
+	variable.TiDBOptCorrelationThreshold,
+	variable.TiDBOptCorrelationExpFactor,
+	variable.TiDBOptCPUFactor,
+	variable.TiDBOptScanFactor,
+	variable.TiDBOptSeekFactor,
+	variable.TiDBOptProjectionPushDown,
+	variable.TiDBOptimizerSelectivityLevel,
+	variable.TiDBEnableIndexMerge,
+	variable.TiDBAllowMPPExecution,
+	variable.TiDBEnforceMPPExecution,
+	variable.TiDBPartitionPruneMode,
+	variable.TiDBCostModelVersion,
+	variable.TiDBDefaultStrMatchSelectivity,
+}
+
+// instancePlanCacheSysVarsValue returns the values of the variables affecting the plans.
+func instancePlanCacheSysVarsValue(vars *variable.SessionVars) string {
+	var sb strings.Builder
+	for _, name := range instancePlanCacheSysVars {
+		val, _ := vars.GetSystemVar(name)
+		sb.WriteString(val)
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// InstancePlanCache is the prepared plan cache shared by all the sessions of the tidb-server, it's used instead of
+// the session plan caches when tidb_enable_instance_plan_cache is on.
+// The cached plans are immutable: a plan is cloned when it's put into the cache, and every hit gets a clone of it
+// bound to the session. The memory usage is limited by tidb_instance_plan_cache_max_mem_size.
+type InstancePlanCache struct {
+	*LRUPlanCache
+
+	// flushTS is the time of the last `admin flush instance plan_cache` handled by the cache.
+	flushTS types.Time
+}
+
+var (
+	instancePlanCache     *InstancePlanCache
+	instancePlanCacheOnce sync.Once
+)
+
+// GetInstancePlanCache returns the instance plan cache of the tidb-server.
+func GetInstancePlanCache() *InstancePlanCache {
+	instancePlanCacheOnce.Do(func() {
+		// The number of the plans is not limited, only the memory usage is.
+		lru := NewLRUPlanCache(math.MaxUint32, 0, 0, PickPlanFromBucket)
+		lru.memTracker = newTrackerForInstancePC()
+		instancePlanCache = &InstancePlanCache{LRUPlanCache: lru}
+	})
+	return instancePlanCache
+}
+
+// Put implements the PlanCache interface. The plans which can't be shared by the sessions are ignored.
+// The hits get the clones of the cached plan bound to the sessions, see clonePlan4Session.
+func (c *InstancePlanCache) Put(key kvcache.Key, value kvcache.Value, paramTypes []*types.FieldType) {
+	cached := value.(*PlanCacheValue)
+	p, ok := cached.Plan.(PhysicalPlan)
+	if !ok || !instancePlanCacheable(p) {
+		return
+	}
+	cloned, err := p.Clone()
+	if err != nil {
+		return
+	}
+	// The cached plan isn't bound to any session, so the session isn't kept alive by the cache.
+	bindPlan2Session(nil, cloned)
+	c.LRUPlanCache.Put(key, &PlanCacheValue{
+		Plan:              cloned,
+		OutPutNames:       cached.OutPutNames,
+		TblInfo2UnionScan: cached.TblInfo2UnionScan,
+		ParamTypes:        cached.ParamTypes,
+	}, paramTypes)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.memTracker.SetBytesLimit(int64(variable.InstancePlanCacheMaxMemSize.Load()))
+	for c.memTracker.CheckExceed() && c.size > 0 {
+		c.removeOldest()
+	}
+}
+
+// Delete implements the PlanCache interface. The plans are shared by the sessions, so they are kept when
+// a session closes its statement, and they are evicted by the LRU instead.
+func (c *InstancePlanCache) Delete(_ kvcache.Key) {}
+
+// DeleteAll implements the PlanCache interface.
+func (c *InstancePlanCache) DeleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removeAll()
+	c.memTracker = newTrackerForInstancePC()
+}
+
+// DeleteSessionPlans deletes the plans put into the cache by the session of connID, it's used by
+// `admin flush session plan_cache`, which must not affect the plans of the other sessions.
+func (c *InstancePlanCache) DeleteSessionPlans(connID uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for element := c.lruList.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*planCacheEntry)
+		if key, ok := entry.PlanKey.(*planCacheKey); ok && key.ownerConnID == connID {
+			c.memTracker.Consume(-entry.MemoryUsage())
+			c.lruList.Remove(element)
+			c.removeFromBucket(element)
+			c.size--
+		}
+		element = next
+	}
+}
+
+// SetCapacity implements the PlanCache interface. The capacity of the session plan caches is
+// not applied to the instance plan cache.
+func (c *InstancePlanCache) SetCapacity(_ uint) error {
+	return nil
+}
+
+// flush clears the cache if it's not cleared since the `admin flush instance plan_cache` executed at ts.
+// Every session handles the flush once, so the cache must not be cleared again by the sessions handling it later.
+func (c *InstancePlanCache) flush(ts types.Time) {
+	c.lock.Lock()
+	flushed := ts.Compare(c.flushTS) <= 0
+	c.flushTS = ts
+	c.lock.Unlock()
+	if !flushed {
+		c.DeleteAll()
+	}
+}
+
+// newTrackerForInstancePC returns the tracker of the instance plan cache.
+func newTrackerForInstancePC() *memory.Tracker {
+	return memory.NewTracker(memory.LabelForInstancePlanCache, int64(variable.InstancePlanCacheMaxMemSize.Load()))
+}
+
+// instancePlanCacheable checks whether the plan can be shared by the sessions. Only the operators which can be
+// cloned and bound to another session are supported.
+func instancePlanCacheable(p PhysicalPlan) bool {
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		if !instancePlanCacheable(x.tablePlan) {
+			return false
+		}
+	case *PhysicalIndexReader:
+		if !instancePlanCacheable(x.indexPlan) {
+			return false
+		}
+	case *PhysicalIndexLookUpReader:
+		if !instancePlanCacheable(x.indexPlan) || !instancePlanCacheable(x.tablePlan) {
+			return false
+		}
+	case *PhysicalTableScan:
+		if x.Table.GetPartitionInfo() != nil {
+			return false
+		}
+	case *PhysicalIndexScan:
+		if x.Table.GetPartitionInfo() != nil || len(x.GenExprs) > 0 {
+			return false
+		}
+	case *PhysicalSelection, *PhysicalProjection, *PhysicalLimit, *PhysicalTopN, *PhysicalSort,
+		*PhysicalHashAgg, *PhysicalStreamAgg:
+	default:
+		return false
+	}
+	for _, child := range p.Children() {
+		if !instancePlanCacheable(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// clonePlan4Session clones the plan in the instance plan cache and binds the clone to the session.
+func clonePlan4Session(sctx sessionctx.Context, cached *PlanCacheValue) (*PlanCacheValue, error) {
+	cloned, err := cached.Plan.(PhysicalPlan).Clone()
+	if err != nil {
+		return nil, err
+	}
+	bindPlan2Session(sctx, cloned)
+	return &PlanCacheValue{
+		Plan:              cloned,
+		OutPutNames:       cached.OutPutNames,
+		TblInfo2UnionScan: cached.TblInfo2UnionScan,
+		ParamTypes:        cached.ParamTypes,
+	}, nil
+}
+
+// bindPlan2Session binds the cloned plan and its expressions to the session.
+func bindPlan2Session(sctx sessionctx.Context, p PhysicalPlan) {
+	p.(interface{ SetSCtx(sessionctx.Context) }).SetSCtx(sctx)
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		// The plans are cloned separately from the plan tree, so they are rebuilt from the cloned tree.
+		x.TablePlans = flattenPushDownPlan(x.tablePlan)
+		bindPlan2Session(sctx, x.tablePlan)
+	case *PhysicalIndexReader:
+		x.IndexPlans = flattenPushDownPlan(x.indexPlan)
+		bindPlan2Session(sctx, x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		x.IndexPlans = flattenPushDownPlan(x.indexPlan)
+		x.TablePlans = flattenPushDownPlan(x.tablePlan)
+		bindPlan2Session(sctx, x.indexPlan)
+		bindPlan2Session(sctx, x.tablePlan)
+	case *PhysicalTableScan:
+		expression.SetCtxForExprs(sctx, x.AccessCondition)
+		expression.SetCtxForExprs(sctx, x.filterCondition)
+	case *PhysicalIndexScan:
+		expression.SetCtxForExprs(sctx, x.AccessCondition)
+	case *PhysicalSelection:
+		expression.SetCtxForExprs(sctx, x.Conditions)
+	case *PhysicalProjection:
+		expression.SetCtxForExprs(sctx, x.Exprs)
+	case *PhysicalTopN:
+		for _, item := range x.ByItems {
+			expression.SetCtxForExprs(sctx, []expression.Expression{item.Expr})
+		}
+	case *PhysicalSort:
+		for _, item := range x.ByItems {
+			expression.SetCtxForExprs(sctx, []expression.Expression{item.Expr})
+		}
+	case *PhysicalHashAgg:
+		bindAggFuncs2Session(sctx, x.AggFuncs)
+		expression.SetCtxForExprs(sctx, x.GroupByItems)
+	case *PhysicalStreamAgg:
+		bindAggFuncs2Session(sctx, x.AggFuncs)
+		expression.SetCtxForExprs(sctx, x.GroupByItems)
+	}
+	for _, child := range p.Children() {
+		bindPlan2Session(sctx, child)
+	}
+}
+
+func bindAggFuncs2Session(sctx sessionctx.Context, aggFuncs []*aggregation.AggFuncDesc) {
+	for _, aggFunc := range aggFuncs {
+		expression.SetCtxForExprs(sctx, aggFunc.Args)
+		for _, item := range aggFunc.OrderByItems {
+			expression.SetCtxForExprs(sctx, []expression.Expression{item.Expr})
+		}
+	}
+}
@@ -162,12 +162,17 @@ func (l *LRUPlanCache) DeleteAll() {
 	l.lock.Lock()
 	defer l.lock.Unlock()
 
+	l.removeAll()
+	l.memTracker = newTrackerForLRUPC()
+}
+
+// removeAll removes all elements from the LRU Cache, the caller should hold the lock.
+func (l *LRUPlanCache) removeAll() {
 	for lru := l.lruList.Back(); lru != nil; lru = l.lruList.Back() {
 		l.lruList.Remove(lru)
 		l.size--
 	}
 	l.buckets = make(map[string]map[*list.Element]struct{}, 1)
-	l.memTracker = newTrackerForLRUPC()
 }
 
 // Size gets the current cache size.
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/sessionctx"
@@ -44,6 +45,9 @@ var (
 		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, buf)
 		return restoreCtx
 	}}
+	paramParserPool = sync.Pool{New: func() interface{} {
+		return parser.New()
+	}}
 )
 
 type paramReplacer struct {
@@ -88,6 +92,40 @@ func ParameterizeAST(sctx sessionctx.Context, stmt ast.StmtNode) (paramSQL strin
 	return
 }
 
+// GetParamSQLFromAST returns the parameterized SQL of this StmtNode and its parameters.
+// Unlike ParameterizeAST, the input stmt is kept unchanged.
+// e.g. `select * from t where a<10 and b<23` --> `select * from t where a<? and b<?`, [10, 23].
+func GetParamSQLFromAST(sctx sessionctx.Context, stmt ast.StmtNode) (paramSQL string, params []*driver.ValueExpr, err error) {
+	paramSQL, params, err = ParameterizeAST(sctx, stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = RestoreASTWithParams(sctx, stmt, params); err != nil {
+		return "", nil, err
+	}
+	return paramSQL, params, nil
+}
+
+// ParseParameterizedSQL parses the parameterized SQL generated by GetParamSQLFromAST into a new StmtNode,
+// which is used to build the PlanCacheStmt of the general plan cache.
+func ParseParameterizedSQL(sctx sessionctx.Context, paramSQL string) (ast.StmtNode, error) {
+	p := paramParserPool.Get().(*parser.Parser)
+	defer paramParserPool.Put(p)
+	vars := sctx.GetSessionVars()
+	p.SetSQLMode(vars.SQLMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	// The parameterized SQL is restored in UTF-8, so the client charset mustn't be applied again.
+	charset, collation := vars.GetCharsetInfo()
+	stmts, _, err := p.ParseSQL(paramSQL, parser.CharsetConnection(charset), parser.CollationConnection(collation))
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) != 1 {
+		return nil, errors.New("the parameterized SQL should contain exactly one statement")
+	}
+	return stmts[0], nil
+}
+
 type paramRestorer struct {
 	params []*driver.ValueExpr
 	err    error
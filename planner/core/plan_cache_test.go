@@ -22,7 +22,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/mysql"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/testkit"
@@ -112,6 +114,147 @@ func TestGeneralPlanCacheBasically(t *testing.T) {
 	}
 }
 
+func TestGeneralPlanCacheStmtSummary(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set global tidb_enable_stmt_summary = 0")
+	tk.MustExec("set global tidb_enable_stmt_summary = 1")
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, key(b))`)
+	tk.MustExec(`insert into t values (1, 1), (2, 2), (3, 3)`)
+	tk.MustExec(`set tidb_enable_general_plan_cache=1`)
+
+	tk.MustQuery("select * from t where a<2").Check(testkit.Rows("1 1"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t where a<3").Sort().Check(testkit.Rows("1 1", "2 2"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t where a<4").Sort().Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk.MustQuery("select exec_count, plan_cache_hits, plan_in_cache from information_schema.statements_summary where digest_text='select * from `t` where `a` < ?'").
+		Check(testkit.Rows("3 2 1"))
+
+	tk.MustQuery("select a from t where b in (1, 3) and a != 1").Check(testkit.Rows("3"))
+	tk.MustQuery("select a from t where b in (2, 3) and a != 3").Check(testkit.Rows("2"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where b between 1 and 2 and a is not null").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select a from t where b between 2 and 3 and a is not null").Sort().Check(testkit.Rows("2", "3"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// The statements with the unsupported expressions don't use the plan cache.
+	tk.MustQuery("select a+1 from t where a<2").Check(testkit.Rows("2"))
+	tk.MustQuery("select a+1 from t where a<2").Check(testkit.Rows("2"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	tk.MustExec(`set tidb_enable_general_plan_cache=0`)
+	tk.MustQuery("select * from t where a<2").Check(testkit.Rows("1 1"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+}
+
+func TestGeneralPlanCacheParams(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int)`)
+	tk.MustExec(`insert into t values (1, 1), (2, 2), (3, 3)`)
+	tk.MustExec(`set tidb_enable_general_plan_cache=1`)
+
+	tk.MustQuery("select * from t where a<2 and b>0").Check(testkit.Rows("1 1"))
+	require.Len(t, tk.Session().GetSessionVars().PreparedParams, 2)
+	tk.MustQuery("select * from t where a<4 and b>1").Sort().Check(testkit.Rows("2 2", "3 3"))
+	// The parameter markers of the cached plan read the constants of the current statement from PreparedParams.
+	params := tk.Session().GetSessionVars().PreparedParams
+	require.Len(t, params, 2)
+	require.Equal(t, int64(4), params[0].GetInt64())
+	require.Equal(t, int64(1), params[1].GetInt64())
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+}
+
+func TestInstancePlanCache(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk1.MustExec(`set global tidb_enable_instance_plan_cache=1`)
+	defer tk1.MustExec(`set global tidb_enable_instance_plan_cache=0`)
+	tk1.MustExec(`use test`)
+	tk2.MustExec(`use test`)
+	tk1.MustExec(`create table t (a int, b int, key(b))`)
+	tk1.MustExec(`insert into t values (1, 1), (2, 2), (3, 3)`)
+	plannercore.GetInstancePlanCache().DeleteAll()
+
+	tk1.MustExec(`prepare st from 'select a from t where b < ? order by a'`)
+	tk1.MustExec(`set @b=2`)
+	tk1.MustQuery(`execute st using @b`).Check(testkit.Rows("1"))
+	tk1.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	require.Equal(t, 1, plannercore.GetInstancePlanCache().Size())
+
+	// The plan generated by tk1 is used by tk2 with its own parameters.
+	tk2.MustExec(`prepare st from 'select a from t where b < ? order by a'`)
+	tk2.MustExec(`set @b=4`)
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk1.MustQuery(`execute st using @b`).Check(testkit.Rows("1"))
+	tk1.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// The shared plan is kept when a session closes the statement.
+	tk1.MustExec(`deallocate prepare st`)
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// The sessions with different optimizer variables don't share the plans.
+	tk2.MustExec(`set tidb_opt_agg_push_down=1`)
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	require.Equal(t, 2, plannercore.GetInstancePlanCache().Size())
+	tk2.MustExec(`set tidb_opt_agg_push_down=0`)
+	tk2.MustExec(`set div_precision_increment=8`)
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	require.Equal(t, 3, plannercore.GetInstancePlanCache().Size())
+
+	// The plans are evicted when the memory limit is exceeded.
+	tk1.MustExec(`set global tidb_instance_plan_cache_max_mem_size=1`)
+	defer tk1.MustExec(`set global tidb_instance_plan_cache_max_mem_size=default`)
+	tk2.MustExec(`set div_precision_increment=default`)
+	tk2.MustExec(`prepare st from 'select a from t where b > ?'`)
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows())
+	require.Equal(t, 0, plannercore.GetInstancePlanCache().Size())
+}
+
+func TestInstancePlanCacheFlush(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk1.MustExec(`set global tidb_enable_instance_plan_cache=1`)
+	defer tk1.MustExec(`set global tidb_enable_instance_plan_cache=0`)
+	tk1.MustExec(`use test`)
+	tk2.MustExec(`use test`)
+	tk1.MustExec(`create table t (a int, b int, key(b))`)
+	tk1.MustExec(`create user u1`)
+	tk1.MustExec(`grant select on test.* to u1`)
+	plannercore.GetInstancePlanCache().DeleteAll()
+
+	tk1.MustExec(`prepare st from 'select a from t where b < ?'`)
+	tk2.MustExec(`prepare st from 'select a from t where b > ?'`)
+	tk1.MustExec(`set @b=1`)
+	tk2.MustExec(`set @b=1`)
+	tk1.MustQuery(`execute st using @b`).Check(testkit.Rows())
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows())
+	require.Equal(t, 2, plannercore.GetInstancePlanCache().Size())
+
+	// The session scope only flushes the plans of the session.
+	tk1.MustExec(`admin flush session plan_cache`)
+	require.Equal(t, 1, plannercore.GetInstancePlanCache().Size())
+	tk2.MustQuery(`execute st using @b`).Check(testkit.Rows())
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// The instance scope needs the SUPER privilege.
+	tk3 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk3.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil))
+	tk3.MustGetErrCode(`admin flush instance plan_cache`, errno.ErrPrivilegeCheckFail)
+	require.Equal(t, 1, plannercore.GetInstancePlanCache().Size())
+	tk1.MustExec(`admin flush instance plan_cache`)
+	require.Equal(t, 0, plannercore.GetInstancePlanCache().Size())
+}
+
 func TestIssue38269(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
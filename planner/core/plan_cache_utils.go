@@ -64,7 +64,8 @@ func (e *paramMarkerExtractor) Leave(in ast.Node) (ast.Node, bool) {
 }
 
 // GeneratePlanCacheStmtWithAST generates the PlanCacheStmt structure for this AST.
-func GeneratePlanCacheStmtWithAST(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode) (*PlanCacheStmt, Plan, int, error) {
+// isGeneralPlanCache indicates whether the statement is cached by the general plan cache or the prepared plan cache.
+func GeneratePlanCacheStmtWithAST(ctx context.Context, sctx sessionctx.Context, isGeneralPlanCache bool, stmt ast.StmtNode) (*PlanCacheStmt, Plan, int, error) {
 	vars := sctx.GetSessionVars()
 	var extractor paramMarkerExtractor
 	stmt.Accept(&extractor)
@@ -114,7 +115,11 @@ func GeneratePlanCacheStmtWithAST(ctx context.Context, sctx sessionctx.Context,
 		normalizedSQL4PC, digest4PC string
 		selectStmtNode              ast.StmtNode
 	)
-	if !vars.EnablePreparedPlanCache {
+	enablePlanCache := vars.EnablePreparedPlanCache
+	if isGeneralPlanCache {
+		enablePlanCache = vars.EnableGeneralPlanCache
+	}
+	if !enablePlanCache {
 		prepared.UseCache = false
 	} else {
 		prepared.UseCache = CacheableWithCtx(sctx, stmt, ret.InfoSchema)
@@ -196,7 +201,12 @@ type planCacheKey struct {
 	restrictedReadOnly       bool
 	TiDBSuperReadOnly        bool
 
-	memoryUsage int64 // Do not include in hash
+	// user and sysVars are only set when the plans are shared by the sessions, see InstancePlanCache.
+	user    string
+	sysVars string
+
+	ownerConnID uint64 // the connection putting the plan into the instance plan cache, do not include in hash
+	memoryUsage int64  // Do not include in hash
 	hash        []byte
 }
 
@@ -231,6 +241,8 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.inRestrictedSQL))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.restrictedReadOnly))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.TiDBSuperReadOnly))...)
+		key.hash = append(key.hash, hack.Slice(key.user)...)
+		key.hash = append(key.hash, hack.Slice(key.sysVars)...)
 	}
 	return key.hash
 }
@@ -246,7 +258,7 @@ func (key *planCacheKey) MemoryUsage() (sum int64) {
 	if key.memoryUsage > 0 {
 		return key.memoryUsage
 	}
-	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)) +
+	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.user)+len(key.sysVars)) +
 		int64(len(key.isolationReadEngines))*size.SizeOfUint8 + int64(cap(key.hash))
 	key.memoryUsage = sum
 	return
@@ -304,6 +316,16 @@ func NewPlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string,
 	for k, v := range sessionVars.IsolationReadEngines {
 		key.isolationReadEngines[k] = v
 	}
+	if variable.EnableInstancePlanCache.Load() {
+		// The plans are shared by the sessions, so the key is related to the user and the variables
+		// affecting the plans instead of the connection.
+		key.ownerConnID = key.connID
+		key.connID = 0
+		if sessionVars.User != nil {
+			key.user = sessionVars.User.AuthUsername + "@" + sessionVars.User.AuthHostname
+		}
+		key.sysVars = instancePlanCacheSysVarsValue(sessionVars)
+	}
 	return key, nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/sessionctx"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	"github.com/pingcap/tidb/util/logutil"
@@ -163,10 +164,15 @@ func GeneralPlanCacheable(node ast.Node, is infoschema.InfoSchema) bool {
 	return GeneralPlanCacheableWithCtx(nil, node, is)
 }
 
+// maxGeneralPlanCacheParams is the max number of the constants in a statement which can be cached by the general
+// plan cache, the statements with too many constants, like the ones with long IN lists, are seldom reused.
+const maxGeneralPlanCacheParams = 200
+
 // GeneralPlanCacheableWithCtx checks whether the input ast is cacheable for general plan cache.
 // Only support: select {field} from {single-table} where {cond} and {cond} ...
-// {cond}: {col} {op} {val}
-// {op}: >, <, =
+// {field}: {col} or *
+// {cond}: {col} {op} {val}, {col} [not] in ({val}, ...), {col} [not] between {val} and {val}, {col} is [not] null
+// {op}: >, <, =, >=, <=, !=
 func GeneralPlanCacheableWithCtx(sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) bool {
 	selectStmt, isSelect := node.(*ast.SelectStmt)
 	if !isSelect { // only support select statement now
@@ -198,6 +204,8 @@ func GeneralPlanCacheableWithCtx(sctx sessionctx.Context, node ast.Node, is info
 		if !isTableName {
 			return false
 		}
+	default:
+		return false
 	}
 
 	checker := generalPlanCacheableChecker{
@@ -215,19 +223,50 @@ type generalPlanCacheableChecker struct {
 	sctx      sessionctx.Context
 	cacheable bool
 	schema    infoschema.InfoSchema
+
+	numParams int
 }
 
 // Enter implements Visitor interface.
 func (checker *generalPlanCacheableChecker) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 	switch node := in.(type) {
+	case *ast.SelectStmt, *ast.FieldList, *ast.TableRefsClause, *ast.Join, *ast.TableSource,
+		*ast.ColumnNameExpr, *ast.ColumnName, *ast.ParenthesesExpr, *ast.IsNullExpr, *ast.BetweenExpr:
+		return in, false
+	case *ast.SelectField:
+		if node.WildCard == nil {
+			// The constants in the field list decide the names of the output columns, they can't be parameterized.
+			if _, isColumn := node.Expr.(*ast.ColumnNameExpr); !isColumn {
+				checker.cacheable = false
+				return in, true
+			}
+		}
+		return in, false
+	case *driver.ValueExpr:
+		checker.numParams++
+		if checker.numParams > maxGeneralPlanCacheParams {
+			checker.cacheable = false
+			return in, true
+		}
+		return in, false
 	case *ast.BinaryOperationExpr:
 		if _, found := expression.GeneralPlanCacheableOp[node.Op.String()]; !found {
 			checker.cacheable = false
 			return in, true
 		}
-	case *ast.FuncCallExpr:
-		checker.cacheable = false
-		return in, true
+		return in, false
+	case *ast.UnaryOperationExpr:
+		if node.Op != opcode.Minus && node.Op != opcode.Not && node.Op != opcode.Not2 {
+			checker.cacheable = false
+			return in, true
+		}
+		return in, false
+	case *ast.PatternInExpr:
+		if node.Sel != nil {
+			checker.cacheable = false
+			return in, true
+		}
+		return in, false
 	case *ast.TableName:
 		if checker.schema != nil {
 			if isPartitionTable(checker.schema, node) {
@@ -243,8 +282,11 @@ func (checker *generalPlanCacheableChecker) Enter(in ast.Node) (out ast.Node, sk
 				return in, true
 			}
 		}
+		return in, false
 	}
-	return in, false
+	// The other nodes, like the functions, sub-queries and variables, aren't supported now.
+	checker.cacheable = false
+	return in, true
 }
 
 // Leave implements Visitor interface.
@@ -273,6 +273,9 @@ func TestGeneralPlanCacheable(t *testing.T) {
 		"select * from t where a in (1, 2, 3)",
 		"select * from t where a<13 or b<15",
 		"select * from t where a<13 or b<15 and c=13",
+		"select a, b from t where a!=1 and b is not null",
+		"select * from t where a between 1 and 10 and b not in (1, 2)",
+		"select * from t where a>-1",
 	}
 
 	unsupported := []string{
@@ -292,6 +295,12 @@ func TestGeneralPlanCacheable(t *testing.T) {
 		"select * from t where a+b=13",      // '+'
 		"select * from t where mod(a, 3)=1", // mod
 		"select * from t where d>now()",     // now
+
+		"select a+1 from t where a<1",                                // expression in field list
+		"select 1 from t where a<1",                                  // constant in field list
+		"select * from t where a=@a",                                 // variable
+		"select * from t where a in (select a from t1)",              // sub-query
+		"with cte as (select * from t1) select * from cte where a<1", // cte
 	}
 
 	for _, q := range unsupported {
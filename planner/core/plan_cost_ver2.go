@@ -27,6 +27,11 @@ import (
 	"github.com/pingcap/tidb/sessionctx/variable"
 )
 
+// GetPlanCost returns the cost of the physical plan when it is executed as a root task.
+func GetPlanCost(p PhysicalPlan) (float64, error) {
+	return getPlanCost(p, property.RootTaskType, NewDefaultPlanCostOption())
+}
+
 func getPlanCost(p PhysicalPlan, taskType property.TaskType, option *PlanCostOption) (float64, error) {
 	if p.SCtx().GetSessionVars().CostModelVersion == modelVer2 {
 		planCost, err := p.getPlanCostVer2(taskType, option)
@@ -576,6 +576,10 @@ type PlanBuilder struct {
 
 	// disableSubQueryPreprocessing indicates whether to pre-process uncorrelated sub-queries in rewriting stage.
 	disableSubQueryPreprocessing bool
+
+	// allowBuildCastArray indicates whether allow cast(... as ... array), it's only used by the generated
+	// expression of multi-valued index.
+	allowBuildCastArray bool
 }
 
 type handleColHelper struct {
@@ -807,7 +811,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		return b.buildAnalyze(x)
 	case *ast.BinlogStmt, *ast.FlushStmt, *ast.UseStmt, *ast.BRIEStmt,
 		*ast.BeginStmt, *ast.CommitStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt, *ast.RollbackStmt, *ast.CreateUserStmt, *ast.SetPwdStmt, *ast.AlterInstanceStmt,
-		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
+		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt, *ast.LockStatsStmt, *ast.UnlockStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
@@ -1177,6 +1181,21 @@ func getLatestIndexInfo(ctx sessionctx.Context, id int64, startVer int64) (map[i
 	return latestIndexes, true, nil
 }
 
+// getHypoIndexOfTable returns the hypothetical index with the column offsets in the current table. The table may be
+// altered after the hypothetical index is created, so the columns are matched by name, and nil is returned if any of
+// them doesn't exist in the table any more.
+func getHypoIndexOfTable(tblInfo *model.TableInfo, index *model.IndexInfo) *model.IndexInfo {
+	index = index.Clone()
+	for _, idxCol := range index.Columns {
+		col := model.FindColumnInfo(tblInfo.Columns, idxCol.Name.L)
+		if col == nil || col.State != model.StatePublic {
+			return nil
+		}
+		idxCol.Offset = col.Offset
+	}
+	return index
+}
+
 func getPossibleAccessPaths(ctx sessionctx.Context, tableHints *tableHintInfo, indexHints []*ast.IndexHint, tbl table.Table, dbName, tblName model.CIStr, check bool, _ int64) ([]*util.AccessPath, error) {
 	tblInfo := tbl.Meta()
 	publicPaths := make([]*util.AccessPath, 0, len(tblInfo.Indices)+2)
@@ -1226,6 +1245,18 @@ func getPossibleAccessPaths(ctx sessionctx.Context, tableHints *tableHintInfo, i
 			publicPaths = append(publicPaths, &util.AccessPath{Index: index})
 		}
 	}
+	// Hypothetical indexes are only seen when explaining a statement without executing it, so they are never used
+	// for execution. The plan is not cached either, otherwise a later execution may pick it up.
+	if stmtCtx := ctx.GetSessionVars().StmtCtx; stmtCtx.InExplainStmt && !stmtCtx.InExplainAnalyzeStmt {
+		for _, hypoIndex := range ctx.GetSessionVars().HypoIndexes[tblInfo.ID] {
+			index := getHypoIndexOfTable(tblInfo, hypoIndex)
+			if index == nil {
+				continue
+			}
+			stmtCtx.SkipPlanCache = true
+			publicPaths = append(publicPaths, &util.AccessPath{Index: index, IsHypo: true})
+		}
+	}
 
 	hasScanHint, hasUseOrForce := false, false
 	available := make([]*util.AccessPath, 0, len(publicPaths))
@@ -1533,6 +1564,10 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 	case ast.AdminReloadStatistics:
 		return &Simple{Statement: as}, nil
 	case ast.AdminFlushPlanCache:
+		if as.StatementScope == ast.StatementScopeInstance {
+			// Flushing the plan cache of the instance affects the other sessions.
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
+		}
 		return &Simple{Statement: as}, nil
 	default:
 		return nil, ErrUnsupportedType.GenWithStack("Unsupported ast.AdminStmt(%T) for buildAdmin", as)
@@ -1717,6 +1752,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.MVIndex {
+			// Skip checking multi-valued index, its entries can't be compared with the rows one by one.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -1870,7 +1909,7 @@ func getColsInfo(tn *ast.TableName) (indicesInfo []*model.IndexInfo, colsInfo []
 		colsInfo = append(colsInfo, col)
 	}
 	for _, idx := range tn.TableInfo.Indices {
-		if idx.State == model.StatePublic {
+		if idx.State == model.StatePublic && !idx.MVIndex {
 			indicesInfo = append(indicesInfo, idx)
 		}
 	}
@@ -1932,6 +1971,33 @@ func BuildHandleColsForAnalyze(ctx sessionctx.Context, tblInfo *model.TableInfo,
 	return handleCols
 }
 
+// filterLockedStats removes the physical tables whose statistics are locked, they are skipped by ANALYZE with a warning.
+func (b *PlanBuilder) filterLockedStats(tblName string, physicalIDs []int64, names []string) ([]int64, []string, error) {
+	statsHandle := domain.GetDomain(b.ctx).StatsHandle()
+	if statsHandle == nil {
+		return physicalIDs, names, nil
+	}
+	lockedTables, err := statsHandle.GetLockedTables()
+	if err != nil || len(lockedTables) == 0 {
+		return physicalIDs, names, err
+	}
+	ids := make([]int64, 0, len(physicalIDs))
+	partitionNames := make([]string, 0, len(names))
+	for i, id := range physicalIDs {
+		if _, ok := lockedTables[id]; !ok {
+			ids = append(ids, id)
+			partitionNames = append(partitionNames, names[i])
+			continue
+		}
+		name := tblName
+		if names[i] != "" {
+			name = fmt.Sprintf("%s partition (%s)", tblName, names[i])
+		}
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("skip analyze locked table: %s", name))
+	}
+	return ids, partitionNames, nil
+}
+
 // GetPhysicalIDsAndPartitionNames returns physical IDs and names of these partitions.
 func GetPhysicalIDsAndPartitionNames(tblInfo *model.TableInfo, partitionNames []model.CIStr) ([]int64, []string, error) {
 	pi := tblInfo.GetPartitionInfo()
@@ -2175,7 +2241,8 @@ func getColOffsetForAnalyze(colsInfo []*model.ColumnInfo, colID int64) int {
 func getModifiedIndexesInfoForAnalyze(tblInfo *model.TableInfo, allColumns bool, colsInfo []*model.ColumnInfo) []*model.IndexInfo {
 	idxsInfo := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
 	for _, originIdx := range tblInfo.Indices {
-		if originIdx.State != model.StatePublic {
+		// The statistics of the multi-valued index can't be built from the row samples yet.
+		if originIdx.State != model.StatePublic || originIdx.MVIndex {
 			continue
 		}
 		if allColumns {
@@ -2451,6 +2518,13 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 		if err != nil {
 			return nil, err
 		}
+		physicalIDs, names, err = b.filterLockedStats(tbl.Name.O, physicalIDs, names)
+		if err != nil {
+			return nil, err
+		}
+		if len(physicalIDs) == 0 {
+			continue
+		}
 		var commonHandleInfo *model.IndexInfo
 		// If we want to analyze this table with analyze version 2 but the existing stats is version 1 and stats feedback is enabled,
 		// we will switch back to analyze version 1.
@@ -2536,6 +2610,10 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 	if err != nil {
 		return nil, err
 	}
+	physicalIDs, names, err = b.filterLockedStats(tblInfo.Name.O, physicalIDs, names)
+	if err != nil || len(physicalIDs) == 0 {
+		return p, err
+	}
 	statsHandle := domain.GetDomain(b.ctx).StatsHandle()
 	if statsHandle == nil {
 		return nil, errors.Errorf("statistics hasn't been initialized, please try again later")
@@ -2601,6 +2679,10 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 	if err != nil {
 		return nil, err
 	}
+	physicalIDs, names, err = b.filterLockedStats(tblInfo.Name.O, physicalIDs, names)
+	if err != nil || len(physicalIDs) == 0 {
+		return p, err
+	}
 	statsHandle := domain.GetDomain(b.ctx).StatsHandle()
 	if statsHandle == nil {
 		return nil, errors.Errorf("statistics hasn't been initialized, please try again later")
@@ -3108,7 +3190,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 		p.setSchemaAndNames(buildShowNextRowID())
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, show.Table.Schema.L, show.Table.Name.L, "", ErrPrivilegeCheckFail)
 		return p, nil
-	case ast.ShowStatsExtended, ast.ShowStatsHealthy, ast.ShowStatsTopN, ast.ShowHistogramsInFlight, ast.ShowColumnStatsUsage:
+	case ast.ShowStatsExtended, ast.ShowStatsHealthy, ast.ShowStatsLocked, ast.ShowStatsTopN, ast.ShowHistogramsInFlight, ast.ShowColumnStatsUsage:
 		var err error
 		if user := b.ctx.GetSessionVars().User; user != nil {
 			err = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, mysql.SystemDB)
@@ -3196,6 +3278,10 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	case *ast.FlushStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("RELOAD")
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ReloadPriv, "", "", "", err)
+	case *ast.LockStatsStmt:
+		b.visitInfo = collectVisitInfoFromStatsTables(b.ctx, b.visitInfo, raw.Tables)
+	case *ast.UnlockStatsStmt:
+		b.visitInfo = collectVisitInfoFromStatsTables(b.ctx, b.visitInfo, raw.Tables)
 	case *ast.AlterInstanceStmt:
 		err := ErrSpecificAccessDenied.GenWithStack("SUPER")
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
@@ -3352,6 +3438,22 @@ func appendVisitInfoIsRestrictedUser(visitInfo []visitInfo, sctx sessionctx.Cont
 	return visitInfo
 }
 
+// collectVisitInfoFromStatsTables appends the privileges required to lock or unlock the statistics of the tables,
+// which are the same as ANALYZE TABLE.
+func collectVisitInfoFromStatsTables(sctx sessionctx.Context, vi []visitInfo, tables []*ast.TableName) []visitInfo {
+	user := sctx.GetSessionVars().User
+	for _, tbl := range tables {
+		var insertErr, selectErr error
+		if user != nil {
+			insertErr = ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername, user.AuthHostname, tbl.Name.O)
+			selectErr = ErrTableaccessDenied.GenWithStackByArgs("SELECT", user.AuthUsername, user.AuthHostname, tbl.Name.O)
+		}
+		vi = appendVisitInfo(vi, mysql.InsertPriv, tbl.Schema.O, tbl.Name.O, "", insertErr)
+		vi = appendVisitInfo(vi, mysql.SelectPriv, tbl.Schema.O, tbl.Name.O, "", selectErr)
+	}
+	return vi
+}
+
 func collectVisitInfoFromGrantStmt(sctx sessionctx.Context, vi []visitInfo, stmt *ast.GrantStmt) ([]visitInfo, error) {
 	// To use GRANT, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -3460,7 +3562,9 @@ func (b *PlanBuilder) resolveGeneratedColumns(ctx context.Context, columns []*ta
 		}
 		colExpr := mockPlan.Schema().Columns[idx]
 
+		b.allowBuildCastArray = true
 		expr, _, err := b.rewrite(ctx, column.GeneratedExpr, mockPlan, nil, true)
+		b.allowBuildCastArray = false
 		if err != nil {
 			return igc, err
 		}
@@ -3527,11 +3631,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		givenPartitionSets := make(map[int64]struct{}, len(insert.PartitionNames))
 		// check partition by name.
 		for _, name := range insert.PartitionNames {
-			id, err := tables.FindPartitionByName(tableInfo, name.L)
+			ids, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 			if err != nil {
 				return nil, err
 			}
-			givenPartitionSets[id] = struct{}{}
+			for _, id := range ids {
+				givenPartitionSets[id] = struct{}{}
+			}
 		}
 		pt := tableInPlan.(table.PartitionedTable)
 		insertPlan.Table = tables.NewPartitionTableWithGivenSets(pt, givenPartitionSets)
@@ -4003,6 +4109,9 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 	if ld.FieldsInfo != nil && len(ld.FieldsInfo.Terminated) == 0 {
 		return nil, ErrNotSupportedYet.GenWithStackByArgs("load data with empty field terminator")
 	}
+	if !ld.IsLocal && sem.IsEnabled() {
+		return nil, ErrNotSupportedWithSem.GenWithStackByArgs("LOAD DATA INFILE")
+	}
 	p := LoadData{
 		IsLocal:            ld.IsLocal,
 		OnDuplicate:        ld.OnDuplicate,
@@ -4025,6 +4134,10 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 	if p.OnDuplicate == ast.OnDuplicateKeyHandlingReplace {
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, p.Table.Schema.O, p.Table.Name.O, "", deleteErr)
 	}
+	if !p.IsLocal {
+		// the files are read by the server, which requires the FILE privilege like SELECT ... INTO OUTFILE.
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	}
 	tableInfo := p.Table.TableInfo
 	tableInPlan, ok := b.is.TableByID(tableInfo.ID)
 	if !ok {
@@ -4573,6 +4686,9 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 	case *ast.DropPlacementPolicyStmt, *ast.CreatePlacementPolicyStmt, *ast.AlterPlacementPolicyStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or PLACEMENT_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "PLACEMENT_ADMIN", false, err)
+	case *ast.CreateResourceGroupStmt, *ast.DropResourceGroupStmt, *ast.AlterResourceGroupStmt:
+		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
+		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
 	}
 	p := &DDL{Statement: node}
 	return p, nil
@@ -4706,14 +4822,15 @@ func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt)
 	}
 	selectIntoInfo := sel.SelectIntoOpt
 	sel.SelectIntoOpt = nil
-	targetPlan, _, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
+	targetPlan, names, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	return &SelectInto{
-		TargetPlan: targetPlan,
-		IntoOpt:    selectIntoInfo,
+		TargetPlan:  targetPlan,
+		IntoOpt:     selectIntoInfo,
+		TargetNames: names,
 	}, nil
 }
 
@@ -4914,6 +5031,9 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 	case ast.ShowStatsHealthy:
 		names = []string{"Db_name", "Table_name", "Partition_name", "Healthy"}
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong}
+	case ast.ShowStatsLocked:
+		names = []string{"Db_name", "Table_name", "Partition_name", "Status"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowHistogramsInFlight:
 		names = []string{"HistogramsInFlight"}
 		ftypes = []byte{mysql.TypeLonglong}
@@ -636,7 +636,8 @@ func newBatchPointGetPlan(
 			return nil
 		}
 
-		if partitionExpr.Expr == nil {
+		// The subpartitioned tables are not supported in BatchGet.
+		if partitionExpr.Expr == nil || partitionExpr.Sub != nil {
 			return nil
 		}
 		if _, ok := partitionExpr.Expr.(*expression.Column); !ok {
@@ -1582,11 +1583,13 @@ func buildPointUpdatePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			if len(updateTable.PartitionNames) > 0 {
 				pids := make(map[int64]struct{}, len(updateTable.PartitionNames))
 				for _, name := range updateTable.PartitionNames {
-					pid, err := tables.FindPartitionByName(tbl, name.L)
+					partIDs, err := tables.FindPartitionIDsByName(tbl, name.L)
 					if err != nil {
 						return updatePlan
 					}
-					pids[pid] = struct{}{}
+					for _, pid := range partIDs {
+						pids[pid] = struct{}{}
+					}
 				}
 				pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 			}
@@ -1746,7 +1749,8 @@ func getPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, pairs []name
 	}
 
 	pi := tbl.GetPartitionInfo()
-	if pi == nil {
+	// The KEY partitioned and subpartitioned tables are not supported in PointGet.
+	if pi == nil || pi.Sub != nil {
 		return nil, 0, 0, false
 	}
 
@@ -289,7 +289,10 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 			}
 			// We can pull up the equal conditions below the aggregation as the join key of the apply, if only
 			// the equal conditions contain the correlated column of this apply.
-			if sel, ok := agg.children[0].(*LogicalSelection); ok && apply.JoinType == LeftOuterJoin {
+			// For the inner join, e.g. the LATERAL derived table, the aggregation must have the group by items,
+			// otherwise it produces a row even if no row matches the join key.
+			canPullUpEqConds := apply.JoinType == LeftOuterJoin || (apply.JoinType == InnerJoin && len(agg.GroupByItems) > 0)
+			if sel, ok := agg.children[0].(*LogicalSelection); ok && canPullUpEqConds {
 				var (
 					eqCondWithCorCol []*expression.ScalarFunction
 					remainedExpr     []expression.Expression
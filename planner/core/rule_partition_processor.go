@@ -254,7 +254,7 @@ func (s *partitionProcessor) convertToIntSlice(or partitionRangeOR, pi *model.Pa
 	ret := make([]int, 0, len(or))
 	for i := 0; i < len(or); i++ {
 		for pos := or[i].start; pos < or[i].end; pos++ {
-			if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[pos].Name.L) {
+			if len(partitionNames) > 0 && !s.matchPartitionNames(partitionNames, &pi.Definitions[pos]) {
 				continue
 			}
 			ret = append(ret, pos)
@@ -354,6 +354,161 @@ func (s *partitionProcessor) processHashPartition(ds *DataSource, pi *model.Part
 	return tableDual, nil
 }
 
+func (s *partitionProcessor) pruneKeyPartition(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column) ([]int, error) {
+	pi := tbl.Meta().Partition
+	pe, err := tbl.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	allUsed := s.convertToIntSlice(fullRange(len(pi.Definitions)), pi, partitionNames)
+	// The key partition columns are built with a mock context, find the columns in the plan by ID.
+	keyCols := make([]*expression.Column, 0, len(pe.KeyPartCols))
+	colLen := make([]int, 0, len(pe.KeyPartCols))
+	for _, partCol := range pe.KeyPartCols {
+		var keyCol *expression.Column
+		for _, col := range columns {
+			if col.ID == partCol.ID {
+				keyCol = col.Clone().(*expression.Column)
+				break
+			}
+		}
+		if keyCol == nil {
+			return allUsed, nil
+		}
+		keyCol.Index = len(keyCols)
+		keyCols = append(keyCols, keyCol)
+		colLen = append(colLen, types.UnspecifiedLength)
+	}
+	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, keyCols, colLen, ctx.GetSessionVars().RangeMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	used := make([]int, 0, len(detachedResult.Ranges))
+	for _, r := range detachedResult.Ranges {
+		// Only the points of all the key columns can be located, the hash of a range is meaningless.
+		if !r.IsPointNullable(ctx) || len(r.LowVal) != len(keyCols) {
+			return allUsed, nil
+		}
+		idx, err := pe.LocateKeyPartition(ctx.GetSessionVars().StmtCtx, pi.Num, r.LowVal)
+		if err != nil {
+			return allUsed, nil
+		}
+		if len(partitionNames) > 0 && !s.matchPartitionNames(partitionNames, &pi.Definitions[idx]) {
+			continue
+		}
+		used = append(used, idx)
+	}
+	slices.Sort(used)
+	ret := used[:0]
+	for i := 0; i < len(used); i++ {
+		if i == 0 || used[i] != used[i-1] {
+			ret = append(ret, used[i])
+		}
+	}
+	return ret, nil
+}
+
+func (s *partitionProcessor) processKeyPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	used, err := s.pruneKeyPartition(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds, ds.TblCols)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+}
+
+// partitionLevelTable presents one level of a subpartitioned table, the parent partitions
+// and the subpartitions are pruned separately through it.
+type partitionLevelTable struct {
+	table.PartitionedTable
+	meta *model.TableInfo
+	pe   *tables.PartitionExpr
+}
+
+func newPartitionLevelTable(tbl table.PartitionedTable, pi *model.PartitionInfo, pe *tables.PartitionExpr) *partitionLevelTable {
+	meta := *tbl.Meta()
+	meta.Partition = pi
+	return &partitionLevelTable{PartitionedTable: tbl, meta: &meta, pe: pe}
+}
+
+// Meta implements the table.Table interface.
+func (t *partitionLevelTable) Meta() *model.TableInfo {
+	return t.meta
+}
+
+// PartitionExpr implements the partitionTable interface.
+func (t *partitionLevelTable) PartitionExpr() (*tables.PartitionExpr, error) {
+	return t.pe, nil
+}
+
+// pruneSubPartition prunes the parent partitions and the subpartitions separately, a subpartition
+// is used if both itself and its parent partition are used.
+func (s *partitionProcessor) pruneSubPartition(ctx sessionctx.Context, tbl table.PartitionedTable, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	pi := tbl.Meta().Partition
+	pe, err := tbl.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	parentPi := pi.ParentPartitionInfo()
+	parentUsed, err := PartitionPruning(ctx, newPartitionLevelTable(tbl, parentPi, pe), conds, nil, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	subNum := int(pi.Sub.Num)
+	subPi := &model.PartitionInfo{
+		Type:        pi.Sub.Type,
+		Expr:        pi.Sub.Expr,
+		Columns:     pi.Sub.Columns,
+		Num:         pi.Sub.Num,
+		Enable:      true,
+		Definitions: pi.Definitions[:subNum],
+	}
+	subUsed, err := PartitionPruning(ctx, newPartitionLevelTable(tbl, subPi, pe.Sub), conds, nil, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	parentUsed = expandFullRange(parentUsed, len(parentPi.Definitions))
+	subUsed = expandFullRange(subUsed, subNum)
+	used := make([]int, 0, len(parentUsed)*len(subUsed))
+	for _, i := range parentUsed {
+		for _, j := range subUsed {
+			idx := i*subNum + j
+			if len(partitionNames) > 0 && !s.matchPartitionNames(partitionNames, &pi.Definitions[idx]) {
+				continue
+			}
+			used = append(used, idx)
+		}
+	}
+	if len(used) == len(pi.Definitions) {
+		return []int{FullRange}, nil
+	}
+	return used, nil
+}
+
+func expandFullRange(used []int, num int) []int {
+	if len(used) != 1 || used[0] != FullRange {
+		return used
+	}
+	ret := make([]int, 0, num)
+	for i := 0; i < num; i++ {
+		ret = append(ret, i)
+	}
+	return ret
+}
+
+func (s *partitionProcessor) processSubPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneSubPartition(ds.SCtx(), ds.table.(table.PartitionedTable), ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+}
+
 // listPartitionPruner uses to prune partition for list partition.
 type listPartitionPruner struct {
 	*partitionProcessor
@@ -641,17 +796,22 @@ func (s *partitionProcessor) prune(ds *DataSource, opt *logicalOptimizeOp) (Logi
 	for i, cond := range ds.allConds {
 		ds.allConds[i] = expression.PushDownNot(ds.ctx, cond)
 	}
+	if pi.Sub != nil {
+		return s.processSubPartition(ds, pi, opt)
+	}
 	// Try to locate partition directly for hash partition.
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return s.processRangePartition(ds, pi, opt)
 	case model.PartitionTypeHash:
 		return s.processHashPartition(ds, pi, opt)
+	case model.PartitionTypeKey:
+		return s.processKeyPartition(ds, pi, opt)
 	case model.PartitionTypeList:
 		return s.processListPartition(ds, pi, opt)
 	}
 
-	// We haven't implement partition by key and so on.
+	// We haven't implement partition by linear hash and so on.
 	return s.makeUnionAllChildren(ds, pi, fullRange(len(pi.Definitions)), opt)
 }
 
@@ -665,6 +825,15 @@ func (s *partitionProcessor) findByName(partitionNames []model.CIStr, partitionN
 	return false
 }
 
+// matchPartitionNames checks whether the partition is specified in `partitionNames`.
+// A subpartition is also specified by the name of its parent partition.
+func (s *partitionProcessor) matchPartitionNames(partitionNames []model.CIStr, def *model.PartitionDefinition) bool {
+	if s.findByName(partitionNames, def.Name.L) {
+		return true
+	}
+	return def.ParentName.L != "" && s.findByName(partitionNames, def.ParentName.L)
+}
+
 func (*partitionProcessor) name() string {
 	return "partition_processor"
 }
@@ -1588,7 +1757,7 @@ func (s *partitionProcessor) makeUnionAllChildren(ds *DataSource, pi *model.Part
 		for i := r.start; i < r.end; i++ {
 			// This is for `table partition (p0,p1)` syntax, only union the specified partition if has specified partitions.
 			if len(ds.partitionNames) != 0 {
-				if !s.findByName(ds.partitionNames, pi.Definitions[i].Name.L) {
+				if !s.matchPartitionNames(ds.partitionNames, &pi.Definitions[i]) {
 					continue
 				}
 			}
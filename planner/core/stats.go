@@ -98,6 +98,22 @@ func getFakeStats(schema *expression.Schema) *property.StatsInfo {
 	return profile
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	// The row count can't be known before evaluating the JSON document, use a pseudo one like the table function in MySQL.
+	p.stats = &property.StatsInfo{
+		RowCount: 2,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range selfSchema.Columns {
+		p.stats.ColNDVs[col.UniqueID] = 2
+	}
+	return p.stats, nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalShowDDLJobs) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.stats != nil {
@@ -392,6 +408,7 @@ func (ds *DataSource) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema,
 	for i, expr := range ds.pushedDownConds {
 		ds.pushedDownConds[i] = expression.PushDownNot(ds.ctx, expr)
 	}
+	mvIndexPaths := ds.extractMVIndexPaths()
 	for _, path := range ds.possibleAccessPaths {
 		if path.IsTablePath() {
 			continue
@@ -408,6 +425,7 @@ func (ds *DataSource) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema,
 	if err != nil {
 		return nil, err
 	}
+	ds.generateMVIndexPaths(mvIndexPaths)
 
 	// Consider the IndexMergePath. Now, we just generate `IndexMergePath` in DNF case.
 	// Use allConds instread of pushedDownConds,
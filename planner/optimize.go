@@ -74,22 +74,36 @@ func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode) (bindRecord
 }
 
 // getPlanFromGeneralPlanCache tries to get an available cached plan from the General Plan Cache for this stmt.
+// The constants of the stmt are parameterized, so the statements which only differ in constants share the same plan.
 func getPlanFromGeneralPlanCache(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode, is infoschema.InfoSchema) (core.Plan, types.NameSlice, bool, error) {
-	if sctx.GetSessionVars().StmtCtx.InPreparedPlanBuilding || // already in cached plan rebuilding phase
+	vars := sctx.GetSessionVars()
+	stmtCtx := vars.StmtCtx
+	if stmtCtx.InPreparedPlanBuilding || // already in cached plan rebuilding phase
+		stmtCtx.InExplainStmt || // explain statements show the plan of the statement itself
+		stmtCtx.EnableOptimizeTrace || stmtCtx.EnableOptimizerCETrace || // in trace
+		vars.InRestrictedSQL || // internal SQL
+		!vars.DisableTxnAutoRetry || // the statements may be retried with their original text
 		!core.GeneralPlanCacheableWithCtx(sctx, stmt, is) {
 		return nil, nil, false, nil
 	}
-	paramSQL, params, err := core.ParameterizeAST(sctx, stmt)
+	paramSQL, params, err := core.GetParamSQLFromAST(sctx, stmt)
 	if err != nil {
 		return nil, nil, false, err
 	}
-	val := sctx.GetSessionVars().GetGeneralPlanCacheStmt(paramSQL)
+	val := vars.GetGeneralPlanCacheStmt(paramSQL)
 	if val == nil {
-		cachedStmt, _, _, err := core.GeneratePlanCacheStmtWithAST(ctx, sctx, stmt)
+		// The PlanCacheStmt is built upon a new AST of the parameterized SQL, the original AST is kept unchanged
+		// since it's still used by the executor.
+		paramStmt, err := core.ParseParameterizedSQL(sctx, paramSQL)
+		if err != nil {
+			// The restored SQL can't be parsed in rare cases, skip the plan cache for it.
+			return nil, nil, false, nil
+		}
+		cachedStmt, _, _, err := core.GeneratePlanCacheStmtWithAST(ctx, sctx, true, paramStmt)
 		if err != nil {
 			return nil, nil, false, err
 		}
-		sctx.GetSessionVars().AddGeneralPlanCacheStmt(paramSQL, cachedStmt)
+		vars.AddGeneralPlanCacheStmt(paramSQL, cachedStmt)
 		val = cachedStmt
 	}
 	cachedStmt := val.(*core.PlanCacheStmt)
@@ -558,7 +572,7 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 	}
 	hintOffs := make(map[string]int, len(hints))
 	var forceNthPlan *ast.TableOptimizerHint
-	var memoryQuotaHintCnt, useToJAHintCnt, useCascadesHintCnt, noIndexMergeHintCnt, readReplicaHintCnt, maxExecutionTimeCnt, forceNthPlanCnt, straightJoinHintCnt int
+	var memoryQuotaHintCnt, useToJAHintCnt, useCascadesHintCnt, noIndexMergeHintCnt, readReplicaHintCnt, maxExecutionTimeCnt, forceNthPlanCnt, straightJoinHintCnt, resourceGroupHintCnt int
 	setVars := make(map[string]string)
 	setVarsOffs := make([]int, 0, len(hints))
 	for i, hint := range hints {
@@ -587,6 +601,9 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 		case "straight_join":
 			hintOffs[hint.HintName.L] = i
 			straightJoinHintCnt++
+		case "resource_group":
+			hintOffs[hint.HintName.L] = i
+			resourceGroupHintCnt++
 		case "set_var":
 			setVarHint := hint.HintData.(ast.HintSetVar)
 
@@ -689,6 +706,16 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 		stmtHints.HasMaxExecutionTime = true
 		stmtHints.MaxExecutionTime = maxExecutionTime.HintData.(uint64)
 	}
+	// Handle RESOURCE_GROUP
+	if resourceGroupHintCnt != 0 {
+		resourceGroup := hints[hintOffs["resource_group"]]
+		if resourceGroupHintCnt > 1 {
+			warn := errors.Errorf("RESOURCE_GROUP() is defined more than once, only the last definition takes effect: RESOURCE_GROUP(%v)", resourceGroup.HintData.(string))
+			warns = append(warns, warn)
+		}
+		stmtHints.HasResourceGroup = true
+		stmtHints.ResourceGroup = strings.ToLower(resourceGroup.HintData.(string))
+	}
 	// Handle NTH_PLAN
 	if forceNthPlanCnt != 0 {
 		if forceNthPlanCnt > 1 {
@@ -64,6 +64,9 @@ type AccessPath struct {
 
 	// Maybe added in model.IndexInfo better, but the cache of model.IndexInfo may lead side effect
 	IsUkShardIndexPath bool
+
+	// IsHypo indicates whether the path is built on a hypothetical index, which only exists in the optimizer.
+	IsHypo bool
 }
 
 // IsTablePath returns true if it's IntHandlePath or CommonHandlePath.
@@ -93,6 +93,9 @@ type Manager interface {
 
 	// Get the authentication plugin for a user
 	GetAuthPlugin(user, host string) (string, error)
+
+	// GetUserResourceGroup gets the resource group bound to a user
+	GetUserResourceGroup(user, host string) string
 }
 
 const key keyType = 0
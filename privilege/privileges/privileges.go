@@ -54,6 +54,7 @@ var dynamicPrivs = []string{
 	"RESTRICTED_USER_ADMIN",           // User can not have their access revoked by SUPER users.
 	"RESTRICTED_CONNECTION_ADMIN",     // Can not be killed by PROCESS/CONNECTION_ADMIN privilege
 	"RESTRICTED_REPLICA_WRITER_ADMIN", // Can write to the sever even when tidb_restriced_read_only is turned on.
+	"RESOURCE_GROUP_ADMIN",            // Can Create/Drop/Alter RESOURCE GROUP
 }
 var dynamicPrivLock sync.Mutex
 
@@ -253,6 +254,20 @@ func (p *UserPrivileges) GetAuthPlugin(user, host string) (string, error) {
 	return "", errors.New("Failed to get plugin for user")
 }
 
+// GetUserResourceGroup gets the resource group bound to the account identified by the user and host.
+func (p *UserPrivileges) GetUserResourceGroup(user, host string) string {
+	if SkipWithGrant {
+		return ""
+	}
+
+	mysqlPriv := p.Handle.Get()
+	record := mysqlPriv.connectionVerification(user, host)
+	if record == nil {
+		return ""
+	}
+	return record.ResourceGroup
+}
+
 // MatchIdentity implements the Manager interface.
 func (p *UserPrivileges) MatchIdentity(user, host string, skipNameResolve bool) (u string, h string, success bool) {
 	if SkipWithGrant {
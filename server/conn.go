@@ -1584,29 +1584,6 @@ func (cc *clientConn) writeReq(ctx context.Context, filePath string) error {
 	return cc.flush(ctx)
 }
 
-func insertDataWithCommit(ctx context.Context, prevData,
-	curData []byte, loadDataInfo *executor.LoadDataInfo) ([]byte, error) {
-	var err error
-	var reachLimit bool
-	for {
-		prevData, reachLimit, err = loadDataInfo.InsertData(ctx, prevData, curData)
-		if err != nil {
-			return nil, err
-		}
-		if !reachLimit {
-			break
-		}
-		// push into commit task queue
-		err = loadDataInfo.EnqOneTask(ctx)
-		if err != nil {
-			return prevData, err
-		}
-		curData = prevData
-		prevData = nil
-	}
-	return prevData, nil
-}
-
 // processStream process input stream from network
 func processStream(ctx context.Context, cc *clientConn, loadDataInfo *executor.LoadDataInfo, wg *sync.WaitGroup) {
 	var err error
@@ -1650,7 +1627,7 @@ func processStream(ctx context.Context, cc *clientConn, loadDataInfo *executor.L
 			break
 		}
 		// prepare batch and enqueue task
-		prevData, err = insertDataWithCommit(ctx, prevData, curData, loadDataInfo)
+		prevData, err = loadDataInfo.InsertDataWithCommit(ctx, prevData, curData)
 		if err != nil {
 			break
 		}
@@ -1671,6 +1648,10 @@ func processStream(ctx context.Context, cc *clientConn, loadDataInfo *executor.L
 // handleLoadData does the additional work after processing the 'load data' query.
 // It sends client a file path, then reads the file content from client, inserts data into database.
 func (cc *clientConn) handleLoadData(ctx context.Context, loadDataInfo *executor.LoadDataInfo) error {
+	if loadDataInfo != nil && loadDataInfo.FromExternalStorage {
+		// the server reads the data from the external storage, the client is not involved.
+		return loadDataInfo.LoadFromExternalStorage(ctx)
+	}
 	// If the server handles the load data request, the client has to set the ClientLocalFiles capability.
 	if cc.capability&mysql.ClientLocalFiles == 0 {
 		return errNotAllowedCommand
@@ -1769,7 +1750,7 @@ func (cc *clientConn) handleLoadStats(ctx context.Context, loadStatsInfo *execut
 }
 
 // handleIndexAdvise does the index advise work and returns the advise result for index.
-func (cc *clientConn) handleIndexAdvise(ctx context.Context, indexAdviseInfo *executor.IndexAdviseInfo) error {
+func (cc *clientConn) handleIndexAdvise(ctx context.Context, indexAdviseInfo *executor.IndexAdviseInfo, status uint16) error {
 	if cc.capability&mysql.ClientLocalFiles == 0 {
 		return errNotAllowedCommand
 	}
@@ -1789,8 +1770,11 @@ func (cc *clientConn) handleIndexAdvise(ctx context.Context, indexAdviseInfo *ex
 		return err
 	}
 
-	// TODO: Write the rss []ResultSet. It will be done in another PR.
-	return nil
+	rs := &tidbResultSet{recordSet: indexAdviseInfo.Result}
+	//nolint:errcheck
+	defer rs.Close()
+	_, err = cc.writeResultset(ctx, rs, false, status, 0)
+	return err
 }
 
 func (cc *clientConn) handlePlanReplayerLoad(ctx context.Context, planReplayerLoadInfo *executor.PlanReplayerLoadInfo) error {
@@ -2112,12 +2096,10 @@ func (cc *clientConn) handleQuerySpecial(ctx context.Context, status uint16) (bo
 
 	indexAdvise := cc.ctx.Value(executor.IndexAdviseVarKey)
 	if indexAdvise != nil {
-		handled = true
 		defer cc.ctx.SetValue(executor.IndexAdviseVarKey, nil)
+		// The index advice is returned as a result set instead of an OK packet.
 		//nolint:forcetypeassert
-		if err := cc.handleIndexAdvise(ctx, indexAdvise.(*executor.IndexAdviseInfo)); err != nil {
-			return handled, err
-		}
+		return true, cc.handleIndexAdvise(ctx, indexAdvise.(*executor.IndexAdviseInfo), status)
 	}
 
 	planReplayerLoad := cc.ctx.Value(executor.PlanReplayerLoadVarKey)
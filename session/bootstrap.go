@@ -425,6 +425,59 @@ const (
 	CreateAdvisoryLocks = `CREATE TABLE IF NOT EXISTS mysql.advisory_locks (
 		lock_name VARCHAR(64) NOT NULL PRIMARY KEY
 	);`
+	// CreateTTLTableStatus stores the status of the TTL jobs of each physical table.
+	CreateTTLTableStatus = `CREATE TABLE IF NOT EXISTS mysql.tidb_ttl_table_status (
+		table_id BIGINT(64) NOT NULL,
+		parent_table_id BIGINT(64) NOT NULL,
+		table_schema CHAR(64) NOT NULL DEFAULT '',
+		table_name CHAR(64) NOT NULL DEFAULT '',
+		partition_name CHAR(64) NOT NULL DEFAULT '',
+		job_status VARCHAR(64) NOT NULL,
+		job_owner VARCHAR(512) NOT NULL comment 'id of the TiDB instance running the ttl job',
+		job_start_time TIMESTAMP NULL DEFAULT NULL,
+		job_end_time TIMESTAMP NULL DEFAULT NULL,
+		expire_time TIMESTAMP NULL DEFAULT NULL,
+		scanned_rows BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		deleted_rows BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		error_message TEXT,
+		update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (table_id)
+	);`
+	// CreateRunawayTable stores the runaway queries matched by the rules of the resource groups.
+	CreateRunawayTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_runaway_queries (
+		resource_group_name varchar(32) NOT NULL,
+		time TIMESTAMP NOT NULL,
+		match_type varchar(12) NOT NULL,
+		action varchar(12) NOT NULL,
+		original_sql TEXT NOT NULL,
+		sql_digest varchar(64) NOT NULL,
+		plan_digest varchar(64) NOT NULL,
+		tidb_server varchar(512),
+		INDEX plan_index(plan_digest),
+		INDEX time_index(time)
+	);`
+	// CreateStatsTableLocked stores the tables whose statistics are locked, and the changes of the row count
+	// made while they are locked.
+	CreateStatsTableLocked = `CREATE TABLE IF NOT EXISTS mysql.stats_table_locked (
+		table_id BIGINT(64) NOT NULL,
+		modify_count BIGINT(64) NOT NULL DEFAULT 0,
+		count BIGINT(64) NOT NULL DEFAULT 0,
+		version BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		PRIMARY KEY (table_id)
+	);`
+	// CreateAnalyzeQueue stores the priority queue of the tables waiting for the auto analyze.
+	CreateAnalyzeQueue = `CREATE TABLE IF NOT EXISTS mysql.analyze_queue (
+		table_id BIGINT(64) NOT NULL,
+		partition_id BIGINT(64) NOT NULL DEFAULT 0,
+		table_schema CHAR(64) NOT NULL DEFAULT '',
+		table_name CHAR(64) NOT NULL DEFAULT '',
+		partition_name CHAR(64) NOT NULL DEFAULT '',
+		weight DOUBLE NOT NULL DEFAULT 0,
+		reason TEXT NOT NULL,
+		create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (table_id, partition_id),
+		KEY (weight)
+	);`
 	// CreateMDLView is a view about metadata locks.
 	CreateMDLView = `CREATE OR REPLACE VIEW mysql.tidb_mdl_view as (
 	select JOB_ID, DB_NAME, TABLE_NAME, QUERY, SESSION_ID, TxnStart, TIDB_DECODE_SQL_DIGESTS(ALL_SQL_DIGESTS, 4096) AS SQL_DIGESTS from information_schema.ddl_jobs, information_schema.CLUSTER_TIDB_TRX, information_schema.CLUSTER_PROCESSLIST where ddl_jobs.STATE = 'running' and find_in_set(ddl_jobs.table_id, CLUSTER_TIDB_TRX.RELATED_TABLE_IDS) and CLUSTER_TIDB_TRX.SESSION_ID=CLUSTER_PROCESSLIST.ID
@@ -478,6 +531,8 @@ const (
 	tidbSystemTZ = "system_tz"
 	// The variable name in mysql.tidb table and it will indicate if the new collations are enabled in the TiDB cluster.
 	tidbNewCollationEnabled = "new_collation_enabled"
+	// The variable name in mysql.tidb table and it will indicate if the latin1 charset uses the real cp1252 encoding.
+	tidbLatin1CP1252Enabled = "latin1_cp1252_enabled"
 	// The variable name in mysql.tidb table and it records the default value of
 	// mem-quota-query when upgrade from v3.0.x to v4.0.9+.
 	tidbDefMemoryQuotaQuery = "default_memory_quota_query"
@@ -635,11 +690,19 @@ const (
 	version94 = 94
 	// version95 add a column `User_attributes` to `mysql.user`
 	version95 = 95
+	// version96 adds the table mysql.tidb_ttl_table_status
+	version96 = 96
+	// version97 adds the table mysql.tidb_runaway_queries
+	version97 = 97
+	// version98 adds the table mysql.stats_table_locked
+	version98 = 98
+	// version99 adds the table mysql.analyze_queue
+	version99 = 99
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version95
+var currentBootstrapVersion int64 = version99
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -740,6 +803,10 @@ var (
 		upgradeToVer93,
 		upgradeToVer94,
 		upgradeToVer95,
+		upgradeToVer96,
+		upgradeToVer97,
+		upgradeToVer98,
+		upgradeToVer99,
 	}
 )
 
@@ -1300,6 +1367,17 @@ func writeNewCollationParameter(s Session, flag bool) {
 	)
 }
 
+func writeLatin1CP1252Parameter(s Session, flag bool) {
+	comment := "If the latin1 charset uses the real cp1252 encoding. Do not edit it."
+	b := varFalse
+	if flag {
+		b = varTrue
+	}
+	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE=%?`,
+		mysql.SystemDB, mysql.TiDBTable, tidbLatin1CP1252Enabled, b, comment, b,
+	)
+}
+
 func upgradeToVer40(s Session, ver int64) {
 	if ver >= version40 {
 		return
@@ -1942,6 +2020,34 @@ func upgradeToVer95(s Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN IF NOT EXISTS `User_attributes` JSON")
 }
 
+func upgradeToVer96(s Session, ver int64) {
+	if ver >= version96 {
+		return
+	}
+	doReentrantDDL(s, CreateTTLTableStatus)
+}
+
+func upgradeToVer97(s Session, ver int64) {
+	if ver >= version97 {
+		return
+	}
+	doReentrantDDL(s, CreateRunawayTable)
+}
+
+func upgradeToVer98(s Session, ver int64) {
+	if ver >= version98 {
+		return
+	}
+	doReentrantDDL(s, CreateStatsTableLocked)
+}
+
+func upgradeToVer99(s Session, ver int64) {
+	if ver >= version99 {
+		return
+	}
+	doReentrantDDL(s, CreateAnalyzeQueue)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -2038,6 +2144,14 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateAdvisoryLocks)
 	// Create mdl view.
 	mustExecute(s, CreateMDLView)
+	// Create ttl table status table.
+	mustExecute(s, CreateTTLTableStatus)
+	// Create tidb_runaway_queries table.
+	mustExecute(s, CreateRunawayTable)
+	// Create stats_table_locked table.
+	mustExecute(s, CreateStatsTableLocked)
+	// Create analyze_queue table.
+	mustExecute(s, CreateAnalyzeQueue)
 }
 
 // inTestSuite checks if we are bootstrapping in the context of tests.
@@ -2124,6 +2238,8 @@ func doDMLWorks(s Session) {
 
 	writeNewCollationParameter(s, config.GetGlobalConfig().NewCollationsEnabledOnFirstBootstrap)
 
+	writeLatin1CP1252Parameter(s, config.GetGlobalConfig().Latin1CP1252EnabledOnFirstBootstrap)
+
 	writeDefaultExprPushDownBlacklist(s)
 
 	writeStmtSummaryVars(s)
@@ -166,6 +166,11 @@ func checkConstraint(stmt *ast.NonTransactionalDMLStmt, se Session) error {
 			return errors.New("Non-transactional update doesn't support limit")
 		}
 		// TODO: metrics
+	case *ast.InsertStmt:
+		if err := checkInsertConstraint(s, sessVars.CurrentDB); err != nil {
+			return err
+		}
+		metrics.NonTransactionalInsertCount.Inc()
 	default:
 		return errors.New("Unsupported DML type for non-transactional DML")
 	}
@@ -173,6 +178,90 @@ func checkConstraint(stmt *ast.NonTransactionalDMLStmt, se Session) error {
 	return nil
 }
 
+// checkInsertConstraint checks INSERT INTO ... SELECT and REPLACE INTO ... SELECT, the SELECT is split into batches
+// by the shard column of the table it reads from.
+func checkInsertConstraint(s *ast.InsertStmt, currentDB string) error {
+	stmtType := "insert"
+	if s.IsReplace {
+		stmtType = "replace"
+	}
+	sel, ok := s.Select.(*ast.SelectStmt)
+	if !ok {
+		return errors.Errorf("Non-transactional %s only supports %s ... select from a single table", stmtType, stmtType)
+	}
+	if sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Left == nil {
+		return errors.New("table reference is nil")
+	}
+	if sel.From.TableRefs.Right != nil {
+		return errors.Errorf("Non-transactional %s doesn't support multiple tables", stmtType)
+	}
+	if sel.Limit != nil {
+		return errors.Errorf("Non-transactional %s doesn't support limit", stmtType)
+	}
+	if sel.OrderBy != nil {
+		return errors.Errorf("Non-transactional %s doesn't support order by", stmtType)
+	}
+	if sel.GroupBy != nil || sel.Having != nil || sel.Distinct || len(sel.WindowSpecs) > 0 {
+		return errors.Errorf("Non-transactional %s doesn't support aggregation, distinct or window functions", stmtType)
+	}
+	// The rows inserted by the committed batches would be read again by the following batches.
+	target, ok := s.Table.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil
+	}
+	targetName, ok := target.Source.(*ast.TableName)
+	if !ok {
+		return nil
+	}
+	checker := &tableNameChecker{
+		currentDB: currentDB,
+		schema:    schemaNameOrCurrentDB(targetName, currentDB),
+		name:      targetName.Name.L,
+	}
+	// All the parts of the statement except the target table are checked, including the subqueries
+	// in the field list and the ON DUPLICATE KEY UPDATE assignments.
+	sel.Accept(checker)
+	for _, assignment := range s.OnDuplicate {
+		assignment.Expr.Accept(checker)
+	}
+	if checker.found {
+		return errors.Errorf("Non-transactional %s doesn't support reading from the target table", stmtType)
+	}
+	return nil
+}
+
+// schemaNameOrCurrentDB returns the lower-case schema name of the table, the current database is used if it's omitted.
+func schemaNameOrCurrentDB(tn *ast.TableName, currentDB string) string {
+	if tn.Schema.L != "" {
+		return tn.Schema.L
+	}
+	return strings.ToLower(currentDB)
+}
+
+// tableNameChecker checks whether the table is referred by the visited node, including its subqueries.
+type tableNameChecker struct {
+	currentDB string
+	schema    string
+	name      string
+	found     bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *tableNameChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok {
+		if tn.Name.L == c.name && schemaNameOrCurrentDB(tn, c.currentDB) == c.schema {
+			c.found = true
+		}
+		return in, true
+	}
+	return in, c.found
+}
+
+// Leave implements ast.Visitor interface.
+func (c *tableNameChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
 // single-threaded worker. work on the key range [start, end]
 func runJobs(ctx context.Context, jobs []job, stmt *ast.NonTransactionalDMLStmt,
 	tableName *ast.TableName, se Session, originalCondition ast.ExprNode) ([]string, error) {
@@ -555,3 +555,84 @@ func TestNonTransactionalDeleteShardOnUnsupportedTypes(t *testing.T) {
 	require.Error(t, err)
 	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("1"))
 }
+
+func TestNonTransactionalInsertAndReplace(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@tidb_max_chunk_size=35")
+	tk.MustExec("use test")
+	tk.MustExec("create table s(a int, b int, primary key(a) clustered)")
+	tk.MustExec("create table t(a int, b int, primary key(a) clustered)")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into s values (%d, %d)", i, i*2))
+	}
+
+	rows := tk.MustQuery("batch on a limit 3 dry run insert into t select * from s where b > 10").Rows()
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.True(t, strings.HasPrefix(row[0].(string), "INSERT INTO `test`.`t` SELECT * FROM `test`.`s` WHERE "))
+	}
+	tk.MustQuery("batch on a limit 3 dry run query replace into t select * from s").Check(
+		testkit.Rows("SELECT `a` FROM `test`.`s` WHERE TRUE ORDER BY IF(ISNULL(`a`),0,1),`a`"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+
+	tk.MustQuery("batch on a limit 30 insert into t select * from s where a < 90").Check(testkit.Rows("3 all succeeded"))
+	tk.MustQuery("select count(*), sum(b) from t").Check(testkit.Rows("90 8010"))
+	tk.MustQuery("batch limit 40 replace into t select a, b + 1 from s").Check(testkit.Rows("3 all succeeded"))
+	tk.MustQuery("select count(*), sum(b) from t").Check(testkit.Rows("100 10000"))
+
+	// The errors of the batches are reported in the same way as DELETE.
+	tk.MustExec("truncate t")
+	tk.MustExec("set @@tidb_nontransactional_ignore_error=1")
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/session/batchDMLError", `1*return(false)->return(true)`))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/session/batchDMLError"))
+	}()
+	err := tk.ExecToErr("batch on a limit 50 insert into t select * from s")
+	require.ErrorContains(t, err,
+		"1/2 jobs failed in the non-transactional DML: job id: 2, estimated size: 50, sql: INSERT INTO `test`.`t` SELECT * FROM `test`.`s` WHERE `a` BETWEEN 50 AND 99, injected batch(non-transactional) DML error;")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("50"))
+}
+
+func TestNonTransactionalInsertCheckConstraint(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table s(a int, b int, key(a))")
+	tk.MustExec("create table t(a int, b int, key(a))")
+
+	err := tk.ExecToErr("batch on a limit 10 insert into t values (1, 1)")
+	require.EqualError(t, err, "Non-transactional insert only supports insert ... select from a single table")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select * from s limit 10")
+	require.EqualError(t, err, "Non-transactional insert doesn't support limit")
+	err = tk.ExecToErr("batch on a limit 10 replace into t select * from s order by a")
+	require.EqualError(t, err, "Non-transactional replace doesn't support order by")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select a, count(*) from s group by a")
+	require.EqualError(t, err, "Non-transactional insert doesn't support aggregation, distinct or window functions")
+	err = tk.ExecToErr("batch on s.a limit 10 insert into t select s.a, t.b from s join t on s.a = t.a")
+	require.EqualError(t, err, "Non-transactional insert doesn't support multiple tables")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select * from t")
+	require.EqualError(t, err, "Non-transactional insert doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select * from test.t")
+	require.EqualError(t, err, "Non-transactional insert doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on a limit 10 replace into test.t select * from T")
+	require.EqualError(t, err, "Non-transactional replace doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select * from s where a in (select a from test.t)")
+	require.EqualError(t, err, "Non-transactional insert doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select * from s where exists (select 1 from t where t.b = s.b)")
+	require.EqualError(t, err, "Non-transactional insert doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select (select max(a) from t), b from s")
+	require.EqualError(t, err, "Non-transactional insert doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on a limit 10 insert into t select * from s on duplicate key update b = (select max(b) from t)")
+	require.EqualError(t, err, "Non-transactional insert doesn't support reading from the target table")
+	err = tk.ExecToErr("batch on b limit 10 insert into t select * from s")
+	require.EqualError(t, err, "Non-transactional DML, shard column b is not indexed")
+
+	// The source table in another schema is not the target table.
+	tk.MustExec("create database test2")
+	tk.MustExec("create table test2.t(a int, b int, key(a))")
+	tk.MustExec("insert into test2.t values (1, 1), (2, 2), (3, 3)")
+	tk.MustQuery("batch on a limit 2 insert into t select * from test2.t").Check(testkit.Rows("2 all succeeded"))
+	tk.MustQuery("batch on a limit 2 insert into t select * from test2.t where b not in (select b from s)").Check(testkit.Rows("2 all succeeded"))
+	tk.MustQuery("select count(*), sum(b) from t").Check(testkit.Rows("6 12"))
+}
@@ -91,6 +91,7 @@ import (
 	"github.com/pingcap/tidb/util/kvcache"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/logutil/consistency"
+	"github.com/pingcap/tidb/util/resourcegrouptag"
 	"github.com/pingcap/tidb/util/sem"
 	"github.com/pingcap/tidb/util/sli"
 	"github.com/pingcap/tidb/util/sqlexec"
@@ -460,6 +461,9 @@ func (s *session) GetPlanCache(isGeneralPlanCache bool) sessionctx.PlanCache {
 	if !s.GetSessionVars().EnablePreparedPlanCache {
 		return nil
 	}
+	if variable.EnableInstancePlanCache.Load() { // the plans are shared by all the sessions
+		return plannercore.GetInstancePlanCache()
+	}
 	if s.preparedPlanCache == nil { // lazy construction
 		s.preparedPlanCache = plannercore.NewLRUPlanCache(uint(s.GetSessionVars().PreparedPlanCacheSize),
 			variable.PreparedPlanCacheMemoryGuardRatio.Load(), plannercore.PreparedPlanCacheMaxMemory.Load(),
@@ -703,7 +707,8 @@ func (s *session) doCommit(ctx context.Context) error {
 		s.txn.SetOption(kv.CommitTSUpperBoundCheck, c.commitTSCheck)
 	}
 
-	err = s.commitTxnWithTemporaryData(tikvutil.SetSessionID(ctx, sessVars.ConnectionID), &s.txn)
+	commitCtx := resourcegrouptag.WithResourceGroupName(tikvutil.SetSessionID(ctx, sessVars.ConnectionID), sessVars.GetResourceGroupName())
+	err = s.commitTxnWithTemporaryData(commitCtx, &s.txn)
 	if err != nil {
 		err = s.handleAssertionFailure(ctx, err)
 	}
@@ -2579,6 +2584,7 @@ func (s *session) Auth(user *auth.UserIdentity, authentication []byte, salt []by
 	user.AuthHostname = authUser.Hostname
 	s.sessionVars.User = user
 	s.sessionVars.ActiveRoles = pm.GetDefaultRoles(user.AuthUsername, user.AuthHostname)
+	s.sessionVars.ResourceGroupName = pm.GetUserResourceGroup(user.AuthUsername, user.AuthHostname)
 	return nil
 }
 
@@ -2613,6 +2619,7 @@ func (s *session) AuthWithoutVerification(user *auth.UserIdentity) bool {
 		user.AuthHostname = authUser.Hostname
 		s.sessionVars.User = user
 		s.sessionVars.ActiveRoles = pm.GetDefaultRoles(user.AuthUsername, user.AuthHostname)
+		s.sessionVars.ResourceGroupName = pm.GetUserResourceGroup(user.AuthUsername, user.AuthHostname)
 		return true
 	}
 	return false
@@ -2719,6 +2726,19 @@ func loadCollationParameter(ctx context.Context, se *session) (bool, error) {
 	return false, nil
 }
 
+// loadLatin1CP1252Parameter loads the latin1 encoding parameter from mysql.tidb.
+// The clusters bootstrapped by the old versions don't have it, latin1 is treated as utf-8 for them.
+func loadLatin1CP1252Parameter(ctx context.Context, se *session) (bool, error) {
+	para, err := se.getTableValue(ctx, mysql.TiDBTable, tidbLatin1CP1252Enabled)
+	if err != nil {
+		if errResultIsEmpty.Equal(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return para == varTrue, nil
+}
+
 var (
 	errResultIsEmpty = dbterror.ClassExecutor.NewStd(errno.ErrResultIsEmpty)
 	// DDLJobTables is a list of tables definitions used in concurrent DDL.
@@ -2862,6 +2882,12 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 		return nil, err
 	}
 	collate.SetNewCollationEnabledForTest(newCollationEnabled)
+	// get the flag from `mysql`.`tidb` which indicating if latin1 uses the real cp1252 encoding.
+	latin1CP1252Enabled, err := loadLatin1CP1252Parameter(ctx, ses[0])
+	if err != nil {
+		return nil, err
+	}
+	charset.SetLatin1CP1252Enabled(latin1CP1252Enabled)
 	// To deal with the location partition failure caused by inconsistent NewCollationEnabled values(see issue #32416).
 	rebuildAllPartitionValueMapAndSorted(ses[0])
 
@@ -2925,6 +2951,8 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 
 	dom.DumpFileGcCheckerLoop()
 	dom.LoadSigningCertLoop()
+	dom.StartTTLJobManager()
+	dom.RunawayRecordFlushLoop()
 
 	if raw, ok := store.(kv.EtcdBackend); ok {
 		err = raw.StartGCWorker()
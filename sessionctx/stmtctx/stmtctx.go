@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/util/disk"
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/resourcegroup"
 	"github.com/pingcap/tidb/util/resourcegrouptag"
 	"github.com/pingcap/tidb/util/topsql/stmtstats"
 	"github.com/pingcap/tidb/util/tracing"
@@ -117,6 +118,7 @@ type StatementContext struct {
 	InSelectStmt           bool
 	InLoadDataStmt         bool
 	InExplainStmt          bool
+	InExplainAnalyzeStmt   bool
 	InCreateOrAlterStmt    bool
 	InSetSessionStatesStmt bool
 	InPreparedPlanBuilding bool
@@ -173,6 +175,7 @@ type StatementContext struct {
 		touched uint64
 
 		message        string
+		progress       string
 		warnings       []SQLWarn
 		errorCount     uint16
 		execDetails    execdetails.ExecDetails
@@ -339,6 +342,9 @@ type StatementContext struct {
 		SavepointName string
 		HasFKCascades bool
 	}
+
+	// RunawayChecker checks the query against the runaway rule of its resource group, it's nil if there is no rule.
+	RunawayChecker *resourcegroup.RunawayChecker
 }
 
 // StmtHints are SessionVars related sql hints.
@@ -356,6 +362,8 @@ type StmtHints struct {
 	// ForceNthPlan indicates the PlanCounterTp number for finding physical plan.
 	// -1 for disable.
 	ForceNthPlan int64
+	// ResourceGroup is the resource group specified by the RESOURCE_GROUP hint.
+	ResourceGroup string
 
 	// Hint flags
 	HasAllowInSubqToJoinAndAggHint bool
@@ -363,6 +371,7 @@ type StmtHints struct {
 	HasReplicaReadHint             bool
 	HasMaxExecutionTime            bool
 	HasEnableCascadesPlannerHint   bool
+	HasResourceGroup               bool
 	SetVars                        map[string]string
 
 	// the original table hints
@@ -652,6 +661,20 @@ func (sc *StatementContext) SetMessage(msg string) {
 	sc.mu.message = msg
 }
 
+// GetProgress gets the progress of the long-running statement, e.g. LOAD DATA from the external storage.
+func (sc *StatementContext) GetProgress() string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.mu.progress
+}
+
+// SetProgress sets the progress of the long-running statement, it's shown in SHOW PROCESSLIST.
+func (sc *StatementContext) SetProgress(progress string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.mu.progress = progress
+}
+
 // GetWarnings gets warnings.
 func (sc *StatementContext) GetWarnings() []SQLWarn {
 	sc.mu.Lock()
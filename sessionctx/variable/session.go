@@ -693,6 +693,10 @@ type SessionVars struct {
 	// User is the user identity with which the session login.
 	User *auth.UserIdentity
 
+	// ResourceGroupName is the resource group the session is bound to, it's loaded from the user attributes
+	// when the session logins.
+	ResourceGroupName string
+
 	// Port is the port of the connected socket
 	Port string
 
@@ -1066,6 +1070,10 @@ type SessionVars struct {
 	// OptimizerUseInvisibleIndexes indicates whether optimizer can use invisible index
 	OptimizerUseInvisibleIndexes bool
 
+	// HypoIndexes are the hypothetical indexes which are only considered by the optimizer and never built,
+	// the key is the table ID.
+	HypoIndexes map[int64][]*model.IndexInfo
+
 	// SelectLimit limits the max counts of select statement's output
 	SelectLimit uint64
 
@@ -1677,6 +1685,20 @@ func (s *SessionVars) SetEnablePseudoForOutdatedStats(val bool) {
 	s.EnablePseudoForOutdatedStats = val
 }
 
+// GetResourceGroupName returns the resource group whose quota should throttle the requests of the session.
+// Internal SQLs are never throttled, and an empty string is returned if resource control is disabled.
+// The group specified by the RESOURCE_GROUP hint takes precedence over the group of the user, the hint is ignored
+// by the executor if the group doesn't exist or the user isn't allowed to use it.
+func (s *SessionVars) GetResourceGroupName() string {
+	if s.InRestrictedSQL || !EnableResourceControl.Load() {
+		return ""
+	}
+	if s.StmtCtx.HasResourceGroup {
+		return s.StmtCtx.ResourceGroup
+	}
+	return s.ResourceGroupName
+}
+
 // GetReplicaRead get ReplicaRead from sql hints and SessionVars.replicaRead.
 func (s *SessionVars) GetReplicaRead() kv.ReplicaReadType {
 	if s.StmtCtx.HasReplicaReadHint {
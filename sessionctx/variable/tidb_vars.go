@@ -16,6 +16,7 @@ package variable
 
 import (
 	"math"
+	"time"
 
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -530,6 +531,21 @@ const (
 	// TiDBStmtSummaryMaxSQLLength indicates the max length of displayed normalized sql and sample sql.
 	TiDBStmtSummaryMaxSQLLength = "tidb_stmt_summary_max_sql_length"
 
+	// TiDBStmtSummaryEnablePersistent indicates whether the statement summaries are persisted to the local files.
+	TiDBStmtSummaryEnablePersistent = "tidb_stmt_summary_enable_persistent"
+
+	// TiDBStmtSummaryFilename indicates the file name of the persisted statement summaries.
+	TiDBStmtSummaryFilename = "tidb_stmt_summary_filename"
+
+	// TiDBStmtSummaryFileMaxDays indicates the maximum number of days to retain the statement summary files.
+	TiDBStmtSummaryFileMaxDays = "tidb_stmt_summary_file_max_days"
+
+	// TiDBStmtSummaryFileMaxSize indicates the maximum size in MB of a statement summary file.
+	TiDBStmtSummaryFileMaxSize = "tidb_stmt_summary_file_max_size"
+
+	// TiDBStmtSummaryFileMaxBackups indicates the maximum number of the rotated statement summary files to retain.
+	TiDBStmtSummaryFileMaxBackups = "tidb_stmt_summary_file_max_backups"
+
 	// TiDBCapturePlanBaseline indicates whether the capture of plan baselines is enabled.
 	TiDBCapturePlanBaseline = "tidb_capture_plan_baselines"
 
@@ -608,6 +624,12 @@ const (
 	// TiDBAutoAnalyzePartitionBatchSize indicates the batch size for partition tables for auto analyze in dynamic mode
 	TiDBAutoAnalyzePartitionBatchSize = "tidb_auto_analyze_partition_batch_size"
 
+	// TiDBEnableAutoAnalyzePriorityQueue indicates whether the tables are auto analyzed in the order of their priority.
+	TiDBEnableAutoAnalyzePriorityQueue = "tidb_enable_auto_analyze_priority_queue"
+
+	// TiDBAutoAnalyzeConcurrency indicates the number of the tables which are auto analyzed concurrently.
+	TiDBAutoAnalyzeConcurrency = "tidb_auto_analyze_concurrency"
+
 	// TiDBEnableIndexMergeJoin indicates whether to enable index merge join.
 	TiDBEnableIndexMergeJoin = "tidb_enable_index_merge_join"
 
@@ -738,6 +760,10 @@ const (
 	TiDBEnableGeneralPlanCache = "tidb_enable_general_plan_cache"
 	// TiDBGeneralPlanCacheSize controls the size of general plan cache.
 	TiDBGeneralPlanCacheSize = "tidb_general_plan_cache_size"
+	// TiDBEnableInstancePlanCache indicates whether the prepared plan cache is shared by all the sessions of the instance.
+	TiDBEnableInstancePlanCache = "tidb_enable_instance_plan_cache"
+	// TiDBInstancePlanCacheMaxMemSize is the memory limit of the instance plan cache.
+	TiDBInstancePlanCacheMaxMemSize = "tidb_instance_plan_cache_max_mem_size"
 
 	// TiDBConstraintCheckInPlacePessimistic controls whether to skip certain kinds of pessimistic locks.
 	TiDBConstraintCheckInPlacePessimistic = "tidb_constraint_check_in_place_pessimistic"
@@ -835,6 +861,18 @@ const (
 	TiDBEnableGOGCTuner = "tidb_enable_gogc_tuner"
 	// TiDBGOGCTunerThreshold is to control the threshold of GOGC tuner.
 	TiDBGOGCTunerThreshold = "tidb_gogc_tuner_threshold"
+	// TiDBTTLJobEnable is used to enable/disable scheduling ttl job
+	TiDBTTLJobEnable = "tidb_ttl_job_enable"
+	// TiDBTTLJobRunInterval represents the schedule interval between two jobs for one TTL table
+	TiDBTTLJobRunInterval = "tidb_ttl_job_run_interval"
+	// TiDBTTLScanBatchSize is used to control the batch size in the SELECT statement for TTL jobs
+	TiDBTTLScanBatchSize = "tidb_ttl_scan_batch_size"
+	// TiDBTTLDeleteBatchSize is used to control the batch size in the DELETE statement for TTL jobs
+	TiDBTTLDeleteBatchSize = "tidb_ttl_delete_batch_size"
+	// TiDBTTLScanWorkerCount indicates the count of the scan workers in each TiDB node
+	TiDBTTLScanWorkerCount = "tidb_ttl_scan_worker_count"
+	// TiDBEnableResourceControl indicates whether resource control feature is enabled
+	TiDBEnableResourceControl = "tidb_enable_resource_control"
 )
 
 // TiDB intentional limits
@@ -976,6 +1014,8 @@ const (
 	DefTiDBGuaranteeLinearizability                = true
 	DefTiDBAnalyzeVersion                          = 2
 	DefTiDBAutoAnalyzePartitionBatchSize           = 1
+	DefTiDBEnableAutoAnalyzePriorityQueue          = true
+	DefTiDBAutoAnalyzeConcurrency                  = 1
 	DefTiDBEnableIndexMergeJoin                    = false
 	DefTiDBTrackAggregateMemoryUsage               = true
 	DefTiDBEnableExchangePartition                 = true
@@ -1068,6 +1108,20 @@ const (
 	DefTiDBEnableGOGCTuner                          = true
 	// DefTiDBGOGCTunerThreshold is to limit TiDBGOGCTunerThreshold.
 	DefTiDBGOGCTunerThreshold float64 = 0.6
+
+	DefTiDBTTLJobEnable          = true
+	DefTiDBTTLJobRunInterval     = time.Hour
+	DefTiDBTTLScanBatchSize      = 500
+	DefTiDBTTLScanBatchMaxSize   = 10240
+	DefTiDBTTLScanBatchMinSize   = 1
+	DefTiDBTTLDeleteBatchSize    = 100
+	DefTiDBTTLDeleteBatchMaxSize = 10240
+	DefTiDBTTLDeleteBatchMinSize = 1
+	DefTiDBTTLScanWorkerCount    = 4
+	DefTiDBEnableResourceControl = false
+
+	DefTiDBEnableInstancePlanCache     = false
+	DefTiDBInstancePlanCacheMaxMemSize = 100 * size.MB
 )
 
 // Process global variables.
@@ -1114,6 +1168,8 @@ var (
 	EnableNoopVariables               = atomic.NewBool(DefTiDBEnableNoopVariables)
 	EnableMDL                         = atomic.NewBool(DefTiDBEnableMDL)
 	AutoAnalyzePartitionBatchSize     = atomic.NewInt64(DefTiDBAutoAnalyzePartitionBatchSize)
+	EnableAutoAnalyzePriorityQueue    = atomic.NewBool(DefTiDBEnableAutoAnalyzePriorityQueue)
+	AutoAnalyzeConcurrency            = atomic.NewInt32(DefTiDBAutoAnalyzeConcurrency)
 	// EnableFastReorg indicates whether to use lightning to enhance DDL reorg performance.
 	EnableFastReorg = atomic.NewBool(DefTiDBEnableFastReorg)
 	// DDLDiskQuota is the temporary variable for set disk quota for lightning
@@ -1126,6 +1182,22 @@ var (
 	// It should be a const and shouldn't be modified after tidb is started.
 	DefTiDBServerMemoryLimit = mathutil.Max(memory.GetMemTotalIgnoreErr()/10*8, 512<<20)
 	GOGCTunerThreshold       = atomic.NewFloat64(DefTiDBGOGCTunerThreshold)
+	// EnableTTLJob indicates whether to enable TTL job
+	EnableTTLJob = atomic.NewBool(DefTiDBTTLJobEnable)
+	// TTLJobRunInterval is the interval between two TTL jobs of the same table
+	TTLJobRunInterval = atomic.NewDuration(DefTiDBTTLJobRunInterval)
+	// TTLScanBatchSize is the batch size of the SELECT statement in TTL jobs
+	TTLScanBatchSize = atomic.NewInt64(DefTiDBTTLScanBatchSize)
+	// TTLDeleteBatchSize is the batch size of the DELETE statement in TTL jobs
+	TTLDeleteBatchSize = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
+	// TTLScanWorkerCount is the count of the TTL scan workers on each TiDB node
+	TTLScanWorkerCount = atomic.NewInt32(DefTiDBTTLScanWorkerCount)
+	// EnableResourceControl indicates whether resource control is enabled
+	EnableResourceControl = atomic.NewBool(DefTiDBEnableResourceControl)
+	// EnableInstancePlanCache indicates whether the prepared plan cache is shared by all the sessions.
+	EnableInstancePlanCache = atomic.NewBool(DefTiDBEnableInstancePlanCache)
+	// InstancePlanCacheMaxMemSize is the memory limit of the instance plan cache.
+	InstancePlanCacheMaxMemSize = atomic.NewUint64(DefTiDBInstancePlanCacheMaxMemSize)
 )
 
 var (
@@ -132,6 +132,16 @@ func checkCharacterSet(normalizedValue string, argName string) (string, error) {
 	return cs.Name, nil
 }
 
+// isNotAllowedClientCharset returns whether the charset can't be used as character_set_client.
+// Same as MySQL, the charsets whose minimum character length is larger than 1 byte are not allowed.
+func isNotAllowedClientCharset(cs string) bool {
+	switch strings.ToLower(cs) {
+	case charset.CharsetUCS2, charset.CharsetUTF16, charset.CharsetUTF16LE, charset.CharsetUTF32:
+		return true
+	}
+	return false
+}
+
 // checkReadOnly requires TiDBEnableNoopFuncs=1 for the same scope otherwise an error will be returned.
 func checkReadOnly(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag, offlineMode bool) (string, error) {
 	errMsg := ErrFunctionsNoopImpl.GenWithStackByArgs("READ ONLY")
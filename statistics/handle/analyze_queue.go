@@ -0,0 +1,341 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/statistics"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mathutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/timeutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// The weights of the factors deciding the priority of the tables in the auto analyze queue.
+const (
+	// changeRatioWeight is the weight of modify_count / count, the tables with more modifications are stale.
+	changeRatioWeight = 0.6
+	// tableSizeWeight is the weight of the table size, the small tables are cheap to analyze.
+	tableSizeWeight = 0.1
+	// analyzeIntervalWeight is the weight of the time since the table was analyzed last time.
+	analyzeIntervalWeight = 0.3
+	// predicateColumnsWeight is added if the predicate columns of the table are used after it was analyzed.
+	predicateColumnsWeight = 0.1
+)
+
+// maxAnalyzeInterval is the time since the last analyze which makes the interval factor reach its max value 1.
+const maxAnalyzeInterval = 7 * 24 * time.Hour
+
+// autoAnalyzeQueueRefreshInterval is the interval to rebuild the auto analyze queue from the statistics.
+// The queue is also rebuilt when all its jobs are taken.
+var autoAnalyzeQueueRefreshInterval = time.Minute
+
+// autoAnalyzeJob is a table or a partition waiting for the auto analyze. The partitions of a table in the
+// dynamic prune mode are analyzed together, so partitionID is 0 for them.
+type autoAnalyzeJob struct {
+	tableID       int64
+	partitionID   int64
+	dbName        string
+	tableName     string
+	partitionName string
+	reason        string
+	weight        float64
+}
+
+// autoAnalyzeQueue keeps the jobs in the descending order of their weights. It's saved in mysql.analyze_queue,
+// so the pending jobs can be inspected by SHOW ANALYZE STATUS.
+type autoAnalyzeQueue struct {
+	sync.Mutex
+	jobs        []*autoAnalyzeJob
+	refreshTime time.Time
+	// saved indicates mysql.analyze_queue may contain some jobs.
+	saved bool
+}
+
+// autoAnalyzeParams are the parameters of a round of the auto analyze.
+type autoAnalyzeParams struct {
+	ratio           float64
+	start, end      time.Time
+	pruneMode       variable.PartitionPruneMode
+	analyzeSnapshot bool
+	lockedTables    map[int64]struct{}
+}
+
+// calcAutoAnalyzeWeight returns the priority of the table or partition in the auto analyze queue.
+func calcAutoAnalyzeWeight(statsTbl *statistics.Table, predicateColsUsed bool, now time.Time) float64 {
+	// The tables never analyzed are handled as the ones with all the rows modified.
+	changeRatio := 1.0
+	lastAnalyzeVersion := statsTbl.Version
+	if TableAnalyzed(statsTbl) {
+		tblCnt := float64(statsTbl.Count)
+		if histCnt := statsTbl.GetColRowCount(); histCnt > 0 {
+			tblCnt = histCnt
+		}
+		changeRatio = float64(statsTbl.ModifyCount) / math.Max(tblCnt, 1)
+		lastAnalyzeVersion = lastAnalyzeVersionOfTable(statsTbl)
+	}
+	sizeFactor := 1 / math.Log10(math.Max(float64(statsTbl.Count), 10))
+	interval := now.Sub(time.UnixMilli(oracle.ExtractPhysical(lastAnalyzeVersion)))
+	intervalFactor := math.Min(math.Max(float64(interval)/float64(maxAnalyzeInterval), 0), 1)
+	weight := changeRatioWeight*changeRatio + tableSizeWeight*sizeFactor + analyzeIntervalWeight*intervalFactor
+	if predicateColsUsed {
+		weight += predicateColumnsWeight
+	}
+	return weight
+}
+
+// lastAnalyzeVersionOfTable returns the version of the latest analyzed column or index.
+func lastAnalyzeVersionOfTable(statsTbl *statistics.Table) uint64 {
+	var version uint64
+	for _, col := range statsTbl.Columns {
+		version = mathutil.Max(version, col.LastUpdateVersion)
+	}
+	for _, idx := range statsTbl.Indices {
+		version = mathutil.Max(version, idx.LastUpdateVersion)
+	}
+	return version
+}
+
+// needAutoAnalyze checks whether the table or partition should be auto analyzed, the conditions are the
+// same as autoAnalyzeTable.
+func needAutoAnalyze(tblInfo *model.TableInfo, statsTbl *statistics.Table, limit time.Duration, ratio float64) (bool, string) {
+	if statsTbl.Pseudo || statsTbl.Count < AutoAnalyzeMinCnt {
+		return false, ""
+	}
+	if needAnalyze, reason := NeedAnalyzeTable(statsTbl, limit, ratio); needAnalyze {
+		return true, reason
+	}
+	for _, idx := range tblInfo.Indices {
+		if _, ok := statsTbl.Indices[idx.ID]; !ok && idx.State == model.StatePublic {
+			return true, fmt.Sprintf("index %s unanalyzed", idx.Name.O)
+		}
+	}
+	return false, ""
+}
+
+// getPredicateColumnsUsedTables returns the IDs of the tables whose predicate columns are used after they
+// were analyzed last time.
+func (h *Handle) getPredicateColumnsUsedTables() (map[int64]struct{}, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rows, _, err := h.execRestrictedSQL(ctx, "SELECT DISTINCT table_id FROM mysql.column_stats_usage WHERE last_used_at IS NOT NULL AND (last_analyzed_at IS NULL OR last_used_at > last_analyzed_at)")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tables := make(map[int64]struct{}, len(rows))
+	for _, row := range rows {
+		tables[row.GetInt64(0)] = struct{}{}
+	}
+	return tables, nil
+}
+
+// buildAutoAnalyzeJobs collects the tables and partitions which need to be analyzed and sorts them by the weights.
+func (h *Handle) buildAutoAnalyzeJobs(is infoschema.InfoSchema, p *autoAnalyzeParams) []*autoAnalyzeJob {
+	usedTables, err := h.getPredicateColumnsUsedTables()
+	if err != nil {
+		logutil.BgLogger().Warn("[stats] load predicate columns for auto analyze failed", zap.Error(err))
+	}
+	now := time.Now()
+	var jobs []*autoAnalyzeJob
+	newJob := func(tblInfo *model.TableInfo, db string, physicalID int64, statsTbl *statistics.Table) *autoAnalyzeJob {
+		needAnalyze, reason := needAutoAnalyze(tblInfo, statsTbl, 20*h.Lease(), p.ratio)
+		if !needAnalyze {
+			return nil
+		}
+		_, used := usedTables[physicalID]
+		return &autoAnalyzeJob{
+			tableID:   tblInfo.ID,
+			dbName:    db,
+			tableName: tblInfo.Name.O,
+			reason:    reason,
+			weight:    calcAutoAnalyzeWeight(statsTbl, used, now),
+		}
+	}
+	for _, db := range is.AllSchemaNames() {
+		if util.IsMemOrSysDB(strings.ToLower(db)) {
+			continue
+		}
+		for _, tbl := range is.SchemaTables(model.NewCIStr(db)) {
+			tblInfo := tbl.Meta()
+			if tblInfo.IsView() {
+				continue
+			}
+			if _, ok := p.lockedTables[tblInfo.ID]; ok {
+				continue
+			}
+			pi := tblInfo.GetPartitionInfo()
+			if pi == nil {
+				if job := newJob(tblInfo, db, tblInfo.ID, h.GetTableStats(tblInfo)); job != nil {
+					jobs = append(jobs, job)
+				}
+				continue
+			}
+			// In the dynamic prune mode, the partitions are analyzed together and the table gets the highest
+			// priority of them.
+			var tableJob *autoAnalyzeJob
+			for _, def := range pi.Definitions {
+				if _, ok := p.lockedTables[def.ID]; ok {
+					continue
+				}
+				job := newJob(tblInfo, db, def.ID, h.GetPartitionStats(tblInfo, def.ID))
+				if job == nil {
+					continue
+				}
+				if p.pruneMode != variable.Dynamic {
+					job.partitionID, job.partitionName = def.ID, def.Name.O
+					jobs = append(jobs, job)
+				} else if tableJob == nil {
+					tableJob = job
+					jobs = append(jobs, job)
+				} else if job.weight > tableJob.weight {
+					tableJob.weight, tableJob.reason = job.weight, job.reason
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(jobs, func(i, j *autoAnalyzeJob) bool {
+		return i.weight > j.weight
+	})
+	return jobs
+}
+
+// refreshAutoAnalyzeQueue rebuilds the auto analyze queue if it's empty or out of date.
+func (h *Handle) refreshAutoAnalyzeQueue(is infoschema.InfoSchema, p *autoAnalyzeParams) {
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	defer q.Unlock()
+	if len(q.jobs) > 0 && time.Since(q.refreshTime) < autoAnalyzeQueueRefreshInterval {
+		return
+	}
+	q.jobs = h.buildAutoAnalyzeJobs(is, p)
+	q.refreshTime = time.Now()
+	if len(q.jobs) == 0 && !q.saved {
+		return
+	}
+	if err := h.saveAutoAnalyzeQueue(q.jobs); err != nil {
+		logutil.BgLogger().Warn("[stats] save auto analyze queue failed", zap.Error(err))
+		return
+	}
+	q.saved = len(q.jobs) > 0
+}
+
+// saveAutoAnalyzeQueue replaces the jobs in mysql.analyze_queue.
+func (h *Handle) saveAutoAnalyzeQueue(jobs []*autoAnalyzeJob) error {
+	const batchSize = 256
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	if _, _, err := h.execRestrictedSQL(ctx, "DELETE FROM mysql.analyze_queue"); err != nil {
+		return errors.Trace(err)
+	}
+	for i := 0; i < len(jobs); i += batchSize {
+		var sql strings.Builder
+		sql.WriteString("INSERT INTO mysql.analyze_queue (table_id, partition_id, table_schema, table_name, partition_name, weight, reason) VALUES ")
+		for j, job := range jobs[i:mathutil.Min(i+batchSize, len(jobs))] {
+			if j > 0 {
+				sql.WriteString(", ")
+			}
+			sqlexec.MustFormatSQL(&sql, "(%?, %?, %?, %?, %?, %?, %?)", job.tableID, job.partitionID, job.dbName, job.tableName, job.partitionName, job.weight, job.reason)
+		}
+		if _, _, err := h.execRestrictedSQL(ctx, sql.String()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// popAutoAnalyzeJob takes the job with the highest priority from the queue, nil is returned if the queue is empty.
+func (h *Handle) popAutoAnalyzeJob() *autoAnalyzeJob {
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	if len(q.jobs) == 0 {
+		q.Unlock()
+		return nil
+	}
+	job := q.jobs[0]
+	q.jobs = q.jobs[1:]
+	q.Unlock()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	_, _, err := h.execRestrictedSQL(ctx, "DELETE FROM mysql.analyze_queue WHERE table_id = %? AND partition_id = %?", job.tableID, job.partitionID)
+	if err != nil {
+		logutil.BgLogger().Warn("[stats] delete job from auto analyze queue failed", zap.Error(err))
+	}
+	return job
+}
+
+// runAutoAnalyzeJob analyzes the table or partition of the job if it still needs to be analyzed.
+func (h *Handle) runAutoAnalyzeJob(is infoschema.InfoSchema, job *autoAnalyzeJob, p *autoAnalyzeParams) bool {
+	tbl, ok := is.TableByID(job.tableID)
+	if !ok {
+		return false
+	}
+	tblInfo := tbl.Meta()
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		sql := "analyze table %n.%n"
+		return h.autoAnalyzeTable(tblInfo, h.GetTableStats(tblInfo), p.ratio, p.analyzeSnapshot, sql, job.dbName, tblInfo.Name.O)
+	}
+	if job.partitionID == 0 {
+		if p.pruneMode != variable.Dynamic {
+			return false
+		}
+		return h.autoAnalyzePartitionTableInDynamicMode(tblInfo, pi, job.dbName, p.ratio, p.analyzeSnapshot, p.lockedTables)
+	}
+	for _, def := range pi.Definitions {
+		if def.ID == job.partitionID {
+			sql := "analyze table %n.%n partition %n"
+			statsTbl := h.GetPartitionStats(tblInfo, def.ID)
+			return h.autoAnalyzeTable(tblInfo, statsTbl, p.ratio, p.analyzeSnapshot, sql, job.dbName, tblInfo.Name.O, def.Name.O)
+		}
+	}
+	return false
+}
+
+// autoAnalyzeByPriority analyzes the tables in the order of their priority. Every worker analyzes at most one
+// table in a round, so the next round gets the freshest parameters.
+func (h *Handle) autoAnalyzeByPriority(is infoschema.InfoSchema, p *autoAnalyzeParams) bool {
+	h.refreshAutoAnalyzeQueue(is, p)
+	var analyzed atomic.Bool
+	var wg util.WaitGroupWrapper
+	for i := 0; i < int(variable.AutoAnalyzeConcurrency.Load()); i++ {
+		wg.Run(func() {
+			for timeutil.WithinDayTimePeriod(p.start, p.end, time.Now()) {
+				job := h.popAutoAnalyzeJob()
+				if job == nil {
+					return
+				}
+				if h.runAutoAnalyzeJob(is, job, p) {
+					analyzed.Store(true)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	return analyzed.Load()
+}
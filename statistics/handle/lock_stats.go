@@ -0,0 +1,95 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// AddLockedTables locks the statistics of the physical tables. The locked statistics are not updated by
+// ANALYZE, LOAD STATS and the auto analyze, and the changes of the row count are kept in
+// mysql.stats_table_locked until the tables are unlocked.
+func (h *Handle) AddLockedTables(tids []int64) error {
+	if len(tids) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	sql.WriteString("INSERT IGNORE INTO mysql.stats_table_locked (table_id) VALUES ")
+	for i, tid := range tids {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sqlexec.MustFormatSQL(&sql, "(%?)", tid)
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	_, _, err := h.execRestrictedSQL(ctx, sql.String())
+	return errors.Trace(err)
+}
+
+// RemoveLockedTables unlocks the statistics of the physical tables, the changes of the row count made
+// while the tables are locked are applied to mysql.stats_meta.
+func (h *Handle) RemoveLockedTables(tids []int64) (err error) {
+	if len(tids) == 0 {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	exec := h.mu.ctx.(sqlexec.SQLExecutor)
+	_, err = exec.ExecuteInternal(ctx, "begin")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		err = finishTransaction(ctx, exec, err)
+	}()
+	txn, err := h.mu.ctx.Txn(true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	startTS := txn.StartTS()
+	for _, tid := range tids {
+		_, err = exec.ExecuteInternal(ctx, `UPDATE mysql.stats_meta m JOIN mysql.stats_table_locked l ON m.table_id = l.table_id
+			SET m.version = %?, m.count = GREATEST(CAST(m.count AS SIGNED) + l.count, 0), m.modify_count = m.modify_count + l.modify_count
+			WHERE l.table_id = %? AND (l.count != 0 OR l.modify_count != 0)`, startTS, tid)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = exec.ExecuteInternal(ctx, "DELETE FROM mysql.stats_table_locked WHERE table_id = %?", tid)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// GetLockedTables returns the IDs of the physical tables whose statistics are locked.
+func (h *Handle) GetLockedTables() (map[int64]struct{}, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rows, _, err := h.execRestrictedSQL(ctx, "SELECT table_id FROM mysql.stats_table_locked")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	locked := make(map[int64]struct{}, len(rows))
+	for _, row := range rows {
+		locked[row.GetInt64(0)] = struct{}{}
+	}
+	return locked, nil
+}
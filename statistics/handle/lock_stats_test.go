@@ -0,0 +1,93 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/statistics/handle"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockAndUnlockTableStats(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, index idx(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("analyze table t")
+
+	h := dom.StatsHandle()
+	is := dom.InfoSchema()
+	tbl, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tableInfo := tbl.Meta()
+	require.Equal(t, int64(3), h.GetTableStats(tableInfo).Count)
+
+	tk.MustExec("lock stats t")
+	tk.MustQuery("show stats_locked").Check(testkit.Rows("test t  locked"))
+
+	// The row count changes are kept until the statistics are unlocked.
+	tk.MustExec("insert into t values (4, 4), (5, 5)")
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	require.NoError(t, h.Update(is))
+	require.Equal(t, int64(3), h.GetTableStats(tableInfo).Count)
+
+	handle.AutoAnalyzeMinCnt = 0
+	tk.MustExec("set global tidb_auto_analyze_ratio = 0.2")
+	defer func() {
+		handle.AutoAnalyzeMinCnt = 1000
+		tk.MustExec("set global tidb_auto_analyze_ratio = 0.0")
+	}()
+	require.False(t, h.HandleAutoAnalyze(is))
+
+	tk.MustExec("analyze table t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip analyze locked table: t"))
+	tk.MustExec("analyze table t index idx")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip analyze locked table: t"))
+	require.Equal(t, int64(3), h.GetTableStats(tableInfo).Count)
+
+	tk.MustExec("unlock stats t")
+	tk.MustQuery("show stats_locked").Check(testkit.Rows())
+	require.NoError(t, h.Update(is))
+	statsTbl := h.GetTableStats(tableInfo)
+	require.Equal(t, int64(5), statsTbl.Count)
+	require.Equal(t, int64(2), statsTbl.ModifyCount)
+	tk.MustExec("analyze table t")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+}
+
+func TestLockAndUnlockPartitionStats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	tk.MustExec("create table t (a int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("insert into t values (1), (11)")
+
+	tk.MustExec("lock stats t partition p0")
+	tk.MustQuery("show stats_locked").Check(testkit.Rows("test t p0 locked"))
+	tk.MustExec("analyze table t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip analyze locked table: t partition (p0)"))
+	tk.MustQuery("select partition_name from mysql.stats_meta m join information_schema.partitions p on m.table_id = p.tidb_partition_id where p.table_name = 't' and m.count > 0").Check(testkit.Rows("p1"))
+	tk.MustExec("unlock stats t partition p0")
+
+	tk.MustExec("lock stats t")
+	tk.MustQuery("show stats_locked").Sort().Check(testkit.Rows("test t  locked", "test t p0 locked", "test t p1 locked"))
+	tk.MustExec("unlock stats t")
+	tk.MustQuery("show stats_locked").Check(testkit.Rows())
+}
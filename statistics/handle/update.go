@@ -482,12 +482,16 @@ func (h *Handle) DumpStatsDeltaToKV(mode dumpMode) error {
 		h.globalMap.data = deltaMap
 		h.globalMap.Unlock()
 	}()
+	lockedTables, err := h.GetLockedTables()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	currentTime := time.Now()
 	for id, item := range deltaMap {
 		if mode == DumpDelta && !needDumpStatsDelta(h, id, item, currentTime) {
 			continue
 		}
-		updated, err := h.dumpTableStatCountToKV(id, item)
+		updated, err := h.dumpTableStatCountToKV(id, item, lockedTables)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -510,7 +514,8 @@ func (h *Handle) DumpStatsDeltaToKV(mode dumpMode) error {
 }
 
 // dumpTableStatDeltaToKV dumps a single delta with some table to KV and updates the version.
-func (h *Handle) dumpTableStatCountToKV(id int64, delta variable.TableDelta) (updated bool, err error) {
+// The delta of the locked tables is kept in mysql.stats_table_locked until they are unlocked.
+func (h *Handle) dumpTableStatCountToKV(id int64, delta variable.TableDelta, lockedTables map[int64]struct{}) (updated bool, err error) {
 	statsVer := uint64(0)
 	defer func() {
 		if err == nil && statsVer != 0 {
@@ -539,6 +544,10 @@ func (h *Handle) dumpTableStatCountToKV(id int64, delta variable.TableDelta) (up
 	startTS := txn.StartTS()
 	updateStatsMeta := func(id int64) error {
 		var err error
+		if _, ok := lockedTables[id]; ok {
+			_, err = exec.ExecuteInternal(ctx, "update mysql.stats_table_locked set version = %?, count = count + %?, modify_count = modify_count + %? where table_id = %?", startTS, delta.Delta, delta.Count, id)
+			return errors.Trace(err)
+		}
 		if delta.Delta < 0 {
 			_, err = exec.ExecuteInternal(ctx, "update mysql.stats_meta set version = %?, count = count - %?, modify_count = modify_count + %? where table_id = %? and count >= %?", startTS, -delta.Delta, delta.Count, id, -delta.Delta)
 		} else {
@@ -1071,6 +1080,21 @@ func (h *Handle) HandleAutoAnalyze(is infoschema.InfoSchema) (analyzed bool) {
 		logutil.BgLogger().Error("[stats] load tidb_enable_analyze_snapshot for auto analyze session failed", zap.Error(err))
 		return false
 	}
+	lockedTables, err := h.GetLockedTables()
+	if err != nil {
+		logutil.BgLogger().Error("[stats] load locked tables for auto analyze session failed", zap.Error(err))
+		return false
+	}
+	if variable.EnableAutoAnalyzePriorityQueue.Load() {
+		return h.autoAnalyzeByPriority(is, &autoAnalyzeParams{
+			ratio:           autoAnalyzeRatio,
+			start:           start,
+			end:             end,
+			pruneMode:       pruneMode,
+			analyzeSnapshot: analyzeSnapshot,
+			lockedTables:    lockedTables,
+		})
+	}
 	rd := rand.New(rand.NewSource(time.Now().UnixNano())) // #nosec G404
 	rd.Shuffle(len(dbs), func(i, j int) {
 		dbs[i], dbs[j] = dbs[j], dbs[i]
@@ -1083,7 +1107,7 @@ func (h *Handle) HandleAutoAnalyze(is infoschema.InfoSchema) (analyzed bool) {
 		// We shuffle dbs and tbls so that the order of iterating tables is random. If the order is fixed and the auto
 		// analyze job of one table fails for some reason, it may always analyze the same table and fail again and again
 		// when the HandleAutoAnalyze is triggered. Randomizing the order can avoid the problem.
-		// TODO: Design a priority queue to place the table which needs analyze most in the front.
+		// The tables are analyzed in the order of their priority if tidb_enable_auto_analyze_priority_queue is on.
 		rd.Shuffle(len(tbls), func(i, j int) {
 			tbls[i], tbls[j] = tbls[j], tbls[i]
 		})
@@ -1092,6 +1116,10 @@ func (h *Handle) HandleAutoAnalyze(is infoschema.InfoSchema) (analyzed bool) {
 			if tblInfo.IsView() {
 				continue
 			}
+			// The statistics of the locked tables are never analyzed automatically.
+			if _, ok := lockedTables[tblInfo.ID]; ok {
+				continue
+			}
 			pi := tblInfo.GetPartitionInfo()
 			if pi == nil {
 				statsTbl := h.GetTableStats(tblInfo)
@@ -1105,13 +1133,16 @@ func (h *Handle) HandleAutoAnalyze(is infoschema.InfoSchema) (analyzed bool) {
 				continue
 			}
 			if pruneMode == variable.Dynamic {
-				analyzed := h.autoAnalyzePartitionTableInDynamicMode(tblInfo, pi, db, autoAnalyzeRatio, analyzeSnapshot)
+				analyzed := h.autoAnalyzePartitionTableInDynamicMode(tblInfo, pi, db, autoAnalyzeRatio, analyzeSnapshot, lockedTables)
 				if analyzed {
 					return true
 				}
 				continue
 			}
 			for _, def := range pi.Definitions {
+				if _, ok := lockedTables[def.ID]; ok {
+					continue
+				}
 				sql := "analyze table %n.%n partition %n"
 				statsTbl := h.GetPartitionStats(tblInfo, def.ID)
 				analyzed := h.autoAnalyzeTable(tblInfo, statsTbl, autoAnalyzeRatio, analyzeSnapshot, sql, db, tblInfo.Name.O, def.Name.O)
@@ -1157,13 +1188,16 @@ func (h *Handle) autoAnalyzeTable(tblInfo *model.TableInfo, statsTbl *statistics
 	return false
 }
 
-func (h *Handle) autoAnalyzePartitionTableInDynamicMode(tblInfo *model.TableInfo, pi *model.PartitionInfo, db string, ratio float64, analyzeSnapshot bool) bool {
+func (h *Handle) autoAnalyzePartitionTableInDynamicMode(tblInfo *model.TableInfo, pi *model.PartitionInfo, db string, ratio float64, analyzeSnapshot bool, lockedTables map[int64]struct{}) bool {
 	h.mu.RLock()
 	tableStatsVer := h.mu.ctx.GetSessionVars().AnalyzeVersion
 	h.mu.RUnlock()
 	analyzePartitionBatchSize := int(variable.AutoAnalyzePartitionBatchSize.Load())
 	partitionNames := make([]interface{}, 0, len(pi.Definitions))
 	for _, def := range pi.Definitions {
+		if _, ok := lockedTables[def.ID]; ok {
+			continue
+		}
 		partitionStatsTbl := h.GetPartitionStats(tblInfo, def.ID)
 		if partitionStatsTbl.Pseudo || partitionStatsTbl.Count < AutoAnalyzeMinCnt {
 			continue
@@ -1215,6 +1249,9 @@ func (h *Handle) autoAnalyzePartitionTableInDynamicMode(tblInfo *model.TableInfo
 			continue
 		}
 		for _, def := range pi.Definitions {
+			if _, ok := lockedTables[def.ID]; ok {
+				continue
+			}
 			partitionStatsTbl := h.GetPartitionStats(tblInfo, def.ID)
 			if _, ok := partitionStatsTbl.Indices[idx.ID]; !ok {
 				partitionNames = append(partitionNames, def.Name.O)
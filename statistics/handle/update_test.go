@@ -2398,3 +2398,59 @@ func TestEnableAndDisableColumnTracking(t *testing.T) {
 	tk.MustExec("set global tidb_enable_column_tracking = 0")
 	tk.MustQuery("show column_stats_usage where db_name = 'test' and table_name = 't' and last_used_at is not null").Check(testkit.Rows())
 }
+
+func TestAutoAnalyzePriorityQueue(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("insert into t1 values (1), (2), (3), (4), (5)")
+	tk.MustExec("insert into t2 values (1), (2), (3), (4), (5)")
+	h := dom.StatsHandle()
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	tk.MustExec("analyze table t1, t2")
+
+	is := dom.InfoSchema()
+	tbl1, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t1"))
+	require.NoError(t, err)
+	tbl2, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t2"))
+	require.NoError(t, err)
+	tk.MustExec("insert into t1 values (6), (7), (8)")
+	tk.MustExec("insert into t2 values (6), (7), (8), (9), (10), (11), (12), (13), (14), (15)")
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	require.NoError(t, h.Update(is))
+
+	handle.AutoAnalyzeMinCnt = 0
+	tk.MustExec("set global tidb_auto_analyze_ratio = 0.2")
+	defer func() {
+		handle.AutoAnalyzeMinCnt = 1000
+		tk.MustExec("set global tidb_auto_analyze_ratio = 0.0")
+	}()
+
+	// t2 has more modifications, so it's analyzed before t1.
+	require.True(t, h.HandleAutoAnalyze(is))
+	require.NoError(t, h.Update(is))
+	require.Equal(t, int64(0), h.GetTableStats(tbl2.Meta()).ModifyCount)
+	require.Equal(t, int64(3), h.GetTableStats(tbl1.Meta()).ModifyCount)
+	tk.MustQuery("select table_name from mysql.analyze_queue").Check(testkit.Rows("t1"))
+	tk.MustQuery("select table_name, state from information_schema.analyze_status where state = 'pending'").Check(testkit.Rows("t1 pending"))
+
+	require.True(t, h.HandleAutoAnalyze(is))
+	require.NoError(t, h.Update(is))
+	require.Equal(t, int64(0), h.GetTableStats(tbl1.Meta()).ModifyCount)
+	tk.MustQuery("select table_name from mysql.analyze_queue").Check(testkit.Rows())
+	require.False(t, h.HandleAutoAnalyze(is))
+
+	// The tables are analyzed concurrently by the workers.
+	tk.MustExec("set global tidb_auto_analyze_concurrency = 2")
+	defer tk.MustExec("set global tidb_auto_analyze_concurrency = default")
+	tk.MustExec("insert into t1 values (9), (10), (11), (12), (13), (14), (15), (16)")
+	tk.MustExec("insert into t2 select a + 15 from t2")
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	require.NoError(t, h.Update(is))
+	require.True(t, h.HandleAutoAnalyze(is))
+	require.NoError(t, h.Update(is))
+	require.Equal(t, int64(0), h.GetTableStats(tbl1.Meta()).ModifyCount)
+	require.Equal(t, int64(0), h.GetTableStats(tbl2.Meta()).ModifyCount)
+}
@@ -23,12 +23,15 @@ import (
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx/binloginfo"
 	derr "github.com/pingcap/tidb/store/driver/error"
 	"github.com/pingcap/tidb/store/driver/options"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/resourcegroup"
+	"github.com/pingcap/tidb/util/resourcegrouptag"
 	tikverr "github.com/tikv/client-go/v2/error"
 	tikvstore "github.com/tikv/client-go/v2/kv"
 	"github.com/tikv/client-go/v2/tikv"
@@ -77,6 +80,13 @@ func (txn *tikvTxn) LockKeys(ctx context.Context, lockCtx *kv.LockCtx, keysInput
 }
 
 func (txn *tikvTxn) Commit(ctx context.Context) error {
+	if name := resourcegrouptag.GetResourceGroupNameFromCtx(ctx); name != "" {
+		// Wait for the tokens of the resource group before writing the data.
+		ru := resourcegroup.WriteRequestRU + resourcegroup.WriteRU(txn.Size())
+		if err := resourcegroup.GlobalController().Acquire(ctx, name, metrics.LblRUWrite, ru); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	err := txn.KVTxn.Commit(ctx)
 	return txn.extractKeyErr(err)
 }
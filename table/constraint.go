@@ -0,0 +1,74 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+// Constraint provides meta and the evaluable expression of a check constraint.
+type Constraint struct {
+	*model.ConstraintInfo
+	// ConstraintExpr is the expression of the check constraint, the columns in it
+	// refer to the public columns of the table by their offsets.
+	ConstraintExpr expression.Expression
+}
+
+// ToConstraint converts model.ConstraintInfo to Constraint.
+func ToConstraint(constraintInfo *model.ConstraintInfo, tblInfo *model.TableInfo) (*Constraint, error) {
+	expr, err := BuildConstraintExpr(mock.NewContext(), constraintInfo.ExprString, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Constraint{
+		ConstraintInfo: constraintInfo,
+		ConstraintExpr: expr,
+	}, nil
+}
+
+// BuildConstraintExpr builds the expression of a check constraint from its restored string.
+func BuildConstraintExpr(ctx sessionctx.Context, exprString string, tblInfo *model.TableInfo) (expression.Expression, error) {
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, exprString, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return expr, nil
+}
+
+// CheckRowConstraint checks whether the row satisfies all the given check constraints.
+// As in MySQL, a constraint is only violated when its expression evaluates to FALSE,
+// an expression evaluating to NULL is regarded as satisfied.
+func CheckRowConstraint(sctx sessionctx.Context, constraints []*Constraint, row []types.Datum) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	r := chunk.MutRowFromDatums(row).ToRow()
+	for _, constraint := range constraints {
+		val, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, r)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !isNull && val == 0 {
+			return ErrCheckConstraintViolated.GenWithStackByArgs(constraint.Name.O)
+		}
+	}
+	return nil
+}
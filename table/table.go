@@ -102,6 +102,8 @@ var (
 	ErrRowDoesNotMatchGivenPartitionSet = dbterror.ClassTable.NewStd(mysql.ErrRowDoesNotMatchGivenPartitionSet)
 	// ErrTempTableFull returns a table is full error, it's used by temporary table now.
 	ErrTempTableFull = dbterror.ClassTable.NewStd(mysql.ErrRecordFileFull)
+	// ErrCheckConstraintViolated returns when a row violates a check constraint.
+	ErrCheckConstraintViolated = dbterror.ClassTable.NewStd(mysql.ErrCheckConstraintViolated)
 	// ErrOptOnCacheTable returns when exec unsupported opt at cache mode
 	ErrOptOnCacheTable = dbterror.ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
 )
@@ -196,6 +198,9 @@ type Table interface {
 
 	// Type returns the type of table
 	Type() Type
+
+	// WritableConstraint returns the enforced check constraints which should be checked when writing rows.
+	WritableConstraint() []*Constraint
 }
 
 // AllocAutoIncrementValue allocates an auto_increment value for a new row.
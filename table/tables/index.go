@@ -16,6 +16,7 @@ package tables
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/opentracing/opentracing-go"
@@ -27,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/rowcodec"
 )
 
@@ -45,6 +47,12 @@ type index struct {
 
 // NeedRestoredData checks whether the index columns needs restored data.
 func NeedRestoredData(idxCols []*model.IndexColumn, colInfos []*model.ColumnInfo) bool {
+	for _, idxCol := range idxCols {
+		// The multi-valued index is never used as a covering index, so nothing needs to be restored.
+		if colInfos[idxCol.Offset].FieldType.IsArray() {
+			return false
+		}
+	}
 	for _, idxCol := range idxCols {
 		col := colInfos[idxCol.Offset]
 		if types.NeedRestoredData(&col.FieldType) {
@@ -102,10 +110,91 @@ func (c *index) GenIndexValue(sc *stmtctx.StatementContext, distinct bool, index
 	return tablecodec.GenIndexValuePortal(sc, c.tblInfo, c.idxInfo, c.needRestoredData, distinct, false, indexedValues, h, c.phyTblID, restoredData)
 }
 
+// GetIndexedValues returns the indexed values of all the entries of a row in the index. A normal index has only
+// one entry for a row, while a multi-valued index has an entry for every distinct element of the array, or an
+// entry of NULL if the array is NULL or empty.
+func GetIndexedValues(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, idxInfo *model.IndexInfo, indexedValues []types.Datum) ([][]types.Datum, error) {
+	if !idxInfo.MVIndex {
+		return [][]types.Datum{indexedValues}, nil
+	}
+	arrayOffset := -1
+	var elemTp *types.FieldType
+	for i, idxCol := range idxInfo.Columns {
+		if col := tblInfo.Columns[idxCol.Offset]; col.FieldType.IsArray() {
+			arrayOffset, elemTp = i, col.FieldType.ArrayType()
+			break
+		}
+	}
+	// The values may have been split already, e.g. the index records of backfilling.
+	if arrayOffset < 0 || indexedValues[arrayOffset].Kind() != types.KindMysqlJSON ||
+		indexedValues[arrayOffset].GetMysqlJSON().TypeCode != types.JSONTypeCodeArray {
+		return [][]types.Datum{indexedValues}, nil
+	}
+	bj := indexedValues[arrayOffset].GetMysqlJSON()
+	elemCount := bj.GetElemCount()
+	elems := make([]types.Datum, 0, elemCount)
+	for i := 0; i < elemCount; i++ {
+		elems = append(elems, types.NewDatumFromJSONScalar(bj.ArrayGetElem(i), elemTp.GetCollate()))
+	}
+	// The equal elements produce the same index key, so sort and deduplicate them.
+	var err error
+	collator := collate.GetCollator(elemTp.GetCollate())
+	sort.Slice(elems, func(i, j int) bool {
+		cmp, err1 := elems[i].Compare(sc, &elems[j], collator)
+		if err1 != nil {
+			err = err1
+		}
+		return cmp < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]types.Datum, 0, len(elems))
+	for i := range elems {
+		if i > 0 {
+			cmp, err := elems[i].Compare(sc, &elems[i-1], collator)
+			if err != nil {
+				return nil, err
+			}
+			if cmp == 0 {
+				continue
+			}
+		}
+		vals := make([]types.Datum, len(indexedValues))
+		copy(vals, indexedValues)
+		vals[arrayOffset] = elems[i]
+		result = append(result, vals)
+	}
+	if len(result) == 0 {
+		vals := make([]types.Datum, len(indexedValues))
+		copy(vals, indexedValues)
+		vals[arrayOffset].SetNull()
+		result = append(result, vals)
+	}
+	return result, nil
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
+// A multi-valued index creates an entry for every distinct element of the array.
 func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum, opts ...table.CreateIdxOptFunc) (kv.Handle, error) {
+	if !c.idxInfo.MVIndex {
+		return c.create(sctx, txn, indexedValues, h, handleRestoreData, opts...)
+	}
+	valsList, err := GetIndexedValues(sctx.GetSessionVars().StmtCtx, c.tblInfo, c.idxInfo, indexedValues)
+	if err != nil {
+		return nil, err
+	}
+	for _, vals := range valsList {
+		if dupHandle, err := c.create(sctx, txn, vals, h, handleRestoreData, opts...); err != nil {
+			return dupHandle, err
+		}
+	}
+	return nil, nil
+}
+
+func (c *index) create(sctx sessionctx.Context, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum, opts ...table.CreateIdxOptFunc) (kv.Handle, error) {
 	if c.Meta().Unique {
 		txn.CacheTableInfo(c.phyTblID, c.tblInfo)
 	}
@@ -277,6 +366,22 @@ var (
 
 // Delete removes the entry for handle h and indexedValues from KV index.
 func (c *index) Delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	if !c.idxInfo.MVIndex {
+		return c.delete(sc, txn, indexedValues, h)
+	}
+	valsList, err := GetIndexedValues(sc, c.tblInfo, c.idxInfo, indexedValues)
+	if err != nil {
+		return err
+	}
+	for _, vals := range valsList {
+		if err := c.delete(sc, txn, vals, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *index) delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
 	key, distinct, err := c.GenIndexKey(sc, indexedValues, h, nil)
 	if err != nil {
 		return err
@@ -355,7 +460,25 @@ func genTempIdxKeyByState(indexInfo *model.IndexInfo, indexKey kv.Key) (key, tem
 	return indexKey, nil, TempIndexKeyTypeNone
 }
 
+// Exist checks whether the entry exists, all the entries of the row must exist for a multi-valued index.
 func (c *index) Exist(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) (bool, kv.Handle, error) {
+	if !c.idxInfo.MVIndex {
+		return c.exist(sc, txn, indexedValues, h)
+	}
+	valsList, err := GetIndexedValues(sc, c.tblInfo, c.idxInfo, indexedValues)
+	if err != nil {
+		return false, nil, err
+	}
+	for _, vals := range valsList {
+		exist, handle, err := c.exist(sc, txn, vals, h)
+		if !exist || err != nil {
+			return exist, handle, err
+		}
+	}
+	return true, h, nil
+}
+
+func (c *index) exist(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) (bool, kv.Handle, error) {
 	key, distinct, err := c.GenIndexKey(sc, indexedValues, h, nil)
 	if err != nil {
 		return false, nil, err
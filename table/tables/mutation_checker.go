@@ -209,6 +209,10 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		// The entry of a multi-valued index holds only an element of the array, it can't be compared with the row.
+		if indexInfo.MVIndex {
+			continue
+		}
 
 		// If this is temp index data, need remove last byte of index data.
 		if idxID != m.indexID {
@@ -19,6 +19,7 @@ import (
 	"context"
 	stderr "errors"
 	"fmt"
+	"hash/fnv"
 	"sort"
 	"strconv"
 	"strings"
@@ -82,6 +83,12 @@ type partitionedTable struct {
 	partitions      map[int64]*partition
 	evalBufferTypes []*types.FieldType
 	evalBufferPool  sync.Pool
+
+	// reorganizedPartitions is the set of visible partitions being reorganized by REORGANIZE PARTITION.
+	// The writes to them are also applied to the invisible partitions of reorgTable.
+	reorganizedPartitions map[int64]struct{}
+	// reorgTable has the partition layout that the invisible partitions of REORGANIZE PARTITION belong to.
+	reorgTable *partitionedTable
 }
 
 func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
@@ -111,9 +118,95 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Tabl
 		partitions[p.ID] = &t
 	}
 	ret.partitions = partitions
+	if pi.DDLAction == model.ActionReorganizePartition && pi.DDLState != model.StateNone {
+		if err := initReorganizedPartitions(ret, tblInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return ret, nil
 }
 
+// initReorganizedPartitions prepares the double writing of REORGANIZE PARTITION. Before StateDeleteReorganization
+// the reorganized partitions are visible and the new partitions are not, then it's reversed until the job is done.
+// Either way, the writes to the visible ones are applied to the invisible ones, so the servers that see
+// the other partition layout can read the same data.
+func initReorganizedPartitions(t *partitionedTable, tblInfo *model.TableInfo) error {
+	pi := tblInfo.Partition
+	visible, invisible := pi.DroppingDefinitions, pi.AddingDefinitions
+	if pi.DDLState == model.StateDeleteReorganization {
+		visible, invisible = pi.AddingDefinitions, pi.DroppingDefinitions
+	}
+
+	reorgTblInfo := *tblInfo
+	reorgPi := *pi
+	reorgPi.Definitions = model.ReplacePartitionDefinitions(pi.Definitions, visible, invisible)
+	reorgPi.AddingDefinitions = nil
+	reorgPi.DroppingDefinitions = nil
+	reorgPi.DDLState = model.StateNone
+	reorgPi.DDLAction = model.ActionNone
+	reorgTblInfo.Partition = &reorgPi
+
+	var tc TableCommon
+	initTableCommon(&tc, &reorgTblInfo, reorgTblInfo.ID, t.Columns, t.allocs)
+	reorgTbl, err := newPartitionedTable(&tc, &reorgTblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.reorgTable = reorgTbl.(*partitionedTable)
+	t.reorganizedPartitions = make(map[int64]struct{}, len(visible))
+	for _, def := range visible {
+		t.reorganizedPartitions[def.ID] = struct{}{}
+	}
+	return nil
+}
+
+func (t *partitionedTable) isReorganizedPartition(pid int64) bool {
+	_, ok := t.reorganizedPartitions[pid]
+	return ok
+}
+
+// reorgDeleteOnly returns true if only the deletions should be applied to the invisible partitions.
+func (t *partitionedTable) reorgDeleteOnly() bool {
+	return t.meta.Partition.DDLState == model.StateDeleteOnly
+}
+
+// locateReorgPartition locates the row in the invisible partitions of REORGANIZE PARTITION.
+func (t *partitionedTable) locateReorgPartition(ctx sessionctx.Context, r []types.Datum) (table.PhysicalTable, error) {
+	pid, err := t.reorgTable.locatePartition(ctx, t.reorgTable.meta.Partition, r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := t.reorgTable.GetPartition(pid)
+	if p == nil {
+		return nil, errors.Errorf("cannot find the reorganized partition %d", pid)
+	}
+	return p, nil
+}
+
+// addReorgRecord adds the record with the handle `h` into the invisible partitions of REORGANIZE PARTITION.
+func (t *partitionedTable) addReorgRecord(ctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	p, err := t.locateReorgPartition(ctx, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !t.meta.PKIsHandle && !t.meta.IsCommonHandle && len(r) == len(t.Cols()) {
+		// Keep the same _tidb_rowid as the visible partition.
+		r = append(r[:len(r):len(r)], types.NewIntDatum(h.IntValue()))
+	}
+	// The options of the visible partition are not passed, the handle is always given explicitly here.
+	_, err = p.AddRecord(ctx, r)
+	return errors.Trace(err)
+}
+
+// removeReorgRecord removes the record with the handle `h` from the invisible partitions of REORGANIZE PARTITION.
+func (t *partitionedTable) removeReorgRecord(ctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	p, err := t.locateReorgPartition(ctx, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.RemoveRecord(ctx, h, r))
+}
+
 func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 	// a partitioned table cannot rely on session context/sql modes, so use a default one!
 	ctx := mock.NewContext()
@@ -123,11 +216,42 @@ func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 		return nil, err
 	}
 	pi := tblInfo.GetPartitionInfo()
+	if pi.Sub == nil {
+		return generatePartitionExpr(ctx, tblInfo, columns, names)
+	}
+	// The partition expression of a subpartitioned table locates the parent partition,
+	// and its Sub locates the subpartition in the parent partition.
+	parentTblInfo := *tblInfo
+	parentTblInfo.Partition = pi.ParentPartitionInfo()
+	ret, err := generatePartitionExpr(ctx, &parentTblInfo, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	subTblInfo := *tblInfo
+	subTblInfo.Partition = &model.PartitionInfo{
+		Type:    pi.Sub.Type,
+		Expr:    pi.Sub.Expr,
+		Columns: pi.Sub.Columns,
+		Num:     pi.Sub.Num,
+		Enable:  true,
+	}
+	ret.Sub, err = generatePartitionExpr(ctx, &subTblInfo, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func generatePartitionExpr(ctx sessionctx.Context, tblInfo *model.TableInfo,
+	columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
+	pi := tblInfo.GetPartitionInfo()
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return generateRangePartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeHash:
 		return generateHashPartitionExpr(ctx, pi, columns, names)
+	case model.PartitionTypeKey:
+		return generateKeyPartitionExpr(pi, columns, names)
 	case model.PartitionTypeList:
 		return generateListPartitionExpr(ctx, tblInfo, columns, names)
 	}
@@ -151,6 +275,10 @@ type PartitionExpr struct {
 	// InValues: x in (1,2); x in (3,4); x in (5,6), used for list partition.
 	InValues []expression.Expression
 	*ForListPruning
+	// Used in the key partition locating and pruning process.
+	*ForKeyPruning
+	// Sub is the subpartition expression, it's nil if the table is not subpartitioned.
+	Sub *PartitionExpr
 }
 
 func initEvalBufferType(t *partitionedTable) {
@@ -934,19 +1062,77 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}, nil
 }
 
+// ForKeyPruning is used for key partition locating and pruning.
+type ForKeyPruning struct {
+	// KeyPartCols is the partition columns of PARTITION BY KEY.
+	KeyPartCols []*expression.Column
+}
+
+func generateKeyPartitionExpr(pi *model.PartitionInfo, columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
+	keyPartCols := make([]*expression.Column, 0, len(pi.Columns))
+	offset := make([]int, 0, len(pi.Columns))
+	for _, colName := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, colName.L)
+		if idx < 0 {
+			return nil, errors.Trace(table.ErrUnknownColumn.GenWithStackByArgs(colName.O, "partition function"))
+		}
+		keyPartCols = append(keyPartCols, columns[idx])
+		offset = append(offset, idx)
+	}
+	return &PartitionExpr{
+		ColumnOffset:  offset,
+		ForKeyPruning: &ForKeyPruning{KeyPartCols: keyPartCols},
+	}, nil
+}
+
+// LocateKeyPartition returns the partition index of the key partition column values.
+// The values must have been converted to the types of the partition columns.
+// The hash is collation aware, so the values that are equal under the collation are in the same partition.
+// Note that it's not the hash of MySQL: it's the FNV-64 hash of the values encoded by codec.HashChunkRow, modulo the
+// number of partitions. The rows are stored in the partitions located by it, so it's an on-disk contract: changing the
+// encoding of codec.HashChunkRow or the sort keys of the collations makes the existing rows unreachable.
+// TestLocateKeyPartition pins the results.
+func (kp *ForKeyPruning) LocateKeyPartition(sc *stmtctx.StatementContext, numParts uint64, vals []types.Datum) (int, error) {
+	fts := make([]*types.FieldType, 0, len(kp.KeyPartCols))
+	colIdx := make([]int, 0, len(kp.KeyPartCols))
+	for i, col := range kp.KeyPartCols {
+		fts = append(fts, col.RetType)
+		colIdx = append(colIdx, i)
+	}
+	row := chunk.MutRowFromTypes(fts)
+	row.SetDatums(vals...)
+	h := fnv.New64()
+	buf := make([]byte, 1)
+	if err := codec.HashChunkRow(sc, h, row.ToRow(), fts, colIdx, buf); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return int(h.Sum64() % numParts), nil
+}
+
 // PartitionExpr returns the partition expression.
 func (t *partitionedTable) PartitionExpr() (*PartitionExpr, error) {
 	return t.partitionExpr, nil
 }
 
 func (t *partitionedTable) GetPartitionColumnNames() []model.CIStr {
-	// PARTITION BY {LIST|RANGE} COLUMNS uses columns directly without expressions
 	pi := t.Meta().Partition
-	if len(pi.Columns) > 0 {
-		return pi.Columns
+	colNames := t.getPartitionColumnNames(pi.Columns, t.partitionExpr)
+	if pi.Sub == nil {
+		return colNames
 	}
+	subColNames := t.getPartitionColumnNames(pi.Sub.Columns, t.partitionExpr.Sub)
+	ret := make([]model.CIStr, 0, len(colNames)+len(subColNames))
+	ret = append(ret, colNames...)
+	return append(ret, subColNames...)
+}
 
-	partitionCols := expression.ExtractColumns(t.partitionExpr.Expr)
+func (t *partitionedTable) getPartitionColumnNames(columns []model.CIStr, pe *PartitionExpr) []model.CIStr {
+	// PARTITION BY {LIST|RANGE} COLUMNS and PARTITION BY KEY use columns directly without expressions
+	if len(columns) > 0 {
+		return columns
+	}
+
+	partitionCols := expression.ExtractColumns(pe.Expr)
 	colIDs := make([]int64, 0, len(partitionCols))
 	for _, col := range partitionCols {
 		colIDs = append(colIDs, col.ID)
@@ -991,13 +1177,29 @@ func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.Par
 			idx, err = t.locateRangeColumnPartition(ctx, pi, r)
 		}
 	case model.PartitionTypeHash:
-		idx, err = t.locateHashPartition(ctx, pi, r)
+		idx, err = t.locateHashPartition(ctx, t.partitionExpr, pi.Num, r)
+	case model.PartitionTypeKey:
+		idx, err = t.locateKeyPartition(ctx, t.partitionExpr, pi.Num, r)
 	case model.PartitionTypeList:
 		idx, err = t.locateListPartition(ctx, pi, r)
 	}
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
+	if sub := pi.Sub; sub != nil {
+		// The subpartitions of the idx-th parent partition are Definitions[idx*sub.Num : (idx+1)*sub.Num].
+		var subIdx int
+		switch sub.Type {
+		case model.PartitionTypeHash:
+			subIdx, err = t.locateHashPartition(ctx, t.partitionExpr.Sub, sub.Num, r)
+		case model.PartitionTypeKey:
+			subIdx, err = t.locateKeyPartition(ctx, t.partitionExpr.Sub, sub.Num, r)
+		}
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		idx = idx*int(sub.Num) + subIdx
+	}
 	return pi.Definitions[idx].ID, nil
 }
 
@@ -1106,8 +1308,8 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 }
 
 // TODO: supports linear hashing
-func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pe *PartitionExpr, num uint64, r []types.Datum) (int, error) {
+	if col, ok := pe.Expr.(*expression.Column); ok {
 		var data types.Datum
 		switch r[col.Index].Kind() {
 		case types.KindInt64, types.KindUint64:
@@ -1120,7 +1322,7 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 			}
 		}
 		ret := data.GetInt64()
-		ret = ret % int64(t.meta.Partition.Num)
+		ret = ret % int64(num)
 		if ret < 0 {
 			ret = -ret
 		}
@@ -1129,20 +1331,28 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
 	evalBuffer.SetDatums(r...)
-	ret, isNull, err := t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+	ret, isNull, err := pe.Expr.EvalInt(ctx, evalBuffer.ToRow())
 	if err != nil {
 		return 0, err
 	}
 	if isNull {
 		return 0, nil
 	}
-	ret = ret % int64(t.meta.Partition.Num)
+	ret = ret % int64(num)
 	if ret < 0 {
 		ret = -ret
 	}
 	return int(ret), nil
 }
 
+func (t *partitionedTable) locateKeyPartition(ctx sessionctx.Context, pe *PartitionExpr, num uint64, r []types.Datum) (int, error) {
+	vals := make([]types.Datum, 0, len(pe.ColumnOffset))
+	for _, offset := range pe.ColumnOffset {
+		vals = append(vals, r[offset])
+	}
+	return pe.LocateKeyPartition(ctx.GetSessionVars().StmtCtx, num, vals)
+}
+
 // GetPartition returns a Table, which is actually a partition.
 func (t *partitionedTable) GetPartition(pid int64) table.PhysicalTable {
 	// Attention, can't simply use `return t.partitions[pid]` here.
@@ -1193,7 +1403,16 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 		}
 	}
 	tbl := t.GetPartition(pid)
-	return tbl.AddRecord(ctx, r, opts...)
+	recordID, err = tbl.AddRecord(ctx, r, opts...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if t.isReorganizedPartition(pid) && !t.reorgDeleteOnly() {
+		if err = t.addReorgRecord(ctx, recordID, r); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return recordID, nil
 }
 
 // partitionTableWithGivenSets is used for this kind of grammar: partition (p0,p1)
@@ -1237,7 +1456,13 @@ func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r [
 	}
 
 	tbl := t.GetPartition(pid)
-	return tbl.RemoveRecord(ctx, h, r)
+	if err = tbl.RemoveRecord(ctx, h, r); err != nil {
+		return errors.Trace(err)
+	}
+	if t.isReorganizedPartition(pid) {
+		return t.removeReorgRecord(ctx, h, r)
+	}
+	return nil
 }
 
 func (t *partitionedTable) GetAllPartitionIDs() []int64 {
@@ -1297,11 +1522,51 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 			logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
 			return errors.Trace(err)
 		}
-		return nil
+		return t.updateReorgRecord(gctx, ctx, h, currData, newData, touched, from, to)
 	}
 
 	tbl := t.GetPartition(to)
-	return tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
+	if err = tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched); err != nil {
+		return errors.Trace(err)
+	}
+	return t.updateReorgRecord(gctx, ctx, h, currData, newData, touched, from, to)
+}
+
+// updateReorgRecord applies the update of a record from partition `from` to partition `to` to the invisible
+// partitions of REORGANIZE PARTITION.
+func (t *partitionedTable) updateReorgRecord(gctx context.Context, ctx sessionctx.Context, h kv.Handle, currData, newData []types.Datum, touched []bool, from, to int64) error {
+	fromReorg, toReorg := t.isReorganizedPartition(from), t.isReorganizedPartition(to)
+	if !fromReorg && !toReorg {
+		return nil
+	}
+	if t.reorgDeleteOnly() {
+		if fromReorg {
+			return t.removeReorgRecord(ctx, h, currData)
+		}
+		return nil
+	}
+	if fromReorg && toReorg {
+		reorgFrom, err := t.locateReorgPartition(ctx, currData)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		reorgTo, err := t.locateReorgPartition(ctx, newData)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if reorgFrom.GetPhysicalID() == reorgTo.GetPhysicalID() {
+			return errors.Trace(reorgTo.UpdateRecord(gctx, ctx, h, currData, newData, touched))
+		}
+	}
+	if toReorg {
+		if err := t.addReorgRecord(ctx, h, newData); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if fromReorg {
+		return t.removeReorgRecord(ctx, h, currData)
+	}
+	return nil
 }
 
 // FindPartitionByName finds partition in table meta by name.
@@ -1316,6 +1581,26 @@ func FindPartitionByName(meta *model.TableInfo, parName string) (int64, error) {
 	return -1, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(parName, meta.Name.O))
 }
 
+// FindPartitionIDsByName finds the physical partitions in table meta by name. The name of a
+// subpartitioned parent partition matches all its subpartitions.
+func FindPartitionIDsByName(meta *model.TableInfo, parName string) ([]int64, error) {
+	pi := meta.Partition
+	if pi.Sub != nil {
+		if start, end := pi.SubPartitionRange(pi.Definitions, parName); start < end {
+			ids := make([]int64, 0, end-start)
+			for _, def := range pi.Definitions[start:end] {
+				ids = append(ids, def.ID)
+			}
+			return ids, nil
+		}
+	}
+	id, err := FindPartitionByName(meta, parName)
+	if err != nil {
+		return nil, err
+	}
+	return []int64{id}, nil
+}
+
 func parseExpr(p *parser.Parser, exprStr string) (ast.ExprNode, error) {
 	exprStr = "select " + exprStr
 	stmts, _, err := p.ParseSQL(exprStr)
@@ -20,15 +20,19 @@ import (
 
 	"github.com/pingcap/failpoint"
 	mysql "github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/model"
+	pmysql "github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/binloginfo"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessiontxn"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/stretchr/testify/require"
 )
@@ -727,3 +731,32 @@ func TestPruneModeWarningInfo(t *testing.T) {
 	tk.MustExec("set global tidb_partition_prune_mode = 'dynamic'")
 	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 Please analyze all partition tables again for consistency between partition and global stats"))
 }
+
+func TestLocateKeyPartition(t *testing.T) {
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+
+	strTp := types.NewFieldType(pmysql.TypeVarchar)
+	strTp.SetCharset("utf8mb4")
+	strTp.SetCollate("utf8mb4_general_ci")
+	kp := &tables.ForKeyPruning{KeyPartCols: []*expression.Column{
+		{RetType: types.NewFieldType(pmysql.TypeLonglong)},
+		{RetType: strTp},
+	}}
+	// The rows are stored in the located partitions, so the results must never change.
+	for _, c := range []struct {
+		vals []interface{}
+		idx  int
+	}{
+		{[]interface{}{int64(0), "a"}, 929},
+		{[]interface{}{int64(1), "a"}, 616},
+		{[]interface{}{int64(1), "A"}, 616},
+		{[]interface{}{int64(-7), "abc"}, 417},
+		{[]interface{}{int64(100), "tidb"}, 751},
+		{[]interface{}{nil, nil}, 1005},
+	} {
+		idx, err := kp.LocateKeyPartition(&stmtctx.StatementContext{}, 1024, types.MakeDatums(c.vals...))
+		require.NoError(t, err)
+		require.Equal(t, c.idx, idx, "%v", c.vals)
+	}
+}
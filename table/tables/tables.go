@@ -68,6 +68,7 @@ type TableCommon struct {
 	FullHiddenColsAndVisibleColumns []*table.Column
 	indices                         []table.Index
 	meta                            *model.TableInfo
+	Constraints                     []*table.Constraint
 	allocs                          autoid.Allocators
 	sequence                        *sequenceCommon
 
@@ -150,6 +151,9 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 
 	var t TableCommon
 	initTableCommon(&t, tblInfo, tblInfo.ID, columns, allocs)
+	if err := initTableConstraints(&t); err != nil {
+		return nil, err
+	}
 	if tblInfo.GetPartitionInfo() == nil {
 		if err := initTableIndices(&t); err != nil {
 			return nil, err
@@ -196,6 +200,22 @@ func initTableIndices(t *TableCommon) error {
 	return nil
 }
 
+// initTableConstraints initializes the check constraints of the TableCommon.
+func initTableConstraints(t *TableCommon) error {
+	tblInfo := t.meta
+	for _, constraintInfo := range tblInfo.Constraints {
+		if constraintInfo.State == model.StateNone {
+			continue
+		}
+		constraint, err := table.ToConstraint(constraintInfo, tblInfo)
+		if err != nil {
+			return err
+		}
+		t.Constraints = append(t.Constraints, constraint)
+	}
+	return nil
+}
+
 func initTableCommonWithIndices(t *TableCommon, tblInfo *model.TableInfo, physicalTableID int64, cols []*table.Column, allocs autoid.Allocators) error {
 	initTableCommon(t, tblInfo, physicalTableID, cols, allocs)
 	return initTableIndices(t)
@@ -219,6 +239,26 @@ func GetWritableIndexByName(idxName string, t table.Table) table.Index {
 	return nil
 }
 
+// WritableConstraint implements table.Table WritableConstraint interface.
+// The constraints being added are checked as soon as they reach the write-only state,
+// so that the rows written during the validation of the existing data are checked too.
+func (t *TableCommon) WritableConstraint() []*table.Constraint {
+	if len(t.Constraints) == 0 {
+		return nil
+	}
+	writableConstraints := make([]*table.Constraint, 0, len(t.Constraints))
+	for _, constraint := range t.Constraints {
+		if !constraint.Enforced {
+			continue
+		}
+		switch constraint.State {
+		case model.StateWriteOnly, model.StateWriteReorganization, model.StatePublic:
+			writableConstraints = append(writableConstraints, constraint)
+		}
+	}
+	return writableConstraints
+}
+
 // deletableIndices implements table.Table deletableIndices interface.
 func (t *TableCommon) deletableIndices() []table.Index {
 	// All indices are deletable because we don't need to check StateNone.
@@ -68,6 +68,7 @@ import (
 	"github.com/pingcap/tidb/util/printer"
 	"github.com/pingcap/tidb/util/sem"
 	"github.com/pingcap/tidb/util/signal"
+	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/pingcap/tidb/util/sys/linux"
 	storageSys "github.com/pingcap/tidb/util/sys/storage"
 	"github.com/pingcap/tidb/util/systimemon"
@@ -715,6 +716,11 @@ func setGlobalVars() {
 	deadlockhistory.GlobalDeadlockHistory.Resize(cfg.PessimisticTxn.DeadlockHistoryCapacity)
 	txninfo.Recorder.ResizeSummaries(cfg.TrxSummary.TransactionSummaryCapacity)
 	txninfo.Recorder.SetMinDuration(time.Duration(cfg.TrxSummary.TransactionIDDigestMinDuration) * time.Millisecond)
+	if cfg.Instance.StmtSummaryEnablePersistent {
+		err = stmtsummary.StmtSummaryByDigestMap.SetupPersistent(cfg.Instance.StmtSummaryFilename,
+			cfg.Instance.StmtSummaryFileMaxDays, cfg.Instance.StmtSummaryFileMaxSize, cfg.Instance.StmtSummaryFileMaxBackups)
+		terror.MustNil(err)
+	}
 }
 
 func setupLog() {
@@ -812,6 +818,7 @@ func cleanup(svr *server.Server, storage kv.Storage, dom *domain.Domain, gracefu
 	closeDomainAndStorage(storage, dom)
 	disk.CleanUp()
 	topsql.Close()
+	terror.Log(stmtsummary.StmtSummaryByDigestMap.ClosePersistent())
 }
 
 func stringToList(repairString string) []string {
@@ -0,0 +1,177 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mathutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	jobStatusRunning  = "running"
+	jobStatusFinished = "finished"
+	jobStatusError    = "error"
+)
+
+var errJobCancelled = errors.New("ttl job is cancelled")
+
+// ttlJob deletes the expired rows of a physical table. It scans the expired rows in the order of the handle,
+// and deletes them batch by batch.
+type ttlJob struct {
+	tbl      *PhysicalTable
+	instance string
+	// isActive returns false if the job should stop, for example, the instance is no longer the TTL owner.
+	isActive func() bool
+
+	scannedRows int64
+	deletedRows int64
+}
+
+func (j *ttlJob) run(ctx context.Context, se sessionctx.Context) error {
+	expire, err := j.getExpireTime(ctx, se)
+	if err != nil {
+		return err
+	}
+
+	if err = j.markStarted(ctx, se, expire); err != nil {
+		return err
+	}
+
+	logutil.Logger(ctx).Info("[ttl] start ttl job", zap.String("table", j.tbl.Name.O),
+		zap.String("partition", j.tbl.Partition.O), zap.Int64("physicalID", j.tbl.PhysicalID), zap.String("expire", expire))
+
+	runErr := j.doDelete(ctx, se, expire)
+	if runErr != nil {
+		logutil.Logger(ctx).Warn("[ttl] ttl job failed", zap.Int64("physicalID", j.tbl.PhysicalID), zap.Error(runErr))
+	} else {
+		logutil.Logger(ctx).Info("[ttl] ttl job finished", zap.Int64("physicalID", j.tbl.PhysicalID),
+			zap.Int64("scannedRows", j.scannedRows), zap.Int64("deletedRows", j.deletedRows))
+	}
+	return j.markFinished(ctx, se, runErr)
+}
+
+func (j *ttlJob) getExpireTime(ctx context.Context, se sessionctx.Context) (string, error) {
+	rows, _, err := execSQL(ctx, se, BuildExpireTimeSQL(j.tbl.TTLInfo))
+	if err != nil {
+		return "", err
+	}
+	if len(rows) != 1 || rows[0].IsNull(0) {
+		return "", errors.Errorf("failed to calculate the expire time of table '%s.%s'", j.tbl.Schema, j.tbl.Name)
+	}
+	return rows[0].GetTime(0).String(), nil
+}
+
+func (j *ttlJob) doDelete(ctx context.Context, se sessionctx.Context, expire string) error {
+	var lowerBound []types.Datum
+	for {
+		if !j.isActive() {
+			return errJobCancelled
+		}
+
+		scanBatchSize := int(variable.TTLScanBatchSize.Load())
+		scanSQL, err := BuildScanSQL(j.tbl, lowerBound, expire, scanBatchSize)
+		if err != nil {
+			return err
+		}
+		rows, fields, err := execSQL(ctx, se, scanSQL)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		fieldTypes := getFieldTypes(fields)
+		keys := make([][]types.Datum, 0, len(rows))
+		for _, row := range rows {
+			keys = append(keys, row.GetDatumRow(fieldTypes))
+		}
+		j.scannedRows += int64(len(keys))
+
+		deleteBatchSize := int(variable.TTLDeleteBatchSize.Load())
+		for start := 0; start < len(keys); start += deleteBatchSize {
+			end := mathutil.Min(start+deleteBatchSize, len(keys))
+			deleteSQL, err := BuildDeleteSQL(j.tbl, keys[start:end], expire)
+			if err != nil {
+				return err
+			}
+			if _, _, err = execSQL(ctx, se, deleteSQL); err != nil {
+				return err
+			}
+			j.deletedRows += int64(se.GetSessionVars().StmtCtx.AffectedRows())
+		}
+
+		if err = j.updateProgress(ctx, se); err != nil {
+			return err
+		}
+		if len(rows) < scanBatchSize {
+			return nil
+		}
+		lowerBound = keys[len(keys)-1]
+	}
+}
+
+func (j *ttlJob) markStarted(ctx context.Context, se sessionctx.Context, expire string) error {
+	_, _, err := execSQL(ctx, se, `REPLACE INTO mysql.tidb_ttl_table_status
+		(table_id, parent_table_id, table_schema, table_name, partition_name, job_status, job_owner,
+		job_start_time, job_end_time, expire_time, scanned_rows, deleted_rows, error_message)
+		VALUES (%?, %?, %?, %?, %?, %?, %?, NOW(), NULL, %?, 0, 0, NULL)`,
+		j.tbl.PhysicalID, j.tbl.ID, j.tbl.Schema.O, j.tbl.Name.O, j.tbl.Partition.O, jobStatusRunning, j.instance, expire)
+	return err
+}
+
+func (j *ttlJob) updateProgress(ctx context.Context, se sessionctx.Context) error {
+	_, _, err := execSQL(ctx, se, `UPDATE mysql.tidb_ttl_table_status SET scanned_rows = %?, deleted_rows = %?
+		WHERE table_id = %? AND job_owner = %?`, j.scannedRows, j.deletedRows, j.tbl.PhysicalID, j.instance)
+	return err
+}
+
+func (j *ttlJob) markFinished(ctx context.Context, se sessionctx.Context, runErr error) error {
+	status := jobStatusFinished
+	var errMsg interface{}
+	if runErr != nil {
+		status = jobStatusError
+		errMsg = runErr.Error()
+	}
+	_, _, err := execSQL(ctx, se, `UPDATE mysql.tidb_ttl_table_status SET job_status = %?, job_end_time = NOW(),
+		scanned_rows = %?, deleted_rows = %?, error_message = %? WHERE table_id = %? AND job_owner = %?`,
+		status, j.scannedRows, j.deletedRows, errMsg, j.tbl.PhysicalID, j.instance)
+	return err
+}
+
+func getFieldTypes(fields []*ast.ResultField) []*types.FieldType {
+	fts := make([]*types.FieldType, 0, len(fields))
+	for _, f := range fields {
+		fts = append(fts, &f.Column.FieldType)
+	}
+	return fts
+}
+
+func execSQL(ctx context.Context, se sessionctx.Context, sql string, args ...interface{}) ([]chunk.Row, []*ast.ResultField, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnTTL)
+	exec := se.(sqlexec.RestrictedSQLExecutor)
+	return exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, sql, args...)
+}
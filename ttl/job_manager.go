@@ -0,0 +1,246 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/owner"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// Prompt is the prompt for TTL job owner manager.
+	Prompt = "ttl"
+	// OwnerKey is the TTL job owner path that is saved to etcd.
+	OwnerKey = "/tidb/ttl/owner"
+
+	// jobCheckInterval is the interval to check whether there are TTL tables that need a new job.
+	jobCheckInterval = time.Minute
+	// jobStaleTimeout is the timeout after which a running job without any progress is considered dead.
+	// A job updates its status after every scan batch, so a job that has not done that for a long time
+	// is probably left by a crashed owner.
+	jobStaleTimeout = 10 * time.Minute
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// JobManager schedules and runs the TTL jobs. Only the TTL owner runs the jobs, and each physical table
+// has at most one running job at the same time.
+type JobManager struct {
+	instance       string
+	pool           sessionPool
+	ownerManager   owner.Manager
+	infoSchemaFunc func() infoschema.InfoSchema
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     util.WaitGroupWrapper
+
+	mu struct {
+		sync.Mutex
+		// running records the physical table ids of the jobs running in this instance.
+		running map[int64]struct{}
+	}
+}
+
+// NewJobManager creates a new TTL JobManager.
+func NewJobManager(instance string, pool sessionPool, ownerManager owner.Manager, infoSchemaFunc func() infoschema.InfoSchema) *JobManager {
+	m := &JobManager{
+		instance:       instance,
+		pool:           pool,
+		ownerManager:   ownerManager,
+		infoSchemaFunc: infoSchemaFunc,
+	}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.mu.running = make(map[int64]struct{})
+	return m
+}
+
+// Run schedules the TTL jobs periodically until `exit` is closed. It waits for all the running jobs to exit
+// before it returns.
+func (m *JobManager) Run(exit <-chan struct{}) {
+	ticker := time.NewTicker(jobCheckInterval)
+	defer func() {
+		ticker.Stop()
+		m.cancel()
+		m.wg.Wait()
+		m.ownerManager.Cancel()
+	}()
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			if !m.isActive() {
+				continue
+			}
+			if err := m.scheduleJobs(); err != nil {
+				logutil.BgLogger().Warn("[ttl] schedule ttl jobs failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// isActive returns whether this instance should run TTL jobs now.
+func (m *JobManager) isActive() bool {
+	return variable.EnableTTLJob.Load() && m.ownerManager.IsOwner() && m.ctx.Err() == nil
+}
+
+type tableJobStatus struct {
+	status string
+	// due indicates the last job started earlier than the run interval.
+	due bool
+	// stale indicates the job status has not been updated for a long time.
+	stale bool
+}
+
+func (m *JobManager) scheduleJobs() error {
+	resource, err := m.pool.Get()
+	if err != nil {
+		return err
+	}
+	se := resource.(sessionctx.Context)
+	defer m.pool.Put(resource)
+
+	statuses, err := m.loadJobStatuses(se)
+	if err != nil {
+		return err
+	}
+
+	is := m.infoSchemaFunc()
+	ttlTableIDs := make([]int64, 0)
+	// busy indicates all the workers are busy, the left tables will be scheduled in the next round
+	busy := false
+	for _, db := range is.AllSchemas() {
+		for _, tblInfo := range db.Tables {
+			if tblInfo.TTLInfo == nil {
+				continue
+			}
+			tbls, err := NewPhysicalTables(db.Name, tblInfo)
+			if err != nil {
+				logutil.BgLogger().Warn("[ttl] failed to get the physical tables", zap.String("table", tblInfo.Name.O), zap.Error(err))
+				continue
+			}
+			for _, tbl := range tbls {
+				ttlTableIDs = append(ttlTableIDs, tbl.PhysicalID)
+				if busy || !tblInfo.TTLInfo.Enable || !m.shouldStartJob(tbl, statuses[tbl.PhysicalID]) {
+					continue
+				}
+				busy = !m.tryStartJob(tbl)
+			}
+		}
+	}
+	return m.gcJobStatuses(se, ttlTableIDs, statuses)
+}
+
+func (m *JobManager) shouldStartJob(tbl *PhysicalTable, status *tableJobStatus) bool {
+	m.mu.Lock()
+	_, running := m.mu.running[tbl.PhysicalID]
+	m.mu.Unlock()
+	if running {
+		return false
+	}
+	if status == nil {
+		return true
+	}
+	if status.status == jobStatusRunning {
+		return status.stale
+	}
+	return status.due
+}
+
+// tryStartJob starts a job for the table if the count of running jobs does not reach the limit.
+func (m *JobManager) tryStartJob(tbl *PhysicalTable) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.mu.running) >= int(variable.TTLScanWorkerCount.Load()) {
+		return false
+	}
+	m.mu.running[tbl.PhysicalID] = struct{}{}
+
+	job := &ttlJob{
+		tbl:      tbl,
+		instance: m.instance,
+		isActive: m.isActive,
+	}
+	m.wg.Run(func() {
+		defer func() {
+			m.mu.Lock()
+			delete(m.mu.running, tbl.PhysicalID)
+			m.mu.Unlock()
+		}()
+		defer util.Recover(metrics.LabelDomain, "ttlJob", nil, false)
+
+		resource, err := m.pool.Get()
+		if err != nil {
+			logutil.BgLogger().Warn("[ttl] failed to get session", zap.Error(err))
+			return
+		}
+		defer m.pool.Put(resource)
+		if err = job.run(m.ctx, resource.(sessionctx.Context)); err != nil {
+			logutil.BgLogger().Warn("[ttl] failed to run ttl job", zap.Int64("physicalID", tbl.PhysicalID), zap.Error(err))
+		}
+	})
+	return true
+}
+
+func (m *JobManager) loadJobStatuses(se sessionctx.Context) (map[int64]*tableJobStatus, error) {
+	interval := int64(variable.TTLJobRunInterval.Load() / time.Second)
+	rows, _, err := execSQL(m.ctx, se, `SELECT table_id, job_status,
+		job_start_time < NOW() - INTERVAL %? SECOND, update_time < NOW() - INTERVAL %? SECOND
+		FROM mysql.tidb_ttl_table_status`, interval, int64(jobStaleTimeout/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[int64]*tableJobStatus, len(rows))
+	for _, row := range rows {
+		statuses[row.GetInt64(0)] = &tableJobStatus{
+			status: row.GetString(1),
+			due:    row.IsNull(2) || row.GetInt64(2) == 1,
+			stale:  row.IsNull(3) || row.GetInt64(3) == 1,
+		}
+	}
+	return statuses, nil
+}
+
+// gcJobStatuses removes the job statuses of the tables which are dropped or no longer have TTL config.
+func (m *JobManager) gcJobStatuses(se sessionctx.Context, ttlTableIDs []int64, statuses map[int64]*tableJobStatus) error {
+	exists := make(map[int64]struct{}, len(ttlTableIDs))
+	for _, id := range ttlTableIDs {
+		exists[id] = struct{}{}
+	}
+	for id := range statuses {
+		if _, ok := exists[id]; ok {
+			continue
+		}
+		if _, _, err := execSQL(m.ctx, se, "DELETE FROM mysql.tidb_ttl_table_status WHERE table_id = %?", id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// BuildExpireTimeSQL builds the SQL to calculate the expire time of a TTL table. The returned time is
+// `NOW() - INTERVAL <expr> <unit>`, all the rows whose time column is earlier than it are expired.
+func BuildExpireTimeSQL(ttlInfo *model.TTLInfo) string {
+	var sb strings.Builder
+	// The interval expression is restored from the AST when the table is created, so it is safe to write it directly.
+	sb.WriteString("SELECT NOW() - INTERVAL ")
+	sb.WriteString(ttlInfo.IntervalExprStr)
+	sb.WriteString(" ")
+	sb.WriteString(ast.TimeUnitType(ttlInfo.IntervalTimeUnit).String())
+	return sb.String()
+}
+
+// BuildScanSQL builds the SQL to scan the expired rows of a physical table. The rows are ordered by the key
+// columns and only the rows after `lowerBound` are returned, so the caller can use the last row of the result as
+// the `lowerBound` of the next batch. The `lowerBound` is nil for the first batch.
+func BuildScanSQL(tbl *PhysicalTable, lowerBound []types.Datum, expire string, limit int) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("SELECT LOW_PRIORITY ")
+	writeColumnList(&sb, tbl.KeyColumns)
+	sb.WriteString(" FROM ")
+	writeTableName(&sb, tbl)
+	sb.WriteString(" WHERE ")
+	if lowerBound != nil {
+		if len(lowerBound) != len(tbl.KeyColumns) {
+			return "", errors.Errorf("the length of lower bound %d does not match the key columns %d", len(lowerBound), len(tbl.KeyColumns))
+		}
+		writeColumnTuple(&sb, tbl.KeyColumns)
+		sb.WriteString(" > ")
+		if err := writeDatumTuple(&sb, lowerBound); err != nil {
+			return "", err
+		}
+		sb.WriteString(" AND ")
+	}
+	writeExpireCondition(&sb, tbl, expire)
+	sb.WriteString(" ORDER BY ")
+	for i, col := range tbl.KeyColumns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlexec.MustFormatSQL(&sb, "%n ASC", col.Name.O)
+	}
+	sqlexec.MustFormatSQL(&sb, " LIMIT %?", limit)
+	return sb.String(), nil
+}
+
+// BuildDeleteSQL builds the SQL to delete the rows located by `keys`. The expire condition is checked again
+// because the rows may be updated after they are scanned.
+func BuildDeleteSQL(tbl *PhysicalTable, keys [][]types.Datum, expire string) (string, error) {
+	if len(keys) == 0 {
+		return "", errors.New("no rows to delete")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("DELETE LOW_PRIORITY FROM ")
+	writeTableName(&sb, tbl)
+	sb.WriteString(" WHERE ")
+	writeColumnTuple(&sb, tbl.KeyColumns)
+	sb.WriteString(" IN (")
+	for i, key := range keys {
+		if len(key) != len(tbl.KeyColumns) {
+			return "", errors.Errorf("the length of key %d does not match the key columns %d", len(key), len(tbl.KeyColumns))
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if err := writeDatumTuple(&sb, key); err != nil {
+			return "", err
+		}
+	}
+	sb.WriteString(") AND ")
+	writeExpireCondition(&sb, tbl, expire)
+	sqlexec.MustFormatSQL(&sb, " LIMIT %?", len(keys))
+	return sb.String(), nil
+}
+
+func writeTableName(sb *strings.Builder, tbl *PhysicalTable) {
+	sqlexec.MustFormatSQL(sb, "%n.%n", tbl.Schema.O, tbl.Name.O)
+	if tbl.Partition.L != "" {
+		sqlexec.MustFormatSQL(sb, " PARTITION(%n)", tbl.Partition.O)
+	}
+}
+
+func writeExpireCondition(sb *strings.Builder, tbl *PhysicalTable, expire string) {
+	sqlexec.MustFormatSQL(sb, "%n < %?", tbl.TimeColumn.Name.O, expire)
+}
+
+func writeColumnList(sb *strings.Builder, cols []*model.ColumnInfo) {
+	for i, col := range cols {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlexec.MustFormatSQL(sb, "%n", col.Name.O)
+	}
+}
+
+func writeColumnTuple(sb *strings.Builder, cols []*model.ColumnInfo) {
+	if len(cols) == 1 {
+		writeColumnList(sb, cols)
+		return
+	}
+	sb.WriteString("(")
+	writeColumnList(sb, cols)
+	sb.WriteString(")")
+}
+
+func writeDatumTuple(sb *strings.Builder, datums []types.Datum) error {
+	if len(datums) > 1 {
+		sb.WriteString("(")
+	}
+	for i := range datums {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if err := writeDatum(sb, &datums[i]); err != nil {
+			return err
+		}
+	}
+	if len(datums) > 1 {
+		sb.WriteString(")")
+	}
+	return nil
+}
+
+func writeDatum(sb *strings.Builder, d *types.Datum) error {
+	switch d.Kind() {
+	case types.KindInt64:
+		sqlexec.MustFormatSQL(sb, "%?", d.GetInt64())
+	case types.KindUint64:
+		sqlexec.MustFormatSQL(sb, "%?", d.GetUint64())
+	case types.KindString:
+		sqlexec.MustFormatSQL(sb, "%?", d.GetString())
+	case types.KindBytes, types.KindMysqlBit, types.KindBinaryLiteral:
+		sqlexec.MustFormatSQL(sb, "%?", d.GetBytes())
+	case types.KindFloat32, types.KindFloat64, types.KindMysqlDecimal, types.KindMysqlTime, types.KindMysqlDuration,
+		types.KindMysqlEnum, types.KindMysqlSet:
+		str, err := d.ToString()
+		if err != nil {
+			return err
+		}
+		sqlexec.MustFormatSQL(sb, "%?", str)
+	default:
+		return errors.Errorf("unsupported datum kind %d for TTL key column", d.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestColumn(id int64, name string, tp byte, flag uint) *model.ColumnInfo {
+	ft := types.NewFieldType(tp)
+	ft.SetFlag(flag)
+	return &model.ColumnInfo{ID: id, Name: model.NewCIStr(name), Offset: int(id - 1), FieldType: *ft, State: model.StatePublic}
+}
+
+func newTestTTLInfo(column string) *model.TTLInfo {
+	return &model.TTLInfo{
+		ColumnName:       model.NewCIStr(column),
+		IntervalExprStr:  "1",
+		IntervalTimeUnit: int(ast.TimeUnitDay),
+		Enable:           true,
+	}
+}
+
+func TestBuildExpireTimeSQL(t *testing.T) {
+	ttlInfo := newTestTTLInfo("t")
+	require.Equal(t, "SELECT NOW() - INTERVAL 1 DAY", BuildExpireTimeSQL(ttlInfo))
+
+	ttlInfo.IntervalExprStr = "'1:2'"
+	ttlInfo.IntervalTimeUnit = int(ast.TimeUnitHourMinute)
+	require.Equal(t, "SELECT NOW() - INTERVAL '1:2' HOUR_MINUTE", BuildExpireTimeSQL(ttlInfo))
+}
+
+func TestPhysicalTable(t *testing.T) {
+	tbl := &model.TableInfo{
+		ID:   1,
+		Name: model.NewCIStr("t1"),
+		Columns: []*model.ColumnInfo{
+			newTestColumn(1, "id", mysql.TypeLonglong, mysql.PriKeyFlag),
+			newTestColumn(2, "t", mysql.TypeDatetime, 0),
+		},
+		PKIsHandle: true,
+	}
+	_, err := NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr(""))
+	require.EqualError(t, err, "table 'test.t1' is not a ttl table")
+
+	tbl.TTLInfo = newTestTTLInfo("t")
+	pt, err := NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr(""))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pt.PhysicalID)
+	require.Len(t, pt.KeyColumns, 1)
+	require.Equal(t, "id", pt.KeyColumns[0].Name.L)
+	require.Equal(t, "t", pt.TimeColumn.Name.L)
+
+	_, err = NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr("p0"))
+	require.EqualError(t, err, "table 'test.t1' is not a partitioned table")
+
+	tbl.PKIsHandle = false
+	pt, err = NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr(""))
+	require.NoError(t, err)
+	require.Len(t, pt.KeyColumns, 1)
+	require.Equal(t, model.ExtraHandleName.L, pt.KeyColumns[0].Name.L)
+
+	tbl.Partition = &model.PartitionInfo{
+		Definitions: []model.PartitionDefinition{
+			{ID: 11, Name: model.NewCIStr("p0")},
+			{ID: 12, Name: model.NewCIStr("p1")},
+		},
+	}
+	_, err = NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr(""))
+	require.EqualError(t, err, "partition name is required for partitioned table 'test.t1'")
+	_, err = NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr("p2"))
+	require.EqualError(t, err, "partition 'p2' is not found in table 'test.t1'")
+	tbls, err := NewPhysicalTables(model.NewCIStr("test"), tbl)
+	require.NoError(t, err)
+	require.Len(t, tbls, 2)
+	require.Equal(t, int64(11), tbls[0].PhysicalID)
+	require.Equal(t, "p0", tbls[0].Partition.L)
+	require.Equal(t, int64(12), tbls[1].PhysicalID)
+	require.Equal(t, "p1", tbls[1].Partition.L)
+}
+
+func TestBuildScanAndDeleteSQL(t *testing.T) {
+	tbl := &model.TableInfo{
+		ID:   1,
+		Name: model.NewCIStr("t1"),
+		Columns: []*model.ColumnInfo{
+			newTestColumn(1, "id", mysql.TypeLonglong, mysql.PriKeyFlag),
+			newTestColumn(2, "t", mysql.TypeDatetime, 0),
+		},
+		PKIsHandle: true,
+		TTLInfo:    newTestTTLInfo("t"),
+	}
+	pt, err := NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr(""))
+	require.NoError(t, err)
+
+	expire := "2022-10-01 00:00:00"
+	sql, err := BuildScanSQL(pt, nil, expire, 500)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY `id` FROM `test`.`t1` WHERE `t` < '2022-10-01 00:00:00' ORDER BY `id` ASC LIMIT 500", sql)
+
+	sql, err = BuildScanSQL(pt, types.MakeDatums(10), expire, 500)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY `id` FROM `test`.`t1` WHERE `id` > 10 AND `t` < '2022-10-01 00:00:00' ORDER BY `id` ASC LIMIT 500", sql)
+
+	sql, err = BuildDeleteSQL(pt, [][]types.Datum{types.MakeDatums(1), types.MakeDatums(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `id` IN (1, 2) AND `t` < '2022-10-01 00:00:00' LIMIT 2", sql)
+
+	_, err = BuildDeleteSQL(pt, nil, expire)
+	require.Error(t, err)
+
+	// common handle with a partition
+	tbl.Columns = append(tbl.Columns, newTestColumn(3, "name", mysql.TypeVarchar, mysql.PriKeyFlag))
+	tbl.PKIsHandle = false
+	tbl.IsCommonHandle = true
+	tbl.Indices = []*model.IndexInfo{{
+		Name:    model.NewCIStr("primary"),
+		Primary: true,
+		State:   model.StatePublic,
+		Columns: []*model.IndexColumn{
+			{Name: model.NewCIStr("name"), Offset: 2},
+			{Name: model.NewCIStr("id"), Offset: 0},
+		},
+	}}
+	tbl.Partition = &model.PartitionInfo{
+		Definitions: []model.PartitionDefinition{{ID: 11, Name: model.NewCIStr("p0")}},
+	}
+	pt, err = NewPhysicalTable(model.NewCIStr("test"), tbl, model.NewCIStr("p0"))
+	require.NoError(t, err)
+
+	sql, err = BuildScanSQL(pt, types.MakeDatums("a'b", 3), expire, 100)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY `name`, `id` FROM `test`.`t1` PARTITION(`p0`) WHERE (`name`, `id`) > ('a\\'b', 3) AND `t` < '2022-10-01 00:00:00' ORDER BY `name` ASC, `id` ASC LIMIT 100", sql)
+
+	sql, err = BuildDeleteSQL(pt, [][]types.Datum{types.MakeDatums("a", 1), types.MakeDatums("b", 2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE LOW_PRIORITY FROM `test`.`t1` PARTITION(`p0`) WHERE (`name`, `id`) IN (('a', 1), ('b', 2)) AND `t` < '2022-10-01 00:00:00' LIMIT 2", sql)
+
+	_, err = BuildScanSQL(pt, types.MakeDatums(1), expire, 100)
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/model"
+)
+
+// PhysicalTable is used to provide some information for a physical table in TTL job
+type PhysicalTable struct {
+	Schema model.CIStr
+	*model.TableInfo
+	// Partition is the partition name, it is empty if the table is not partitioned
+	Partition model.CIStr
+	// PhysicalID is the table id or the partition id
+	PhysicalID int64
+	// KeyColumns are the columns of the handle, they are used to locate the rows to delete
+	KeyColumns []*model.ColumnInfo
+	// TimeColumn is the column that the TTL config refers to
+	TimeColumn *model.ColumnInfo
+}
+
+// NewPhysicalTable creates a new PhysicalTable. The `partition` should be empty if the table is not partitioned.
+func NewPhysicalTable(schema model.CIStr, tbl *model.TableInfo, partition model.CIStr) (*PhysicalTable, error) {
+	if tbl.TTLInfo == nil {
+		return nil, errors.Errorf("table '%s.%s' is not a ttl table", schema, tbl.Name)
+	}
+
+	timeColumn := model.FindColumnInfo(tbl.Columns, tbl.TTLInfo.ColumnName.L)
+	if timeColumn == nil {
+		return nil, errors.Errorf("time column '%s' is not found in table '%s.%s'", tbl.TTLInfo.ColumnName, schema, tbl.Name)
+	}
+
+	physicalID := tbl.ID
+	if tbl.Partition != nil {
+		if partition.L == "" {
+			return nil, errors.Errorf("partition name is required for partitioned table '%s.%s'", schema, tbl.Name)
+		}
+		def := findPartitionDef(tbl, partition)
+		if def == nil {
+			return nil, errors.Errorf("partition '%s' is not found in table '%s.%s'", partition, schema, tbl.Name)
+		}
+		physicalID = def.ID
+	} else if partition.L != "" {
+		return nil, errors.Errorf("table '%s.%s' is not a partitioned table", schema, tbl.Name)
+	}
+
+	return &PhysicalTable{
+		Schema:     schema,
+		TableInfo:  tbl,
+		Partition:  partition,
+		PhysicalID: physicalID,
+		KeyColumns: getKeyColumns(tbl),
+		TimeColumn: timeColumn,
+	}, nil
+}
+
+// NewPhysicalTables returns all the physical tables of a TTL table.
+func NewPhysicalTables(schema model.CIStr, tbl *model.TableInfo) ([]*PhysicalTable, error) {
+	if tbl.Partition == nil {
+		t, err := NewPhysicalTable(schema, tbl, model.NewCIStr(""))
+		if err != nil {
+			return nil, err
+		}
+		return []*PhysicalTable{t}, nil
+	}
+
+	result := make([]*PhysicalTable, 0, len(tbl.Partition.Definitions))
+	for _, def := range tbl.Partition.Definitions {
+		t, err := NewPhysicalTable(schema, tbl, def.Name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func findPartitionDef(tbl *model.TableInfo, partition model.CIStr) *model.PartitionDefinition {
+	for i := range tbl.Partition.Definitions {
+		if tbl.Partition.Definitions[i].Name.L == partition.L {
+			return &tbl.Partition.Definitions[i]
+		}
+	}
+	return nil
+}
+
+func getKeyColumns(tbl *model.TableInfo) []*model.ColumnInfo {
+	switch {
+	case tbl.PKIsHandle:
+		if col := tbl.GetPkColInfo(); col != nil {
+			return []*model.ColumnInfo{col}
+		}
+	case tbl.IsCommonHandle:
+		idxInfo := tbl.GetPrimaryKey()
+		columns := make([]*model.ColumnInfo, len(idxInfo.Columns))
+		for i, idxCol := range idxInfo.Columns {
+			columns[i] = tbl.Columns[idxCol.Offset]
+		}
+		return columns
+	}
+	return []*model.ColumnInfo{model.NewExtraHandleColInfo()}
+}
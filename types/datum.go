@@ -2170,6 +2170,28 @@ func NewJSONDatum(j BinaryJSON) (d Datum) {
 	return d
 }
 
+// NewDatumFromJSONScalar creates a new Datum from a scalar BinaryJSON value, it's used to build the entries of
+// the multi-valued index. The JSON string is converted to a string Datum with the collation.
+func NewDatumFromJSONScalar(j BinaryJSON, collation string) (d Datum) {
+	switch j.TypeCode {
+	case JSONTypeCodeInt64:
+		d.SetInt64(j.GetInt64())
+	case JSONTypeCodeUint64:
+		d.SetUint64(j.GetUint64())
+	case JSONTypeCodeFloat64:
+		d.SetFloat64(j.GetFloat64())
+	case JSONTypeCodeString:
+		d.SetString(string(j.GetString()), collation)
+	case JSONTypeCodeDate, JSONTypeCodeDatetime, JSONTypeCodeTimestamp:
+		d.SetMysqlTime(j.GetTime())
+	case JSONTypeCodeDuration:
+		d.SetMysqlDuration(j.GetDuration())
+	default:
+		d.SetMysqlJSON(j)
+	}
+	return d
+}
+
 // NewBinaryLiteralDatum creates a new BinaryLiteral Datum for a BinaryLiteral value.
 func NewBinaryLiteralDatum(b BinaryLiteral) (d Datum) {
 	d.SetBinaryLiteral(b)
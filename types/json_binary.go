@@ -279,6 +279,11 @@ func (bj BinaryJSON) arrayGetElem(idx int) BinaryJSON {
 	return bj.valEntryGet(headerSize + idx*valEntrySize)
 }
 
+// ArrayGetElem gets the element of the index idx of the Array.
+func (bj BinaryJSON) ArrayGetElem(idx int) BinaryJSON {
+	return bj.arrayGetElem(idx)
+}
+
 func (bj BinaryJSON) objectGetKey(i int) []byte {
 	keyOff := int(jsonEndian.Uint32(bj.Value[headerSize+i*keyEntrySize:]))
 	keyLen := int(jsonEndian.Uint16(bj.Value[headerSize+i*keyEntrySize+keyLenOff:]))
@@ -254,6 +254,12 @@ func (bj BinaryJSON) Extract(pathExprList []JSONPathExpression) (ret BinaryJSON,
 	return
 }
 
+// ExtractAll returns all the values matched by the path expression without
+// wrapping them into an array, it's used by JSON_TABLE to iterate the matches.
+func (bj BinaryJSON) ExtractAll(pathExpr JSONPathExpression) []BinaryJSON {
+	return bj.extractTo(make([]BinaryJSON, 0, 1), pathExpr, make(map[*byte]struct{}), false)
+}
+
 func (bj BinaryJSON) extractOne(pathExpr JSONPathExpression) []BinaryJSON {
 	result := make([]BinaryJSON, 0, 1)
 	return bj.extractTo(result, pathExpr, nil, true)
@@ -1082,6 +1088,51 @@ func ContainsBinaryJSON(obj, target BinaryJSON) bool {
 	}
 }
 
+// OverlapsBinaryJSON checks whether the two JSON documents have any element in common according the following rules:
+// 1) two objects overlap if they have at least one key-value pair in common;
+// 2) two arrays overlap if they have at least one element in common;
+// 3) a nonarray value is treated as an array with one element when it's compared with an array;
+// 4) two scalars overlap if and only if they are comparable and are equal.
+func OverlapsBinaryJSON(obj, target BinaryJSON) bool {
+	if obj.TypeCode != JSONTypeCodeArray && target.TypeCode == JSONTypeCodeArray {
+		obj, target = target, obj
+	}
+	switch obj.TypeCode {
+	case JSONTypeCodeObject:
+		if target.TypeCode == JSONTypeCodeObject {
+			elemCount := target.GetElemCount()
+			for i := 0; i < elemCount; i++ {
+				key := target.objectGetKey(i)
+				val := target.objectGetVal(i)
+				if exp, exists := obj.objectSearchKey(key); exists && CompareBinaryJSON(exp, val) == 0 {
+					return true
+				}
+			}
+		}
+		return false
+	case JSONTypeCodeArray:
+		elemCount := obj.GetElemCount()
+		for i := 0; i < elemCount; i++ {
+			elem := obj.arrayGetElem(i)
+			if target.TypeCode != JSONTypeCodeArray {
+				if CompareBinaryJSON(elem, target) == 0 {
+					return true
+				}
+				continue
+			}
+			targetCount := target.GetElemCount()
+			for j := 0; j < targetCount; j++ {
+				if CompareBinaryJSON(elem, target.arrayGetElem(j)) == 0 {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		return CompareBinaryJSON(obj, target) == 0
+	}
+}
+
 // GetElemDepth for JSON_DEPTH
 // Returns the maximum depth of a JSON document
 // rules referenced by MySQL JSON_DEPTH function
@@ -106,6 +106,29 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	bj := mustParseBinaryFromString(t, `{"a": [1, "2", {"aa": "bb"}], "b": {"aa": "cc"}}`)
+	var tests = []struct {
+		pathExpr string
+		expected []string
+	}{
+		{"$.a", []string{`[1, "2", {"aa": "bb"}]`}},
+		{"$.a[*]", []string{`1`, `"2"`, `{"aa": "bb"}`}},
+		{"$**.aa", []string{`"bb"`, `"cc"`}},
+		{"$.a[0]", []string{`1`}},
+		{"$.c", []string{}},
+	}
+	for _, test := range tests {
+		pathExpr, err := ParseJSONPathExpr(test.pathExpr)
+		require.NoError(t, err)
+		result := bj.ExtractAll(pathExpr)
+		require.Len(t, result, len(test.expected), test.pathExpr)
+		for i, expected := range test.expected {
+			require.Equal(t, mustParseBinaryFromString(t, expected).String(), result[i].String())
+		}
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	var tests = []struct {
 		in  string
@@ -418,6 +441,35 @@ func TestBinaryJSONContains(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONOverlaps(t *testing.T) {
+	var tests = []struct {
+		input    string
+		target   string
+		expected bool
+	}{
+		{`{}`, `{}`, false},
+		{`{"a":1}`, `{"a":1,"b":2}`, true},
+		{`{"a":1}`, `{"a":2}`, false},
+		{`{"a":[1]}`, `[1]`, false},
+		{`1`, `1`, true},
+		{`1`, `[1,2]`, true},
+		{`[1,2]`, `3`, false},
+		{`[1,2]`, `[3,1]`, true},
+		{`[1,2]`, `["1"]`, false},
+		{`[[1,2],3]`, `[1,2]`, false},
+		{`[[1,2],3]`, `[[1,2]]`, true},
+		{`[{"a":1}]`, `{"a":1}`, true},
+		{`[]`, `[]`, false},
+	}
+
+	for _, test := range tests {
+		obj := mustParseBinaryFromString(t, test.input)
+		target := mustParseBinaryFromString(t, test.target)
+		require.Equal(t, test.expected, OverlapsBinaryJSON(obj, target), test)
+		require.Equal(t, test.expected, OverlapsBinaryJSON(target, obj), test)
+	}
+}
+
 func TestBinaryJSONCopy(t *testing.T) {
 	expectedList := []string{
 		`{"a": [1, "2", {"aa": "bb"}, 4, null], "b": true, "c": null}`,
@@ -173,6 +173,10 @@ func bytesToI64Slice(b []byte) (i64s []int64) {
 const varElemLen = -1
 
 func getFixedLen(colType *types.FieldType) int {
+	if colType.IsArray() {
+		// The array is stored as a JSON array.
+		return varElemLen
+	}
 	switch colType.GetType() {
 	case mysql.TypeFloat:
 		return 4
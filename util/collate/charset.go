@@ -25,4 +25,25 @@ func switchDefaultCollation(flag bool) {
 	}
 	charset.CharacterSetInfos[charset.CharsetGBK].Collations[charset.CollationGBKBin].IsDefault = !flag
 	charset.CharacterSetInfos[charset.CharsetGBK].Collations[charset.CollationGBKChineseCI].IsDefault = flag
+	for _, cs := range unicodeEncodingCollations {
+		if flag {
+			charset.CharacterSetInfos[cs.charset].DefaultCollation = cs.ci
+		} else {
+			charset.CharacterSetInfos[cs.charset].DefaultCollation = cs.bin
+		}
+	}
+}
+
+// unicodeEncodingCollations are the collations of the utf16, utf16le, utf32 and ucs2 charsets.
+// The default collation is `xx_bin` when new collation is disabled and `xx_general_ci` when enabled, but
+// unlike gbk their IsDefault flags are always the same as MySQL.
+var unicodeEncodingCollations = []struct {
+	charset string
+	bin     string
+	ci      string
+}{
+	{charset.CharsetUTF16, charset.CollationUTF16Bin, charset.CollationUTF16GeneralCI},
+	{charset.CharsetUTF16LE, charset.CollationUTF16LEBin, charset.CollationUTF16LEGeneralCI},
+	{charset.CharsetUTF32, charset.CollationUTF32Bin, charset.CollationUTF32GeneralCI},
+	{charset.CharsetUCS2, charset.CollationUCS2Bin, charset.CollationUCS2GeneralCI},
 }
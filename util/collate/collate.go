@@ -101,7 +101,8 @@ func NewCollationEnabled() bool {
 func CompatibleCollate(collate1, collate2 string) bool {
 	if (collate1 == "utf8mb4_general_ci" || collate1 == "utf8_general_ci") && (collate2 == "utf8mb4_general_ci" || collate2 == "utf8_general_ci") {
 		return true
-	} else if (collate1 == "utf8mb4_bin" || collate1 == "utf8_bin" || collate1 == "latin1_bin") && (collate2 == "utf8mb4_bin" || collate2 == "utf8_bin") {
+	} else if (collate1 == "utf8mb4_bin" || collate1 == "utf8_bin" || collate1 == "latin1_bin" && !charset.Latin1CP1252Enabled()) && (collate2 == "utf8mb4_bin" || collate2 == "utf8_bin") {
+		// latin1_bin compares the cp1252 bytes when the real cp1252 encoding is enabled, so it is not the same as utf8mb4_bin.
 		return true
 	} else if (collate1 == "utf8mb4_unicode_ci" || collate1 == "utf8_unicode_ci") && (collate2 == "utf8mb4_unicode_ci" || collate2 == "utf8_unicode_ci") {
 		return true
@@ -324,7 +325,10 @@ func runeLen(b byte) int {
 // IsCICollation returns if the collation is case-insensitive
 func IsCICollation(collate string) bool {
 	return collate == "utf8_general_ci" || collate == "utf8mb4_general_ci" ||
-		collate == "utf8_unicode_ci" || collate == "utf8mb4_unicode_ci" || collate == "gbk_chinese_ci"
+		collate == "utf8_unicode_ci" || collate == "utf8mb4_unicode_ci" || collate == "gbk_chinese_ci" ||
+		collate == charset.CollationUTF8MB40900AICI || collate == charset.CollationUTF16GeneralCI ||
+		collate == charset.CollationUTF16LEGeneralCI || collate == charset.CollationUTF32GeneralCI ||
+		collate == charset.CollationUCS2GeneralCI
 }
 
 // IsBinCollation returns if the collation is 'xx_bin' or 'bin'.
@@ -377,8 +381,8 @@ func init() {
 	newCollatorIDMap[CollationName2ID("binary")] = &binCollator{}
 	newCollatorMap["ascii_bin"] = &binPaddingCollator{}
 	newCollatorIDMap[CollationName2ID("ascii_bin")] = &binPaddingCollator{}
-	newCollatorMap["latin1_bin"] = &binPaddingCollator{}
-	newCollatorIDMap[CollationName2ID("latin1_bin")] = &binPaddingCollator{}
+	newCollatorMap["latin1_bin"] = &latin1BinCollator{}
+	newCollatorIDMap[CollationName2ID("latin1_bin")] = &latin1BinCollator{}
 	newCollatorMap["utf8mb4_bin"] = &binPaddingCollator{}
 	newCollatorIDMap[CollationName2ID("utf8mb4_bin")] = &binPaddingCollator{}
 	newCollatorMap["utf8_bin"] = &binPaddingCollator{}
@@ -391,10 +395,22 @@ func init() {
 	newCollatorIDMap[CollationName2ID("utf8mb4_unicode_ci")] = &unicodeCICollator{}
 	newCollatorMap["utf8_unicode_ci"] = &unicodeCICollator{}
 	newCollatorIDMap[CollationName2ID("utf8_unicode_ci")] = &unicodeCICollator{}
+	newCollatorMap[charset.CollationUTF8MB40900AICI] = &unicode0900AICICollator{}
+	newCollatorIDMap[CollationName2ID(charset.CollationUTF8MB40900AICI)] = &unicode0900AICICollator{}
+	newCollatorMap[charset.CollationUTF8MB40900Bin] = &binCollator{}
+	newCollatorIDMap[CollationName2ID(charset.CollationUTF8MB40900Bin)] = &binCollator{}
 	newCollatorMap["utf8mb4_zh_pinyin_tidb_as_cs"] = &zhPinyinTiDBASCSCollator{}
 	newCollatorIDMap[CollationName2ID("utf8mb4_zh_pinyin_tidb_as_cs")] = &zhPinyinTiDBASCSCollator{}
 	newCollatorMap[charset.CollationGBKBin] = &gbkBinCollator{charset.NewCustomGBKEncoder()}
 	newCollatorIDMap[CollationName2ID(charset.CollationGBKBin)] = &gbkBinCollator{charset.NewCustomGBKEncoder()}
 	newCollatorMap[charset.CollationGBKChineseCI] = &gbkChineseCICollator{}
 	newCollatorIDMap[CollationName2ID(charset.CollationGBKChineseCI)] = &gbkChineseCICollator{}
+	// The code point order of utf16, utf16le, utf32 and ucs2 is the same as the utf-8 byte order,
+	// so the collators of utf-8 are reused as the strings are stored in utf-8.
+	for _, cs := range unicodeEncodingCollations {
+		newCollatorMap[cs.bin] = &binPaddingCollator{}
+		newCollatorIDMap[CollationName2ID(cs.bin)] = &binPaddingCollator{}
+		newCollatorMap[cs.ci] = &generalCICollator{}
+		newCollatorIDMap[CollationName2ID(cs.ci)] = &generalCICollator{}
+	}
 }
@@ -0,0 +1,54 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collate
+
+import (
+	"bytes"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+// latin1BinCollator is collator for latin1_bin.
+// TiDB stores the latin1 strings as utf-8, so the strings are compared in their utf-8 bytes
+// like binPaddingCollator by default. When the real cp1252 encoding is enabled, the strings
+// are compared in their cp1252 bytes like MySQL, e.g. '€'(0x80) is less than 'é'(0xE9).
+type latin1BinCollator struct {
+	binPaddingCollator
+}
+
+// Compare implement Collator interface.
+func (l *latin1BinCollator) Compare(a, b string) int {
+	if !charset.Latin1CP1252Enabled() {
+		return l.binPaddingCollator.Compare(a, b)
+	}
+	return bytes.Compare(l.Key(a), l.Key(b))
+}
+
+// Key implement Collator interface.
+func (l *latin1BinCollator) Key(str string) []byte {
+	return l.KeyWithoutTrimRightSpace(truncateTailingSpace(str))
+}
+
+// KeyWithoutTrimRightSpace implement Collator interface.
+func (l *latin1BinCollator) KeyWithoutTrimRightSpace(str string) []byte {
+	if !charset.Latin1CP1252Enabled() {
+		return l.binPaddingCollator.KeyWithoutTrimRightSpace(str)
+	}
+	// if convert error happened, '?'(0x3F) is used to replace it.
+	// It should not happen because the string has been checked when it is written.
+	key, _ := charset.EncodingCP1252Impl.Transform(nil, hack.Slice(str), charset.OpEncodeReplace)
+	return key
+}
@@ -229,6 +229,12 @@ var (
 	ErrPartitionMgmtOnNonpartitioned = ClassDDL.NewStd(mysql.ErrPartitionMgmtOnNonpartitioned)
 	// ErrDropPartitionNonExistent returns error in list of partition.
 	ErrDropPartitionNonExistent = ClassDDL.NewStd(mysql.ErrDropPartitionNonExistent)
+	// ErrReorgNoParam returns when REORGANIZE PARTITION is used without partition names on a non-hash partitioned table.
+	ErrReorgNoParam = ClassDDL.NewStd(mysql.ErrReorgNoParam)
+	// ErrConsecutiveReorgPartitions returns when the reorganized range partitions are not consecutive.
+	ErrConsecutiveReorgPartitions = ClassDDL.NewStd(mysql.ErrConsecutiveReorgPartitions)
+	// ErrReorgOutsideRange returns when the reorganized partitions change the total ranges.
+	ErrReorgOutsideRange = ClassDDL.NewStd(mysql.ErrReorgOutsideRange)
 	// ErrSameNamePartition returns duplicate partition name.
 	ErrSameNamePartition = ClassDDL.NewStd(mysql.ErrSameNamePartition)
 	// ErrSameNamePartitionField returns duplicate partition field.
@@ -255,6 +261,10 @@ var (
 	ErrWarnDataTruncated = ClassDDL.NewStd(mysql.WarnDataTruncated)
 	// ErrCoalesceOnlyOnHashPartition returns coalesce partition can only be used on hash/key partitions.
 	ErrCoalesceOnlyOnHashPartition = ClassDDL.NewStd(mysql.ErrCoalesceOnlyOnHashPartition)
+	// ErrSubpartition returns subpartitions can only be HASH or KEY partitions of RANGE or LIST partitions.
+	ErrSubpartition = ClassDDL.NewStd(mysql.ErrSubpartition)
+	// ErrPartitionWrongNoSubpart returns the number of subpartitions does not match the other partitions.
+	ErrPartitionWrongNoSubpart = ClassDDL.NewStd(mysql.ErrPartitionWrongNoSubpart)
 	// ErrViewWrongList returns create view must include all columns in the select clause
 	ErrViewWrongList = ClassDDL.NewStd(mysql.ErrViewWrongList)
 	// ErrAlterOperationNotSupported returns when alter operations is not supported.
@@ -395,6 +405,15 @@ var (
 	// ErrColumnInChange indicates there is modification on the column in parallel.
 	ErrColumnInChange = ClassDDL.NewStd(mysql.ErrColumnInChange)
 
+	// ErrUnsupportedColumnInTTLConfig returns when a column type is not expected in TTL config
+	ErrUnsupportedColumnInTTLConfig = ClassDDL.NewStd(mysql.ErrUnsupportedColumnInTTLConfig)
+	// ErrTTLColumnCannotDrop returns when a column is dropped while referenced by TTL config
+	ErrTTLColumnCannotDrop = ClassDDL.NewStd(mysql.ErrTTLColumnCannotDrop)
+	// ErrSetTTLOptionForNonTTLTable returns when the `TTL_ENABLE` is set on a table without TTL config
+	ErrSetTTLOptionForNonTTLTable = ClassDDL.NewStd(mysql.ErrSetTTLOptionForNonTTLTable)
+	// ErrTempTableNotAllowedWithTTL returns when setting TTL config for a temp table
+	ErrTempTableNotAllowedWithTTL = ClassDDL.NewStd(mysql.ErrTempTableNotAllowedWithTTL)
+
 	// ErrAlterTiFlashModeForTableWithoutTiFlashReplica returns when set tiflash mode on table whose tiflash_replica is null or tiflash_replica_count = 0
 	ErrAlterTiFlashModeForTableWithoutTiFlashReplica = ClassDDL.NewStdErr(0, parser_mysql.Message("TiFlash mode will take effect after at least one TiFlash replica is set for the table", nil))
 
@@ -416,4 +435,30 @@ var (
 	ErrForeignKeyColumnCannotChangeChild = ClassDDL.NewStd(mysql.ErrForeignKeyColumnCannotChangeChild)
 	// ErrNoReferencedRow2 returns when there are rows in child table don't have related foreign key value in refer table.
 	ErrNoReferencedRow2 = ClassDDL.NewStd(mysql.ErrNoReferencedRow2)
+
+	// ErrNonBooleanExprForCheckConstraint returns when the expression of a check constraint is not a boolean.
+	ErrNonBooleanExprForCheckConstraint = ClassDDL.NewStd(mysql.ErrNonBooleanExprForCheckConstraint)
+	// ErrColumnCheckConstraintReferencesOtherColumn returns when a column check constraint refers to another column.
+	ErrColumnCheckConstraintReferencesOtherColumn = ClassDDL.NewStd(mysql.ErrColumnCheckConstraintReferencesOtherColumn)
+	// ErrCheckConstraintNamedFunctionIsNotAllowed returns when a check constraint uses a disallowed function.
+	ErrCheckConstraintNamedFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	// ErrCheckConstraintFunctionIsNotAllowed returns when a check constraint uses a disallowed function.
+	ErrCheckConstraintFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrCheckConstraintFunctionIsNotAllowed)
+	// ErrCheckConstraintVariables returns when a check constraint refers to a user or system variable.
+	ErrCheckConstraintVariables = ClassDDL.NewStd(mysql.ErrCheckConstraintVariables)
+	// ErrCheckConstraintRefersAutoIncrementColumn returns when a check constraint refers to an auto-increment column.
+	ErrCheckConstraintRefersAutoIncrementColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintRefersAutoIncrementColumn)
+	// ErrCheckConstraintRefersUnknownColumn returns when a check constraint refers to a column which doesn't exist.
+	ErrCheckConstraintRefersUnknownColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintRefersUnknownColumn)
+	// ErrCheckConstraintNotFound returns when the check constraint to alter or drop doesn't exist.
+	ErrCheckConstraintNotFound = ClassDDL.NewStd(mysql.ErrCheckConstraintNotFound)
+	// ErrCheckConstraintDupName returns when the check constraint name is duplicated.
+	ErrCheckConstraintDupName = ClassDDL.NewStd(mysql.ErrCheckConstraintDupName)
+	// ErrDependentByCheckConstraint returns when the dropped or renamed column is used by a check constraint.
+	ErrDependentByCheckConstraint = ClassDDL.NewStd(mysql.ErrDependentByCheckConstraint)
+
+	// ErrResourceGroupSupportDisabled returns for resource group feature is disabled.
+	ErrResourceGroupSupportDisabled = ClassDDL.NewStd(mysql.ErrResourceGroupSupportDisabled)
+	// ErrInvalidResourceGroup returns for invalid resource group settings.
+	ErrInvalidResourceGroup = ClassDDL.NewStd(mysql.ErrInvalidResourceGroup)
 )
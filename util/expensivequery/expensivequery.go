@@ -71,6 +71,11 @@ func (eqh *Handle) Run() {
 						zap.Duration("maxExecutionTime", time.Duration(info.MaxExecutionTime)*time.Millisecond), zap.String("processInfo", info.String()))
 					sm.Kill(info.ID, true)
 				}
+				if info.StmtCtx != nil && info.StmtCtx.RunawayChecker != nil && info.StmtCtx.RunawayChecker.CheckKillAction(time.Now()) {
+					logutil.BgLogger().Warn("runaway query timeout, kill it", zap.Duration("costTime", costTime),
+						zap.String("processInfo", info.String()))
+					sm.Kill(info.ID, true)
+				}
 				if info.ID == util.GetAutoAnalyzeProcID(sm.ServerID) {
 					maxAutoAnalyzeTime := variable.MaxAutoAnalyzeTime.Load()
 					if maxAutoAnalyzeTime > 0 && costTime > time.Duration(maxAutoAnalyzeTime)*time.Second {
@@ -787,6 +787,8 @@ const (
 	LabelForGlobalAnalyzeMemory int = -25
 	// LabelForPreparedPlanCache represents the label of the prepared plan cache memory usage
 	LabelForPreparedPlanCache int = -26
+	// LabelForInstancePlanCache represents the label of the instance plan cache memory usage
+	LabelForInstancePlanCache int = -27
 )
 
 // MetricsTypes is used to get label for metrics
@@ -144,6 +144,10 @@ func TestBasicFuncProcessInfo(t *testing.T) {
 	row3 := pi.ToRow(time.UTC)
 	assert.Equal(t, row, row3[:8])
 	assert.Equal(t, int64(0), row3[9])
+
+	pi.StmtCtx.SetProgress("load data: 50.00%")
+	row = pi.ToRowForShow(false)
+	assert.Equal(t, "in transaction; autocommit; load data: 50.00%", row[6])
 }
 
 func TestBasicFuncRandomBuf(t *testing.T) {
@@ -127,6 +127,8 @@ const (
 	TypeCTE = "CTEFullScan"
 	// TypeCTEDefinition is the type of CTE definition
 	TypeCTEDefinition = "CTE"
+	// TypeJSONTable is the type of JSON_TABLE.
+	TypeJSONTable = "JSONTable"
 )
 
 // plan id.
@@ -187,6 +189,7 @@ const (
 	typePartitionUnionID      int = 53
 	typeShuffleID             int = 54
 	typeShuffleReceiverID     int = 55
+	typeJSONTableID           int = 56
 )
 
 // TypeStringToPhysicalID converts the plan type string to plan id.
@@ -302,6 +305,8 @@ func TypeStringToPhysicalID(tp string) int {
 		return typeCTEDefinitionID
 	case TypeCTETable:
 		return typeCTETableID
+	case TypeJSONTable:
+		return typeJSONTableID
 	}
 	// Should never reach here.
 	return 0
@@ -420,6 +425,8 @@ func PhysicalIDToTypeString(id int) string {
 		return TypeCTEDefinition
 	case typeCTETableID:
 		return TypeCTETable
+	case typeJSONTableID:
+		return TypeJSONTable
 	}
 
 	// Should never reach here.
@@ -84,6 +84,12 @@ func (pi *ProcessInfo) ToRowForShow(full bool) []interface{} {
 	} else {
 		host = pi.Host
 	}
+	state := serverStatus2Str(pi.State)
+	if pi.StmtCtx != nil {
+		if progress := pi.StmtCtx.GetProgress(); len(progress) > 0 {
+			state = fmt.Sprintf("%s; %s", state, progress)
+		}
+	}
 	return []interface{}{
 		pi.ID,
 		pi.User,
@@ -91,7 +97,7 @@ func (pi *ProcessInfo) ToRowForShow(full bool) []interface{} {
 		db,
 		mysql.Command2Str[pi.Command],
 		t,
-		serverStatus2Str(pi.State),
+		state,
 		info,
 	}
 }
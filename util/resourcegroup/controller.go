@@ -0,0 +1,207 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser/model"
+	"go.uber.org/atomic"
+	"golang.org/x/time/rate"
+)
+
+// The request unit (RU) is an abstract unit of the resources consumed by the requests.
+// The costs below are rough estimations which are good enough to share the cluster between groups.
+const (
+	// ReadRequestRU is the cost of sending one coprocessor request.
+	ReadRequestRU = 1
+	// ReadBytesPerRU is the number of bytes read from the storage per RU.
+	ReadBytesPerRU = 64 * 1024
+	// WriteRequestRU is the cost of committing one transaction.
+	WriteRequestRU = 1
+	// WriteBytesPerRU is the number of bytes written to the storage per RU.
+	WriteBytesPerRU = 1024
+)
+
+// ReadRU returns the RU consumed by reading n bytes.
+func ReadRU(n int) uint64 {
+	return uint64((n + ReadBytesPerRU - 1) / ReadBytesPerRU)
+}
+
+// WriteRU returns the RU consumed by writing n bytes.
+func WriteRU(n int) uint64 {
+	return uint64((n + WriteBytesPerRU - 1) / WriteBytesPerRU)
+}
+
+// Consumption is the RU consumed by a resource group on this TiDB instance.
+type Consumption struct {
+	Name    string
+	ReadRU  uint64
+	WriteRU uint64
+}
+
+type groupLimiter struct {
+	settings model.ResourceGroupSettings
+	limiter  *rate.Limiter
+	readRU   atomic.Uint64
+	writeRU  atomic.Uint64
+}
+
+func newGroupLimiter(settings model.ResourceGroupSettings) *groupLimiter {
+	g := &groupLimiter{settings: settings}
+	if settings.Burstable {
+		// Burstable groups may use the spare capacity of the cluster, so they are never throttled.
+		g.limiter = rate.NewLimiter(rate.Inf, 0)
+		return g
+	}
+	// Allow at most one second worth of tokens to be accumulated.
+	burst := settings.RURate
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	g.limiter = rate.NewLimiter(rate.Limit(settings.RURate), int(burst))
+	return g
+}
+
+// clip makes sure n tokens can be taken from the bucket at once.
+func (g *groupLimiter) clip(n uint64) int {
+	if burst := uint64(g.limiter.Burst()); g.limiter.Limit() != rate.Inf && n > burst {
+		return int(burst)
+	}
+	if n > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(n)
+}
+
+func (g *groupLimiter) record(name, tp string, ru uint64) {
+	if ru == 0 {
+		return
+	}
+	if tp == metrics.LblRUWrite {
+		g.writeRU.Add(ru)
+	} else {
+		g.readRU.Add(ru)
+	}
+	metrics.ResourceGroupRUCounter.WithLabelValues(name, tp).Add(float64(ru))
+}
+
+// Controller throttles the requests of the resource groups with token buckets.
+// Each resource group owns a bucket which is refilled at RU_PER_SEC tokens per second.
+type Controller struct {
+	mu     sync.RWMutex
+	groups map[string]*groupLimiter
+}
+
+// NewController creates a Controller.
+func NewController() *Controller {
+	return &Controller{groups: make(map[string]*groupLimiter)}
+}
+
+var globalController = NewController()
+
+// GlobalController returns the Controller shared by the whole TiDB instance.
+func GlobalController() *Controller {
+	return globalController
+}
+
+// UpdateGroups synchronizes the buckets with the resource groups in the info schema.
+// The consumption of the groups whose settings are unchanged are kept.
+func (c *Controller) UpdateGroups(groups []*model.ResourceGroupInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newGroups := make(map[string]*groupLimiter, len(groups))
+	for _, group := range groups {
+		if group.ResourceGroupSettings == nil {
+			continue
+		}
+		old, ok := c.groups[group.Name.L]
+		if !ok {
+			newGroups[group.Name.L] = newGroupLimiter(*group.ResourceGroupSettings)
+			continue
+		}
+		// Only the RU settings affect the bucket, the other settings such as the runaway rule are ignored.
+		if old.settings.RURate == group.RURate && old.settings.Burstable == group.Burstable {
+			newGroups[group.Name.L] = old
+			continue
+		}
+		// The limiter may be in use, so a new one is created instead of modifying it.
+		g := newGroupLimiter(*group.ResourceGroupSettings)
+		g.readRU.Store(old.readRU.Load())
+		g.writeRU.Store(old.writeRU.Load())
+		newGroups[group.Name.L] = g
+	}
+	c.groups = newGroups
+}
+
+func (c *Controller) getGroup(name string) *groupLimiter {
+	if name == "" {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.groups[name]
+}
+
+// Acquire waits until ru tokens are available in the bucket of the group and takes them.
+// Requests of unknown groups are not throttled.
+func (c *Controller) Acquire(ctx context.Context, name, tp string, ru uint64) error {
+	g := c.getGroup(name)
+	if g == nil {
+		return nil
+	}
+	start := time.Now()
+	if err := g.limiter.WaitN(ctx, g.clip(ru)); err != nil {
+		return err
+	}
+	if waited := time.Since(start); waited > time.Millisecond {
+		metrics.ResourceGroupThrottleDuration.WithLabelValues(name).Observe(waited.Seconds())
+	}
+	g.record(name, tp, ru)
+	return nil
+}
+
+// Consume takes ru tokens from the bucket of the group without waiting.
+// It is used to charge the cost which is only known after the request is finished,
+// the bucket may go into debt and the following requests of the group will be delayed.
+func (c *Controller) Consume(name, tp string, ru uint64) {
+	g := c.getGroup(name)
+	if g == nil || ru == 0 {
+		return
+	}
+	g.limiter.ReserveN(time.Now(), g.clip(ru))
+	g.record(name, tp, ru)
+}
+
+// Consumptions returns the RU consumed by each resource group on this TiDB instance.
+func (c *Controller) Consumptions() []Consumption {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make([]Consumption, 0, len(c.groups))
+	for name, g := range c.groups {
+		result = append(result, Consumption{
+			Name:    name,
+			ReadRU:  g.readRU.Load(),
+			WriteRU: g.writeRU.Load(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newGroup(name string, ruRate uint64, burstable bool) *model.ResourceGroupInfo {
+	return &model.ResourceGroupInfo{
+		Name: model.NewCIStr(name),
+		ResourceGroupSettings: &model.ResourceGroupSettings{
+			RURate:    ruRate,
+			Burstable: burstable,
+		},
+	}
+}
+
+func TestRU(t *testing.T) {
+	require.Equal(t, uint64(0), ReadRU(0))
+	require.Equal(t, uint64(1), ReadRU(1))
+	require.Equal(t, uint64(2), ReadRU(ReadBytesPerRU+1))
+	require.Equal(t, uint64(1), WriteRU(WriteBytesPerRU))
+	require.Equal(t, uint64(3), WriteRU(2*WriteBytesPerRU+1))
+}
+
+func TestControllerThrottle(t *testing.T) {
+	c := NewController()
+	c.UpdateGroups([]*model.ResourceGroupInfo{newGroup("rg1", 10, false), newGroup("rg2", 10, true)})
+	ctx := context.Background()
+
+	// The bucket starts full, so the first second worth of tokens are available immediately.
+	require.NoError(t, c.Acquire(ctx, "rg1", metrics.LblRURead, 10))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, c.Acquire(timeoutCtx, "rg1", metrics.LblRURead, 5))
+
+	// Burstable groups and unknown groups are never throttled.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, c.Acquire(timeoutCtx, "rg2", metrics.LblRURead, 100))
+		require.NoError(t, c.Acquire(timeoutCtx, "unknown", metrics.LblRURead, 100))
+	}
+	c.Consume("rg2", metrics.LblRUWrite, 7)
+
+	require.Equal(t, []Consumption{
+		{Name: "rg1", ReadRU: 10},
+		{Name: "rg2", ReadRU: 1000, WriteRU: 7},
+	}, c.Consumptions())
+
+	// Consumption is kept when the settings are changed, and removed with the group.
+	c.UpdateGroups([]*model.ResourceGroupInfo{newGroup("rg1", 10, true)})
+	require.NoError(t, c.Acquire(timeoutCtx, "rg1", metrics.LblRURead, 100))
+	require.Equal(t, []Consumption{{Name: "rg1", ReadRU: 110}}, c.Consumptions())
+}
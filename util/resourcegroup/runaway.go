@@ -0,0 +1,236 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+	"go.uber.org/atomic"
+)
+
+// ErrQueryRunawayQuarantine is returned when the query is killed because it's in the runaway watch list.
+var ErrQueryRunawayQuarantine = dbterror.ClassExecutor.NewStd(errno.ErrResourceGroupQueryRunaway)
+
+// The match types of the runaway records.
+const (
+	// RunawayMatchIdentify means the query is identified as a runaway query when it runs too long.
+	RunawayMatchIdentify = "identify"
+	// RunawayMatchWatch means the query matches a runaway query in the watch list.
+	RunawayMatchWatch = "watch"
+)
+
+// maxRunawayRecords is the max number of the records which are not flushed to mysql.tidb_runaway_queries yet,
+// the later records are dropped if the records can't be flushed in time.
+const maxRunawayRecords = 1024
+
+// RunawayRecord is a runaway query matched by the rule of its resource group,
+// it's recorded in mysql.tidb_runaway_queries.
+type RunawayRecord struct {
+	ResourceGroupName string
+	Time              time.Time
+	Match             string
+	Action            string
+	SQLText           string
+	SQLDigest         string
+	PlanDigest        string
+}
+
+type runawayWatchItem struct {
+	expireAt time.Time
+	action   model.RunawayActionType
+}
+
+// RunawayManager keeps the watch list of the runaway queries and the records not flushed yet.
+// The watch list is kept in memory, so a runaway query is only quarantined on the TiDB instance identifying it.
+type RunawayManager struct {
+	mu      sync.Mutex
+	watches map[string]*runawayWatchItem
+	records []*RunawayRecord
+}
+
+// NewRunawayManager creates a RunawayManager.
+func NewRunawayManager() *RunawayManager {
+	return &RunawayManager{watches: make(map[string]*runawayWatchItem)}
+}
+
+var globalRunawayManager = NewRunawayManager()
+
+// GlobalRunawayManager returns the RunawayManager shared by the whole TiDB instance.
+func GlobalRunawayManager() *RunawayManager {
+	return globalRunawayManager
+}
+
+func runawayWatchKey(group string, tp model.RunawayWatchType, value string) string {
+	var sb strings.Builder
+	sb.WriteString(group)
+	sb.WriteByte('/')
+	sb.WriteString(tp.String())
+	sb.WriteByte('/')
+	sb.WriteString(value)
+	return sb.String()
+}
+
+func (m *RunawayManager) addWatch(key string, item *runawayWatchItem) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watches[key] = item
+}
+
+func (m *RunawayManager) matchWatch(key string, now time.Time) (*runawayWatchItem, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.watches[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(item.expireAt) {
+		delete(m.watches, key)
+		return nil, false
+	}
+	return item, true
+}
+
+func (m *RunawayManager) addRecord(record *RunawayRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.records) < maxRunawayRecords {
+		m.records = append(m.records, record)
+	}
+}
+
+// TakeRecords returns the records not flushed yet and clears them.
+func (m *RunawayManager) TakeRecords() []*RunawayRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	records := m.records
+	m.records = nil
+	return records
+}
+
+// DeriveChecker creates the checker of a query in the resource group. nil is returned if the group has no rule
+// of the runaway queries.
+func (m *RunawayManager) DeriveChecker(group string, settings *model.ResourceGroupRunawaySettings, sqlText, sqlDigest, planDigest string, start time.Time) *RunawayChecker {
+	if settings == nil {
+		return nil
+	}
+	return &RunawayChecker{
+		manager:    m,
+		group:      group,
+		settings:   settings,
+		sqlText:    sqlText,
+		sqlDigest:  sqlDigest,
+		planDigest: planDigest,
+		deadline:   start.Add(settings.ExecElapsed),
+	}
+}
+
+// RunawayChecker checks whether a query is a runaway query and takes the action of the rule on it.
+type RunawayChecker struct {
+	manager    *RunawayManager
+	group      string
+	settings   *model.ResourceGroupRunawaySettings
+	sqlText    string
+	sqlDigest  string
+	planDigest string
+	deadline   time.Time
+
+	// marked indicates the query is already identified as a runaway query or matched by the watch list.
+	marked   atomic.Bool
+	cooldown atomic.Bool
+}
+
+// watchValue returns the value of the query matched by the watch type.
+func (r *RunawayChecker) watchValue(tp model.RunawayWatchType) string {
+	switch tp {
+	case model.RunawayWatchExact:
+		return r.sqlText
+	case model.RunawayWatchSimilar:
+		return r.sqlDigest
+	case model.RunawayWatchPlan:
+		return r.planDigest
+	}
+	return ""
+}
+
+func (r *RunawayChecker) record(match string, action model.RunawayActionType, now time.Time) {
+	r.manager.addRecord(&RunawayRecord{
+		ResourceGroupName: r.group,
+		Time:              now,
+		Match:             match,
+		Action:            strings.ToLower(action.String()),
+		SQLText:           r.sqlText,
+		SQLDigest:         r.sqlDigest,
+		PlanDigest:        r.planDigest,
+	})
+}
+
+// BeforeExecutor checks the query against the watch list before it's executed. An error is returned if the
+// query is quarantined by the KILL action.
+func (r *RunawayChecker) BeforeExecutor() error {
+	tp := r.settings.WatchType
+	value := r.watchValue(tp)
+	if tp == model.RunawayWatchNone || value == "" {
+		return nil
+	}
+	now := time.Now()
+	item, ok := r.manager.matchWatch(runawayWatchKey(r.group, tp, value), now)
+	if !ok {
+		return nil
+	}
+	r.marked.Store(true)
+	r.record(RunawayMatchWatch, item.action, now)
+	switch item.action {
+	case model.RunawayActionKill:
+		return ErrQueryRunawayQuarantine
+	case model.RunawayActionCooldown:
+		r.cooldown.Store(true)
+	}
+	return nil
+}
+
+// CheckKillAction checks whether the query runs longer than EXEC_ELAPSED, it's called periodically while the
+// query is running. The query is recorded and watched when it's identified as a runaway query for the first time,
+// true is returned if it should be killed.
+func (r *RunawayChecker) CheckKillAction(now time.Time) bool {
+	if now.Before(r.deadline) || !r.marked.CompareAndSwap(false, true) {
+		return false
+	}
+	r.record(RunawayMatchIdentify, r.settings.Action, now)
+	if tp := r.settings.WatchType; tp != model.RunawayWatchNone {
+		if value := r.watchValue(tp); value != "" {
+			r.manager.addWatch(runawayWatchKey(r.group, tp, value), &runawayWatchItem{
+				expireAt: now.Add(r.settings.WatchDuration),
+				action:   r.settings.Action,
+			})
+		}
+	}
+	switch r.settings.Action {
+	case model.RunawayActionKill:
+		return true
+	case model.RunawayActionCooldown:
+		r.cooldown.Store(true)
+	}
+	return false
+}
+
+// ShouldCooldown returns whether the requests of the query should be sent with the low priority.
+func (r *RunawayChecker) ShouldCooldown() bool {
+	return r != nil && r.cooldown.Load()
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunawayChecker(t *testing.T) {
+	m := NewRunawayManager()
+	require.Nil(t, m.DeriveChecker("rg1", nil, "select 1", "d1", "p1", time.Now()))
+
+	settings := &model.ResourceGroupRunawaySettings{
+		ExecElapsed:   time.Second,
+		Action:        model.RunawayActionKill,
+		WatchType:     model.RunawayWatchSimilar,
+		WatchDuration: time.Minute,
+	}
+	start := time.Now()
+	checker := m.DeriveChecker("rg1", settings, "select 1", "d1", "p1", start)
+	require.NoError(t, checker.BeforeExecutor())
+	require.False(t, checker.CheckKillAction(start))
+	// The query is identified and killed only once.
+	require.True(t, checker.CheckKillAction(start.Add(time.Second)))
+	require.False(t, checker.CheckKillAction(start.Add(2*time.Second)))
+
+	// The later executions with the same digest are quarantined.
+	checker = m.DeriveChecker("rg1", settings, "select 2", "d1", "p2", time.Now())
+	require.ErrorIs(t, checker.BeforeExecutor(), ErrQueryRunawayQuarantine)
+	require.NoError(t, m.DeriveChecker("rg2", settings, "select 1", "d1", "p1", time.Now()).BeforeExecutor())
+	require.NoError(t, m.DeriveChecker("rg1", settings, "select 1", "d2", "p1", time.Now()).BeforeExecutor())
+
+	records := m.TakeRecords()
+	require.Len(t, records, 2)
+	require.Equal(t, RunawayMatchIdentify, records[0].Match)
+	require.Equal(t, "kill", records[0].Action)
+	require.Equal(t, RunawayMatchWatch, records[1].Match)
+	require.Equal(t, "select 2", records[1].SQLText)
+	require.Len(t, m.TakeRecords(), 0)
+
+	// The COOLDOWN action lowers the priority instead of killing the query.
+	settings = &model.ResourceGroupRunawaySettings{
+		ExecElapsed:   time.Second,
+		Action:        model.RunawayActionCooldown,
+		WatchType:     model.RunawayWatchPlan,
+		WatchDuration: time.Second,
+	}
+	checker = m.DeriveChecker("rg3", settings, "select 3", "d3", "p3", start)
+	require.False(t, checker.ShouldCooldown())
+	require.False(t, checker.CheckKillAction(start.Add(time.Second)))
+	require.True(t, checker.ShouldCooldown())
+	checker = m.DeriveChecker("rg3", settings, "select 4", "d4", "p3", time.Now())
+	require.NoError(t, checker.BeforeExecutor())
+	require.True(t, checker.ShouldCooldown())
+	var nilChecker *RunawayChecker
+	require.False(t, nilChecker.ShouldCooldown())
+
+	// The watch item expires after the duration.
+	_, ok := m.matchWatch(runawayWatchKey("rg3", model.RunawayWatchPlan, "p3"), start.Add(2*time.Second))
+	require.False(t, ok)
+}
@@ -15,6 +15,8 @@
 package resourcegrouptag
 
 import (
+	"context"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/coprocessor"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
@@ -57,6 +59,30 @@ func DecodeResourceGroupTag(data []byte) (sqlDigest []byte, err error) {
 	return tag.SqlDigest, nil
 }
 
+type resourceGroupNameKeyType struct{}
+
+var resourceGroupNameKey = resourceGroupNameKeyType{}
+
+// WithResourceGroupName tags the context with the resource group the requests issued under it belong to.
+func WithResourceGroupName(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, resourceGroupNameKey, name)
+}
+
+// GetResourceGroupNameFromCtx returns the resource group name tagged by WithResourceGroupName.
+// An empty string is returned if the context is not tagged.
+func GetResourceGroupNameFromCtx(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if name, ok := ctx.Value(resourceGroupNameKey).(string); ok {
+		return name
+	}
+	return ""
+}
+
 // GetResourceGroupLabelByKey determines the tipb.ResourceGroupTagLabel of key.
 func GetResourceGroupLabelByKey(key []byte) tipb.ResourceGroupTagLabel {
 	switch rowindexcodec.GetKeyKind(key) {
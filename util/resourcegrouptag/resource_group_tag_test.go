@@ -15,6 +15,7 @@
 package resourcegrouptag
 
 import (
+	"context"
 	"crypto/sha256"
 	"math/rand"
 	"testing"
@@ -198,3 +199,14 @@ func genDigest(str string) []byte {
 	hasher.Write(hack.Slice(str))
 	return hasher.Sum(nil)
 }
+
+func TestResourceGroupNameFromCtx(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, "", GetResourceGroupNameFromCtx(ctx))
+	require.Equal(t, ctx, WithResourceGroupName(ctx, ""))
+
+	ctx = WithResourceGroupName(ctx, "rg1")
+	require.Equal(t, "rg1", GetResourceGroupNameFromCtx(ctx))
+	ctx = WithResourceGroupName(ctx, "rg2")
+	require.Equal(t, "rg2", GetResourceGroupNameFromCtx(ctx))
+}
@@ -0,0 +1,715 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmtsummary
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// rotatedTimeFormat is the time format in the names of the files rotated by lumberjack.
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+// stmtSummaryPersister writes the statement summaries of the finished intervals to the local files,
+// one JSON record per line. The files are rotated and purged by lumberjack.
+type stmtSummaryPersister struct {
+	sync.Mutex
+	filename string
+	logger   *lumberjack.Logger
+}
+
+func newStmtSummaryPersister(filename string, maxDays, maxSize, maxBackups int) (*stmtSummaryPersister, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &stmtSummaryPersister{
+		filename: filename,
+		logger: &lumberjack.Logger{
+			Filename:   filename,
+			MaxSize:    maxSize,
+			MaxAge:     maxDays,
+			MaxBackups: maxBackups,
+		},
+	}, nil
+}
+
+// collectPersistentRecords collects the summaries of the interval which begins at `beginTime`, the end time
+// of the records is truncated to `endTime` in case the interval is ended in advance.
+func collectPersistentRecords(values []kvcache.Value, other *stmtSummaryByDigestEvicted, beginTime, endTime int64) []*stmtRecord {
+	records := make([]*stmtRecord, 0, len(values)+1)
+	for _, value := range values {
+		if record := collectPersistentRecord(value.(*stmtSummaryByDigest), beginTime); record != nil {
+			records = append(records, record)
+		}
+	}
+	if record := collectEvictedPersistentRecord(other, beginTime); record != nil {
+		records = append(records, record)
+	}
+	for _, record := range records {
+		if record.EndTime > endTime {
+			record.EndTime = endTime
+		}
+	}
+	return records
+}
+
+// persist writes the records to the file, one JSON record per line.
+func (p *stmtSummaryPersister) persist(records []*stmtRecord) {
+	if len(records) == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			logutil.BgLogger().Warn("encode statement summary failed", zap.String("digest", record.Digest), zap.Error(err))
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if _, err := p.logger.Write(buf.Bytes()); err != nil {
+		logutil.BgLogger().Warn("persist statement summary failed", zap.String("filename", p.filename), zap.Error(err))
+	}
+}
+
+func (p *stmtSummaryPersister) close() error {
+	p.Lock()
+	defer p.Unlock()
+	return errors.Trace(p.logger.Close())
+}
+
+func collectPersistentRecord(ssbd *stmtSummaryByDigest, beginTime int64) *stmtRecord {
+	ssbd.Lock()
+	defer ssbd.Unlock()
+	if !ssbd.initialized {
+		return nil
+	}
+	// The latest summary is at the back of the history.
+	for e := ssbd.history.Back(); e != nil; e = e.Prev() {
+		ssElement := e.Value.(*stmtSummaryByDigestElement)
+		if ssElement.beginTime < beginTime {
+			break
+		}
+		if ssElement.beginTime == beginTime {
+			ssElement.Lock()
+			defer ssElement.Unlock()
+			return newStmtRecord(ssbd, ssElement)
+		}
+	}
+	return nil
+}
+
+func collectEvictedPersistentRecord(ssbde *stmtSummaryByDigestEvicted, beginTime int64) *stmtRecord {
+	ssbde.Lock()
+	defer ssbde.Unlock()
+	for e := ssbde.history.Back(); e != nil; e = e.Prev() {
+		seElement := e.Value.(*stmtSummaryByDigestEvictedElement)
+		if seElement.beginTime < beginTime {
+			break
+		}
+		if seElement.beginTime == beginTime {
+			return newStmtRecord(new(stmtSummaryByDigest), seElement.otherSummary)
+		}
+	}
+	return nil
+}
+
+// SetupPersistent enables persisting the statement summary history to the local files.
+// `maxSize` is in megabytes and `maxDays` is the days to retain the rotated files.
+func (ssMap *stmtSummaryByDigestMap) SetupPersistent(filename string, maxDays, maxSize, maxBackups int) error {
+	persister, err := newStmtSummaryPersister(filename, maxDays, maxSize, maxBackups)
+	if err != nil {
+		return err
+	}
+	ssMap.Lock()
+	defer ssMap.Unlock()
+	ssMap.persister = persister
+	return nil
+}
+
+// ClosePersistent persists the summaries of the current interval and closes the files.
+// It's called when the server is shutting down.
+func (ssMap *stmtSummaryByDigestMap) ClosePersistent() error {
+	ssMap.Lock()
+	persister := ssMap.persister
+	ssMap.persister = nil
+	values := ssMap.summaryMap.Values()
+	beginTime := ssMap.beginTimeForCurInterval
+	ssMap.Unlock()
+
+	if persister == nil {
+		return nil
+	}
+	if beginTime > 0 {
+		persister.persist(collectPersistentRecords(values, ssMap.other, beginTime, time.Now().Unix()))
+	}
+	return persister.close()
+}
+
+// PersistentEnabled returns whether the statement summary history is persisted to the local files.
+func (ssMap *stmtSummaryByDigestMap) PersistentEnabled() bool {
+	ssMap.Lock()
+	defer ssMap.Unlock()
+	return ssMap.persister != nil
+}
+
+// persistentFilename returns the filename of the persisted history, or empty if it's disabled.
+func (ssMap *stmtSummaryByDigestMap) persistentFilename() string {
+	ssMap.Lock()
+	defer ssMap.Unlock()
+	if ssMap.persister == nil {
+		return ""
+	}
+	return ssMap.persister.filename
+}
+
+// HistoryReader reads the statement summary history from the persisted files, and then the summaries
+// of the current interval from the memory.
+type HistoryReader struct {
+	ssr *stmtSummaryReader
+	// startTime and endTime are in seconds, 0 means unbounded.
+	startTime int64
+	endTime   int64
+
+	files   []string
+	file    *os.File
+	reader  *bufio.Reader
+	memRead bool
+}
+
+// NewHistoryReader returns a reader which reads the history in the time range [startTime, endTime],
+// zero time means unbounded.
+func (ssr *stmtSummaryReader) NewHistoryReader(startTime, endTime time.Time) (*HistoryReader, error) {
+	r := &HistoryReader{ssr: ssr}
+	if !startTime.IsZero() {
+		r.startTime = startTime.Unix()
+	}
+	if !endTime.IsZero() {
+		r.endTime = endTime.Unix()
+	}
+	filename := ssr.ssMap.persistentFilename()
+	if filename == "" {
+		return r, nil
+	}
+	files, err := getPersistentFiles(filename, startTime)
+	if err != nil {
+		return nil, err
+	}
+	r.files = files
+	return r, nil
+}
+
+// getPersistentFiles returns the rotated files and the current file from the oldest to the newest.
+// The rotated files which are rotated before `startTime` are skipped.
+func getPersistentFiles(filename string, startTime time.Time) ([]string, error) {
+	dir := filepath.Dir(filename)
+	ext := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filepath.Base(filename), ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	type rotatedFile struct {
+		name string
+		time time.Time
+	}
+	rotatedFiles := make([]rotatedFile, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		// The rotated files are named as `<name>-<rotated time>.<ext>` by lumberjack.
+		t, err := time.Parse(rotatedTimeFormat, name[len(prefix):len(name)-len(ext)])
+		if err != nil {
+			continue
+		}
+		if !startTime.IsZero() && t.Before(startTime) {
+			continue
+		}
+		rotatedFiles = append(rotatedFiles, rotatedFile{name: filepath.Join(dir, name), time: t})
+	}
+	slices.SortFunc(rotatedFiles, func(i, j rotatedFile) bool {
+		return i.time.Before(j.time)
+	})
+	files := make([]string, 0, len(rotatedFiles)+1)
+	for _, f := range rotatedFiles {
+		files = append(files, f.name)
+	}
+	return append(files, filename), nil
+}
+
+// Rows returns the next batch of the rows, an empty result means all rows are read.
+func (r *HistoryReader) Rows(batchSize int) ([][]types.Datum, error) {
+	rows := make([][]types.Datum, 0, batchSize)
+	for len(rows) < batchSize {
+		if r.reader == nil {
+			if len(r.files) == 0 {
+				if !r.memRead {
+					r.memRead = true
+					rows = append(rows, r.currentRows()...)
+				}
+				break
+			}
+			if err := r.openFile(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if row := r.decodeRow(line); row != nil {
+				rows = append(rows, row)
+			}
+		}
+		if err == io.EOF {
+			if err = r.closeFile(); err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return rows, nil
+}
+
+func (r *HistoryReader) openFile() error {
+	filename := r.files[0]
+	r.files = r.files[1:]
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		// The file may be purged or not created yet.
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	r.file = file
+	r.reader = bufio.NewReader(file)
+	return nil
+}
+
+func (r *HistoryReader) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file, r.reader = nil, nil
+	return errors.Trace(err)
+}
+
+func (r *HistoryReader) decodeRow(line []byte) []types.Datum {
+	begin, end, ok := parseRecordTimeRange(line)
+	if !ok || (r.startTime > 0 && end < r.startTime) || (r.endTime > 0 && begin > r.endTime) {
+		return nil
+	}
+	record := new(stmtRecord)
+	if err := json.Unmarshal(line, record); err != nil {
+		logutil.BgLogger().Warn("decode persisted statement summary failed", zap.Error(err))
+		return nil
+	}
+	if r.ssr.checker != nil && !r.ssr.checker.isDigestValid(record.Digest) {
+		return nil
+	}
+	ssbd, ssElement := record.toSummary()
+	return r.ssr.getStmtByDigestElementRow(ssElement, ssbd)
+}
+
+// currentRows returns the rows in the memory. If the history is not persisted, all the history in the
+// memory is returned, otherwise only the current interval is returned.
+func (r *HistoryReader) currentRows() [][]types.Datum {
+	ssMap := r.ssr.ssMap
+	ssMap.Lock()
+	persistent := ssMap.persister != nil
+	beginTime := ssMap.beginTimeForCurInterval
+	ssMap.Unlock()
+
+	if !persistent {
+		return r.ssr.GetStmtSummaryHistoryRows()
+	}
+	if r.endTime > 0 && beginTime > r.endTime {
+		return nil
+	}
+	return r.ssr.GetStmtSummaryCurrentRows()
+}
+
+// Close closes the opened file.
+func (r *HistoryReader) Close() error {
+	return r.closeFile()
+}
+
+// parseRecordTimeRange parses the begin time and end time from the head of a persisted record,
+// so that the records out of the queried time range can be skipped without decoding the whole line.
+func parseRecordTimeRange(line []byte) (begin, end int64, ok bool) {
+	const beginPrefix, endPrefix = `{"begin":`, `,"end":`
+	if !bytes.HasPrefix(line, []byte(beginPrefix)) {
+		return 0, 0, false
+	}
+	line = line[len(beginPrefix):]
+	idx := bytes.IndexByte(line, ',')
+	if idx < 0 {
+		return 0, 0, false
+	}
+	begin, err := strconv.ParseInt(string(line[:idx]), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	line = line[idx:]
+	if !bytes.HasPrefix(line, []byte(endPrefix)) {
+		return 0, 0, false
+	}
+	line = line[len(endPrefix):]
+	idx = bytes.IndexAny(line, ",}")
+	if idx < 0 {
+		return 0, 0, false
+	}
+	end, err = strconv.ParseInt(string(line[:idx]), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return begin, end, true
+}
+
+// stmtRecord is the persisted format of a statement summary in one interval.
+type stmtRecord struct {
+	// BeginTime and EndTime must be the first fields, see parseRecordTimeRange.
+	BeginTime int64 `json:"begin"`
+	EndTime   int64 `json:"end"`
+	// The fields of stmtSummaryByDigest.
+	SchemaName    string `json:"schema_name,omitempty"`
+	Digest        string `json:"digest,omitempty"`
+	PlanDigest    string `json:"plan_digest,omitempty"`
+	StmtType      string `json:"stmt_type,omitempty"`
+	NormalizedSQL string `json:"normalized_sql,omitempty"`
+	TableNames    string `json:"table_names,omitempty"`
+	// The fields of stmtSummaryByDigestElement.
+	SampleSQL                    string         `json:"sample_sql,omitempty"`
+	Charset                      string         `json:"charset,omitempty"`
+	Collation                    string         `json:"collation,omitempty"`
+	PrevSQL                      string         `json:"prev_sql,omitempty"`
+	SamplePlan                   string         `json:"sample_plan,omitempty"`
+	SampleBinaryPlan             string         `json:"sample_binary_plan,omitempty"`
+	PlanHint                     string         `json:"plan_hint,omitempty"`
+	IndexNames                   []string       `json:"index_names,omitempty"`
+	ExecCount                    int64          `json:"exec_count,omitempty"`
+	SumErrors                    int            `json:"sum_errors,omitempty"`
+	SumWarnings                  int            `json:"sum_warnings,omitempty"`
+	SumLatency                   time.Duration  `json:"sum_latency,omitempty"`
+	MaxLatency                   time.Duration  `json:"max_latency,omitempty"`
+	MinLatency                   time.Duration  `json:"min_latency,omitempty"`
+	SumParseLatency              time.Duration  `json:"sum_parse_latency,omitempty"`
+	MaxParseLatency              time.Duration  `json:"max_parse_latency,omitempty"`
+	SumCompileLatency            time.Duration  `json:"sum_compile_latency,omitempty"`
+	MaxCompileLatency            time.Duration  `json:"max_compile_latency,omitempty"`
+	SumNumCopTasks               int64          `json:"sum_num_cop_tasks,omitempty"`
+	MaxCopProcessTime            time.Duration  `json:"max_cop_process_time,omitempty"`
+	MaxCopProcessAddress         string         `json:"max_cop_process_address,omitempty"`
+	MaxCopWaitTime               time.Duration  `json:"max_cop_wait_time,omitempty"`
+	MaxCopWaitAddress            string         `json:"max_cop_wait_address,omitempty"`
+	SumProcessTime               time.Duration  `json:"sum_process_time,omitempty"`
+	MaxProcessTime               time.Duration  `json:"max_process_time,omitempty"`
+	SumWaitTime                  time.Duration  `json:"sum_wait_time,omitempty"`
+	MaxWaitTime                  time.Duration  `json:"max_wait_time,omitempty"`
+	SumBackoffTime               time.Duration  `json:"sum_backoff_time,omitempty"`
+	MaxBackoffTime               time.Duration  `json:"max_backoff_time,omitempty"`
+	SumTotalKeys                 int64          `json:"sum_total_keys,omitempty"`
+	MaxTotalKeys                 int64          `json:"max_total_keys,omitempty"`
+	SumProcessedKeys             int64          `json:"sum_processed_keys,omitempty"`
+	MaxProcessedKeys             int64          `json:"max_processed_keys,omitempty"`
+	SumRocksdbDeleteSkippedCount uint64         `json:"sum_rocksdb_delete_skipped_count,omitempty"`
+	MaxRocksdbDeleteSkippedCount uint64         `json:"max_rocksdb_delete_skipped_count,omitempty"`
+	SumRocksdbKeySkippedCount    uint64         `json:"sum_rocksdb_key_skipped_count,omitempty"`
+	MaxRocksdbKeySkippedCount    uint64         `json:"max_rocksdb_key_skipped_count,omitempty"`
+	SumRocksdbBlockCacheHitCount uint64         `json:"sum_rocksdb_block_cache_hit_count,omitempty"`
+	MaxRocksdbBlockCacheHitCount uint64         `json:"max_rocksdb_block_cache_hit_count,omitempty"`
+	SumRocksdbBlockReadCount     uint64         `json:"sum_rocksdb_block_read_count,omitempty"`
+	MaxRocksdbBlockReadCount     uint64         `json:"max_rocksdb_block_read_count,omitempty"`
+	SumRocksdbBlockReadByte      uint64         `json:"sum_rocksdb_block_read_byte,omitempty"`
+	MaxRocksdbBlockReadByte      uint64         `json:"max_rocksdb_block_read_byte,omitempty"`
+	CommitCount                  int64          `json:"commit_count,omitempty"`
+	SumGetCommitTsTime           time.Duration  `json:"sum_get_commit_ts_time,omitempty"`
+	MaxGetCommitTsTime           time.Duration  `json:"max_get_commit_ts_time,omitempty"`
+	SumPrewriteTime              time.Duration  `json:"sum_prewrite_time,omitempty"`
+	MaxPrewriteTime              time.Duration  `json:"max_prewrite_time,omitempty"`
+	SumCommitTime                time.Duration  `json:"sum_commit_time,omitempty"`
+	MaxCommitTime                time.Duration  `json:"max_commit_time,omitempty"`
+	SumLocalLatchTime            time.Duration  `json:"sum_local_latch_time,omitempty"`
+	MaxLocalLatchTime            time.Duration  `json:"max_local_latch_time,omitempty"`
+	SumCommitBackoffTime         int64          `json:"sum_commit_backoff_time,omitempty"`
+	MaxCommitBackoffTime         int64          `json:"max_commit_backoff_time,omitempty"`
+	SumResolveLockTime           int64          `json:"sum_resolve_lock_time,omitempty"`
+	MaxResolveLockTime           int64          `json:"max_resolve_lock_time,omitempty"`
+	SumWriteKeys                 int64          `json:"sum_write_keys,omitempty"`
+	MaxWriteKeys                 int            `json:"max_write_keys,omitempty"`
+	SumWriteSize                 int64          `json:"sum_write_size,omitempty"`
+	MaxWriteSize                 int            `json:"max_write_size,omitempty"`
+	SumPrewriteRegionNum         int64          `json:"sum_prewrite_region_num,omitempty"`
+	MaxPrewriteRegionNum         int32          `json:"max_prewrite_region_num,omitempty"`
+	SumTxnRetry                  int64          `json:"sum_txn_retry,omitempty"`
+	MaxTxnRetry                  int            `json:"max_txn_retry,omitempty"`
+	SumBackoffTimes              int64          `json:"sum_backoff_times,omitempty"`
+	BackoffTypes                 map[string]int `json:"backoff_types,omitempty"`
+	AuthUsers                    []string       `json:"auth_users,omitempty"`
+	SumMem                       int64          `json:"sum_mem,omitempty"`
+	MaxMem                       int64          `json:"max_mem,omitempty"`
+	SumDisk                      int64          `json:"sum_disk,omitempty"`
+	MaxDisk                      int64          `json:"max_disk,omitempty"`
+	SumAffectedRows              uint64         `json:"sum_affected_rows,omitempty"`
+	SumKVTotal                   time.Duration  `json:"sum_kv_total,omitempty"`
+	SumPDTotal                   time.Duration  `json:"sum_pd_total,omitempty"`
+	SumBackoffTotal              time.Duration  `json:"sum_backoff_total,omitempty"`
+	SumWriteSQLRespTotal         time.Duration  `json:"sum_write_sql_resp_total,omitempty"`
+	SumResultRows                int64          `json:"sum_result_rows,omitempty"`
+	MaxResultRows                int64          `json:"max_result_rows,omitempty"`
+	MinResultRows                int64          `json:"min_result_rows,omitempty"`
+	Prepared                     bool           `json:"prepared,omitempty"`
+	FirstSeen                    time.Time      `json:"first_seen"`
+	LastSeen                     time.Time      `json:"last_seen"`
+	PlanInCache                  bool           `json:"plan_in_cache,omitempty"`
+	PlanCacheHits                int64          `json:"plan_cache_hits,omitempty"`
+	PlanInBinding                bool           `json:"plan_in_binding,omitempty"`
+	ExecRetryCount               uint           `json:"exec_retry_count,omitempty"`
+	ExecRetryTime                time.Duration  `json:"exec_retry_time,omitempty"`
+}
+
+func newStmtRecord(ssbd *stmtSummaryByDigest, ssElement *stmtSummaryByDigestElement) *stmtRecord {
+	authUsers := make([]string, 0, len(ssElement.authUsers))
+	for user := range ssElement.authUsers {
+		authUsers = append(authUsers, user)
+	}
+	return &stmtRecord{
+		BeginTime:                    ssElement.beginTime,
+		EndTime:                      ssElement.endTime,
+		SchemaName:                   ssbd.schemaName,
+		Digest:                       ssbd.digest,
+		PlanDigest:                   ssbd.planDigest,
+		StmtType:                     ssbd.stmtType,
+		NormalizedSQL:                ssbd.normalizedSQL,
+		TableNames:                   ssbd.tableNames,
+		SampleSQL:                    ssElement.sampleSQL,
+		Charset:                      ssElement.charset,
+		Collation:                    ssElement.collation,
+		PrevSQL:                      ssElement.prevSQL,
+		SamplePlan:                   ssElement.samplePlan,
+		SampleBinaryPlan:             ssElement.sampleBinaryPlan,
+		PlanHint:                     ssElement.planHint,
+		IndexNames:                   ssElement.indexNames,
+		ExecCount:                    ssElement.execCount,
+		SumErrors:                    ssElement.sumErrors,
+		SumWarnings:                  ssElement.sumWarnings,
+		SumLatency:                   ssElement.sumLatency,
+		MaxLatency:                   ssElement.maxLatency,
+		MinLatency:                   ssElement.minLatency,
+		SumParseLatency:              ssElement.sumParseLatency,
+		MaxParseLatency:              ssElement.maxParseLatency,
+		SumCompileLatency:            ssElement.sumCompileLatency,
+		MaxCompileLatency:            ssElement.maxCompileLatency,
+		SumNumCopTasks:               ssElement.sumNumCopTasks,
+		MaxCopProcessTime:            ssElement.maxCopProcessTime,
+		MaxCopProcessAddress:         ssElement.maxCopProcessAddress,
+		MaxCopWaitTime:               ssElement.maxCopWaitTime,
+		MaxCopWaitAddress:            ssElement.maxCopWaitAddress,
+		SumProcessTime:               ssElement.sumProcessTime,
+		MaxProcessTime:               ssElement.maxProcessTime,
+		SumWaitTime:                  ssElement.sumWaitTime,
+		MaxWaitTime:                  ssElement.maxWaitTime,
+		SumBackoffTime:               ssElement.sumBackoffTime,
+		MaxBackoffTime:               ssElement.maxBackoffTime,
+		SumTotalKeys:                 ssElement.sumTotalKeys,
+		MaxTotalKeys:                 ssElement.maxTotalKeys,
+		SumProcessedKeys:             ssElement.sumProcessedKeys,
+		MaxProcessedKeys:             ssElement.maxProcessedKeys,
+		SumRocksdbDeleteSkippedCount: ssElement.sumRocksdbDeleteSkippedCount,
+		MaxRocksdbDeleteSkippedCount: ssElement.maxRocksdbDeleteSkippedCount,
+		SumRocksdbKeySkippedCount:    ssElement.sumRocksdbKeySkippedCount,
+		MaxRocksdbKeySkippedCount:    ssElement.maxRocksdbKeySkippedCount,
+		SumRocksdbBlockCacheHitCount: ssElement.sumRocksdbBlockCacheHitCount,
+		MaxRocksdbBlockCacheHitCount: ssElement.maxRocksdbBlockCacheHitCount,
+		SumRocksdbBlockReadCount:     ssElement.sumRocksdbBlockReadCount,
+		MaxRocksdbBlockReadCount:     ssElement.maxRocksdbBlockReadCount,
+		SumRocksdbBlockReadByte:      ssElement.sumRocksdbBlockReadByte,
+		MaxRocksdbBlockReadByte:      ssElement.maxRocksdbBlockReadByte,
+		CommitCount:                  ssElement.commitCount,
+		SumGetCommitTsTime:           ssElement.sumGetCommitTsTime,
+		MaxGetCommitTsTime:           ssElement.maxGetCommitTsTime,
+		SumPrewriteTime:              ssElement.sumPrewriteTime,
+		MaxPrewriteTime:              ssElement.maxPrewriteTime,
+		SumCommitTime:                ssElement.sumCommitTime,
+		MaxCommitTime:                ssElement.maxCommitTime,
+		SumLocalLatchTime:            ssElement.sumLocalLatchTime,
+		MaxLocalLatchTime:            ssElement.maxLocalLatchTime,
+		SumCommitBackoffTime:         ssElement.sumCommitBackoffTime,
+		MaxCommitBackoffTime:         ssElement.maxCommitBackoffTime,
+		SumResolveLockTime:           ssElement.sumResolveLockTime,
+		MaxResolveLockTime:           ssElement.maxResolveLockTime,
+		SumWriteKeys:                 ssElement.sumWriteKeys,
+		MaxWriteKeys:                 ssElement.maxWriteKeys,
+		SumWriteSize:                 ssElement.sumWriteSize,
+		MaxWriteSize:                 ssElement.maxWriteSize,
+		SumPrewriteRegionNum:         ssElement.sumPrewriteRegionNum,
+		MaxPrewriteRegionNum:         ssElement.maxPrewriteRegionNum,
+		SumTxnRetry:                  ssElement.sumTxnRetry,
+		MaxTxnRetry:                  ssElement.maxTxnRetry,
+		SumBackoffTimes:              ssElement.sumBackoffTimes,
+		BackoffTypes:                 ssElement.backoffTypes,
+		AuthUsers:                    authUsers,
+		SumMem:                       ssElement.sumMem,
+		MaxMem:                       ssElement.maxMem,
+		SumDisk:                      ssElement.sumDisk,
+		MaxDisk:                      ssElement.maxDisk,
+		SumAffectedRows:              ssElement.sumAffectedRows,
+		SumKVTotal:                   ssElement.sumKVTotal,
+		SumPDTotal:                   ssElement.sumPDTotal,
+		SumBackoffTotal:              ssElement.sumBackoffTotal,
+		SumWriteSQLRespTotal:         ssElement.sumWriteSQLRespTotal,
+		SumResultRows:                ssElement.sumResultRows,
+		MaxResultRows:                ssElement.maxResultRows,
+		MinResultRows:                ssElement.minResultRows,
+		Prepared:                     ssElement.prepared,
+		FirstSeen:                    ssElement.firstSeen,
+		LastSeen:                     ssElement.lastSeen,
+		PlanInCache:                  ssElement.planInCache,
+		PlanCacheHits:                ssElement.planCacheHits,
+		PlanInBinding:                ssElement.planInBinding,
+		ExecRetryCount:               ssElement.execRetryCount,
+		ExecRetryTime:                ssElement.execRetryTime,
+	}
+}
+
+func (r *stmtRecord) toSummary() (*stmtSummaryByDigest, *stmtSummaryByDigestElement) {
+	authUsers := make(map[string]struct{}, len(r.AuthUsers))
+	for _, user := range r.AuthUsers {
+		authUsers[user] = struct{}{}
+	}
+	ssbd := &stmtSummaryByDigest{
+		initialized:   true,
+		schemaName:    r.SchemaName,
+		digest:        r.Digest,
+		planDigest:    r.PlanDigest,
+		stmtType:      r.StmtType,
+		normalizedSQL: r.NormalizedSQL,
+		tableNames:    r.TableNames,
+	}
+	ssElement := &stmtSummaryByDigestElement{
+		beginTime:                    r.BeginTime,
+		endTime:                      r.EndTime,
+		sampleSQL:                    r.SampleSQL,
+		charset:                      r.Charset,
+		collation:                    r.Collation,
+		prevSQL:                      r.PrevSQL,
+		samplePlan:                   r.SamplePlan,
+		sampleBinaryPlan:             r.SampleBinaryPlan,
+		planHint:                     r.PlanHint,
+		indexNames:                   r.IndexNames,
+		execCount:                    r.ExecCount,
+		sumErrors:                    r.SumErrors,
+		sumWarnings:                  r.SumWarnings,
+		sumLatency:                   r.SumLatency,
+		maxLatency:                   r.MaxLatency,
+		minLatency:                   r.MinLatency,
+		sumParseLatency:              r.SumParseLatency,
+		maxParseLatency:              r.MaxParseLatency,
+		sumCompileLatency:            r.SumCompileLatency,
+		maxCompileLatency:            r.MaxCompileLatency,
+		sumNumCopTasks:               r.SumNumCopTasks,
+		maxCopProcessTime:            r.MaxCopProcessTime,
+		maxCopProcessAddress:         r.MaxCopProcessAddress,
+		maxCopWaitTime:               r.MaxCopWaitTime,
+		maxCopWaitAddress:            r.MaxCopWaitAddress,
+		sumProcessTime:               r.SumProcessTime,
+		maxProcessTime:               r.MaxProcessTime,
+		sumWaitTime:                  r.SumWaitTime,
+		maxWaitTime:                  r.MaxWaitTime,
+		sumBackoffTime:               r.SumBackoffTime,
+		maxBackoffTime:               r.MaxBackoffTime,
+		sumTotalKeys:                 r.SumTotalKeys,
+		maxTotalKeys:                 r.MaxTotalKeys,
+		sumProcessedKeys:             r.SumProcessedKeys,
+		maxProcessedKeys:             r.MaxProcessedKeys,
+		sumRocksdbDeleteSkippedCount: r.SumRocksdbDeleteSkippedCount,
+		maxRocksdbDeleteSkippedCount: r.MaxRocksdbDeleteSkippedCount,
+		sumRocksdbKeySkippedCount:    r.SumRocksdbKeySkippedCount,
+		maxRocksdbKeySkippedCount:    r.MaxRocksdbKeySkippedCount,
+		sumRocksdbBlockCacheHitCount: r.SumRocksdbBlockCacheHitCount,
+		maxRocksdbBlockCacheHitCount: r.MaxRocksdbBlockCacheHitCount,
+		sumRocksdbBlockReadCount:     r.SumRocksdbBlockReadCount,
+		maxRocksdbBlockReadCount:     r.MaxRocksdbBlockReadCount,
+		sumRocksdbBlockReadByte:      r.SumRocksdbBlockReadByte,
+		maxRocksdbBlockReadByte:      r.MaxRocksdbBlockReadByte,
+		commitCount:                  r.CommitCount,
+		sumGetCommitTsTime:           r.SumGetCommitTsTime,
+		maxGetCommitTsTime:           r.MaxGetCommitTsTime,
+		sumPrewriteTime:              r.SumPrewriteTime,
+		maxPrewriteTime:              r.MaxPrewriteTime,
+		sumCommitTime:                r.SumCommitTime,
+		maxCommitTime:                r.MaxCommitTime,
+		sumLocalLatchTime:            r.SumLocalLatchTime,
+		maxLocalLatchTime:            r.MaxLocalLatchTime,
+		sumCommitBackoffTime:         r.SumCommitBackoffTime,
+		maxCommitBackoffTime:         r.MaxCommitBackoffTime,
+		sumResolveLockTime:           r.SumResolveLockTime,
+		maxResolveLockTime:           r.MaxResolveLockTime,
+		sumWriteKeys:                 r.SumWriteKeys,
+		maxWriteKeys:                 r.MaxWriteKeys,
+		sumWriteSize:                 r.SumWriteSize,
+		maxWriteSize:                 r.MaxWriteSize,
+		sumPrewriteRegionNum:         r.SumPrewriteRegionNum,
+		maxPrewriteRegionNum:         r.MaxPrewriteRegionNum,
+		sumTxnRetry:                  r.SumTxnRetry,
+		maxTxnRetry:                  r.MaxTxnRetry,
+		sumBackoffTimes:              r.SumBackoffTimes,
+		backoffTypes:                 r.BackoffTypes,
+		authUsers:                    authUsers,
+		sumMem:                       r.SumMem,
+		maxMem:                       r.MaxMem,
+		sumDisk:                      r.SumDisk,
+		maxDisk:                      r.MaxDisk,
+		sumAffectedRows:              r.SumAffectedRows,
+		sumKVTotal:                   r.SumKVTotal,
+		sumPDTotal:                   r.SumPDTotal,
+		sumBackoffTotal:              r.SumBackoffTotal,
+		sumWriteSQLRespTotal:         r.SumWriteSQLRespTotal,
+		sumResultRows:                r.SumResultRows,
+		maxResultRows:                r.MaxResultRows,
+		minResultRows:                r.MinResultRows,
+		prepared:                     r.Prepared,
+		firstSeen:                    r.FirstSeen,
+		lastSeen:                     r.LastSeen,
+		planInCache:                  r.PlanInCache,
+		planCacheHits:                r.PlanCacheHits,
+		planInBinding:                r.PlanInBinding,
+		execRetryCount:               r.ExecRetryCount,
+		execRetryTime:                r.ExecRetryTime,
+	}
+	return ssbd, ssElement
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmtsummary
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/set"
+	"github.com/stretchr/testify/require"
+)
+
+func readHistoryRows(t *testing.T, reader *stmtSummaryReader, startTime, endTime time.Time) [][]types.Datum {
+	historyReader, err := reader.NewHistoryReader(startTime, endTime)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, historyReader.Close())
+	}()
+	var rows [][]types.Datum
+	for {
+		batch, err := historyReader.Rows(1)
+		require.NoError(t, err)
+		if len(batch) == 0 {
+			return rows
+		}
+		rows = append(rows, batch...)
+	}
+}
+
+func TestPersistentHistory(t *testing.T) {
+	ssMap := newStmtSummaryByDigestMap()
+	filename := filepath.Join(t.TempDir(), "tidb-statements.log")
+	require.NoError(t, ssMap.SetupPersistent(filename, 1, 1, 0))
+	require.True(t, ssMap.PersistentEnabled())
+	reader := newStmtSummaryReaderForTest(ssMap)
+
+	now := time.Now().Unix()
+	ssMap.beginTimeForCurInterval = now - 10
+	stmtExecInfo1 := generateAnyExecInfo()
+	ssMap.AddStatement(stmtExecInfo1)
+	expectedRow := reader.GetStmtSummaryCurrentRows()[0]
+
+	// Shorten the interval so that the next statement starts a new interval and the last one is persisted.
+	require.NoError(t, ssMap.SetRefreshInterval(5))
+	defer func() {
+		require.NoError(t, ssMap.SetRefreshInterval(1800))
+	}()
+	ssMap.AddStatement(stmtExecInfo1)
+	var rows [][]types.Datum
+	require.Eventually(t, func() bool {
+		rows = readHistoryRows(t, reader, time.Time{}, time.Time{})
+		return len(rows) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	// The persisted interval is read before the current interval.
+	beginTime := types.NewTime(types.FromGoTime(time.Unix(now-10, 0).In(time.UTC)), mysql.TypeTimestamp, types.DefaultFsp)
+	require.Equal(t, beginTime, rows[0][0].GetMysqlTime())
+	for _, row := range rows {
+		// digest, table names, index names, exec count
+		for _, i := range []int{4, 6, 7, 9} {
+			require.Equal(t, expectedRow[i], row[i])
+		}
+	}
+
+	// The persisted records are skipped by the time range.
+	rows = readHistoryRows(t, reader, time.Unix(now+60, 0), time.Time{})
+	require.Len(t, rows, 1)
+	require.NotEqual(t, beginTime, rows[0][0].GetMysqlTime())
+	rows = readHistoryRows(t, reader, time.Time{}, time.Unix(now-20, 0))
+	require.Len(t, rows, 0)
+
+	// The current interval is persisted when closing.
+	require.NoError(t, ssMap.ClosePersistent())
+	require.False(t, ssMap.PersistentEnabled())
+	ssMap.Clear()
+	require.NoError(t, ssMap.SetupPersistent(filename, 1, 1, 0))
+	rows = readHistoryRows(t, reader, time.Time{}, time.Time{})
+	require.Len(t, rows, 2)
+
+	reader.SetChecker(NewStmtSummaryChecker(set.NewStringSet("unknown")))
+	rows = readHistoryRows(t, reader, time.Time{}, time.Time{})
+	require.Len(t, rows, 0)
+	reader.SetChecker(NewStmtSummaryChecker(set.NewStringSet(stmtExecInfo1.Digest)))
+	rows = readHistoryRows(t, reader, time.Time{}, time.Time{})
+	require.Len(t, rows, 2)
+	require.NoError(t, ssMap.ClosePersistent())
+}
+
+func TestParseRecordTimeRange(t *testing.T) {
+	line, err := json.Marshal(&stmtRecord{BeginTime: 1665367200, EndTime: 1665369000, Digest: "digest"})
+	require.NoError(t, err)
+	begin, end, ok := parseRecordTimeRange(line)
+	require.True(t, ok)
+	require.Equal(t, int64(1665367200), begin)
+	require.Equal(t, int64(1665369000), end)
+
+	_, _, ok = parseRecordTimeRange([]byte(`{"digest":"digest"}`))
+	require.False(t, ok)
+	_, _, ok = parseRecordTimeRange([]byte(`{"begin":1665367200}`))
+	require.False(t, ok)
+}
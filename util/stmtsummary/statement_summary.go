@@ -81,6 +81,9 @@ type stmtSummaryByDigestMap struct {
 
 	// other stores summary of evicted data.
 	other *stmtSummaryByDigestEvicted
+
+	// persister writes the summaries of the finished intervals to the local files if it's not nil.
+	persister *stmtSummaryPersister
 }
 
 // StmtSummaryByDigestMap is a global map containing all statement summaries.
@@ -303,6 +306,11 @@ func (ssMap *stmtSummaryByDigestMap) AddStatement(sei *StmtExecInfo) {
 	// Calculate hash value in advance, to reduce the time holding the lock.
 	key.Hash()
 
+	var (
+		persister     *stmtSummaryPersister
+		lastBeginTime int64
+		lastValues    []kvcache.Value
+	)
 	// Enclose the block in a function to ensure the lock will always be released.
 	summary, beginTime := func() (*stmtSummaryByDigest, int64) {
 		ssMap.Lock()
@@ -317,6 +325,11 @@ func (ssMap *stmtSummaryByDigestMap) AddStatement(sei *StmtExecInfo) {
 		}
 
 		if ssMap.beginTimeForCurInterval+intervalSeconds <= now {
+			// The summaries of the last interval are persisted out of the lock.
+			if ssMap.persister != nil && ssMap.beginTimeForCurInterval > 0 {
+				persister, lastBeginTime = ssMap.persister, ssMap.beginTimeForCurInterval
+				lastValues = ssMap.summaryMap.Values()
+			}
 			// `beginTimeForCurInterval` is a multiple of intervalSeconds, so that when the interval is a multiple
 			// of 60 (or 600, 1800, 3600, etc), begin time shows 'XX:XX:00', not 'XX:XX:01'~'XX:XX:59'.
 			ssMap.beginTimeForCurInterval = now / intervalSeconds * intervalSeconds
@@ -335,6 +348,11 @@ func (ssMap *stmtSummaryByDigestMap) AddStatement(sei *StmtExecInfo) {
 		summary.isInternal = summary.isInternal && sei.IsInternal
 		return summary, beginTime
 	}()
+	if persister != nil {
+		// Collect the records before adding the statement, in case the last summaries are removed from the history.
+		records := collectPersistentRecords(lastValues, ssMap.other, lastBeginTime, now)
+		go persister.persist(records)
+	}
 	// Lock a single entry, not the whole cache.
 	if summary != nil {
 		summary.add(sei, beginTime, intervalSeconds, historySize)